@@ -0,0 +1,15 @@
+data "launchdarkly_permission_check" "developers_update_production" {
+  custom_role_keys = [launchdarkly_custom_role.developers.key]
+  role_attributes = {
+    projects = ["web"]
+  }
+  action   = "updateOn"
+  resource = "proj/web:env/production:flag/checkout"
+}
+
+check "developers_cannot_toggle_production" {
+  assert {
+    condition     = !data.launchdarkly_permission_check.developers_update_production.allowed
+    error_message = data.launchdarkly_permission_check.developers_update_production.reason
+  }
+}
@@ -0,0 +1,23 @@
+data "launchdarkly_policy_document" "flag_editors" {
+  statements = [
+    {
+      sid       = "edit-flags"
+      effect    = "allow"
+      resources = ["proj/$${roleAttribute/projects}:env/*:flag/*"]
+      actions   = ["*"]
+    },
+    {
+      sid       = "protect-production"
+      effect    = "deny"
+      resources = ["proj/*:env/production:flag/*;critical"]
+      actions   = ["updateOn", "deleteFlag"]
+    },
+  ]
+}
+
+resource "launchdarkly_custom_role" "flag_editors" {
+  key                    = "flag-editors"
+  name                   = "Flag editors"
+  base_permissions       = "no_access"
+  policy_statements_json = data.launchdarkly_policy_document.flag_editors.json
+}
@@ -0,0 +1,9 @@
+ephemeral "launchdarkly_access_token" "reader" {
+  name = "Terraform run reader token"
+  role = "reader"
+}
+
+provider "launchdarkly" {
+  alias        = "reader"
+  access_token = ephemeral.launchdarkly_access_token.reader.token
+}
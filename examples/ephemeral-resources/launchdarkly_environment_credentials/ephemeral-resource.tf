@@ -0,0 +1,16 @@
+ephemeral "launchdarkly_environment_credentials" "production" {
+  project_key     = "example-project"
+  environment_key = "production"
+}
+
+resource "kubernetes_secret_v1" "launchdarkly" {
+  metadata {
+    name = "launchdarkly"
+  }
+
+  data_wo = {
+    sdk_key    = ephemeral.launchdarkly_environment_credentials.production.api_key
+    mobile_key = ephemeral.launchdarkly_environment_credentials.production.mobile_key
+  }
+  data_wo_revision = 1
+}
@@ -0,0 +1,15 @@
+ephemeral "launchdarkly_sdk_key" "mobile_analytics" {
+  project_key     = "example-project"
+  environment_key = "production"
+  key             = "mobile-analytics-key"
+}
+
+resource "vault_kv_secret_v2" "mobile_analytics" {
+  mount = "secret"
+  name  = "launchdarkly/mobile-analytics"
+
+  data_json_wo = jsonencode({
+    sdk_key = ephemeral.launchdarkly_sdk_key.mobile_analytics.value
+  })
+  data_json_wo_version = 1
+}
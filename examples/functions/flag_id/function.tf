@@ -0,0 +1,5 @@
+import {
+  to = launchdarkly_feature_flag.checkout
+  # "default/checkout"
+  id = provider::launchdarkly::flag_id("default", "checkout")
+}
@@ -0,0 +1,8 @@
+locals {
+  # { project_key = "default", key = "checkout" }
+  checkout = provider::launchdarkly::parse_flag_id(launchdarkly_feature_flag.checkout.id)
+}
+
+output "checkout_flag_key" {
+  value = local.checkout.key
+}
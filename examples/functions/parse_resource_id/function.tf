@@ -0,0 +1,11 @@
+locals {
+  # { project_key = "default", env_key = "production", flag_key = "checkout" }
+  checkout = provider::launchdarkly::parse_resource_id(
+    "launchdarkly_feature_flag_environment",
+    launchdarkly_feature_flag_environment.checkout.id,
+  )
+}
+
+output "checkout_environment" {
+  value = local.checkout.env_key
+}
@@ -0,0 +1,13 @@
+resource "launchdarkly_custom_role" "checkout_editors" {
+  key  = "checkout-editors"
+  name = "Checkout editors"
+
+  policy_statements = [
+    {
+      effect = "allow"
+      # ["proj/default:env/*:flag/checkout"]
+      resources = [provider::launchdarkly::resource_specifier("launchdarkly_feature_flag", launchdarkly_feature_flag.checkout.id)]
+      actions   = ["*"]
+    },
+  ]
+}
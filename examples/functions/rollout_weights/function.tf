@@ -0,0 +1,12 @@
+resource "launchdarkly_feature_flag_environment" "checkout" {
+  flag_id = launchdarkly_feature_flag.checkout.id
+  env_key = "production"
+
+  on = true
+
+  fallthrough = {
+    # [33333, 33333, 33334]
+    rollout_weights = provider::launchdarkly::rollout_weights([33.333, 33.333, 33.334])
+  }
+  off_variation = 0
+}
@@ -0,0 +1,5 @@
+import {
+  to = launchdarkly_segment.beta_users
+  # "default/production/beta-users"
+  id = provider::launchdarkly::segment_id("default", "production", "beta-users")
+}
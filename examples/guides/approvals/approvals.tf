@@ -0,0 +1,31 @@
+provider "launchdarkly" {
+  access_token = var.launchdarkly_access_token
+
+  # Submit changes that an environment gates behind approvals as approval
+  # requests, and wait up to 30 minutes for them to be reviewed.
+  approval_mode             = "request_and_wait"
+  approval_notify_team_keys = ["release-managers"]
+  approval_wait_timeout     = 1800
+}
+
+resource "launchdarkly_feature_flag_environment" "checkout_production" {
+  flag_id = "example-project/checkout"
+  env_key = "production"
+
+  on          = true
+  fallthrough = {
+    variation = 0
+  }
+}
+
+resource "launchdarkly_segment" "beta_testers" {
+  key         = "beta-testers"
+  project_key = "example-project"
+  env_key     = "production"
+  name        = "Beta testers"
+  included    = ["user-1", "user-2"]
+
+  # Submit the request but do not wait for it: the apply fails with the
+  # request's ID, and a later apply records the change once it is applied.
+  approval_mode = "request"
+}
@@ -20,6 +20,11 @@ provider "launchdarkly" {
   # Optional. When true, removing a launchdarkly_feature_flag from your configuration archives the
   # flag in LaunchDarkly instead of deleting it. Defaults to false.
   archive_flags_on_destroy = false
+
+  # Optional. Tags merged into the tags of every taggable resource the provider manages.
+  default_tags {
+    tags = ["managed-by-terraform"]
+  }
 }
 
 # Create a project with a single environment
@@ -1,2 +1,5 @@
 # Import a feature flag using the feature flag's ID in the format `project_key/flag_key`.
-terraform import launchdarkly_feature_flag.building_materials example-project/building-materials
\ No newline at end of file
+terraform import launchdarkly_feature_flag.building_materials example-project/building-materials
+
+# Append `/environments` to also import the targeting of every environment into `environments`.
+terraform import launchdarkly_feature_flag.building_materials example-project/building-materials/environments
\ No newline at end of file
@@ -64,6 +64,72 @@ resource "launchdarkly_feature_flag" "json_example" {
   }
 }
 
+# Example: Feature flag configuring its environments inline
+# The flag and every environment listed are read in one request and changed in
+# one update. Environments left out of `environments` are not managed, so do not
+# also manage them with launchdarkly_feature_flag_environment.
+resource "launchdarkly_feature_flag" "checkout_redesign" {
+  project_key = "example-project"
+  key         = "checkout-redesign"
+  name        = "Checkout redesign"
+
+  variation_type = "boolean"
+  variations = [
+    { value = "true", name = "Enabled" },
+    { value = "false", name = "Disabled" },
+  ]
+
+  environments = {
+    production = {
+      on                 = true
+      off_variation_name = "Disabled"
+      rules = [{
+        clauses = [{
+          attribute = "country"
+          op        = "in"
+          values    = ["gb", "ie"]
+        }]
+        variation_name = "Enabled"
+      }]
+      fallthrough = {
+        variation_name = "Disabled"
+      }
+    }
+    test = {
+      on = true
+      fallthrough = {
+        rollout = { "true" = 50000, "false" = 50000 }
+      }
+    }
+  }
+}
+
+# Example: Migration flag
+# LaunchDarkly creates one string variation per stage of the migration, so
+# `variations` is left out. Targeting refers to the stages by name.
+resource "launchdarkly_feature_flag" "orders_db_migration" {
+  project_key = "example-project"
+  key         = "orders-db-migration"
+  name        = "Orders database migration"
+
+  variation_type = "string"
+  migration_settings = {
+    context_kind = "user"
+    stage_count  = 6
+  }
+}
+
+resource "launchdarkly_feature_flag_environment" "orders_db_migration" {
+  flag_id = launchdarkly_feature_flag.orders_db_migration.id
+  env_key = "production"
+
+  on                  = true
+  off_variation_value = "off"
+  fallthrough = {
+    variation_value = "dualwrite"
+  }
+}
+
 # Example: Feature flag with view associations
 # This approach is ideal for modular Terraform where each flag is managed in its own file
 #
@@ -166,3 +166,50 @@ resource "launchdarkly_feature_flag_environment" "big_flag_environment" {
   }
   off_variation = 0
 }
+
+# This example references variations by value and name instead of by index, so it keeps serving the same variations when the flag's variations are reordered
+resource "launchdarkly_feature_flag_environment" "variation_refs" {
+  flag_id = launchdarkly_feature_flag.number.id
+  env_key = launchdarkly_environment.staging.key
+
+  on = true
+
+  rules = [{
+    clauses = [{
+      attribute = "country"
+      op        = "in"
+      values    = ["de"]
+    }]
+    variation_name = "treatment"
+  }]
+
+  fallthrough = {
+    rollout = {
+      "10" = 90000
+      "20" = 10000
+    }
+  }
+  off_variation_value = "10"
+}
+
+# This example gives percentage rollouts as percentages, which are converted to rollout weights that add up to exactly 100000
+resource "launchdarkly_feature_flag_environment" "rollout_percentages" {
+  flag_id = launchdarkly_feature_flag.number.id
+  env_key = launchdarkly_environment.staging.key
+
+  on = true
+
+  rules = [{
+    clauses = [{
+      attribute = "country"
+      op        = "in"
+      values    = ["fr"]
+    }]
+    rollout_percentages = [33.333, 33.333, 33.334]
+  }]
+
+  fallthrough = {
+    rollout_percentages = [87.5, 12.5, 0]
+  }
+  off_variation = 0
+}
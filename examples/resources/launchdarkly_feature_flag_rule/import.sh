@@ -0,0 +1,3 @@
+# LaunchDarkly feature flag rules can be imported using the resource's ID in the form `project_key/env_key/flag_key/ref`.
+# Rules created without a ref are imported using their rule ID in place of the ref.
+terraform import launchdarkly_feature_flag_rule.example example-project/example-env/example-flag-key/internal-employees
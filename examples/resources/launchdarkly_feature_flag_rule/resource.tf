@@ -0,0 +1,38 @@
+# The platform team keeps an "internal employees" rule at the top of the
+# flag's rules, while product teams edit the other rules in the UI.
+resource "launchdarkly_feature_flag_rule" "internal_employees" {
+  project_key = "example-project"
+  env_key     = "production"
+  flag_key    = "new-checkout"
+  ref         = "internal-employees"
+  description = "Internal employees"
+  position    = "first"
+  variation   = 0
+
+  clauses = [
+    {
+      attribute = "email"
+      op        = "endsWith"
+      values    = ["@example.com"]
+    },
+  ]
+}
+
+resource "launchdarkly_feature_flag_rule" "beta_rollout" {
+  project_key     = "example-project"
+  env_key         = "production"
+  flag_key        = "new-checkout"
+  ref             = "beta-rollout"
+  rollout_weights = [25000, 75000]
+  bucket_by       = "email"
+  before_rule_ref = "legacy-customers"
+
+  clauses = [
+    {
+      attribute  = "beta"
+      op         = "in"
+      values     = ["true"]
+      value_type = "boolean"
+    },
+  ]
+}
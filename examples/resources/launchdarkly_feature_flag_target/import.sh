@@ -0,0 +1,3 @@
+# LaunchDarkly feature flag targets can be imported using the resource's ID in the form `project_key/env_key/flag_key/context_kind/variation`.
+# The imported resource manages every context key currently targeted to the variation.
+terraform import launchdarkly_feature_flag_target.example example-project/example-env/example-flag-key/user/0
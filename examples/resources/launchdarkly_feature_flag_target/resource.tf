@@ -0,0 +1,33 @@
+# Each team manages the test accounts it targets to the "on" variation,
+# without taking over the rest of the flag's targeting.
+resource "launchdarkly_feature_flag_target" "checkout_team" {
+  project_key = "example-project"
+  env_key     = "staging"
+  flag_key    = "new-checkout"
+  variation   = 0
+  values      = ["checkout-qa-1", "checkout-qa-2"]
+}
+
+resource "launchdarkly_feature_flag_target" "beta_orgs" {
+  project_key  = "example-project"
+  env_key      = "staging"
+  flag_key     = "new-checkout"
+  context_kind = "organization"
+  variation    = 0
+  values       = ["acme"]
+}
+
+# Beta access for customers that ends on its own: LaunchDarkly stops
+# targeting each key at its expiration.
+resource "launchdarkly_feature_flag_target" "beta_customers" {
+  project_key = "example-project"
+  env_key     = "production"
+  flag_key    = "new-checkout"
+  variation   = 0
+  values      = ["customer-123", "customer-456"]
+
+  expirations = {
+    "customer-123" = "2026-04-01T06:00:00-05:00"
+    "customer-456" = "2026-04-15T06:00:00-05:00"
+  }
+}
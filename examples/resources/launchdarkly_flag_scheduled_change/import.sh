@@ -0,0 +1,2 @@
+# LaunchDarkly flag scheduled changes can be imported using the resource's ID in the form `project_key/env_key/flag_key/id`
+terraform import launchdarkly_flag_scheduled_change.example example-project/example-env/example-flag-key/61d490757f7821150815518f
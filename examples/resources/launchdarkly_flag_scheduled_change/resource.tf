@@ -0,0 +1,38 @@
+# Launch the new checkout to 10% of customers at 6am Eastern, serving
+# "enabled" to the QA team.
+resource "launchdarkly_flag_scheduled_change" "launch" {
+  project_key    = "example-project"
+  env_key        = "production"
+  flag_key       = "new-checkout"
+  execution_date = "2026-03-02T06:00:00-05:00"
+  comment        = "Scheduled launch, see RELEASE-42"
+
+  instructions = [
+    {
+      kind = "turnFlagOn"
+    },
+    {
+      kind            = "updateFallthroughVariationOrRollout"
+      rollout_weights = [10000, 90000]
+    },
+    {
+      kind         = "addTargets"
+      variation    = 0
+      context_kind = "user"
+      values       = ["qa-alice", "qa-bob"]
+    },
+    {
+      kind        = "addRule"
+      ref         = "internal-employees"
+      description = "Internal employees"
+      variation   = 0
+      clauses = [
+        {
+          attribute = "email"
+          op        = "endsWith"
+          values    = ["@example.com"]
+        },
+      ]
+    },
+  ]
+}
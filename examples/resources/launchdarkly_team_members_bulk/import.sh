@@ -0,0 +1,2 @@
+# Existing LaunchDarkly team members can be imported using their comma-separated emails.
+terraform import launchdarkly_team_members_bulk.engineering ada@example.com,grace@example.com
@@ -0,0 +1,18 @@
+resource "launchdarkly_team_members_bulk" "engineering" {
+  members = [
+    {
+      email        = "ada@example.com"
+      role         = "no_access"
+      custom_roles = ["engineer"]
+      role_attributes = {
+        developer-envs = ["development", "staging"]
+      }
+      teams = ["platform"]
+    },
+    {
+      email = "grace@example.com"
+      role  = "writer"
+      teams = ["platform", "payments"]
+    },
+  ]
+}
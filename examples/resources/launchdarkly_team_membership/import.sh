@@ -0,0 +1,3 @@
+# A LaunchDarkly team membership can be imported using the team key and the comma-separated member IDs, in the form `team_key/member_id_1,member_id_2`.
+# Only the listed members are managed by the imported resource.
+terraform import launchdarkly_team_membership.payments_on_call incident-responders/507f1f77bcf86cd799439011,569f183514f4432160000007
@@ -0,0 +1,4 @@
+resource "launchdarkly_team_membership" "payments_on_call" {
+  team_key   = "incident-responders"
+  member_ids = ["507f1f77bcf86cd799439011", "569f183514f4432160000007"]
+}
@@ -17,3 +17,13 @@ resource "launchdarkly_webhook" "example" {
     },
   ]
 }
+
+# The signing secret is sent to LaunchDarkly but never stored in state.
+# Increment secret_wo_version to rotate it.
+resource "launchdarkly_webhook" "signed" {
+  url               = "http://webhooks.com/signed"
+  name              = "Signed Webhook"
+  on                = true
+  secret_wo         = var.webhook_signing_secret
+  secret_wo_version = 1
+}
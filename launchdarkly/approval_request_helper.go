@@ -0,0 +1,278 @@
+package launchdarkly
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ldapi "github.com/launchdarkly/api-client-go/v24"
+)
+
+// When an environment requires approvals, LaunchDarkly rejects direct
+// changes to its flag targeting and segments with "approval is required".
+// approval_mode decides what the provider does instead: fail (the
+// historical behavior), submit the planned change as an approval request,
+// or submit it and wait until a reviewer has approved it and it has been
+// applied. Approval requests carry semantic-patch instructions, so each
+// gated resource translates its plan into instructions before calling
+// submitForApproval.
+
+const (
+	APPROVAL_MODE_FAIL             = "fail"
+	APPROVAL_MODE_REQUEST          = "request"
+	APPROVAL_MODE_REQUEST_AND_WAIT = "request_and_wait"
+
+	DEFAULT_APPROVAL_WAIT_TIMEOUT_S = 3600
+	DEFAULT_APPROVAL_POLL_INTERVAL  = 15 * time.Second
+)
+
+var approvalModes = []string{APPROVAL_MODE_FAIL, APPROVAL_MODE_REQUEST, APPROVAL_MODE_REQUEST_AND_WAIT}
+
+// approvalRequestTagPrefix marks the approval requests the provider creates.
+// The tag carries a fingerprint of the requested change so a re-run of the
+// same apply reuses the pending request instead of opening a duplicate.
+const approvalRequestTagPrefix = "[terraform:"
+
+// approvalConfig is the provider-level approval configuration.
+type approvalConfig struct {
+	mode            string
+	notifyMemberIDs []string
+	notifyTeamKeys  []string
+	waitTimeout     time.Duration
+	pollInterval    time.Duration
+}
+
+func approvalConfigFromProviderModel(ctx context.Context, data launchdarklyProviderModel) (approvalConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	cfg := approvalConfig{
+		mode:         APPROVAL_MODE_FAIL,
+		waitTimeout:  DEFAULT_APPROVAL_WAIT_TIMEOUT_S * time.Second,
+		pollInterval: DEFAULT_APPROVAL_POLL_INTERVAL,
+	}
+	if mode := data.ApprovalMode.ValueString(); mode != "" {
+		cfg.mode = mode
+	}
+	if timeout := data.ApprovalWaitTimeout.ValueInt64(); timeout > 0 {
+		cfg.waitTimeout = time.Duration(timeout) * time.Second
+	}
+	members, d := stringSliceFromSet(ctx, data.ApprovalNotifyMembers)
+	diags.Append(d...)
+	teams, d := stringSliceFromSet(ctx, data.ApprovalNotifyTeams)
+	diags.Append(d...)
+	cfg.notifyMemberIDs = members
+	cfg.notifyTeamKeys = teams
+	return cfg, diags
+}
+
+// resourceApprovalModeAttribute is the per-resource override of the
+// provider's approval_mode.
+func resourceApprovalModeAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Description: "Overrides the provider's `approval_mode` for this resource. Must be one of `fail`, `request`, or `request_and_wait`. This setting only affects how changes are applied and is not read from LaunchDarkly.",
+		Validators: []validator.String{
+			oneOfValidator{allowed: approvalModes},
+		},
+	}
+}
+
+// approvalModeFor resolves a resource's approval_mode override against the
+// provider default.
+func (c *Client) approvalModeFor(override types.String) string {
+	if mode := override.ValueString(); mode != "" {
+		return mode
+	}
+	if c.approvals.mode == "" {
+		return APPROVAL_MODE_FAIL
+	}
+	return c.approvals.mode
+}
+
+// flagApprovalResourceID returns the resource specifier LaunchDarkly uses for
+// approval requests against a flag's configuration in one environment.
+func flagApprovalResourceID(projectKey, envKey, flagKey string) string {
+	return fmt.Sprintf("proj/%s:env/%s:flag/%s", projectKey, envKey, flagKey)
+}
+
+// segmentApprovalResourceID returns the resource specifier LaunchDarkly uses
+// for approval requests against a segment.
+func segmentApprovalResourceID(projectKey, envKey, segmentKey string) string {
+	return fmt.Sprintf("proj/%s:env/%s:segment/%s", projectKey, envKey, segmentKey)
+}
+
+// approvalRequestSpec describes a change rejected because it requires
+// approval.
+type approvalRequestSpec struct {
+	// resourceID is the approval resource specifier, see
+	// flagApprovalResourceID and segmentApprovalResourceID.
+	resourceID string
+	// summary names the resource in diagnostics and in the request
+	// description, e.g. `flag "checkout" in environment "production"`.
+	summary      string
+	instructions []map[string]interface{}
+	comment      string
+}
+
+// fingerprint identifies the requested change. Two applies of the same plan
+// against the same live state produce the same fingerprint.
+func (s approvalRequestSpec) fingerprint() (string, error) {
+	raw, err := json.Marshal(s.instructions)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(append([]byte(s.resourceID+"\n"), raw...))
+	return hex.EncodeToString(sum[:])[:12], nil
+}
+
+// submitForApproval handles a change LaunchDarkly rejected with "approval is
+// required" according to mode, which must be request or request_and_wait.
+// It returns no error diagnostics only when the change has been applied, so
+// callers can go on to read the resource back into state.
+func (c *Client) submitForApproval(mode string, spec approvalRequestSpec) diag.Diagnostics {
+	var diags diag.Diagnostics
+	fingerprint, err := spec.fingerprint()
+	if err != nil {
+		diags.AddError(fmt.Sprintf("failed to build approval request for %s", spec.summary), err.Error())
+		return diags
+	}
+	tag := approvalRequestTagPrefix + fingerprint + "]"
+
+	id, err := c.findPendingApprovalRequest(spec.resourceID, tag)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("failed to list approval requests for %s: %s", spec.summary, handleLdapiErr(err).Error()), "")
+		return diags
+	}
+	if id == "" {
+		id, err = c.createApprovalRequest(spec, tag)
+		if err != nil {
+			diags.AddError(fmt.Sprintf("failed to create approval request for %s: %s", spec.summary, handleLdapiErr(err).Error()), "")
+			return diags
+		}
+	}
+
+	if mode != APPROVAL_MODE_REQUEST_AND_WAIT {
+		diags.AddError(
+			fmt.Sprintf("approval request %q is pending for %s", id, spec.summary),
+			"The environment requires approval for this change, so it was submitted as an approval request instead of being applied. Once the request has been approved and applied in LaunchDarkly, run terraform apply again to record the change in state. Re-running the apply before then reuses the pending request.",
+		)
+		return diags
+	}
+	return c.waitForApprovalRequest(id, spec)
+}
+
+// findPendingApprovalRequest returns the ID of a pending approval request for
+// resourceID whose description carries tag, or "" if there is none.
+func (c *Client) findPendingApprovalRequest(resourceID, tag string) (string, error) {
+	var requests *ldapi.ExpandableApprovalRequestsResponse
+	err := c.withConcurrency(c.ctx, func() error {
+		var e error
+		requests, _, e = c.ld.ApprovalsApi.GetApprovalRequests(c.ctx).Filter(fmt.Sprintf("resourceId equals %s", resourceID)).Execute()
+		return e
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, r := range requests.GetItems() {
+		if r.GetStatus() != "pending" || r.GetReviewStatus() == "declined" {
+			continue
+		}
+		if strings.Contains(r.GetDescription(), tag) {
+			return r.GetId(), nil
+		}
+	}
+	return "", nil
+}
+
+func (c *Client) createApprovalRequest(spec approvalRequestSpec, tag string) (string, error) {
+	body := ldapi.CreateApprovalRequestRequest{
+		ResourceId:      spec.resourceID,
+		Description:     fmt.Sprintf("Terraform change to %s %s", spec.summary, tag),
+		Instructions:    spec.instructions,
+		NotifyMemberIds: c.approvals.notifyMemberIDs,
+		NotifyTeamKeys:  c.approvals.notifyTeamKeys,
+	}
+	if spec.comment != "" {
+		body.Comment = ldapi.PtrString(spec.comment)
+	}
+	var created *ldapi.ApprovalRequestResponse
+	err := c.withConcurrency(c.ctx, func() error {
+		var e error
+		created, _, e = c.ld.ApprovalsApi.PostApprovalRequest(c.ctx).CreateApprovalRequestRequest(body).Execute()
+		return e
+	})
+	if err != nil {
+		return "", err
+	}
+	return created.GetId(), nil
+}
+
+// waitForApprovalRequest polls approval request id until it has been applied,
+// declined or has failed, or until the configured timeout elapses. An
+// approved request that has not been applied yet is applied by the provider.
+func (c *Client) waitForApprovalRequest(id string, spec approvalRequestSpec) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pollInterval := c.approvals.pollInterval
+	if pollInterval <= 0 {
+		pollInterval = DEFAULT_APPROVAL_POLL_INTERVAL
+	}
+	timeout := c.approvals.waitTimeout
+	if timeout <= 0 {
+		timeout = DEFAULT_APPROVAL_WAIT_TIMEOUT_S * time.Second
+	}
+	deadline := time.Now().Add(timeout)
+	applied := false
+	for {
+		var request *ldapi.ExpandableApprovalRequestResponse
+		err := c.withConcurrency(c.ctx, func() error {
+			var e error
+			request, _, e = c.ld.ApprovalsApi.GetApprovalRequest(c.ctx, id).Execute()
+			return e
+		})
+		if err != nil {
+			diags.AddError(fmt.Sprintf("failed to get approval request %q for %s: %s", id, spec.summary, handleLdapiErr(err).Error()), "")
+			return diags
+		}
+
+		switch {
+		case request.GetStatus() == "completed":
+			return diags
+		case request.GetStatus() == "failed":
+			diags.AddError(fmt.Sprintf("approval request %q for %s failed to apply", id, spec.summary), "Review the request in LaunchDarkly for details, then run terraform apply again.")
+			return diags
+		case request.GetReviewStatus() == "declined":
+			diags.AddError(fmt.Sprintf("approval request %q for %s was declined", id, spec.summary), "The change was not applied.")
+			return diags
+		case request.GetReviewStatus() == "approved" && !applied:
+			err := c.withConcurrency(c.ctx, func() error {
+				var e error
+				_, _, e = c.ld.ApprovalsApi.PostApprovalRequestApply(c.ctx, id).PostApprovalRequestApplyRequest(ldapi.PostApprovalRequestApplyRequest{
+					Comment: ldapi.PtrString(spec.comment),
+				}).Execute()
+				return e
+			})
+			if err != nil {
+				diags.AddError(fmt.Sprintf("failed to apply approved request %q for %s: %s", id, spec.summary, handleLdapiErr(err).Error()), "")
+				return diags
+			}
+			applied = true
+			continue
+		}
+
+		if time.Now().Add(pollInterval).After(deadline) {
+			diags.AddError(
+				fmt.Sprintf("timed out waiting for approval request %q for %s", id, spec.summary),
+				fmt.Sprintf("The request was not reviewed within %s. It remains open in LaunchDarkly; re-running terraform apply waits on the same request.", timeout),
+			)
+			return diags
+		}
+		time.Sleep(pollInterval)
+	}
+}
@@ -0,0 +1,249 @@
+package launchdarkly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ldapi "github.com/launchdarkly/api-client-go/v24"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockApprovalRequest returns the JSON shape of an approval request with
+// the fields the generated client requires.
+func mockApprovalRequest(id, status, reviewStatus, description string) map[string]interface{} {
+	return map[string]interface{}{
+		"_id":             id,
+		"_version":        1,
+		"creationDate":    1700000000000,
+		"serviceKind":     "launchdarkly",
+		"description":     description,
+		"resourceId":      "proj/p:env/production:flag/f",
+		"reviewStatus":    reviewStatus,
+		"status":          status,
+		"allReviews":      []interface{}{},
+		"notifyMemberIds": []string{},
+		"instructions":    []interface{}{},
+		"conflicts":       []interface{}{},
+		"_links":          map[string]interface{}{},
+	}
+}
+
+// approvalTestServer fakes the approval request endpoints. getStatuses is
+// the sequence of (status, reviewStatus) pairs GET returns for the created
+// request; the last pair repeats.
+type approvalTestServer struct {
+	mu          sync.Mutex
+	existing    []map[string]interface{}
+	getStatuses [][2]string
+	gets        int
+	created     []ldapi.CreateApprovalRequestRequest
+	applied     int
+}
+
+func (s *approvalTestServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v2/approval-requests":
+		mustWriteJSON(w, map[string]interface{}{"items": s.existing, "totalCount": len(s.existing)})
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v2/approval-requests":
+		var body ldapi.CreateApprovalRequestRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.created = append(s.created, body)
+		w.WriteHeader(http.StatusCreated)
+		mustWriteJSON(w, mockApprovalRequest("created-1", "pending", "pending", body.Description))
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v2/approval-requests/created-1":
+		i := s.gets
+		if i >= len(s.getStatuses) {
+			i = len(s.getStatuses) - 1
+		}
+		s.gets++
+		mustWriteJSON(w, mockApprovalRequest("created-1", s.getStatuses[i][0], s.getStatuses[i][1], ""))
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v2/approval-requests/created-1/apply":
+		s.applied++
+		// Once applied, the request completes.
+		s.getStatuses = [][2]string{{"completed", "approved"}}
+		mustWriteJSON(w, mockApprovalRequest("created-1", "completed", "approved", ""))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newApprovalTestClient(t *testing.T, srv *approvalTestServer, mode string) *Client {
+	t.Helper()
+	client, ts := createTestClientWithServer(t, srv.handle)
+	t.Cleanup(ts.Close)
+	client.approvals = approvalConfig{
+		mode:            mode,
+		notifyMemberIDs: []string{"member-1"},
+		notifyTeamKeys:  []string{"release-managers"},
+		waitTimeout:     time.Second,
+		pollInterval:    time.Millisecond,
+	}
+	return client
+}
+
+func testApprovalRequestSpec() approvalRequestSpec {
+	return approvalRequestSpec{
+		resourceID:   flagApprovalResourceID("p", "production", "f"),
+		summary:      `flag "f" in environment "production" of project "p"`,
+		instructions: []map[string]interface{}{{"kind": "turnFlagOn"}},
+		comment:      "Terraform",
+	}
+}
+
+func TestSubmitForApproval(t *testing.T) {
+	t.Run("request mode creates a request and fails with its ID", func(t *testing.T) {
+		srv := &approvalTestServer{}
+		client := newApprovalTestClient(t, srv, APPROVAL_MODE_REQUEST)
+
+		diags := client.submitForApproval(APPROVAL_MODE_REQUEST, testApprovalRequestSpec())
+		require.True(t, diags.HasError())
+		assert.Contains(t, diags[0].Summary(), `approval request "created-1" is pending`)
+
+		require.Len(t, srv.created, 1)
+		assert.Equal(t, "proj/p:env/production:flag/f", srv.created[0].ResourceId)
+		assert.Equal(t, []string{"member-1"}, srv.created[0].NotifyMemberIds)
+		assert.Equal(t, []string{"release-managers"}, srv.created[0].NotifyTeamKeys)
+		assert.Contains(t, srv.created[0].Description, approvalRequestTagPrefix)
+	})
+
+	t.Run("request mode reuses a pending request for the same change", func(t *testing.T) {
+		fingerprint, err := testApprovalRequestSpec().fingerprint()
+		require.NoError(t, err)
+		srv := &approvalTestServer{existing: []map[string]interface{}{
+			mockApprovalRequest("declined-1", "pending", "declined", "Terraform change "+approvalRequestTagPrefix+fingerprint+"]"),
+			mockApprovalRequest("existing-1", "pending", "pending", "Terraform change "+approvalRequestTagPrefix+fingerprint+"]"),
+		}}
+		client := newApprovalTestClient(t, srv, APPROVAL_MODE_REQUEST)
+
+		diags := client.submitForApproval(APPROVAL_MODE_REQUEST, testApprovalRequestSpec())
+		require.True(t, diags.HasError())
+		assert.Contains(t, diags[0].Summary(), `"existing-1"`)
+		assert.Empty(t, srv.created)
+	})
+
+	t.Run("wait mode applies an approved request", func(t *testing.T) {
+		srv := &approvalTestServer{getStatuses: [][2]string{{"pending", "pending"}, {"pending", "approved"}}}
+		client := newApprovalTestClient(t, srv, APPROVAL_MODE_REQUEST_AND_WAIT)
+
+		diags := client.submitForApproval(APPROVAL_MODE_REQUEST_AND_WAIT, testApprovalRequestSpec())
+		require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+		assert.Equal(t, 1, srv.applied)
+	})
+
+	t.Run("wait mode fails when the request is declined", func(t *testing.T) {
+		srv := &approvalTestServer{getStatuses: [][2]string{{"pending", "declined"}}}
+		client := newApprovalTestClient(t, srv, APPROVAL_MODE_REQUEST_AND_WAIT)
+
+		diags := client.submitForApproval(APPROVAL_MODE_REQUEST_AND_WAIT, testApprovalRequestSpec())
+		require.True(t, diags.HasError())
+		assert.Contains(t, diags[0].Summary(), "was declined")
+		assert.Equal(t, 0, srv.applied)
+	})
+
+	t.Run("wait mode times out", func(t *testing.T) {
+		srv := &approvalTestServer{getStatuses: [][2]string{{"pending", "pending"}}}
+		client := newApprovalTestClient(t, srv, APPROVAL_MODE_REQUEST_AND_WAIT)
+		client.approvals.waitTimeout = 20 * time.Millisecond
+		client.approvals.pollInterval = 5 * time.Millisecond
+
+		diags := client.submitForApproval(APPROVAL_MODE_REQUEST_AND_WAIT, testApprovalRequestSpec())
+		require.True(t, diags.HasError())
+		assert.Contains(t, diags[0].Summary(), "timed out")
+	})
+}
+
+func TestApprovalModeFor(t *testing.T) {
+	client := &Client{approvals: approvalConfig{mode: APPROVAL_MODE_REQUEST}}
+	assert.Equal(t, APPROVAL_MODE_REQUEST, client.approvalModeFor(types.StringNull()))
+	assert.Equal(t, APPROVAL_MODE_FAIL, client.approvalModeFor(types.StringValue(APPROVAL_MODE_FAIL)))
+	assert.Equal(t, APPROVAL_MODE_FAIL, (&Client{}).approvalModeFor(types.StringNull()))
+}
+
+func TestBuildFFEInstructions(t *testing.T) {
+	ctx := context.Background()
+	ids := newFlagVariationIDs(nil, "p")
+	ids.byFlag["f"] = []string{"var-true", "var-false"}
+	ids.byFlag["prereq"] = []string{"pre-a", "pre-b"}
+
+	state := ffeTestBaselineModel(t)
+	state.OffVariation = types.Int64Value(1)
+	plan := state
+	plan.On = types.BoolValue(true)
+	plan.OffVariation = types.Int64Value(0)
+	prereqs, d := types.ListValue(types.ObjectType{AttrTypes: ffeResourcePrerequisiteAttrTypes}, []attr.Value{
+		types.ObjectValueMust(ffeResourcePrerequisiteAttrTypes, withNullVariationRefs(map[string]attr.Value{
+			FLAG_KEY:  types.StringValue("prereq"),
+			VARIATION: types.Int64Value(1),
+		}, false)),
+	})
+	require.False(t, d.HasError())
+	plan.Prerequisites = prereqs
+	plan.Fallthrough = types.ObjectValueMust(ffeResourceFallthroughAttrTypes, withNullExperimentSettings(withNullVariationRefs(map[string]attr.Value{
+		VARIATION:       types.Int64Value(0),
+		BUCKET_BY:       types.StringNull(),
+		CONTEXT_KIND:    types.StringValue("user"),
+		ROLLOUT_WEIGHTS: types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(60000), types.Int64Value(40000)}),
+	}, true), false))
+
+	instructions, d := buildFFEInstructions(ctx, "f", plan, state, nil, ids)
+	require.False(t, d.HasError(), "unexpected diagnostics: %v", d)
+	require.Len(t, instructions, 4)
+	assert.Equal(t, map[string]interface{}{"kind": "turnFlagOn"}, instructions[0])
+	assert.Equal(t, map[string]interface{}{"kind": "updateOffVariation", "variationId": "var-true"}, instructions[1])
+	assert.Equal(t, map[string]interface{}{"kind": "addPrerequisite", "key": "prereq", "variationId": "pre-b"}, instructions[2])
+	assert.Equal(t, "updateFallthroughVariationOrRollout", instructions[3]["kind"])
+	assert.Equal(t, map[string]int32{"var-true": 60000, "var-false": 40000}, instructions[3]["rolloutWeights"])
+
+	t.Run("no change produces no instructions", func(t *testing.T) {
+		instructions, d := buildFFEInstructions(ctx, "f", state, state, nil, ids)
+		require.False(t, d.HasError())
+		assert.Empty(t, instructions)
+	})
+
+	t.Run("unsetting the off variation is rejected", func(t *testing.T) {
+		unset := state
+		unset.OffVariation = types.Int64Null()
+		_, d := buildFFEInstructions(ctx, "f", unset, state, nil, ids)
+		require.True(t, d.HasError())
+	})
+}
+
+func TestBuildSegmentInstructions(t *testing.T) {
+	ctx := context.Background()
+	included, d := listFromStringSlice(ctx, []string{"a", "c"})
+	require.False(t, d.HasError())
+	plan := SegmentResourceModel{
+		Included:         included,
+		Excluded:         types.ListNull(types.StringType),
+		IncludedContexts: types.ListNull(types.ObjectType{AttrTypes: segmentIncludedContextAttrTypes}),
+		ExcludedContexts: types.ListNull(types.ObjectType{AttrTypes: segmentTargetAttrTypes}),
+		Rules:            types.ListNull(types.ObjectType{AttrTypes: segmentResourceRuleAttrTypes}),
+	}
+	live := &ldapi.UserSegment{
+		Included: []string{"a", "b"},
+		IncludedContexts: []ldapi.SegmentTarget{
+			{Values: []string{"org-1"}, ContextKind: ldapi.PtrString("organization")},
+		},
+	}
+
+	instructions, d := buildSegmentInstructions(ctx, plan, live)
+	require.False(t, d.HasError(), "unexpected diagnostics: %v", d)
+	assert.Equal(t, []map[string]interface{}{
+		{"kind": "addIncludedUsers", "values": []string{"c"}},
+		{"kind": "removeIncludedUsers", "values": []string{"b"}},
+		{"kind": "removeIncludedTargets", "contextKind": "organization", "values": []string{"org-1"}},
+	}, instructions)
+}
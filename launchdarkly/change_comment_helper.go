@@ -0,0 +1,45 @@
+package launchdarkly
+
+import (
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Every change the provider makes through a patch endpoint carries a comment
+// that shows up in the LaunchDarkly audit log. The provider's change_comment
+// is a template expanded once at configure time, so it can reference values
+// such as the CI run URL or the commit SHA through environment variables.
+// Resources may override it with their own comment attribute.
+
+// DEFAULT_CHANGE_COMMENT is the comment sent when neither the provider nor the
+// resource configures one.
+const DEFAULT_CHANGE_COMMENT = "Terraform"
+
+// expandChangeComment replaces $NAME and ${NAME} in template with the value of
+// the environment variable NAME. Unset variables expand to "".
+func expandChangeComment(template string) string {
+	return os.Expand(template, os.Getenv)
+}
+
+// resourceCommentAttribute is the per-resource override of the provider's
+// change_comment.
+func resourceCommentAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Description: "The comment recorded in the LaunchDarkly audit log for changes Terraform makes to this resource. Overrides the provider's `change_comment` and supports the same environment variable interpolation. This setting only affects how changes are applied and is not read from LaunchDarkly.",
+	}
+}
+
+// changeCommentFor resolves a resource's comment override against the
+// provider's change_comment.
+func (c *Client) changeCommentFor(override types.String) string {
+	if comment := expandChangeComment(override.ValueString()); comment != "" {
+		return comment
+	}
+	if c.changeComment != "" {
+		return c.changeComment
+	}
+	return DEFAULT_CHANGE_COMMENT
+}
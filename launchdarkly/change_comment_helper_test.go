@@ -0,0 +1,40 @@
+package launchdarkly
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandChangeComment(t *testing.T) {
+	t.Setenv("TF_TEST_RUN_URL", "https://ci.example.com/runs/42")
+	t.Setenv("TF_TEST_SHA", "abc123")
+
+	assert.Equal(t, "Terraform run https://ci.example.com/runs/42 (abc123)", expandChangeComment("Terraform run ${TF_TEST_RUN_URL} ($TF_TEST_SHA)"))
+	assert.Equal(t, "workspace: ", expandChangeComment("workspace: ${TF_TEST_UNSET_VARIABLE}"))
+	assert.Equal(t, "no variables", expandChangeComment("no variables"))
+}
+
+func TestChangeCommentFor(t *testing.T) {
+	t.Setenv("TF_TEST_SHA", "abc123")
+
+	t.Run("defaults when nothing is configured", func(t *testing.T) {
+		assert.Equal(t, DEFAULT_CHANGE_COMMENT, (&Client{}).changeCommentFor(types.StringNull()))
+	})
+
+	t.Run("uses the provider comment", func(t *testing.T) {
+		client := &Client{changeComment: "CI deploy"}
+		assert.Equal(t, "CI deploy", client.changeCommentFor(types.StringNull()))
+	})
+
+	t.Run("resource comment overrides the provider comment", func(t *testing.T) {
+		client := &Client{changeComment: "CI deploy"}
+		assert.Equal(t, "hotfix abc123", client.changeCommentFor(types.StringValue("hotfix $TF_TEST_SHA")))
+	})
+
+	t.Run("resource comment that expands to nothing falls back", func(t *testing.T) {
+		client := &Client{changeComment: "CI deploy"}
+		assert.Equal(t, "CI deploy", client.changeCommentFor(types.StringValue("$TF_TEST_UNSET_VARIABLE")))
+	})
+}
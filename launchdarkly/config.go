@@ -10,11 +10,13 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"golang.org/x/sync/semaphore"
 
 	retryablehttp "github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	ldapi "github.com/launchdarkly/api-client-go/v24"
 )
 
@@ -55,6 +57,95 @@ type Client struct {
 	// archives the flag instead of deleting it. Configured at the provider
 	// level via the archive_flags_on_destroy attribute. Defaults to false.
 	archiveFlagsOnDestroy bool
+
+	// approvals controls how gated changes are handled when an environment
+	// requires approvals. Configured at the provider level via approval_mode
+	// and its companion attributes; see approval_request_helper.go.
+	approvals approvalConfig
+
+	// changeComment is the expanded provider-level change_comment. Use
+	// changeCommentFor to resolve it against a resource's comment override.
+	changeComment string
+
+	// defaultTags are the provider-level default_tags merged into the tags
+	// of every taggable resource; see default_tags_helper.go.
+	defaultTags []string
+
+	// guardrails restrict the projects and environments the provider may
+	// change; see guardrails_helper.go.
+	guardrails guardrailConfig
+
+	// allowCriticalChanges: when true, changes to critical environments do
+	// not need a per-resource acknowledge_critical. criticalEnvironments
+	// caches environmentIsCritical lookups; see critical_environment_helper.go.
+	allowCriticalChanges bool
+	criticalEnvironments sync.Map
+
+	// readOnly: when true, every request other than a GET is rejected before
+	// it is sent. Configured at the provider level via the read_only
+	// attribute; see enableReadOnly.
+	readOnly bool
+}
+
+// errReadOnly is returned for requests rejected because the provider is in
+// read_only mode.
+var errReadOnly = errors.New("the provider is configured with read_only = true")
+
+// readOnlyTransport rejects every request other than a GET before it leaves
+// the process. It wraps the retrying transport, so rejected requests are not
+// retried.
+type readOnlyTransport struct {
+	next http.RoundTripper
+}
+
+func (t readOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, fmt.Errorf("%w: refusing to send %s %s", errReadOnly, req.Method, req.URL.Path)
+	}
+	return t.next.RoundTrip(req)
+}
+
+// rejectReadOnly adds an error naming resourceType and operation, such as
+// "create", and returns true when the client is in read_only mode. Resources
+// call it first thing in Create, Update and Delete, so the error says what
+// Terraform tried to change rather than which request readOnlyTransport
+// refused.
+func (c *Client) rejectReadOnly(resourceType, operation string, diags *diag.Diagnostics) bool {
+	if c == nil || !c.readOnly {
+		return false
+	}
+	diags.AddError(
+		"Change blocked by read_only",
+		fmt.Sprintf("Cannot %s %s: the provider is configured with read_only = true, so it only reads from LaunchDarkly. Set read_only to false to apply this change.", operation, resourceType),
+	)
+	return true
+}
+
+// enableReadOnly puts the client in read_only mode. Every HTTP client the
+// provider uses to reach LaunchDarkly, including the raw requests built in
+// the *_helper.go files, goes through one of the clients wrapped here, and
+// derived beta clients inherit the mode in betaClientFromConfig.
+func (c *Client) enableReadOnly() {
+	if c.readOnly {
+		return
+	}
+	c.readOnly = true
+	for _, httpClient := range []*http.Client{c.ld.GetConfig().HTTPClient, c.ld404Retry.GetConfig().HTTPClient, c.fallbackClient} {
+		if httpClient == nil {
+			continue
+		}
+		if _, ok := httpClient.Transport.(readOnlyTransport); ok {
+			continue
+		}
+		next := httpClient.Transport
+		if next == nil {
+			next = http.DefaultTransport
+		}
+		httpClient.Transport = readOnlyTransport{next: next}
+	}
 }
 
 // betaClientFromConfig returns a beta-API client that inherits this client's
@@ -78,7 +169,14 @@ func (c *Client) betaClientFromConfig() (*Client, error) {
 	if concurrency <= 0 {
 		concurrency = DEFAULT_MAX_CONCURRENCY
 	}
-	return newBetaClient(c.apiKey, c.apiHost, false, timeout, concurrency)
+	beta, err := newBetaClient(c.apiKey, c.apiHost, false, timeout, concurrency)
+	if err != nil {
+		return nil, err
+	}
+	if c.readOnly {
+		beta.enableReadOnly()
+	}
+	return beta, nil
 }
 
 func (c *Client) withConcurrency(ctx context.Context, fn func() error) error {
@@ -1,14 +1,18 @@
 package launchdarkly
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -307,3 +311,57 @@ func TestSemaphoreConcurrencyLimits(t *testing.T) {
 	assert.LessOrEqual(t, maxConcurrent, int32(maxConcurrency),
 		"Max concurrent requests (%d) exceeded semaphore limit (%d)", maxConcurrent, maxConcurrency)
 }
+
+func TestReadOnlyClient(t *testing.T) {
+	var methods []string
+	var mu sync.Mutex
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		methods = append(methods, r.Method)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client, err := newClient("token", ts.URL, false, DEFAULT_HTTP_TIMEOUT_S, DEFAULT_MAX_CONCURRENCY)
+	require.NoError(t, err)
+	client.enableReadOnly()
+	beta, err := client.betaClientFromConfig()
+	require.NoError(t, err)
+
+	for _, httpClient := range []*http.Client{client.ld.GetConfig().HTTPClient, client.ld404Retry.GetConfig().HTTPClient, beta.ld.GetConfig().HTTPClient} {
+		res, err := httpClient.Get(ts.URL + "/api/v2/flags/p")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+
+		req, err := http.NewRequest(http.MethodPatch, ts.URL+"/api/v2/flags/p/f", strings.NewReader("[]"))
+		require.NoError(t, err)
+		_, err = httpClient.Do(req)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errReadOnly)
+		assert.Contains(t, err.Error(), "refusing to send PATCH /api/v2/flags/p/f")
+	}
+
+	assert.Equal(t, []string{http.MethodGet, http.MethodGet, http.MethodGet}, methods, "only GET requests reach the server")
+}
+
+func TestReadOnlyResourceOperations(t *testing.T) {
+	t.Parallel()
+
+	client := &Client{readOnly: true}
+	create := resource.CreateResponse{}
+	(&FeatureFlagResource{client: client}).Create(context.Background(), resource.CreateRequest{}, &create)
+	require.Len(t, create.Diagnostics.Errors(), 1)
+	assert.Equal(t, "Change blocked by read_only", create.Diagnostics.Errors()[0].Summary())
+	assert.Equal(t, "Cannot create launchdarkly_feature_flag: the provider is configured with read_only = true, so it only reads from LaunchDarkly. Set read_only to false to apply this change.", create.Diagnostics.Errors()[0].Detail())
+
+	destroy := resource.DeleteResponse{}
+	(&SegmentResource{client: client}).Delete(context.Background(), resource.DeleteRequest{}, &destroy)
+	require.Len(t, destroy.Diagnostics.Errors(), 1)
+	assert.Contains(t, destroy.Diagnostics.Errors()[0].Detail(), "Cannot destroy launchdarkly_segment:")
+
+	var diags diag.Diagnostics
+	assert.False(t, (&Client{}).rejectReadOnly("launchdarkly_segment", "update", &diags))
+	assert.False(t, (*Client)(nil).rejectReadOnly("launchdarkly_segment", "update", &diags))
+	assert.Empty(t, diags)
+}
@@ -1,10 +1,22 @@
 package launchdarkly
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	ldapi "github.com/launchdarkly/api-client-go/v24"
 )
 
+// contextKindIdToKeys parses a `project_key/context_kind_key` composite id.
+func contextKindIdToKeys(id string) (projectKey, key string, err error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("found unexpected context kind id format: %q expected format: 'project_key/context_kind_key'", id)
+	}
+	return parts[0], parts[1], nil
+}
+
 // findContextKindByKey scans a context-kind list response for an item matching the given key.
 // The LaunchDarkly REST API does not expose a single-GET endpoint for context kinds, so reads
 // must always go through the project-scoped list.
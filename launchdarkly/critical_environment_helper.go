@@ -0,0 +1,277 @@
+package launchdarkly
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// LaunchDarkly asks members to confirm changes to environments marked
+// critical or with confirm_changes set. The provider mirrors that: a plan that
+// changes flag targeting, a segment or an SDK key in such an environment, or
+// that deletes one, fails unless the resource sets acknowledge_critical or the
+// provider sets allow_critical_changes. As with guardrails, keys that are
+// unknown at plan time are checked when Terraform plans again during apply.
+
+// criticalChangeLocalAttributes only affect how a change is applied, so
+// changing them alone does not change the environment.
+var criticalChangeLocalAttributes = map[string]bool{
+	ACKNOWLEDGE_CRITICAL: true,
+	APPROVAL_MODE:        true,
+	COMMENT:              true,
+}
+
+// acknowledgeCriticalAttribute is the per-resource acknowledgement of changes
+// to critical environments.
+func acknowledgeCriticalAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional:    true,
+		Description: "Set to `true` to acknowledge that applying this resource changes an environment that is critical or requires confirmation of changes. Without it, or the provider's `allow_critical_changes`, planning such a change fails. To destroy the resource, set it and apply before removing the resource from your configuration. This setting only affects how changes are applied and is not read from LaunchDarkly.",
+	}
+}
+
+// environmentIsCritical reports whether LaunchDarkly asks for confirmation of
+// changes to the environment. Lookups are cached for the life of the client.
+// An environment that does not exist yet is not critical.
+func (c *Client) environmentIsCritical(projectKey, envKey string) (bool, error) {
+	cacheKey := projectKey + "/" + envKey
+	if critical, ok := c.criticalEnvironments.Load(cacheKey); ok {
+		return critical.(bool), nil
+	}
+	var critical bool
+	var res *http.Response
+	err := c.withConcurrency(c.ctx, func() error {
+		env, r, err := c.ld.EnvironmentsApi.GetEnvironment(c.ctx, projectKey, envKey).Execute()
+		res = r
+		if err == nil {
+			critical = env.Critical || env.ConfirmChanges
+		}
+		return err
+	})
+	if isStatusNotFound(res) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get environment %q in project %q: %s", envKey, projectKey, handleLdapiErr(err))
+	}
+	c.criticalEnvironments.Store(cacheKey, critical)
+	return critical, nil
+}
+
+// planCriticalEnvironment fails the plan when it changes a resource in a
+// critical environment without an acknowledgement. scope locates the
+// resource's project and environment keys, as for guardrails.
+func (c *Client) planCriticalEnvironment(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, scope guardrailScope) {
+	if c == nil || c.allowCriticalChanges || req.Plan.Raw.Equal(req.State.Raw) {
+		return
+	}
+	operation, changes := criticalChanges(req)
+	if operation == "update" && len(changes) == 0 {
+		return
+	}
+	if criticalChangeAcknowledged(ctx, req, resp) || resp.Diagnostics.HasError() {
+		return
+	}
+
+	var sources []func(context.Context, path.Path, interface{}) diag.Diagnostics
+	if !req.Plan.Raw.IsNull() {
+		sources = append(sources, req.Plan.GetAttribute)
+	}
+	if !req.State.Raw.IsNull() {
+		sources = append(sources, req.State.GetAttribute)
+	}
+	checked := make(map[string]bool)
+	for _, get := range sources {
+		projectKey, envKey, known, diags := scope.keys(ctx, get)
+		resp.Diagnostics.Append(diags...)
+		if !known || checked[projectKey+"/"+envKey] {
+			continue
+		}
+		checked[projectKey+"/"+envKey] = true
+		critical, err := c.environmentIsCritical(projectKey, envKey)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to check whether the environment is critical", err.Error())
+			continue
+		}
+		if critical {
+			addCriticalChangeError(resp, operation, changes, projectKey, envKey)
+		}
+	}
+}
+
+// planCriticalEnvironmentDestroy fails a plan that destroys or replaces a
+// critical launchdarkly_environment without an acknowledgement. The
+// environment's own state says whether it is critical.
+func (c *Client) planCriticalEnvironmentDestroy(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if c == nil || c.allowCriticalChanges || req.State.Raw.IsNull() {
+		return
+	}
+	var state EnvironmentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || (!state.Critical.ValueBool() && !state.ConfirmChanges.ValueBool()) {
+		return
+	}
+	if !req.Plan.Raw.IsNull() {
+		// Changing either key replaces the environment.
+		var projectKey, key types.String
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(PROJECT_KEY), &projectKey)...)
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(KEY), &key)...)
+		if resp.Diagnostics.HasError() || (projectKey.Equal(state.ProjectKey) && key.Equal(state.Key)) {
+			return
+		}
+	}
+	if criticalChangeAcknowledged(ctx, req, resp) || resp.Diagnostics.HasError() {
+		return
+	}
+	addCriticalChangeError(resp, "delete", nil, state.ProjectKey.ValueString(), state.Key.ValueString())
+}
+
+// planCriticalProjectEnvironments fails a plan that deletes critical
+// environments managed by a launchdarkly_project without an acknowledgement,
+// whether by removing them from environments or by destroying the project.
+func (c *Client) planCriticalProjectEnvironments(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if c == nil || c.allowCriticalChanges || req.State.Raw.IsNull() {
+		return
+	}
+	var prior, planned types.Map
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(ENVIRONMENTS), &prior)...)
+	if !req.Plan.Raw.IsNull() {
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(ENVIRONMENTS), &planned)...)
+	} else {
+		planned = types.MapNull(prior.ElementType(ctx))
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	priorEnvs, diags := environmentModelsFromMap(ctx, prior)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var critical []string
+	for _, key := range removedEnvKeys(planned, prior) {
+		if env := priorEnvs[key]; env.Critical.ValueBool() || env.ConfirmChanges.ValueBool() {
+			critical = append(critical, key)
+		}
+	}
+	if len(critical) == 0 || criticalChangeAcknowledged(ctx, req, resp) || resp.Diagnostics.HasError() {
+		return
+	}
+	var projectKey types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(KEY), &projectKey)...)
+	for _, envKey := range critical {
+		addCriticalChangeError(resp, "delete", nil, projectKey.ValueString(), envKey)
+	}
+}
+
+// planCriticalFlagEnvironments fails a plan that changes the environments a
+// launchdarkly_feature_flag configures inline when any of them is critical
+// and the change is not acknowledged. Removing an environment from
+// environments leaves it unchanged.
+func (c *Client) planCriticalFlagEnvironments(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if c == nil || c.allowCriticalChanges || req.Plan.Raw.IsNull() {
+		return
+	}
+	var projectKey types.String
+	var planned, prior types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(PROJECT_KEY), &projectKey)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(ENVIRONMENTS), &planned)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(ENVIRONMENTS), &prior)...)
+	}
+	if resp.Diagnostics.HasError() || projectKey.IsUnknown() || planned.IsNull() || planned.IsUnknown() {
+		return
+	}
+	priorEnvs := prior.Elements()
+	var changed []string
+	for key, env := range planned.Elements() {
+		if before, ok := priorEnvs[key]; !ok || !env.Equal(before) {
+			changed = append(changed, key)
+		}
+	}
+	if len(changed) == 0 || criticalChangeAcknowledged(ctx, req, resp) || resp.Diagnostics.HasError() {
+		return
+	}
+	sort.Strings(changed)
+	for _, envKey := range changed {
+		critical, err := c.environmentIsCritical(projectKey.ValueString(), envKey)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to check whether the environment is critical", err.Error())
+			continue
+		}
+		if critical {
+			addCriticalChangeError(resp, "update", []string{ENVIRONMENTS + "." + envKey}, projectKey.ValueString(), envKey)
+		}
+	}
+}
+
+// criticalChangeAcknowledged reads acknowledge_critical from the plan, or
+// from the state when the resource is being destroyed.
+func criticalChangeAcknowledged(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) bool {
+	var acknowledged types.Bool
+	if req.Plan.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(ACKNOWLEDGE_CRITICAL), &acknowledged)...)
+	} else {
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(ACKNOWLEDGE_CRITICAL), &acknowledged)...)
+	}
+	return acknowledged.ValueBool()
+}
+
+// criticalChanges describes a planned change: the operation and, for creates
+// and updates, the top-level attributes it sets or changes. Attributes still
+// unknown at plan time and those in criticalChangeLocalAttributes are left
+// out.
+func criticalChanges(req resource.ModifyPlanRequest) (operation string, attributes []string) {
+	if req.Plan.Raw.IsNull() {
+		return "destroy", nil
+	}
+	var planned, prior map[string]tftypes.Value
+	if err := req.Plan.Raw.As(&planned); err != nil {
+		return "update", nil
+	}
+	operation = "update"
+	if req.State.Raw.IsNull() {
+		operation = "create"
+	} else if err := req.State.Raw.As(&prior); err != nil {
+		return operation, nil
+	}
+	for name, value := range planned {
+		if criticalChangeLocalAttributes[name] || !value.IsKnown() {
+			continue
+		}
+		if operation == "create" && value.IsNull() {
+			continue
+		}
+		if before, ok := prior[name]; ok && value.Equal(before) {
+			continue
+		}
+		attributes = append(attributes, name)
+	}
+	sort.Strings(attributes)
+	return operation, attributes
+}
+
+// addCriticalChangeError reports an unacknowledged change to a critical
+// environment. operation is "create", "update" or "destroy" for resources in
+// the environment, or "delete" for the environment itself.
+func addCriticalChangeError(resp *resource.ModifyPlanResponse, operation string, changes []string, projectKey, envKey string) {
+	target := fmt.Sprintf("%s a resource in environment %q of project %q", operation, envKey, projectKey)
+	if operation == "delete" {
+		target = fmt.Sprintf("delete environment %q of project %q", envKey, projectKey)
+	}
+	detail := fmt.Sprintf("This plan would %s, which LaunchDarkly marks as critical or as requiring confirmation of changes.", target)
+	if len(changes) > 0 {
+		detail += fmt.Sprintf(" Attributes that would change: %s.", strings.Join(changes, ", "))
+	}
+	detail += fmt.Sprintf(" Set %s = true on the resource, or %s = true on the provider, to confirm the change.", ACKNOWLEDGE_CRITICAL, ALLOW_CRITICAL_CHANGES)
+	resp.Diagnostics.AddError("Change to a critical environment requires acknowledgement", detail)
+}
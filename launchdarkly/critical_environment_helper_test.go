@@ -0,0 +1,99 @@
+package launchdarkly
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanCriticalEnvironment(t *testing.T) {
+	ctx := context.Background()
+	s := schema.Schema{Attributes: map[string]schema.Attribute{
+		PROJECT_KEY:          schema.StringAttribute{Required: true},
+		ENV_KEY:              schema.StringAttribute{Required: true},
+		NAME:                 schema.StringAttribute{Optional: true},
+		TAGS:                 schema.SetAttribute{Optional: true, ElementType: types.StringType},
+		ACKNOWLEDGE_CRITICAL: acknowledgeCriticalAttribute(),
+	}}
+	tagsType := tftypes.Set{ElementType: tftypes.String}
+	objType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		PROJECT_KEY:          tftypes.String,
+		ENV_KEY:              tftypes.String,
+		NAME:                 tftypes.String,
+		TAGS:                 tagsType,
+		ACKNOWLEDGE_CRITICAL: tftypes.Bool,
+	}}
+	value := func(envKey, name string, acknowledged interface{}) tftypes.Value {
+		return tftypes.NewValue(objType, map[string]tftypes.Value{
+			PROJECT_KEY:          tftypes.NewValue(tftypes.String, "p"),
+			ENV_KEY:              tftypes.NewValue(tftypes.String, envKey),
+			NAME:                 tftypes.NewValue(tftypes.String, name),
+			TAGS:                 tftypes.NewValue(tagsType, nil),
+			ACKNOWLEDGE_CRITICAL: tftypes.NewValue(tftypes.Bool, acknowledged),
+		})
+	}
+	null := tftypes.NewValue(objType, nil)
+	scope := guardrailScope{project: PROJECT_KEY, env: ENV_KEY}
+
+	newClient := func() *Client {
+		client := &Client{}
+		// Seed the lookup cache so the test needs no API.
+		client.criticalEnvironments.Store("p/production", true)
+		client.criticalEnvironments.Store("p/staging", false)
+		return client
+	}
+	plan := func(client *Client, prior, planned tftypes.Value) resource.ModifyPlanResponse {
+		req := resource.ModifyPlanRequest{
+			State: tfsdk.State{Schema: s, Raw: prior},
+			Plan:  tfsdk.Plan{Schema: s, Raw: planned},
+		}
+		resp := resource.ModifyPlanResponse{Plan: req.Plan}
+		client.planCriticalEnvironment(ctx, req, &resp, scope)
+		return resp
+	}
+
+	t.Run("lists the attributes that would change", func(t *testing.T) {
+		resp := plan(newClient(), value("production", "a", nil), value("production", "b", nil))
+		require.True(t, resp.Diagnostics.HasError())
+		assert.Contains(t, resp.Diagnostics[0].Detail(), `update a resource in environment "production" of project "p"`)
+		assert.Contains(t, resp.Diagnostics[0].Detail(), "Attributes that would change: name.")
+	})
+
+	t.Run("blocks destroying without a prior acknowledgement", func(t *testing.T) {
+		resp := plan(newClient(), value("production", "a", nil), null)
+		require.True(t, resp.Diagnostics.HasError())
+		assert.Contains(t, resp.Diagnostics[0].Detail(), "destroy")
+	})
+
+	t.Run("allows acknowledged changes", func(t *testing.T) {
+		resp := plan(newClient(), value("production", "a", true), value("production", "b", true))
+		assert.False(t, resp.Diagnostics.HasError())
+
+		resp = plan(newClient(), value("production", "a", true), null)
+		assert.False(t, resp.Diagnostics.HasError(), "destroy reads the acknowledgement from state")
+	})
+
+	t.Run("allows changes when the provider allows critical changes", func(t *testing.T) {
+		client := newClient()
+		client.allowCriticalChanges = true
+		resp := plan(client, value("production", "a", nil), value("production", "b", nil))
+		assert.False(t, resp.Diagnostics.HasError())
+	})
+
+	t.Run("allows changes to other environments", func(t *testing.T) {
+		resp := plan(newClient(), null, value("staging", "a", nil))
+		assert.False(t, resp.Diagnostics.HasError())
+	})
+
+	t.Run("changing only the acknowledgement is not a change", func(t *testing.T) {
+		resp := plan(newClient(), value("production", "a", true), value("production", "a", false))
+		assert.False(t, resp.Diagnostics.HasError())
+	})
+}
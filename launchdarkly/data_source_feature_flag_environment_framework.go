@@ -54,6 +54,7 @@ var ffeRuleAttrTypes = map[string]attr.Type{
 	ROLLOUT_WEIGHTS: types.ListType{ElemType: types.Int64Type},
 	BUCKET_BY:       types.StringType,
 	CONTEXT_KIND:    types.StringType,
+	REF:             types.StringType,
 }
 
 var ffeFallthroughAttrTypes = map[string]attr.Type{
@@ -130,6 +131,7 @@ func (d *FeatureFlagEnvironmentDataSource) Schema(_ context.Context, _ datasourc
 						VARIATION:    schema.Int64Attribute{Computed: true},
 						BUCKET_BY:    schema.StringAttribute{Computed: true},
 						CONTEXT_KIND: schema.StringAttribute{Computed: true},
+						REF:          schema.StringAttribute{Computed: true, Description: "The rule's stable identifier in LaunchDarkly."},
 						ROLLOUT_WEIGHTS: schema.ListAttribute{
 							Computed:    true,
 							ElementType: types.Int64Type,
@@ -332,6 +334,7 @@ func ffeRulesValue(ctx context.Context, rules []ldapi.Rule, diags interface {
 			ROLLOUT_WEIGHTS: weightsList,
 			BUCKET_BY:       types.StringValue(bucketBy),
 			CONTEXT_KIND:    types.StringValue(contextKind),
+			REF:             types.StringValue(ffeRuleRef(r)),
 		})
 		elements = append(elements, obj)
 	}
@@ -0,0 +1,86 @@
+package launchdarkly
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataSourcePermissionCheck_statements(t *testing.T) {
+	resourceName := "data.launchdarkly_permission_check.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "launchdarkly_permission_check" "test" {
+	statements = [
+		{
+			effect    = "allow"
+			resources = ["proj/$${roleAttribute/projects}:env/*:flag/*"]
+			actions   = ["*"]
+		},
+		{
+			effect    = "deny"
+			resources = ["proj/*:env/production:flag/*"]
+			actions   = ["updateOn"]
+		},
+	]
+	role_attributes = {
+		projects = ["web"]
+	}
+	action   = "updateOn"
+	resource = "proj/web:env/production:flag/checkout"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, ALLOWED, "false"),
+					resource.TestCheckResourceAttr(resourceName, "decided_by.statement_index", "1"),
+					resource.TestCheckResourceAttr(resourceName, "decided_by.effect", "deny"),
+					resource.TestCheckNoResourceAttr(resourceName, "decided_by.custom_role_key"),
+					resource.TestCheckResourceAttr(resourceName, REASON, "updateOn on proj/web:env/production:flag/checkout is denied by statements[1]."),
+				),
+			},
+			{
+				Config: `
+data "launchdarkly_permission_check" "test" {
+	statements = [
+		{
+			effect    = "allow"
+			resources = ["proj/*:env/*:flag/*"]
+			actions   = ["*"]
+		},
+	]
+	action   = "updateOn"
+	resource = "proj/web:env/production:flag/checkout"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, ALLOWED, "true"),
+					resource.TestCheckResourceAttr(resourceName, "decided_by.statement_index", "0"),
+					resource.TestCheckResourceAttr(resourceName, "decided_by.effect", "allow"),
+				),
+			},
+			{
+				Config: `
+data "launchdarkly_permission_check" "test" {
+	statements = [
+		{
+			effect    = "allow"
+			resources = ["proj/*:env/*:flag/*"]
+			actions   = ["*"]
+		},
+	]
+	action   = "updateOn"
+	resource = "proj/web:flag/checkout"
+}
+`,
+				ExpectError: regexp.MustCompile(`"flag" must come after "env", not "proj"`),
+			},
+		},
+	})
+}
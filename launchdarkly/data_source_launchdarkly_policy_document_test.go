@@ -0,0 +1,98 @@
+package launchdarkly
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataSourcePolicyDocument_basic(t *testing.T) {
+	resourceName := "data.launchdarkly_policy_document.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "launchdarkly_policy_document" "base" {
+	statements = [
+		{
+			sid       = "flags"
+			effect    = "allow"
+			resources = ["proj/*:env/*:flag/*"]
+			actions   = ["*"]
+		},
+	]
+}
+
+data "launchdarkly_policy_document" "test" {
+	source_policy_documents = [data.launchdarkly_policy_document.base.json]
+	statements = [
+		{
+			effect    = "deny"
+			resources = ["proj/$${roleAttribute/projects}:env/production:flag/*"]
+			actions   = ["updateOn"]
+		},
+	]
+	override_policy_documents = [jsonencode([
+		{
+			effect        = "allow"
+			not_resources = ["proj/*:env/production"]
+			actions       = ["*"]
+		},
+	])]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, JSON, `[{"actions":["*"],"effect":"allow","resources":["proj/*:env/*:flag/*"]},{"actions":["updateOn"],"effect":"deny","resources":["proj/${roleAttribute/projects}:env/production:flag/*"]},{"actions":["*"],"effect":"allow","not_resources":["proj/*:env/production"]}]`),
+					resource.TestCheckResourceAttr(resourceName, "policy_statements.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "policy_statements.1.effect", "deny"),
+					resource.TestCheckResourceAttr(resourceName, "policy_statements.2.not_resources.0", "proj/*:env/production"),
+					resource.TestCheckResourceAttrSet(resourceName, ID),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourcePolicyDocument_invalidResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "launchdarkly_policy_document" "test" {
+	statements = [
+		{
+			effect    = "allow"
+			resources = ["proj/*:env/*:flags/*"]
+			actions   = ["*"]
+		},
+	]
+}
+`,
+				ExpectError: regexp.MustCompile(`unknown resource kind "flags", did you mean "flag"\?`),
+			},
+			{
+				Config: `
+data "launchdarkly_policy_document" "test" {
+	source_policy_documents = [jsonencode([
+		{
+			effect    = "allow"
+			resources = ["proj/$${roleattribute/projects}"]
+			actions   = ["*"]
+		},
+	])]
+}
+`,
+				ExpectError: regexp.MustCompile(`not a valid role attribute placeholder`),
+			},
+		},
+	})
+}
@@ -0,0 +1,223 @@
+package launchdarkly
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ldapi "github.com/launchdarkly/api-client-go/v24"
+)
+
+var _ datasource.DataSource = &PermissionCheckDataSource{}
+
+// PermissionCheckDataSource evaluates policy statements locally. It only
+// calls LaunchDarkly to read the statements of custom_role_keys.
+type PermissionCheckDataSource struct {
+	client *Client
+}
+
+type PermissionCheckDataSourceModel struct {
+	ID             types.String        `tfsdk:"id"`
+	Statements     types.List          `tfsdk:"statements"`
+	CustomRoleKeys []string            `tfsdk:"custom_role_keys"`
+	RoleAttributes map[string][]string `tfsdk:"role_attributes"`
+	Action         types.String        `tfsdk:"action"`
+	Resource       types.String        `tfsdk:"resource"`
+	Allowed        types.Bool          `tfsdk:"allowed"`
+	DecidedBy      types.Object        `tfsdk:"decided_by"`
+	Reason         types.String        `tfsdk:"reason"`
+}
+
+var permissionCheckDecidedByAttrTypes = map[string]attr.Type{
+	CUSTOM_ROLE_KEY: types.StringType,
+	STATEMENT_INDEX: types.Int64Type,
+	EFFECT:          types.StringType,
+}
+
+func NewPermissionCheckDataSource() datasource.DataSource {
+	return &PermissionCheckDataSource{}
+}
+
+func (d *PermissionCheckDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_permission_check"
+}
+
+func (d *PermissionCheckDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Checks whether policy statements allow an action on a resource, such as whether any custom role allows `updateOn` on the flags of a production environment.\n\nThis data source evaluates the statements locally, the way LaunchDarkly does: a statement applies when both its resources and its actions match, a `deny` statement that applies wins over every `allow` statement, and nothing is allowed unless an `allow` statement applies. Use it in `check` blocks and tests to catch roles that grant more than intended. It only calls the LaunchDarkly API to read the statements of `custom_role_keys`, and does not take the roles' `base_permissions` into account.",
+		Attributes: map[string]schema.Attribute{
+			ID: schema.StringAttribute{
+				Computed:    true,
+				Description: "The action and the resource specifier, separated by a space.",
+			},
+			STATEMENTS: frameworkPolicyStatementsDataSourceInputAttribute("Policy statements to evaluate, such as the `policy_statements` of a `launchdarkly_policy_document`. At least one of `statements` and `custom_role_keys` is required."),
+			CUSTOM_ROLE_KEYS: schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The keys of custom roles whose policy statements to evaluate, after `statements`.",
+			},
+			ROLE_ATTRIBUTES: schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.ListType{ElemType: types.StringType},
+				Description: "The [role attribute](https://launchdarkly.com/docs/home/getting-started/vocabulary#role-attribute) values of the member or team to check for, substituted for the `${roleAttribute/<key>}` placeholders of resource specifiers. A placeholder of an attribute without values matches nothing.",
+			},
+			ACTION: schema.StringAttribute{
+				Required:    true,
+				Description: "The action to check, such as `updateOn`. For a list of available actions read [Actions reference](https://launchdarkly.com/docs/home/account/roles/role-actions#actions-reference).",
+			},
+			RESOURCE: schema.StringAttribute{
+				Required:    true,
+				Description: "The resource specifier of the resource to check, such as `proj/web:env/production:flag/checkout`. Add the resource's tags to match statements that specify tags, such as `proj/web:env/production:flag/checkout;mobile`.",
+			},
+			ALLOWED: schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the statements allow the action on the resource.",
+			},
+			DECIDED_BY: schema.SingleNestedAttribute{
+				Computed:    true,
+				Description: "The statement that decided the outcome: the first `deny` statement that applies or, if none does, the first `allow` statement that applies. Null when no statement applies, so the action is denied.",
+				Attributes: map[string]schema.Attribute{
+					CUSTOM_ROLE_KEY: schema.StringAttribute{
+						Computed:    true,
+						Description: "The key of the custom role the statement belongs to. Null for `statements`.",
+					},
+					STATEMENT_INDEX: schema.Int64Attribute{
+						Computed:    true,
+						Description: "The index of the statement in `statements` or in the custom role's policy.",
+					},
+					EFFECT: schema.StringAttribute{
+						Computed:    true,
+						Description: "The statement's effect, either `allow` or `deny`.",
+					},
+				},
+			},
+			REASON: schema.StringAttribute{
+				Computed:    true,
+				Description: "A sentence explaining the outcome, for use in `check` block error messages.",
+			},
+		},
+	}
+}
+
+func (d *PermissionCheckDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = configureDataSourceClient(req, resp)
+}
+
+func (d *PermissionCheckDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PermissionCheckDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.Statements.IsNull() && len(data.CustomRoleKeys) == 0 {
+		resp.Diagnostics.AddAttributeError(path.Root(STATEMENTS), "Missing policy statements", "At least one of statements and custom_role_keys is required.")
+		return
+	}
+	if len(data.CustomRoleKeys) > 0 && d.client == nil {
+		return
+	}
+
+	action := data.Action.ValueString()
+	specifier := data.Resource.ValueString()
+	resource, err := parsePolicyResource(specifier)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root(RESOURCE), "Invalid resource specifier", err.Error())
+		return
+	}
+
+	var inline []frameworkPolicyStatementModel
+	if !data.Statements.IsNull() {
+		resp.Diagnostics.Append(data.Statements.ElementsAs(ctx, &inline, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	statements := make([]policyCheckStatement, 0, len(inline))
+	for i, s := range inline {
+		if _, diags := s.toLDAPI(); diags.HasError() {
+			for _, d := range diags {
+				resp.Diagnostics.AddAttributeError(path.Root(STATEMENTS).AtListIndex(i), d.Summary(), d.Detail())
+			}
+			continue
+		}
+		statements = append(statements, policyCheckStatement{index: i, statement: s})
+	}
+	for _, key := range data.CustomRoleKeys {
+		policy, err := d.customRolePolicy(key)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(CUSTOM_ROLE_KEYS), "Failed to get custom role", err.Error())
+			continue
+		}
+		for i, s := range policy {
+			statements = append(statements, policyCheckStatement{
+				customRoleKey: key,
+				index:         i,
+				statement: frameworkPolicyStatementModel{
+					Resources:    s.Resources,
+					NotResources: s.NotResources,
+					Actions:      s.Actions,
+					NotActions:   s.NotActions,
+					Effect:       s.Effect,
+				},
+			})
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	decision, err := checkPolicy(statements, action, resource, data.RoleAttributes)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid policy statement", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(action + " " + specifier)
+	data.Allowed = types.BoolValue(decision.allowed)
+	data.DecidedBy = types.ObjectNull(permissionCheckDecidedByAttrTypes)
+	if decision.decidedBy == nil {
+		data.Reason = types.StringValue(fmt.Sprintf("%s on %s is denied because no statement allows it.", action, specifier))
+	} else {
+		s := decision.decidedBy
+		outcome := "allowed"
+		if !decision.allowed {
+			outcome = "denied"
+		}
+		data.Reason = types.StringValue(fmt.Sprintf("%s on %s is %s by %s.", action, specifier, outcome, s))
+		customRoleKey := types.StringNull()
+		if s.customRoleKey != "" {
+			customRoleKey = types.StringValue(s.customRoleKey)
+		}
+		decidedBy, diags := types.ObjectValue(permissionCheckDecidedByAttrTypes, map[string]attr.Value{
+			CUSTOM_ROLE_KEY: customRoleKey,
+			STATEMENT_INDEX: types.Int64Value(int64(s.index)),
+			EFFECT:          types.StringValue(s.statement.Effect),
+		})
+		resp.Diagnostics.Append(diags...)
+		data.DecidedBy = decidedBy
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// customRolePolicy returns the policy statements of the custom role with key.
+func (d *PermissionCheckDataSource) customRolePolicy(key string) ([]ldapi.Statement, error) {
+	var role *ldapi.CustomRole
+	var res *http.Response
+	var err error
+	err = d.client.withConcurrency(d.client.ctx, func() error {
+		role, res, err = d.client.ld.CustomRolesApi.GetCustomRole(d.client.ctx, key).Execute()
+		return err
+	})
+	if isStatusNotFound(res) {
+		return nil, fmt.Errorf("custom role %q not found", key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get custom role %q: %s", key, handleLdapiErr(err))
+	}
+	return role.Policy, nil
+}
@@ -0,0 +1,219 @@
+package launchdarkly
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ldapi "github.com/launchdarkly/api-client-go/v24"
+)
+
+var _ datasource.DataSource = &PolicyDocumentDataSource{}
+
+// PolicyDocumentDataSource composes policy statements locally, without
+// calling LaunchDarkly, so it needs no client.
+type PolicyDocumentDataSource struct{}
+
+type PolicyDocumentDataSourceModel struct {
+	ID                      types.String `tfsdk:"id"`
+	SourcePolicyDocuments   []string     `tfsdk:"source_policy_documents"`
+	OverridePolicyDocuments []string     `tfsdk:"override_policy_documents"`
+	Statements              types.List   `tfsdk:"statements"`
+	JSON                    types.String `tfsdk:"json"`
+	PolicyStatements        types.List   `tfsdk:"policy_statements"`
+}
+
+type policyDocumentStatementModel struct {
+	Sid          types.String `tfsdk:"sid"`
+	Resources    []string     `tfsdk:"resources"`
+	NotResources []string     `tfsdk:"not_resources"`
+	Actions      []string     `tfsdk:"actions"`
+	NotActions   []string     `tfsdk:"not_actions"`
+	Effect       string       `tfsdk:"effect"`
+}
+
+// policyDocumentStatement is a statement with the sid it is merged by, if
+// any.
+type policyDocumentStatement struct {
+	sid       string
+	statement ldapi.StatementPost
+}
+
+func NewPolicyDocumentDataSource() datasource.DataSource {
+	return &PolicyDocumentDataSource{}
+}
+
+func (d *PolicyDocumentDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policy_document"
+}
+
+func (d *PolicyDocumentDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Generates a LaunchDarkly policy document in JSON format for use with resources that take policy statements, such as `launchdarkly_custom_role`.\n\nThis data source composes statements from `statements` and other policy documents, and validates every resource specifier, including [role attribute](https://launchdarkly.com/docs/home/getting-started/vocabulary#role-attribute) placeholders, when planning. It does not call the LaunchDarkly API.",
+		Attributes: map[string]schema.Attribute{
+			ID: schema.StringAttribute{
+				Computed:    true,
+				Description: "A hash of the generated JSON.",
+			},
+			SOURCE_POLICY_DOCUMENTS: schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Policy documents, in the JSON format of `policy_statements_json`, whose statements come first. A statement with the same `sid` as an earlier one replaces it.",
+			},
+			OVERRIDE_POLICY_DOCUMENTS: schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Policy documents, in the JSON format of `policy_statements_json`, whose statements come last. A statement with the same `sid` as one from `source_policy_documents` or `statements` replaces it, and other statements are added.",
+			},
+			STATEMENTS: policyDocumentStatementsAttribute(),
+			JSON: schema.StringAttribute{
+				Computed:    true,
+				Description: "The merged statements as normalized JSON, for `policy_statements_json` and other attributes that take a JSON policy.",
+			},
+			POLICY_STATEMENTS: frameworkPolicyStatementsDataSourceAttribute("The merged statements, for `policy_statements` and other attributes that take a list of policy statements."),
+		},
+	}
+}
+
+// policyDocumentStatementsAttribute returns the statements attribute, whose
+// statements also have a sid to merge documents by.
+func policyDocumentStatementsAttribute() schema.ListNestedAttribute {
+	attr := frameworkPolicyStatementsDataSourceInputAttribute("Policy statements, which come after those of `source_policy_documents`.")
+	attr.NestedObject.Attributes[SID] = schema.StringAttribute{
+		Optional:    true,
+		Description: "An identifier of the statement, unique within the statements, that documents are merged by. LaunchDarkly does not store it, so it is left out of `json`.",
+	}
+	return attr
+}
+
+func (d *PolicyDocumentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PolicyDocumentDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var merged []policyDocumentStatement
+	for i, raw := range data.SourcePolicyDocuments {
+		document, err := policyDocumentStatementsFromJSON(raw, fmt.Sprintf("%s[%d]", SOURCE_POLICY_DOCUMENTS, i))
+		if err == nil {
+			merged, err = mergePolicyDocumentStatements(merged, document)
+		}
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(SOURCE_POLICY_DOCUMENTS).AtListIndex(i), "Invalid policy document", err.Error())
+		}
+	}
+
+	var statements []policyDocumentStatementModel
+	if !data.Statements.IsNull() {
+		resp.Diagnostics.Append(data.Statements.ElementsAs(ctx, &statements, false)...)
+	}
+	document := make([]policyDocumentStatement, 0, len(statements))
+	for i, s := range statements {
+		model := frameworkPolicyStatementModel{
+			Resources:    s.Resources,
+			NotResources: s.NotResources,
+			Actions:      s.Actions,
+			NotActions:   s.NotActions,
+			Effect:       s.Effect,
+		}
+		statement, diags := model.toLDAPI()
+		for _, d := range diags {
+			resp.Diagnostics.AddAttributeError(path.Root(STATEMENTS).AtListIndex(i), d.Summary(), d.Detail())
+		}
+		document = append(document, policyDocumentStatement{sid: s.Sid.ValueString(), statement: statement})
+	}
+	if !resp.Diagnostics.HasError() {
+		var err error
+		if merged, err = mergePolicyDocumentStatements(merged, document); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(STATEMENTS), "Invalid policy statements", err.Error())
+		}
+	}
+
+	for i, raw := range data.OverridePolicyDocuments {
+		document, err := policyDocumentStatementsFromJSON(raw, fmt.Sprintf("%s[%d]", OVERRIDE_POLICY_DOCUMENTS, i))
+		if err == nil {
+			merged, err = mergePolicyDocumentStatements(merged, document)
+		}
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(OVERRIDE_POLICY_DOCUMENTS).AtListIndex(i), "Invalid policy document", err.Error())
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(merged) == 0 {
+		resp.Diagnostics.AddError("Empty policy document", "A policy document needs at least one statement, from statements or a source or override policy document.")
+		return
+	}
+
+	posts := make([]ldapi.StatementPost, 0, len(merged))
+	for _, s := range merged {
+		posts = append(posts, s.statement)
+	}
+	reps := statementPostsToStatementReps(posts)
+	encoded, err := policyStatementsToJSON(reps)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to encode policy document", err.Error())
+		return
+	}
+	policyStatements, diags := frameworkPolicyStatementsValue(ctx, reps)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sum := sha256.Sum256([]byte(encoded))
+	data.ID = types.StringValue(hex.EncodeToString(sum[:]))
+	data.JSON = types.StringValue(encoded)
+	data.PolicyStatements = policyStatements
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// policyDocumentStatementsFromJSON decodes the policy document in attribute
+// name.
+func policyDocumentStatementsFromJSON(raw string, name string) ([]policyDocumentStatement, error) {
+	statements, sids, err := policyStatementsFromJSONDocument(raw, name)
+	if err != nil {
+		return nil, err
+	}
+	document := make([]policyDocumentStatement, 0, len(statements))
+	for i, s := range statements {
+		document = append(document, policyDocumentStatement{sid: sids[i], statement: s})
+	}
+	return document, nil
+}
+
+// mergePolicyDocumentStatements merges the statements of document into
+// merged: each statement replaces the statement of merged with the same sid,
+// in place, or is added after them. Sids must be unique within document.
+func mergePolicyDocumentStatements(merged, document []policyDocumentStatement) ([]policyDocumentStatement, error) {
+	seen := make(map[string]bool, len(document))
+	for _, s := range document {
+		if s.sid == "" {
+			merged = append(merged, s)
+			continue
+		}
+		if seen[s.sid] {
+			return nil, fmt.Errorf("sid %q is used by more than one statement", s.sid)
+		}
+		seen[s.sid] = true
+		replaced := false
+		for i := range merged {
+			if merged[i].sid == s.sid {
+				merged[i] = s
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, s)
+		}
+	}
+	return merged, nil
+}
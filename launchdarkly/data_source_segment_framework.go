@@ -47,6 +47,7 @@ var segmentRuleAttrTypes = map[string]attr.Type{
 	WEIGHT:               types.Int64Type,
 	BUCKET_BY:            types.StringType,
 	ROLLOUT_CONTEXT_KIND: types.StringType,
+	REF:                  types.StringType,
 }
 
 func NewSegmentDataSource() datasource.DataSource {
@@ -125,6 +126,7 @@ func (d *SegmentDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 						WEIGHT:               schema.Int64Attribute{Computed: true, Description: "Rule weight (1-100000)."},
 						BUCKET_BY:            schema.StringAttribute{Computed: true, Description: "Attribute for bucketing contexts."},
 						ROLLOUT_CONTEXT_KIND: schema.StringAttribute{Computed: true, Description: "Context kind for the rollout."},
+						REF:                  schema.StringAttribute{Computed: true, Description: "The rule's ID in LaunchDarkly."},
 						CLAUSES:              frameworkClausesDataSourceAttribute(),
 					},
 				},
@@ -279,6 +281,7 @@ func segmentRulesToFrameworkList(ctx context.Context, rules []ldapi.UserSegmentR
 			WEIGHT:               types.Int64Value(weight),
 			BUCKET_BY:            types.StringValue(bucketBy),
 			ROLLOUT_CONTEXT_KIND: types.StringValue(rolloutContextKind),
+			REF:                  types.StringValue(r.GetId()),
 		})
 		elements = append(elements, obj)
 	}
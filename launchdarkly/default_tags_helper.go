@@ -0,0 +1,149 @@
+package launchdarkly
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// The provider's default_tags are merged into the tags of every taggable
+// resource. LaunchDarkly tags are plain strings, so the merge is a set
+// union. Each resource exposes the merged set as the computed tags_all,
+// sets it at plan time in ModifyPlan (see planTagsAll), and sends it to
+// LaunchDarkly in place of tags. On read, tags keeps only the tags the
+// resource declares (see splitDefaultTags), so default tags never show up
+// as drift. A tag that is both declared on the resource and in
+// default_tags belongs to the resource: it is kept when it is later
+// removed from default_tags.
+
+// defaultTagsFromProviderModel returns the tags of the provider's
+// default_tags block, sorted, or nil when the block is absent.
+func defaultTagsFromProviderModel(ctx context.Context, data launchdarklyProviderModel) ([]string, diag.Diagnostics) {
+	if data.DefaultTags.IsNull() || data.DefaultTags.IsUnknown() {
+		return nil, nil
+	}
+	var block struct {
+		Tags types.Set `tfsdk:"tags"`
+	}
+	diags := data.DefaultTags.As(ctx, &block, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+	tags, d := stringSliceFromSet(ctx, block.Tags)
+	diags.Append(d...)
+	sort.Strings(tags)
+	return tags, diags
+}
+
+// tagsAllAttribute is the computed tags_all attribute of taggable resources.
+func tagsAllAttribute() schema.SetAttribute {
+	return schema.SetAttribute{
+		Computed:    true,
+		ElementType: types.StringType,
+		Description: "All tags applied to the resource in LaunchDarkly: the resource's `tags` merged with the provider's `default_tags`.",
+	}
+}
+
+// withDefaultTags returns the sorted union of tags and the provider's
+// default_tags. It is safe to call on a nil client, which has no defaults.
+func (c *Client) withDefaultTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	out := make([]string, 0, len(tags))
+	var defaults []string
+	if c != nil {
+		defaults = c.defaultTags
+	}
+	for _, t := range append(append([]string{}, tags...), defaults...) {
+		if !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// planTagsAll sets tags_all in the plan of a taggable resource. Resources
+// whose ModifyPlan later sets the plan from req.Plan defer it; resources
+// that compare the plan to state call it first and read resp.Plan instead.
+func (c *Client) planTagsAll(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var tags types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(TAGS), &tags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if tags.IsNull() {
+		// Not configured. Optional+Computed tags keep their planned value,
+		// falling back to the prior state when the plan marks them unknown.
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(TAGS), &tags)...)
+		if tags.IsUnknown() && !req.State.Raw.IsNull() {
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(TAGS), &tags)...)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if tags.IsUnknown() {
+			tags = types.SetNull(types.StringType)
+		}
+	}
+
+	// Until the configured tags are known, neither is tags_all.
+	unknown := tags.IsUnknown()
+	own := make([]string, 0, len(tags.Elements()))
+	for _, e := range tags.Elements() {
+		s, ok := e.(types.String)
+		if !ok || s.IsUnknown() {
+			unknown = true
+			break
+		}
+		own = append(own, s.ValueString())
+	}
+	if unknown {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(TAGS_ALL), types.SetUnknown(types.StringType))...)
+		return
+	}
+
+	all, d := setFromStringSlice(ctx, c.withDefaultTags(own))
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(TAGS_ALL), all)...)
+}
+
+// splitDefaultTags splits the tags LaunchDarkly reports for a resource into
+// the resource's own tags, which excludes default tags the resource does not
+// declare in prior, and tags_all. prior is the resource's tags in the plan
+// or state being refreshed.
+func (c *Client) splitDefaultTags(ctx context.Context, apiTags []string, prior types.Set) ([]string, types.Set, diag.Diagnostics) {
+	declared := make(map[string]bool, len(prior.Elements()))
+	for _, e := range prior.Elements() {
+		if s, ok := e.(types.String); ok && !s.IsUnknown() && !s.IsNull() {
+			declared[s.ValueString()] = true
+		}
+	}
+	isDefault := make(map[string]bool)
+	if c != nil {
+		for _, t := range c.defaultTags {
+			isDefault[t] = true
+		}
+	}
+
+	own := make([]string, 0, len(apiTags))
+	for _, t := range apiTags {
+		if declared[t] || !isDefault[t] {
+			own = append(own, t)
+		}
+	}
+	all, diags := setFromStringSlice(ctx, apiTags)
+	return own, all, diags
+}
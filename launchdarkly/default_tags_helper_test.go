@@ -0,0 +1,59 @@
+package launchdarkly
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultTagsFromProviderModel(t *testing.T) {
+	ctx := context.Background()
+
+	tags, d := defaultTagsFromProviderModel(ctx, launchdarklyProviderModel{
+		DefaultTags: types.ObjectNull(map[string]attr.Type{TAGS: types.SetType{ElemType: types.StringType}}),
+	})
+	require.False(t, d.HasError())
+	assert.Nil(t, tags)
+
+	set, d := setFromStringSlice(ctx, []string{"team-a", "managed-by-terraform"})
+	require.False(t, d.HasError())
+	block := types.ObjectValueMust(map[string]attr.Type{TAGS: types.SetType{ElemType: types.StringType}}, map[string]attr.Value{TAGS: set})
+	tags, d = defaultTagsFromProviderModel(ctx, launchdarklyProviderModel{DefaultTags: block})
+	require.False(t, d.HasError(), "unexpected diagnostics: %v", d)
+	assert.Equal(t, []string{"managed-by-terraform", "team-a"}, tags)
+}
+
+func TestWithDefaultTags(t *testing.T) {
+	client := &Client{defaultTags: []string{"managed-by-terraform", "team-a"}}
+	assert.Equal(t, []string{"checkout", "managed-by-terraform", "team-a"}, client.withDefaultTags([]string{"team-a", "checkout"}))
+	assert.Equal(t, []string{"managed-by-terraform", "team-a"}, client.withDefaultTags(nil))
+	assert.Equal(t, []string{"checkout"}, (&Client{}).withDefaultTags([]string{"checkout"}))
+
+	var noClient *Client
+	assert.Equal(t, []string{"checkout"}, noClient.withDefaultTags([]string{"checkout"}))
+}
+
+func TestSplitDefaultTags(t *testing.T) {
+	ctx := context.Background()
+	client := &Client{defaultTags: []string{"managed-by-terraform", "team-a"}}
+
+	declared, d := setFromStringSlice(ctx, []string{"checkout", "team-a"})
+	require.False(t, d.HasError())
+
+	own, all, d := client.splitDefaultTags(ctx, []string{"checkout", "managed-by-terraform", "team-a"}, declared)
+	require.False(t, d.HasError())
+	assert.Equal(t, []string{"checkout", "team-a"}, own, "declared tags stay on the resource even when they are also defaults")
+	allTags, d := stringSliceFromSet(ctx, all)
+	require.False(t, d.HasError())
+	assert.ElementsMatch(t, []string{"checkout", "managed-by-terraform", "team-a"}, allTags)
+
+	t.Run("tags added outside Terraform are kept", func(t *testing.T) {
+		own, _, d := client.splitDefaultTags(ctx, []string{"managed-by-terraform", "manual"}, types.SetNull(types.StringType))
+		require.False(t, d.HasError())
+		assert.Equal(t, []string{"manual"}, own)
+	})
+}
@@ -0,0 +1,209 @@
+package launchdarkly
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/ephemeralvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ldapi "github.com/launchdarkly/api-client-go/v24"
+)
+
+var (
+	_ ephemeral.EphemeralResource                     = &AccessTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure        = &AccessTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose            = &AccessTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigValidators = &AccessTokenEphemeralResource{}
+)
+
+// accessTokenEphemeralPrivateKey is the private-data key under which Open
+// records the ID of the token it created, so Close can delete it.
+const accessTokenEphemeralPrivateKey = "access_token"
+
+// accessTokenEphemeralDefaultName names the tokens created without a name.
+const accessTokenEphemeralDefaultName = "Terraform ephemeral access token"
+
+type AccessTokenEphemeralResource struct {
+	client *Client
+}
+
+type AccessTokenEphemeralResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	Role              types.String `tfsdk:"role"`
+	CustomRoles       types.Set    `tfsdk:"custom_roles"`
+	DefaultAPIVersion types.Int64  `tfsdk:"default_api_version"`
+	Token             types.String `tfsdk:"token"`
+}
+
+// accessTokenEphemeralPrivate is the JSON shape stored in private data
+// between Open and Close.
+type accessTokenEphemeralPrivate struct {
+	ID string `json:"id"`
+}
+
+func NewAccessTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &AccessTokenEphemeralResource{}
+}
+
+func (r *AccessTokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_token"
+}
+
+func (r *AccessTokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `Provides a short-lived LaunchDarkly access token as an ephemeral resource.
+
+LaunchDarkly returns the plaintext value of an access token only once, when the token is created. This ephemeral resource therefore creates a new personal access token each time Terraform opens it and deletes the token when Terraform closes it at the end of the run. The token value is never written to the Terraform plan or state.
+
+Use it to hand a scoped, single-run credential to another provider or to a write-only argument. For a long-lived token, use the ` + "`launchdarkly_access_token`" + ` resource instead.
+
+The ephemeral resource must contain either a "role" or "custom_roles" attribute.
+
+-> **Note:** Ephemeral resources require Terraform 1.10 or later. Terraform opens ephemeral resources during both plan and apply, so each run creates and deletes a token in each phase.`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the access token created for this run.",
+			},
+			NAME: schema.StringAttribute{
+				Optional:    true,
+				Description: "A human-friendly name for the access token. Defaults to `Terraform ephemeral access token`.",
+			},
+			ROLE: schema.StringAttribute{
+				Optional:    true,
+				Description: "A built-in LaunchDarkly role. Can be `reader`, `writer`, or `admin`",
+				Validators: []validator.String{
+					oneOfValidator{allowed: []string{"reader", "writer", "admin"}},
+				},
+			},
+			CUSTOM_ROLES: schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "A list of custom role IDs to use as access limits for the access token.",
+			},
+			DEFAULT_API_VERSION: schema.Int64Attribute{
+				Optional:    true,
+				Description: "The default API version for this token. Defaults to the latest API version.",
+				Validators: []validator.Int64{
+					apiVersionValidator{},
+				},
+			},
+			TOKEN: schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The access token used to authorize usage of the LaunchDarkly API.",
+			},
+		},
+	}
+}
+
+func (r *AccessTokenEphemeralResource) ConfigValidators(_ context.Context) []ephemeral.ConfigValidator {
+	return []ephemeral.ConfigValidator{
+		ephemeralvalidator.ExactlyOneOf(
+			path.MatchRoot(ROLE),
+			path.MatchRoot(CUSTOM_ROLES),
+		),
+	}
+}
+
+func (r *AccessTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	r.client = configureEphemeralResourceClient(req, resp)
+}
+
+func (r *AccessTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	if r.client == nil {
+		return
+	}
+	if r.client.rejectReadOnly("launchdarkly_access_token", "open", &resp.Diagnostics) {
+		return
+	}
+
+	var data AccessTokenEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An unnamed token is hard to recognize in LaunchDarkly if it outlives
+	// the run, so it is given a name that says where it came from.
+	name := data.Name.ValueString()
+	if data.Name.IsNull() {
+		name = accessTokenEphemeralDefaultName
+	}
+	body := ldapi.AccessTokenPost{
+		Name:         ldapi.PtrString(name),
+		ServiceToken: ldapi.PtrBool(false),
+	}
+	if !data.DefaultAPIVersion.IsNull() && data.DefaultAPIVersion.ValueInt64() != 0 {
+		v := int32(data.DefaultAPIVersion.ValueInt64())
+		body.DefaultApiVersion = &v
+	}
+	customRoles, diags := stringSliceFromSet(ctx, data.CustomRoles)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(customRoles) > 0 {
+		body.CustomRoleIds = customRoles
+	} else {
+		body.Role = ldapi.PtrString(data.Role.ValueString())
+	}
+
+	var token *ldapi.Token
+	err := r.client.withConcurrency(r.client.ctx, func() error {
+		var e error
+		token, _, e = r.client.ld.AccessTokensApi.PostToken(r.client.ctx).AccessTokenPost(body).Execute()
+		return e
+	})
+	if err != nil {
+		addLdapiError(&resp.Diagnostics, "Failed to create access token", err)
+		return
+	}
+
+	private, err := json.Marshal(accessTokenEphemeralPrivate{ID: token.Id})
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to record access token ID", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, accessTokenEphemeralPrivateKey, private)...)
+
+	data.ID = types.StringValue(token.Id)
+	data.Token = stringValueFromPointer(token.Token)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// Close deletes the token created by Open. A token that is already gone is
+// not an error: the goal of Close is that the token no longer exists.
+func (r *AccessTokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	if r.client == nil {
+		return
+	}
+
+	raw, diags := req.Private.GetKey(ctx, accessTokenEphemeralPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || raw == nil {
+		return
+	}
+	var private accessTokenEphemeralPrivate
+	if err := json.Unmarshal(raw, &private); err != nil {
+		resp.Diagnostics.AddError("Failed to read access token ID", err.Error())
+		return
+	}
+
+	var res *http.Response
+	err := r.client.withConcurrency(r.client.ctx, func() error {
+		var e error
+		res, e = r.client.ld.AccessTokensApi.DeleteToken(r.client.ctx, private.ID).Execute()
+		return e
+	})
+	if err != nil && !isStatusNotFound(res) {
+		addLdapiError(&resp.Diagnostics, fmt.Sprintf("Failed to delete access token %q", private.ID), err)
+	}
+}
@@ -0,0 +1,113 @@
+package launchdarkly
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ldapi "github.com/launchdarkly/api-client-go/v24"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &EnvironmentCredentialsEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &EnvironmentCredentialsEphemeralResource{}
+)
+
+type EnvironmentCredentialsEphemeralResource struct {
+	client *Client
+}
+
+type EnvironmentCredentialsEphemeralResourceModel struct {
+	ProjectKey     types.String `tfsdk:"project_key"`
+	EnvironmentKey types.String `tfsdk:"environment_key"`
+	APIKey         types.String `tfsdk:"api_key"`
+	MobileKey      types.String `tfsdk:"mobile_key"`
+	ClientSideID   types.String `tfsdk:"client_side_id"`
+}
+
+func NewEnvironmentCredentialsEphemeralResource() ephemeral.EphemeralResource {
+	return &EnvironmentCredentialsEphemeralResource{}
+}
+
+func (r *EnvironmentCredentialsEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_environment_credentials"
+}
+
+func (r *EnvironmentCredentialsEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `Provides the credentials of a LaunchDarkly environment as an ephemeral resource.
+
+This ephemeral resource reads an environment's SDK key, mobile key, and client-side ID at apply time. The values are never written to the Terraform plan or state, so you can pass them to write-only arguments of other resources, such as a secret in Vault or Kubernetes, without persisting them.
+
+-> **Note:** Ephemeral resources require Terraform 1.10 or later.`,
+		Attributes: map[string]schema.Attribute{
+			PROJECT_KEY: schema.StringAttribute{
+				Required:    true,
+				Description: "The project key.",
+				Validators:  []validator.String{keyValidator()},
+			},
+			ENVIRONMENT_KEY: schema.StringAttribute{
+				Required:    true,
+				Description: "The environment key.",
+				Validators:  []validator.String{keyValidator()},
+			},
+			API_KEY: schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The environment's SDK key.",
+			},
+			MOBILE_KEY: schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The environment's mobile key.",
+			},
+			CLIENT_SIDE_ID: schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The environment's client-side ID.",
+			},
+		},
+	}
+}
+
+func (r *EnvironmentCredentialsEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	r.client = configureEphemeralResourceClient(req, resp)
+}
+
+func (r *EnvironmentCredentialsEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	if r.client == nil {
+		return
+	}
+
+	var data EnvironmentCredentialsEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectKey := data.ProjectKey.ValueString()
+	envKey := data.EnvironmentKey.ValueString()
+
+	var env *ldapi.Environment
+	var err error
+	err = r.client.withConcurrency(r.client.ctx, func() error {
+		env, _, err = r.client.ld.EnvironmentsApi.GetEnvironment(r.client.ctx, projectKey, envKey).Execute()
+		return err
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("failed to get environment with key %q for project key: %q: %s", envKey, projectKey, handleLdapiErr(err).Error()),
+			"",
+		)
+		return
+	}
+
+	data.APIKey = types.StringValue(env.ApiKey)
+	data.MobileKey = types.StringValue(env.MobileKey)
+	data.ClientSideID = types.StringValue(env.Id)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
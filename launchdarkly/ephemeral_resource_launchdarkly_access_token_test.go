@@ -0,0 +1,70 @@
+package launchdarkly
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+const testAccEphemeralAccessToken = `
+ephemeral "launchdarkly_access_token" "test" {
+	name = "Terraform ephemeral access token"
+	role = "reader"
+}
+
+provider "echo" {
+	data = ephemeral.launchdarkly_access_token.test
+}
+
+resource "echo" "test" {}
+`
+
+func TestAccEphemeralAccessToken_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: testAccEphemeralProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEphemeralAccessToken,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("echo.test", "data.role", "reader"),
+					resource.TestCheckResourceAttrSet("echo.test", "data.id"),
+					resource.TestCheckResourceAttrSet("echo.test", "data.token"),
+					// Close deletes the token at the end of the run, so the ID
+					// echoed into state must no longer resolve.
+					testAccCheckEphemeralAccessTokenDeleted("echo.test"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckEphemeralAccessTokenDeleted(echoResourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[echoResourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", echoResourceName)
+		}
+		id := rs.Primary.Attributes["data.id"]
+		if id == "" {
+			return fmt.Errorf("%s did not echo an access token ID", echoResourceName)
+		}
+		client := mustTestAccClient()
+		_, res, err := client.ld.AccessTokensApi.GetToken(client.ctx, id).Execute()
+		if isStatusNotFound(res) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("ephemeral access token %s still exists after close", id)
+	}
+}
@@ -0,0 +1,57 @@
+package launchdarkly
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// testAccEphemeralProtoV6ProviderFactories adds the echo provider, which
+// copies an ephemeral value into the state of its `echo` resource so a test
+// can assert on data Terraform never persists otherwise.
+var testAccEphemeralProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"launchdarkly": testAccProtoV6ProviderFactories["launchdarkly"],
+	"echo":         echoprovider.NewProviderServer(),
+}
+
+const testAccEphemeralEnvironmentCredentials = `
+ephemeral "launchdarkly_environment_credentials" "test" {
+	project_key     = launchdarkly_project.test.key
+	environment_key = "test"
+}
+
+provider "echo" {
+	data = ephemeral.launchdarkly_environment_credentials.test
+}
+
+resource "echo" "test" {}
+`
+
+func TestAccEphemeralEnvironmentCredentials_Basic(t *testing.T) {
+	projectKey := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: testAccEphemeralProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: withRandomProject(projectKey, testAccEphemeralEnvironmentCredentials),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("echo.test", "data.project_key", projectKey),
+					resource.TestCheckResourceAttr("echo.test", "data.environment_key", "test"),
+					resource.TestCheckResourceAttrPair("echo.test", "data.api_key", "launchdarkly_project.test", "environments.test.api_key"),
+					resource.TestCheckResourceAttrPair("echo.test", "data.mobile_key", "launchdarkly_project.test", "environments.test.mobile_key"),
+					resource.TestCheckResourceAttrPair("echo.test", "data.client_side_id", "launchdarkly_project.test", "environments.test.client_side_id"),
+				),
+			},
+		},
+	})
+}
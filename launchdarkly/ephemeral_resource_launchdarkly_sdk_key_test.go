@@ -0,0 +1,54 @@
+package launchdarkly
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+const testAccEphemeralSdkKey = `
+resource "launchdarkly_sdk_key" "test" {
+	project_key     = launchdarkly_project.test.key
+	environment_key = "test"
+	key             = "tf-test-ephemeral-sdk-key"
+	name            = "Terraform ephemeral SDK key"
+}
+
+ephemeral "launchdarkly_sdk_key" "test" {
+	project_key     = launchdarkly_sdk_key.test.project_key
+	environment_key = launchdarkly_sdk_key.test.environment_key
+	key             = launchdarkly_sdk_key.test.key
+}
+
+provider "echo" {
+	data = ephemeral.launchdarkly_sdk_key.test
+}
+
+resource "echo" "test" {}
+`
+
+func TestAccEphemeralSdkKey_Basic(t *testing.T) {
+	projectKey := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: testAccEphemeralProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSdkKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: withRandomProject(projectKey, testAccEphemeralSdkKey),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("echo.test", "data.key", "tf-test-ephemeral-sdk-key"),
+					resource.TestCheckResourceAttr("echo.test", "data.kind", "sdk"),
+					resource.TestCheckResourceAttrPair("echo.test", "data.value", "launchdarkly_sdk_key.test", VALUE),
+				),
+			},
+		},
+	})
+}
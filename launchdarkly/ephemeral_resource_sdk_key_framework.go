@@ -0,0 +1,114 @@
+package launchdarkly
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &SdkKeyEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &SdkKeyEphemeralResource{}
+)
+
+type SdkKeyEphemeralResource struct {
+	client *Client
+}
+
+type SdkKeyEphemeralResourceModel struct {
+	ProjectKey     types.String `tfsdk:"project_key"`
+	EnvironmentKey types.String `tfsdk:"environment_key"`
+	Key            types.String `tfsdk:"key"`
+	Kind           types.String `tfsdk:"kind"`
+	Value          types.String `tfsdk:"value"`
+}
+
+func NewSdkKeyEphemeralResource() ephemeral.EphemeralResource {
+	return &SdkKeyEphemeralResource{}
+}
+
+func (r *SdkKeyEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sdk_key"
+}
+
+func (r *SdkKeyEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `Provides the value of a LaunchDarkly SDK key as an ephemeral resource.
+
+~> **Beta:** This ephemeral resource uses a beta API. Beta resources may change or be removed in future versions.
+
+This ephemeral resource reads the value of an SDK key, such as one managed by ` + "`launchdarkly_sdk_key`" + `, at apply time. The value is never written to the Terraform plan or state.
+
+-> **Note:** Ephemeral resources require Terraform 1.10 or later.`,
+		Attributes: map[string]schema.Attribute{
+			PROJECT_KEY: schema.StringAttribute{
+				Required:    true,
+				Description: "The project key.",
+				Validators:  []validator.String{keyValidator()},
+			},
+			ENVIRONMENT_KEY: schema.StringAttribute{
+				Required:    true,
+				Description: "The environment key.",
+				Validators:  []validator.String{keyValidator()},
+			},
+			KEY: schema.StringAttribute{
+				Required:    true,
+				Description: "The user-defined identifying key of the SDK key.",
+				Validators:  []validator.String{keyValidator()},
+			},
+			KIND: schema.StringAttribute{
+				Computed:    true,
+				Description: "The kind of SDK key. Either `sdk` (server-side) or `mobile`.",
+			},
+			VALUE: schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The actual SDK key value. Use this when configuring your SDK.",
+			},
+		},
+	}
+}
+
+func (r *SdkKeyEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	r.client = configureEphemeralResourceClient(req, resp)
+}
+
+func (r *SdkKeyEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	if r.client == nil {
+		return
+	}
+
+	var data SdkKeyEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	beta, err := r.client.betaClientFromConfig()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to construct beta client", err.Error())
+		return
+	}
+
+	projectKey := data.ProjectKey.ValueString()
+	environmentKey := data.EnvironmentKey.ValueString()
+	sdkKeyKey := data.Key.ValueString()
+
+	sdkKey, _, err := getSdkKey(beta, projectKey, environmentKey, sdkKeyKey)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("failed to get SDK key %q in environment %q of project %q: %s", sdkKeyKey, environmentKey, projectKey, handleLdapiErr(err).Error()),
+			"",
+		)
+		return
+	}
+
+	data.Kind = types.StringValue(string(sdkKey.Kind))
+	data.Value = types.StringValue(sdkKey.Value)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,354 @@
+package launchdarkly
+
+// Starting an experiment on a flag environment's fallthrough or on one of its
+// rules turns the percentage rollout there into an experiment: LaunchDarkly
+// seeds its bucketing, records the variation that serves contexts outside
+// the experiment, marks buckets that are not tracked, and tracks the rule's
+// events. launchdarkly_feature_flag_environment reads these settings back
+// and keeps them when the configuration leaves them out, so an apply does
+// not stop an experiment started in the UI. Semantic-patch instructions
+// cannot set them, so Update patches them separately once the instructions
+// are applied.
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	ldapi "github.com/launchdarkly/api-client-go/v24"
+)
+
+var experimentAllocationAttrTypes = map[string]attr.Type{
+	DEFAULT_VARIATION:    types.Int64Type,
+	CAN_RESHUFFLE:        types.BoolType,
+	UNTRACKED_VARIATIONS: types.SetType{ElemType: types.Int64Type},
+}
+
+type experimentAllocationModel struct {
+	DefaultVariation    types.Int64 `tfsdk:"default_variation"`
+	CanReshuffle        types.Bool  `tfsdk:"can_reshuffle"`
+	UntrackedVariations types.Set   `tfsdk:"untracked_variations"`
+}
+
+// withRolloutExperimentAttrTypes adds the experiment settings of a rollout,
+// and for a rule whether its events are tracked, to attrTypes.
+func withRolloutExperimentAttrTypes(attrTypes map[string]attr.Type, rule bool) map[string]attr.Type {
+	attrTypes = maps.Clone(attrTypes)
+	attrTypes[SEED] = types.Int64Type
+	attrTypes[EXPERIMENT_ALLOCATION] = types.ObjectType{AttrTypes: experimentAllocationAttrTypes}
+	if rule {
+		attrTypes[TRACK_EVENTS] = types.BoolType
+	}
+	return attrTypes
+}
+
+// withNullExperimentSettings adds null experiment settings to the attributes
+// of a rule or fallthrough object that has none.
+func withNullExperimentSettings(attrs map[string]attr.Value, rule bool) map[string]attr.Value {
+	attrs[SEED] = types.Int64Null()
+	attrs[EXPERIMENT_ALLOCATION] = types.ObjectNull(experimentAllocationAttrTypes)
+	if rule {
+		attrs[TRACK_EVENTS] = types.BoolNull()
+	}
+	return attrs
+}
+
+func rolloutSeedAttribute() schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "The seed LaunchDarkly buckets contexts into the percentage rollout with. Contexts keep their bucket for as long as the seed is unchanged. This argument is only valid if `rollout_weights` is also specified. If omitted, the value LaunchDarkly has is kept.",
+	}
+}
+
+func experimentAllocationAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Makes the percentage rollout an experiment, as starting an experiment in the LaunchDarkly UI does. This argument is only valid if `rollout_weights` is also specified. If omitted, the value LaunchDarkly has is kept.",
+		Attributes: map[string]schema.Attribute{
+			DEFAULT_VARIATION: schema.Int64Attribute{
+				Required:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+				Description: "The index of the variation served to contexts that are not in the experiment.",
+			},
+			CAN_RESHUFFLE: schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether LaunchDarkly may reassign contexts to new buckets when the experiment's allocation changes. Defaults to `false`.",
+			},
+			UNTRACKED_VARIATIONS: schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.Int64Type,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueInt64sAre(int64validator.AtLeast(0)),
+				},
+				Description: "The indexes of the variations whose rollout buckets are not part of the experiment.",
+			},
+		},
+	}
+}
+
+func ruleTrackEventsAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Whether to send event data for evaluations that match this rule back to LaunchDarkly. LaunchDarkly turns it on for rules with a running experiment. If omitted, the value LaunchDarkly has is kept.",
+	}
+}
+
+func trackEventsFallthroughAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional:      true,
+		Computed:      true,
+		PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+		Description:   "Whether to send event data for evaluations served by the `fallthrough` back to LaunchDarkly. LaunchDarkly turns it on when an experiment runs on the fallthrough. If omitted, the value LaunchDarkly has is kept.",
+	}
+}
+
+// ffeRolloutExperimentValues returns the seed and experiment allocation of
+// rollout, which may be nil.
+func ffeRolloutExperimentValues(rollout *ldapi.Rollout, diags *diag.Diagnostics) (types.Int64, types.Object) {
+	seed := types.Int64Null()
+	allocation := types.ObjectNull(experimentAllocationAttrTypes)
+	if rollout == nil {
+		return seed, allocation
+	}
+	if rollout.Seed != nil {
+		seed = types.Int64Value(int64(*rollout.Seed))
+	}
+	if rollout.ExperimentAllocation == nil {
+		return seed, allocation
+	}
+	var untracked []attr.Value
+	for i, v := range rollout.Variations {
+		if v.Untracked != nil && *v.Untracked {
+			untracked = append(untracked, types.Int64Value(int64(i)))
+		}
+	}
+	untrackedSet := types.SetNull(types.Int64Type)
+	if len(untracked) > 0 {
+		s, d := types.SetValue(types.Int64Type, untracked)
+		diags.Append(d...)
+		untrackedSet = s
+	}
+	allocation, d := types.ObjectValue(experimentAllocationAttrTypes, map[string]attr.Value{
+		DEFAULT_VARIATION:    types.Int64Value(int64(rollout.ExperimentAllocation.DefaultVariation)),
+		CAN_RESHUFFLE:        types.BoolValue(rollout.ExperimentAllocation.CanReshuffle),
+		UNTRACKED_VARIATIONS: untrackedSet,
+	})
+	diags.Append(d...)
+	return seed, allocation
+}
+
+// withRolloutExperiment sets the experiment settings of rollout to seed and
+// allocation. Null and unknown settings are left unset.
+func withRolloutExperiment(ctx context.Context, rollout *ldapi.Rollout, seed types.Int64, allocation types.Object) diag.Diagnostics {
+	var diags diag.Diagnostics
+	rollout.Seed = nil
+	if !seed.IsNull() && !seed.IsUnknown() {
+		s := int32(seed.ValueInt64())
+		rollout.Seed = &s
+	}
+	rollout.ExperimentAllocation = nil
+	for i := range rollout.Variations {
+		rollout.Variations[i].Untracked = nil
+	}
+	if allocation.IsNull() || allocation.IsUnknown() {
+		return diags
+	}
+	var m experimentAllocationModel
+	diags.Append(allocation.As(ctx, &m, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return diags
+	}
+	rollout.ExperimentAllocation = &ldapi.ExperimentAllocationRep{
+		DefaultVariation: int32(m.DefaultVariation.ValueInt64()),
+		CanReshuffle:     m.CanReshuffle.ValueBool(),
+	}
+	var untracked []int64
+	if !m.UntrackedVariations.IsNull() && !m.UntrackedVariations.IsUnknown() {
+		diags.Append(m.UntrackedVariations.ElementsAs(ctx, &untracked, false)...)
+	}
+	for i := range rollout.Variations {
+		if slices.Contains(untracked, int64(i)) {
+			rollout.Variations[i].Untracked = ldapi.PtrBool(true)
+		}
+	}
+	return diags
+}
+
+// checkRolloutExperiment rejects experiment settings on what is not a
+// percentage rollout.
+func checkRolloutExperiment(of string, hasRollout bool, seed types.Int64, allocation types.Object, diags *diag.Diagnostics) {
+	if hasRollout {
+		return
+	}
+	if !seed.IsNull() && !seed.IsUnknown() {
+		diags.AddError(fmt.Sprintf("%s: cannot use %s argument with variation, only with rollout_weights", of, SEED), "")
+	}
+	if !allocation.IsNull() && !allocation.IsUnknown() {
+		diags.AddError(fmt.Sprintf("%s: cannot use %s argument with variation, only with rollout_weights", of, EXPERIMENT_ALLOCATION), "")
+	}
+}
+
+// withPriorExperimentSettings fills in the experiment settings planned
+// leaves unknown: null when it serves a single variation, and otherwise the
+// settings of prior, which may be nil. Settings of a rollout whose weights
+// are not yet known stay unknown.
+func withPriorExperimentSettings(ctx context.Context, planned types.Object, prior map[string]attr.Value, diags *diag.Diagnostics) types.Object {
+	attrs := planned.Attributes()
+	weights, _ := attrs[ROLLOUT_WEIGHTS].(types.List)
+	changed := false
+	for name, null := range withNullExperimentSettings(map[string]attr.Value{}, true) {
+		value, ok := attrs[name]
+		if !ok || !value.IsUnknown() {
+			continue
+		}
+		switch {
+		case name != TRACK_EVENTS && weights.IsNull():
+			attrs[name] = null
+		case name != TRACK_EVENTS && weights.IsUnknown(), prior == nil:
+			continue
+		default:
+			attrs[name] = prior[name]
+		}
+		changed = true
+	}
+	if !changed {
+		return planned
+	}
+	obj, d := types.ObjectValue(planned.AttributeTypes(ctx), attrs)
+	diags.Append(d...)
+	return obj
+}
+
+// ffeWithPriorExperimentSettings returns plan with the experiment settings
+// its rules and fallthrough leave unknown filled in from the prior rules
+// they match and the prior fallthrough, so an experiment running in
+// LaunchDarkly is kept.
+func ffeWithPriorExperimentSettings(ctx context.Context, plan, prior FeatureFlagEnvironmentResourceModel, diags *diag.Diagnostics) FeatureFlagEnvironmentResourceModel {
+	if !plan.Rules.IsNull() && !plan.Rules.IsUnknown() {
+		elements := slices.Clone(plan.Rules.Elements())
+		var priorElements []attr.Value
+		if !prior.Rules.IsUnknown() {
+			priorElements = prior.Rules.Elements()
+		}
+		for i, j := range matchRules(elements, priorElements) {
+			var priorAttrs map[string]attr.Value
+			if j >= 0 {
+				priorAttrs = priorElements[j].(types.Object).Attributes()
+			}
+			elements[i] = withPriorExperimentSettings(ctx, elements[i].(types.Object), priorAttrs, diags)
+		}
+		list, d := types.ListValue(plan.Rules.ElementType(ctx), elements)
+		diags.Append(d...)
+		plan.Rules = list
+	}
+	if !plan.Fallthrough.IsNull() && !plan.Fallthrough.IsUnknown() {
+		var priorAttrs map[string]attr.Value
+		if !prior.Fallthrough.IsNull() && !prior.Fallthrough.IsUnknown() {
+			priorAttrs = prior.Fallthrough.Attributes()
+		}
+		plan.Fallthrough = withPriorExperimentSettings(ctx, plan.Fallthrough, priorAttrs, diags)
+	}
+	return plan
+}
+
+// planExperimentSettings keeps the experiment settings of the planned rules
+// and fallthrough that the configuration leaves unset.
+func planExperimentSettings(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var plan, prior FeatureFlagEnvironmentResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	filled := ffeWithPriorExperimentSettings(ctx, plan, prior, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !filled.Rules.Equal(plan.Rules) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(RULES), filled.Rules)...)
+	}
+	if !filled.Fallthrough.Equal(plan.Fallthrough) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(FALLTHROUGH), filled.Fallthrough)...)
+	}
+}
+
+// ffeExperimentPatches returns the JSON Patch operations that give the rules
+// and fallthrough of environment, as LaunchDarkly has them once the
+// semantic-patch instructions are applied, the experiment settings plan
+// has for them. Settings plan leaves unknown are kept as they are.
+func ffeExperimentPatches(ctx context.Context, envKey string, plan FeatureFlagEnvironmentResourceModel, environment ldapi.FeatureFlagConfig) ([]ldapi.PatchOperation, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var patches []ldapi.PatchOperation
+	rolloutPatch := func(pointer string, live *ldapi.Rollout, planned map[string]attr.Value) {
+		if live == nil {
+			return
+		}
+		liveSeed, liveAllocation := ffeRolloutExperimentValues(live, &diags)
+		seed, _ := planned[SEED].(types.Int64)
+		allocation, _ := planned[EXPERIMENT_ALLOCATION].(types.Object)
+		if seed.IsUnknown() {
+			seed = liveSeed
+		}
+		if allocation.IsUnknown() {
+			allocation = liveAllocation
+		}
+		if seed.Equal(liveSeed) && allocation.Equal(liveAllocation) {
+			return
+		}
+		rollout := *live
+		rollout.Variations = slices.Clone(live.Variations)
+		diags.Append(withRolloutExperiment(ctx, &rollout, seed, allocation)...)
+		patches = append(patches, patchReplace(ffePatchPath(envKey, pointer), rollout))
+	}
+
+	if !plan.Rules.IsNull() && !plan.Rules.IsUnknown() {
+		live := ffeResourceRulesValue(ctx, environment.Rules, &diags)
+		if diags.HasError() {
+			return nil, diags
+		}
+		planRules := plan.Rules.Elements()
+		for i, k := range matchRules(planRules, live.Elements()) {
+			if k < 0 {
+				continue
+			}
+			planned := planRules[i].(types.Object).Attributes()
+			rule := environment.Rules[k]
+			if trackEvents, _ := planned[TRACK_EVENTS].(types.Bool); !trackEvents.IsNull() && !trackEvents.IsUnknown() && trackEvents.ValueBool() != rule.TrackEvents {
+				patches = append(patches, patchReplace(ffePatchPath(envKey, fmt.Sprintf("rules/%d/trackEvents", k)), trackEvents.ValueBool()))
+			}
+			rolloutPatch(fmt.Sprintf("rules/%d/rollout", k), rule.Rollout, planned)
+		}
+	}
+	if !plan.Fallthrough.IsNull() && !plan.Fallthrough.IsUnknown() && environment.Fallthrough != nil {
+		rolloutPatch("fallthrough/rollout", environment.Fallthrough.Rollout, plan.Fallthrough.Attributes())
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+	return patches, diags
+}
@@ -0,0 +1,162 @@
+package launchdarkly
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ldapi "github.com/launchdarkly/api-client-go/v24"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ffeTestExperimentRollout returns a 50/50 rollout running an experiment
+// whose second bucket is untracked.
+func ffeTestExperimentRollout() *ldapi.Rollout {
+	return &ldapi.Rollout{
+		Variations: []ldapi.WeightedVariation{
+			{Variation: 0, Weight: 50000},
+			{Variation: 1, Weight: 50000, Untracked: ldapi.PtrBool(true)},
+		},
+		Seed:                 ldapi.PtrInt32(42),
+		ExperimentAllocation: &ldapi.ExperimentAllocationRep{DefaultVariation: 1, CanReshuffle: true},
+		ContextKind:          ldapi.PtrString("user"),
+	}
+}
+
+func TestFFEExperimentSettingsRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	rule := ffeTestRule("r1", "email", "a@example.com", 0)
+	rule.Variation = nil
+	rule.Rollout = ffeTestExperimentRollout()
+	rule.TrackEvents = true
+
+	var diags diag.Diagnostics
+	rules := ffeResourceRulesValue(ctx, []ldapi.Rule{rule}, &diags)
+	fallthroughObj := ffeResourceFallthroughValue(ctx, &ldapi.VariationOrRolloutRep{Rollout: ffeTestExperimentRollout()}, &diags)
+	require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+
+	attrs := rules.Elements()[0].(types.Object).Attributes()
+	assert.Equal(t, types.Int64Value(42), attrs[SEED])
+	assert.Equal(t, types.BoolValue(true), attrs[TRACK_EVENTS])
+	allocation := attrs[EXPERIMENT_ALLOCATION].(types.Object).Attributes()
+	assert.Equal(t, types.Int64Value(1), allocation[DEFAULT_VARIATION])
+	assert.Equal(t, types.BoolValue(true), allocation[CAN_RESHUFFLE])
+	assert.Equal(t, []attr.Value{types.Int64Value(1)}, allocation[UNTRACKED_VARIATIONS].(types.Set).Elements())
+
+	payloads, d := ffeRulesFromList(ctx, rules)
+	require.False(t, d.HasError(), "unexpected diagnostics: %v", d)
+	require.Len(t, payloads, 1)
+	assert.Equal(t, rule.Rollout, payloads[0].Rollout, "the rollout is sent as it was read")
+	assert.Equal(t, ldapi.PtrBool(true), payloads[0].TrackEvents)
+
+	fall, d := ffeFallthroughFromObject(ctx, fallthroughObj)
+	require.False(t, d.HasError(), "unexpected diagnostics: %v", d)
+	assert.Equal(t, ffeTestExperimentRollout(), fall.Rollout)
+
+	t.Run("a rollout without an experiment", func(t *testing.T) {
+		var diags diag.Diagnostics
+		obj := ffeResourceFallthroughValue(ctx, &ldapi.VariationOrRolloutRep{Rollout: &ldapi.Rollout{
+			Variations: []ldapi.WeightedVariation{{Variation: 0, Weight: 100000}, {Variation: 1, Weight: 0}},
+		}}, &diags)
+		require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+		assert.True(t, obj.Attributes()[SEED].IsNull())
+		assert.True(t, obj.Attributes()[EXPERIMENT_ALLOCATION].IsNull())
+	})
+}
+
+func TestFFEWithPriorExperimentSettings(t *testing.T) {
+	ctx := context.Background()
+	rule := ffeTestRule("r1", "email", "a@example.com", 0)
+	rule.Variation = nil
+	rule.Rollout = ffeTestExperimentRollout()
+	rule.TrackEvents = true
+	var diags diag.Diagnostics
+	prior := FeatureFlagEnvironmentResourceModel{
+		Rules:       ffeResourceRulesValue(ctx, []ldapi.Rule{rule}, &diags),
+		Fallthrough: ffeResourceFallthroughValue(ctx, &ldapi.VariationOrRolloutRep{Rollout: ffeTestExperimentRollout()}, &diags),
+	}
+	require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+
+	// The configuration leaves the experiment settings out, so they are
+	// planned unknown.
+	unknown := func(obj types.Object, rule bool) types.Object {
+		attrs := obj.Attributes()
+		attrs[SEED] = types.Int64Unknown()
+		attrs[EXPERIMENT_ALLOCATION] = types.ObjectUnknown(experimentAllocationAttrTypes)
+		if rule {
+			attrs[TRACK_EVENTS] = types.BoolUnknown()
+		}
+		return types.ObjectValueMust(obj.AttributeTypes(ctx), attrs)
+	}
+	plannedRule := unknown(prior.Rules.Elements()[0].(types.Object), true)
+	plan := FeatureFlagEnvironmentResourceModel{
+		Rules:       types.ListValueMust(prior.Rules.ElementType(ctx), []attr.Value{plannedRule}),
+		Fallthrough: unknown(prior.Fallthrough, false),
+	}
+
+	filled := ffeWithPriorExperimentSettings(ctx, plan, prior, &diags)
+	require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+	assert.True(t, filled.Rules.Equal(prior.Rules), "the running experiment is kept")
+	assert.True(t, filled.Fallthrough.Equal(prior.Fallthrough), "the running experiment is kept")
+
+	t.Run("a rule that now serves a variation", func(t *testing.T) {
+		attrs := plannedRule.Attributes()
+		attrs[VARIATION] = types.Int64Value(0)
+		attrs[ROLLOUT_WEIGHTS] = types.ListNull(types.Int64Type)
+		plan := FeatureFlagEnvironmentResourceModel{
+			Rules:       types.ListValueMust(prior.Rules.ElementType(ctx), []attr.Value{types.ObjectValueMust(plannedRule.AttributeTypes(ctx), attrs)}),
+			Fallthrough: types.ObjectNull(ffeResourceFallthroughAttrTypes),
+		}
+		var diags diag.Diagnostics
+		filled := ffeWithPriorExperimentSettings(ctx, plan, prior, &diags)
+		require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+		filledAttrs := filled.Rules.Elements()[0].(types.Object).Attributes()
+		assert.True(t, filledAttrs[SEED].IsNull())
+		assert.True(t, filledAttrs[EXPERIMENT_ALLOCATION].IsNull())
+		assert.Equal(t, types.BoolValue(true), filledAttrs[TRACK_EVENTS])
+	})
+}
+
+func TestFFEExperimentPatches(t *testing.T) {
+	ctx := context.Background()
+	rule := ffeTestRule("r1", "email", "a@example.com", 0)
+	rule.Variation = nil
+	rule.Rollout = ffeTestExperimentRollout()
+	var diags diag.Diagnostics
+	plan := FeatureFlagEnvironmentResourceModel{
+		Rules:       ffeResourceRulesValue(ctx, []ldapi.Rule{rule}, &diags),
+		Fallthrough: ffeResourceFallthroughValue(ctx, &ldapi.VariationOrRolloutRep{Rollout: ffeTestExperimentRollout()}, &diags),
+	}
+	require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+
+	// The semantic patch reset the rollouts' experiment settings.
+	reset := func() *ldapi.Rollout {
+		return &ldapi.Rollout{
+			Variations:  []ldapi.WeightedVariation{{Variation: 0, Weight: 50000}, {Variation: 1, Weight: 50000}},
+			ContextKind: ldapi.PtrString("user"),
+		}
+	}
+	live := rule
+	live.Rollout = reset()
+	patches, d := ffeExperimentPatches(ctx, "test", plan, ldapi.FeatureFlagConfig{
+		Rules:       []ldapi.Rule{ffeTestRule("r0", "key", "x", 1), live},
+		Fallthrough: &ldapi.VariationOrRolloutRep{Rollout: reset()},
+	})
+	require.False(t, d.HasError(), "unexpected diagnostics: %v", d)
+	require.Len(t, patches, 2)
+	assert.Equal(t, "/environments/test/rules/1/rollout", patches[0].Path)
+	assert.Equal(t, *ffeTestExperimentRollout(), *patches[0].Value)
+	assert.Equal(t, "/environments/test/fallthrough/rollout", patches[1].Path)
+
+	t.Run("nothing to patch", func(t *testing.T) {
+		patches, d := ffeExperimentPatches(ctx, "test", plan, ldapi.FeatureFlagConfig{
+			Rules:       []ldapi.Rule{rule},
+			Fallthrough: &ldapi.VariationOrRolloutRep{Rollout: ffeTestExperimentRollout()},
+		})
+		require.False(t, d.HasError(), "unexpected diagnostics: %v", d)
+		assert.Empty(t, patches)
+	})
+}
@@ -1,11 +1,30 @@
 package launchdarkly
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
 	"net/http"
+	"net/url"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	ldapi "github.com/launchdarkly/api-client-go/v24"
 )
 
+// featureFlagEnvironmentIdToKeys parses a `project_key/env_key/flag_key`
+// composite id.
+func featureFlagEnvironmentIdToKeys(id string) (projectKey, envKey, flagKey string, err error) {
+	if strings.Count(id, "/") != 2 {
+		return "", "", "", fmt.Errorf("found unexpected feature flag environment id format: %q expected format: 'project_key/env_key/flag_key'", id)
+	}
+	parts := strings.SplitN(id, "/", 3)
+	return parts[0], parts[1], parts[2], nil
+}
+
 // rule is the patch payload shape used by acceptance tests that patch
 // FFE rules via the LD API directly. Kept here so test files don't need
 // to inline it; the framework resource builds its own ffeRulePayload
@@ -39,3 +58,83 @@ func getFeatureFlagEnvironment(client *Client, projectKey, flagKey, environmentK
 	})
 	return flag, res, err
 }
+
+// semanticPatchContentType selects LaunchDarkly's semantic patch format on
+// PATCH endpoints that also accept JSON Patch.
+const semanticPatchContentType = "application/json; domain-model=launchdarkly.semanticpatch"
+
+// patchFeatureFlagSemantic applies semantic-patch instructions to one
+// environment of a flag. The generated API client only sends JSON Patch to
+// this endpoint, so we use raw HTTP. Errors carry the response body so that
+// isApprovalRequiredErr can recognise environments that require approvals.
+func patchFeatureFlagSemantic(ctx context.Context, client *Client, projectKey, flagKey, envKey, comment string, instructions []map[string]interface{}) error {
+	log.Printf("[DEBUG] semantic patch instructions for flag %q in environment %q: %+v\n", flagKey, envKey, instructions)
+	host := client.apiHost
+	if host == "" {
+		host = DEFAULT_LAUNCHDARKLY_HOST
+	}
+	escapedProjectKey := url.PathEscape(projectKey)
+	escapedFlagKey := url.PathEscape(flagKey)
+	var endpoint string
+	if u, err := url.Parse(host); err == nil && u.Scheme != "" {
+		u.Path = fmt.Sprintf("/api/v2/flags/%s/%s", escapedProjectKey, escapedFlagKey)
+		endpoint = u.String()
+	} else {
+		endpoint = fmt.Sprintf("https://%s/api/v2/flags/%s/%s", host, escapedProjectKey, escapedFlagKey)
+	}
+
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"environmentKey": envKey,
+		"comment":        comment,
+		"instructions":   instructions,
+	})
+	if err != nil {
+		return err
+	}
+
+	return client.withConcurrency(ctx, func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPatch, endpoint, bytes.NewReader(jsonData))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", client.apiKey)
+		req.Header.Set("Content-Type", semanticPatchContentType)
+		req.Header.Set("LD-API-Version", APIVersion)
+		req.Header.Set("User-Agent", fmt.Sprintf("launchdarkly-terraform-provider/%s", version))
+
+		resp, err := client.ld.GetConfig().HTTPClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		respBody, readErr := io.ReadAll(resp.Body)
+		if readErr != nil {
+			return readErr
+		}
+		if resp.StatusCode >= 400 {
+			return fmt.Errorf("%d %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), string(respBody))
+		}
+		return nil
+	})
+}
+
+// applyFlagInstructions applies instructions to one environment of a flag.
+// When LaunchDarkly rejects them because the environment requires approval,
+// they are submitted as an approval request according to mode instead.
+func (c *Client) applyFlagInstructions(projectKey, envKey, flagKey, mode, comment string, instructions []map[string]interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	err := patchFeatureFlagSemantic(c.ctx, c, projectKey, flagKey, envKey, comment, instructions)
+	if err == nil {
+		return diags
+	}
+	if isApprovalRequiredErr(err) && mode != APPROVAL_MODE_FAIL {
+		return c.submitForApproval(mode, approvalRequestSpec{
+			resourceID:   flagApprovalResourceID(projectKey, envKey, flagKey),
+			summary:      fmt.Sprintf("flag %q in environment %q of project %q", flagKey, envKey, projectKey),
+			instructions: instructions,
+			comment:      comment,
+		})
+	}
+	diags.AddError(fmt.Sprintf("failed to update flag %q in project %q, environment %q: %s", flagKey, projectKey, envKey, handleLdapiErr(err).Error()), "")
+	return diags
+}
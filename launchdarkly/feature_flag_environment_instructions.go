@@ -0,0 +1,519 @@
+package launchdarkly
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ldapi "github.com/launchdarkly/api-client-go/v24"
+)
+
+// flagVariationIDs resolves variation indexes, which the Terraform schema
+// uses, to the variation IDs that semantic-patch instructions use. Each flag
+// is fetched at most once.
+type flagVariationIDs struct {
+	client     *Client
+	projectKey string
+	byFlag     map[string][]string
+}
+
+func newFlagVariationIDs(client *Client, projectKey string) *flagVariationIDs {
+	return &flagVariationIDs{client: client, projectKey: projectKey, byFlag: make(map[string][]string)}
+}
+
+// seed records the variation IDs of a flag the caller has already fetched.
+func (v *flagVariationIDs) seed(flag *ldapi.FeatureFlag) {
+	ids := make([]string, 0, len(flag.Variations))
+	for _, variation := range flag.Variations {
+		ids = append(ids, variation.GetId())
+	}
+	v.byFlag[flag.Key] = ids
+}
+
+func (v *flagVariationIDs) id(flagKey string, index int32) (string, error) {
+	ids, err := v.of(flagKey)
+	if err != nil {
+		return "", err
+	}
+	if index < 0 || int(index) >= len(ids) || ids[index] == "" {
+		return "", fmt.Errorf("flag %q in project %q has no variation at index %d", flagKey, v.projectKey, index)
+	}
+	return ids[index], nil
+}
+
+// index returns the index of the variation of flagKey with ID id, or -1 when
+// the flag has no such variation.
+func (v *flagVariationIDs) index(flagKey, id string) (int, error) {
+	ids, err := v.of(flagKey)
+	if err != nil {
+		return -1, err
+	}
+	return slices.Index(ids, id), nil
+}
+
+// of returns the variation IDs of flagKey, fetching the flag the first time.
+func (v *flagVariationIDs) of(flagKey string) ([]string, error) {
+	if ids, ok := v.byFlag[flagKey]; ok {
+		return ids, nil
+	}
+	var flag *ldapi.FeatureFlag
+	err := v.client.withConcurrency(v.client.ctx, func() error {
+		var e error
+		flag, _, e = v.client.ld.FeatureFlagsApi.GetFeatureFlag(v.client.ctx, v.projectKey, flagKey).Execute()
+		return e
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get flag %q in project %q: %s", flagKey, v.projectKey, handleLdapiErr(err).Error())
+	}
+	v.seed(flag)
+	return v.byFlag[flagKey], nil
+}
+
+// buildFFEInstructions translates the change from prior to plan into
+// semantic-patch instructions for the environment of flag flagKey. Only what
+// changed is sent: target values are added and removed individually, and
+// rules are added, removed, updated and reordered one by one, so edits made
+// in LaunchDarkly since prior was read are kept. liveRules are the
+// environment's current rules, which supply the IDs rule instructions refer
+// to.
+func buildFFEInstructions(ctx context.Context, flagKey string, plan, prior FeatureFlagEnvironmentResourceModel, liveRules []ldapi.Rule, ids *flagVariationIDs) ([]map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	instructions := make([]map[string]interface{}, 0)
+	addErr := func(err error) ([]map[string]interface{}, diag.Diagnostics) {
+		diags.AddError(err.Error(), "")
+		return nil, diags
+	}
+
+	if !plan.On.Equal(prior.On) {
+		kind := "turnFlagOff"
+		if plan.On.ValueBool() {
+			kind = "turnFlagOn"
+		}
+		instructions = append(instructions, map[string]interface{}{"kind": kind})
+	}
+	if !plan.OffVariation.Equal(prior.OffVariation) {
+		if plan.OffVariation.IsNull() {
+			diags.AddError(
+				fmt.Sprintf("cannot unset %s of flag %q through an approval request", OFF_VARIATION, flagKey),
+				fmt.Sprintf("Approval requests can only set the off variation. Set %s explicitly.", OFF_VARIATION),
+			)
+			return nil, diags
+		}
+		id, err := ids.id(flagKey, int32(plan.OffVariation.ValueInt64()))
+		if err != nil {
+			return addErr(err)
+		}
+		instructions = append(instructions, map[string]interface{}{"kind": "updateOffVariation", "variationId": id})
+	}
+	if !plan.TrackEvents.Equal(prior.TrackEvents) {
+		instructions = append(instructions, map[string]interface{}{"kind": "updateTrackEvents", "trackEvents": plan.TrackEvents.ValueBool()})
+	}
+	if tracked := plan.TrackEventsFallthrough; !tracked.IsNull() && !tracked.IsUnknown() && !tracked.Equal(prior.TrackEventsFallthrough) {
+		instructions = append(instructions, map[string]interface{}{"kind": "updateTrackEventsFallthrough", "trackEvents": tracked.ValueBool()})
+	}
+
+	ruleInstructions, d := ffeRuleInstructions(ctx, flagKey, plan.Rules, prior.Rules, liveRules, ids)
+	diags.Append(d...)
+	prereqInstructions, d := ffePrerequisiteInstructions(ctx, plan.Prerequisites, prior.Prerequisites, ids)
+	diags.Append(d...)
+	targetInstructions, d := ffeTargetInstructions(ctx, flagKey, plan, prior, ids)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	instructions = append(instructions, ruleInstructions...)
+	instructions = append(instructions, prereqInstructions...)
+	instructions = append(instructions, targetInstructions...)
+
+	// Experiment settings are not part of the instruction; see
+	// ffeExperimentPatches.
+	if !plan.Fallthrough.Equal(prior.Fallthrough) && (plan.Fallthrough.IsNull() || prior.Fallthrough.IsNull() || !ffeAttributesEqual(plan.Fallthrough.Attributes(), prior.Fallthrough.Attributes(), VARIATION, ROLLOUT_WEIGHTS, BUCKET_BY, CONTEXT_KIND)) {
+		fall, d := ffeFallthroughFromObject(ctx, plan.Fallthrough)
+		diags.Append(d...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		i := map[string]interface{}{"kind": "updateFallthroughVariationOrRollout"}
+		if err := ffeVariationOrRolloutInstruction(i, fall.Variation, fall.Rollout, flagKey, ids); err != nil {
+			return addErr(err)
+		}
+		instructions = append(instructions, i)
+	}
+	return instructions, diags
+}
+
+// ffeRuleInstructions diffs the planned rules against the prior ones. Planned
+// and prior rules are paired with matchRules: a paired rule is updated in
+// place, and is moved if its position changed. Other planned rules are added
+// and other prior rules removed. Prior rules are matched to liveRules the same
+// way to find their IDs. Rules added in LaunchDarkly outside Terraform keep
+// their positions.
+func ffeRuleInstructions(ctx context.Context, flagKey string, plan, prior types.List, liveRules []ldapi.Rule, ids *flagVariationIDs) ([]map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if plan.Equal(prior) {
+		return nil, diags
+	}
+	payloads, d := ffeRulesFromList(ctx, plan)
+	diags.Append(d...)
+	live := ffeResourceRulesValue(ctx, liveRules, &diags)
+	if diags.HasError() {
+		return nil, diags
+	}
+	planRules, priorRules := plan.Elements(), prior.Elements()
+	planToPrior := matchRules(planRules, priorRules)
+	priorToLive := matchRules(priorRules, live.Elements())
+
+	instructions := make([]map[string]interface{}, 0)
+	kept := make([]bool, len(priorRules))
+	for _, j := range planToPrior {
+		if j >= 0 {
+			kept[j] = true
+		}
+	}
+	removed := make(map[string]bool)
+	for j, k := range priorToLive {
+		// A rule already deleted in LaunchDarkly needs no removal.
+		if kept[j] || k < 0 {
+			continue
+		}
+		id := liveRules[k].GetId()
+		removed[id] = true
+		instructions = append(instructions, map[string]interface{}{"kind": "removeRule", "ruleId": id})
+	}
+
+	// ruleIDs holds the live ID of each planned rule that already exists.
+	ruleIDs := make([]string, len(planRules))
+	for i, j := range planToPrior {
+		if j < 0 {
+			continue
+		}
+		k := priorToLive[j]
+		if k < 0 {
+			diags.AddError(
+				fmt.Sprintf("rule %d of flag %q changed in LaunchDarkly since it was last read", i+1, flagKey),
+				"Refresh the state and plan again.",
+			)
+			return nil, diags
+		}
+		ruleIDs[i] = liveRules[k].GetId()
+		planned := planRules[i].(types.Object).Attributes()
+		was := priorRules[j].(types.Object).Attributes()
+		updates, err := ffeUpdateRuleInstructions(flagKey, liveRules[k], planned, was, payloads[i], ids)
+		if err != nil {
+			diags.AddError(err.Error(), "")
+			return nil, diags
+		}
+		instructions = append(instructions, updates...)
+	}
+
+	current := make([]string, 0, len(liveRules))
+	for _, r := range liveRules {
+		if !removed[r.GetId()] {
+			current = append(current, r.GetId())
+		}
+	}
+	if in := reorderRulesInstruction(current, ruleIDs); in != nil {
+		instructions = append(instructions, in)
+	}
+
+	for i, payload := range payloads {
+		if ruleIDs[i] != "" {
+			continue
+		}
+		in, err := ffeAddRuleInstruction(flagKey, payload, nextRuleID(ruleIDs, i), ids)
+		if err != nil {
+			diags.AddError(err.Error(), "")
+			return nil, diags
+		}
+		instructions = append(instructions, in)
+	}
+	return instructions, diags
+}
+
+// ffeAddRuleInstruction adds the rule described by payload before the rule
+// with ID beforeRuleID, or last when beforeRuleID is "".
+func ffeAddRuleInstruction(flagKey string, payload ffeRulePayload, beforeRuleID string, ids *flagVariationIDs) (map[string]interface{}, error) {
+	in := map[string]interface{}{"kind": "addRule", "clauses": payload.Clauses}
+	if payload.Description != nil && *payload.Description != "" {
+		in["description"] = *payload.Description
+	}
+	if payload.Ref != nil {
+		in["ref"] = *payload.Ref
+	}
+	if beforeRuleID != "" {
+		in["beforeRuleId"] = beforeRuleID
+	}
+	if err := ffeVariationOrRolloutInstruction(in, payload.Variation, payload.Rollout, flagKey, ids); err != nil {
+		return nil, err
+	}
+	return in, nil
+}
+
+// ffeUpdateRuleInstructions updates live, the rule planned and was describe,
+// in place: its clauses, its variation or rollout and its description, for
+// whichever of those changed. payload is planned as sent to LaunchDarkly.
+func ffeUpdateRuleInstructions(flagKey string, live ldapi.Rule, planned, was map[string]attr.Value, payload ffeRulePayload, ids *flagVariationIDs) ([]map[string]interface{}, error) {
+	id := live.GetId()
+	var instructions []map[string]interface{}
+	if !planned[CLAUSES].Equal(was[CLAUSES]) {
+		// The new clauses are added before the old ones are removed so the
+		// rule is never left without any.
+		clauseIDs := make([]string, 0, len(live.Clauses))
+		for _, c := range live.Clauses {
+			clauseIDs = append(clauseIDs, c.GetId())
+		}
+		instructions = append(instructions,
+			map[string]interface{}{"kind": "addClauses", "ruleId": id, "clauses": payload.Clauses},
+			map[string]interface{}{"kind": "removeClauses", "ruleId": id, "clauseIds": clauseIDs},
+		)
+	}
+	if !ffeAttributesEqual(planned, was, VARIATION, ROLLOUT_WEIGHTS, BUCKET_BY, CONTEXT_KIND) {
+		in := map[string]interface{}{"kind": "updateRuleVariationOrRollout", "ruleId": id}
+		if err := ffeVariationOrRolloutInstruction(in, payload.Variation, payload.Rollout, flagKey, ids); err != nil {
+			return nil, err
+		}
+		instructions = append(instructions, in)
+	}
+	if !planned[DESCRIPTION].IsUnknown() && !planned[DESCRIPTION].Equal(was[DESCRIPTION]) {
+		instructions = append(instructions, map[string]interface{}{"kind": "updateRuleDescription", "ruleId": id, "description": *payload.Description})
+	}
+	return instructions, nil
+}
+
+func ffeAttributesEqual(a, b map[string]attr.Value, names ...string) bool {
+	for _, name := range names {
+		if !a[name].Equal(b[name]) {
+			return false
+		}
+	}
+	return true
+}
+
+// ffePrerequisiteInstructions adds, removes and updates individual
+// prerequisites. LaunchDarkly appends added prerequisites, so when that would
+// not produce the planned order the whole list is replaced instead.
+func ffePrerequisiteInstructions(ctx context.Context, plan, prior types.List, ids *flagVariationIDs) ([]map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if plan.Equal(prior) {
+		return nil, diags
+	}
+	planned, d := ffePrerequisitesFromList(ctx, plan)
+	diags.Append(d...)
+	was, d := ffePrerequisitesFromList(ctx, prior)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	plannedVariations := make(map[string]int32, len(planned))
+	for _, p := range planned {
+		plannedVariations[p.Key] = p.Variation
+	}
+	wasVariations := make(map[string]int32, len(was))
+	instructions := make([]map[string]interface{}, 0)
+	var expectedOrder []string
+	for _, p := range was {
+		wasVariations[p.Key] = p.Variation
+		if _, ok := plannedVariations[p.Key]; !ok {
+			instructions = append(instructions, map[string]interface{}{"kind": "removePrerequisite", "key": p.Key})
+			continue
+		}
+		expectedOrder = append(expectedOrder, p.Key)
+	}
+	var plannedOrder []string
+	for _, p := range planned {
+		plannedOrder = append(plannedOrder, p.Key)
+		variation, ok := wasVariations[p.Key]
+		if ok && variation == p.Variation {
+			continue
+		}
+		id, err := ids.id(p.Key, p.Variation)
+		if err != nil {
+			diags.AddError(err.Error(), "")
+			return nil, diags
+		}
+		kind := "updatePrerequisite"
+		if !ok {
+			kind = "addPrerequisite"
+			expectedOrder = append(expectedOrder, p.Key)
+		}
+		instructions = append(instructions, map[string]interface{}{"kind": kind, "key": p.Key, "variationId": id})
+	}
+	if slices.Equal(expectedOrder, plannedOrder) {
+		return instructions, diags
+	}
+
+	prereqInstructions := make([]map[string]interface{}, 0, len(planned))
+	for _, p := range planned {
+		id, err := ids.id(p.Key, p.Variation)
+		if err != nil {
+			diags.AddError(err.Error(), "")
+			return nil, diags
+		}
+		prereqInstructions = append(prereqInstructions, map[string]interface{}{"key": p.Key, "variationId": id})
+	}
+	return []map[string]interface{}{{"kind": "replacePrerequisites", "prerequisites": prereqInstructions}}, diags
+}
+
+// ffeTargetKey identifies the individual targets of one variation for one
+// context kind.
+type ffeTargetKey struct {
+	contextKind string
+	variation   int32
+}
+
+// ffeTargetValues merges targets and context_targets by context kind and
+// variation.
+func ffeTargetValues(ctx context.Context, m FeatureFlagEnvironmentResourceModel) (map[ffeTargetKey][]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	targets, d := ffeTargetsFromSet(ctx, m.Targets, false)
+	diags.Append(d...)
+	ctxTargets, d := ffeTargetsFromSet(ctx, m.ContextTargets, true)
+	diags.Append(d...)
+	values := make(map[ffeTargetKey][]string)
+	for _, t := range append(targets, ctxTargets...) {
+		key := ffeTargetKey{contextKind: t.GetContextKind(), variation: t.Variation}
+		values[key] = append(values[key], t.Values...)
+	}
+	return values, diags
+}
+
+// ffeTargetInstructions adds and removes individual target values. Removals
+// come first so a value can move between variations. Target values are sets
+// in LaunchDarkly, so a reordering alone sends nothing; ffeTargetsInOrder
+// reads values back in the configured order.
+func ffeTargetInstructions(ctx context.Context, flagKey string, plan, prior FeatureFlagEnvironmentResourceModel, ids *flagVariationIDs) ([]map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if plan.Targets.Equal(prior.Targets) && plan.ContextTargets.Equal(prior.ContextTargets) {
+		return nil, diags
+	}
+	planned, d := ffeTargetValues(ctx, plan)
+	diags.Append(d...)
+	was, d := ffeTargetValues(ctx, prior)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	keys := make([]ffeTargetKey, 0, len(planned)+len(was))
+	for key := range planned {
+		keys = append(keys, key)
+	}
+	for key := range was {
+		if _, ok := planned[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].contextKind != keys[j].contextKind {
+			return keys[i].contextKind < keys[j].contextKind
+		}
+		return keys[i].variation < keys[j].variation
+	})
+
+	var removals, additions []map[string]interface{}
+	for _, key := range keys {
+		_, removed := partitionStrings(was[key], planned[key])
+		_, added := partitionStrings(planned[key], was[key])
+		if len(removed) == 0 && len(added) == 0 {
+			continue
+		}
+		id, err := ids.id(flagKey, key.variation)
+		if err != nil {
+			diags.AddError(err.Error(), "")
+			return nil, diags
+		}
+		if len(removed) > 0 {
+			removals = append(removals, map[string]interface{}{"kind": "removeTargets", "contextKind": key.contextKind, "variationId": id, "values": removed})
+		}
+		if len(added) > 0 {
+			additions = append(additions, map[string]interface{}{"kind": "addTargets", "contextKind": key.contextKind, "variationId": id, "values": added})
+		}
+	}
+	return append(removals, additions...), diags
+}
+
+// partitionStrings splits values into those that are also in other and those
+// that are not, keeping their order.
+func partitionStrings(values, other []string) (in, notIn []string) {
+	set := make(map[string]bool, len(other))
+	for _, v := range other {
+		set[v] = true
+	}
+	for _, v := range values {
+		if set[v] {
+			in = append(in, v)
+		} else {
+			notIn = append(notIn, v)
+		}
+	}
+	return in, notIn
+}
+
+// ffeLiveRules returns the rules of the flag's environment envKey.
+func ffeLiveRules(flag *ldapi.FeatureFlag, envKey string) []ldapi.Rule {
+	if flag == nil || flag.Environments == nil {
+		return nil
+	}
+	return (*flag.Environments)[envKey].Rules
+}
+
+// ffeVariationOrRolloutInstruction adds the variationId, or the rollout
+// parameters, that rule and fallthrough instructions share to into.
+func ffeVariationOrRolloutInstruction(into map[string]interface{}, variation *int32, rollout *ldapi.Rollout, flagKey string, ids *flagVariationIDs) error {
+	if rollout == nil {
+		var index int32
+		if variation != nil {
+			index = *variation
+		}
+		id, err := ids.id(flagKey, index)
+		if err != nil {
+			return err
+		}
+		into["variationId"] = id
+		return nil
+	}
+	weights := make(map[string]int32, len(rollout.Variations))
+	for _, wv := range rollout.Variations {
+		id, err := ids.id(flagKey, wv.Variation)
+		if err != nil {
+			return err
+		}
+		weights[id] = wv.Weight
+	}
+	into["rolloutWeights"] = weights
+	if rollout.BucketBy != nil {
+		into["rolloutBucketBy"] = *rollout.BucketBy
+	}
+	if rollout.ContextKind != nil {
+		into["rolloutContextKind"] = *rollout.ContextKind
+	}
+	return nil
+}
+
+// ffeResetModel is the configuration Delete restores an environment to:
+// targeting off, no targets, rules or prerequisites, and the flag's default
+// off variation.
+func ffeResetModel(offVariation int32) (FeatureFlagEnvironmentResourceModel, diag.Diagnostics) {
+	fallthroughObj, diags := types.ObjectValue(ffeResourceFallthroughAttrTypes, withNullExperimentSettings(withNullVariationRefs(map[string]attr.Value{
+		VARIATION:       types.Int64Value(0),
+		BUCKET_BY:       types.StringNull(),
+		CONTEXT_KIND:    types.StringValue("user"),
+		ROLLOUT_WEIGHTS: types.ListNull(types.Int64Type),
+	}, true), false))
+	return FeatureFlagEnvironmentResourceModel{
+		On:                     types.BoolValue(false),
+		TrackEvents:            types.BoolValue(false),
+		TrackEventsFallthrough: types.BoolValue(false),
+		OffVariation:           types.Int64Value(int64(offVariation)),
+		Rules:                  types.ListNull(types.ObjectType{AttrTypes: ffeResourceRuleAttrTypes}),
+		Prerequisites:          types.ListNull(types.ObjectType{AttrTypes: ffeResourcePrerequisiteAttrTypes}),
+		Targets:                types.SetNull(types.ObjectType{AttrTypes: ffeTargetAttrTypes}),
+		ContextTargets:         types.SetNull(types.ObjectType{AttrTypes: ffeContextTargetAttrTypes}),
+		Fallthrough:            fallthroughObj,
+	}, diags
+}
@@ -0,0 +1,121 @@
+package launchdarkly
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ldapi "github.com/launchdarkly/api-client-go/v24"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ffeTestRule(id, attribute, value string, variation int32) ldapi.Rule {
+	r := ldapi.Rule{
+		Variation: ldapi.PtrInt32(variation),
+		Clauses: []ldapi.Clause{{
+			Attribute:   attribute,
+			Op:          "in",
+			Values:      []interface{}{value},
+			ContextKind: ldapi.PtrString("user"),
+		}},
+	}
+	if id != "" {
+		r.Id = ldapi.PtrString(id)
+		r.Clauses[0].Id = ldapi.PtrString(id + "-clause")
+	}
+	return r
+}
+
+func TestFFERuleInstructions(t *testing.T) {
+	ctx := context.Background()
+	ids := newFlagVariationIDs(nil, "p")
+	ids.byFlag["f"] = []string{"var-true", "var-false"}
+
+	rulesValue := func(rules ...ldapi.Rule) types.List {
+		var diags diag.Diagnostics
+		list := ffeResourceRulesValue(ctx, rules, &diags)
+		require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+		return list
+	}
+	email := ffeTestRule("r1", "email", "a@example.com", 0)
+	country := ffeTestRule("r2", "country", "us", 1)
+	key := ffeTestRule("r3", "key", "x", 0)
+	prior := rulesValue(email, country, key)
+
+	// country moves to the top and serves another variation, a new rule
+	// goes before email, and key is removed.
+	plan := rulesValue(
+		ffeTestRule("", "country", "us", 0),
+		ffeTestRule("", "name", "z", 1),
+		ffeTestRule("", "email", "a@example.com", 0),
+	)
+
+	instructions, d := ffeRuleInstructions(ctx, "f", plan, prior, []ldapi.Rule{email, country, key}, ids)
+	require.False(t, d.HasError(), "unexpected diagnostics: %v", d)
+	require.Len(t, instructions, 4)
+	assert.Equal(t, map[string]interface{}{"kind": "removeRule", "ruleId": "r3"}, instructions[0])
+	assert.Equal(t, map[string]interface{}{"kind": "updateRuleVariationOrRollout", "ruleId": "r2", "variationId": "var-true"}, instructions[1])
+	assert.Equal(t, map[string]interface{}{"kind": "reorderRules", "ruleIds": []string{"r2", "r1"}}, instructions[2])
+	assert.Equal(t, "addRule", instructions[3]["kind"])
+	assert.Equal(t, "r1", instructions[3]["beforeRuleId"])
+	assert.Equal(t, "var-false", instructions[3]["variationId"])
+
+	t.Run("rules added outside Terraform keep their place", func(t *testing.T) {
+		manual := ffeTestRule("r9", "plan", "beta", 1)
+		instructions, d := ffeRuleInstructions(ctx, "f", rulesValue(country, email), rulesValue(email, country), []ldapi.Rule{manual, email, country}, ids)
+		require.False(t, d.HasError(), "unexpected diagnostics: %v", d)
+		assert.Equal(t, []map[string]interface{}{{"kind": "reorderRules", "ruleIds": []string{"r9", "r2", "r1"}}}, instructions)
+	})
+
+	t.Run("a rule matched by ref is updated in place", func(t *testing.T) {
+		renamed := ffeTestRule("r1", "email", "b@example.com", 0)
+		instructions, d := ffeRuleInstructions(ctx, "f", rulesValue(renamed), rulesValue(email), []ldapi.Rule{email}, ids)
+		require.False(t, d.HasError(), "unexpected diagnostics: %v", d)
+		require.Len(t, instructions, 2)
+		assert.Equal(t, "addClauses", instructions[0]["kind"])
+		assert.Equal(t, "r1", instructions[0]["ruleId"])
+		assert.Equal(t, map[string]interface{}{"kind": "removeClauses", "ruleId": "r1", "clauseIds": []string{"r1-clause"}}, instructions[1])
+	})
+
+	t.Run("a changed rule missing from LaunchDarkly is an error", func(t *testing.T) {
+		_, d := ffeRuleInstructions(ctx, "f", rulesValue(ffeTestRule("", "email", "a@example.com", 1)), rulesValue(email), nil, ids)
+		require.True(t, d.HasError())
+	})
+}
+
+func TestFFETargetInstructions(t *testing.T) {
+	ctx := context.Background()
+	ids := newFlagVariationIDs(nil, "p")
+	ids.byFlag["f"] = []string{"var-true", "var-false"}
+
+	prior := ffeTestBaselineModel(t)
+	prior.Targets = ffeTargetsValue(ctx, []ldapi.Target{{Variation: 0, Values: []string{"a", "b"}}}, false, noopDiagSink{})
+	plan := prior
+	plan.Targets = ffeTargetsValue(ctx, []ldapi.Target{
+		{Variation: 0, Values: []string{"a"}},
+		{Variation: 1, Values: []string{"b", "c"}},
+	}, false, noopDiagSink{})
+
+	instructions, d := ffeTargetInstructions(ctx, "f", plan, prior, ids)
+	require.False(t, d.HasError(), "unexpected diagnostics: %v", d)
+	assert.Equal(t, []map[string]interface{}{
+		{"kind": "removeTargets", "contextKind": "user", "variationId": "var-true", "values": []string{"b"}},
+		{"kind": "addTargets", "contextKind": "user", "variationId": "var-false", "values": []string{"b", "c"}},
+	}, instructions)
+
+	t.Run("reordered values only add and remove", func(t *testing.T) {
+		reordered := prior
+		reordered.Targets = ffeTargetsValue(ctx, []ldapi.Target{{Variation: 0, Values: []string{"c", "b", "a"}}}, false, noopDiagSink{})
+		instructions, d := ffeTargetInstructions(ctx, "f", reordered, prior, ids)
+		require.False(t, d.HasError(), "unexpected diagnostics: %v", d)
+		assert.Equal(t, []map[string]interface{}{
+			{"kind": "addTargets", "contextKind": "user", "variationId": "var-true", "values": []string{"c"}},
+		}, instructions)
+
+		live := []ldapi.Target{{Variation: 0, Values: []string{"a", "b", "c", "ui"}}}
+		assert.Equal(t, []ldapi.Target{{Variation: 0, Values: []string{"c", "b", "a", "ui"}}}, ffeTargetsInOrder(ctx, live, reordered.Targets, false),
+			"values are read in the configured order, followed by values added elsewhere")
+	})
+}
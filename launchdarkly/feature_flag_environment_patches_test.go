@@ -15,18 +15,18 @@ import (
 // OffVariation.
 func ffeTestBaselineModel(t *testing.T) FeatureFlagEnvironmentResourceModel {
 	t.Helper()
-	fallthrough_, d := types.ObjectValue(ffeFallthroughAttrTypes, map[string]attr.Value{
+	fallthrough_, d := types.ObjectValue(ffeResourceFallthroughAttrTypes, withNullExperimentSettings(withNullVariationRefs(map[string]attr.Value{
 		VARIATION:       types.Int64Value(0),
 		BUCKET_BY:       types.StringNull(),
 		CONTEXT_KIND:    types.StringNull(),
 		ROLLOUT_WEIGHTS: types.ListNull(types.Int64Type),
-	})
+	}, true), false))
 	require.False(t, d.HasError(), "failed to build baseline fallthrough object: %v", d)
 	return FeatureFlagEnvironmentResourceModel{
 		On:             types.BoolValue(false),
 		TrackEvents:    types.BoolValue(false),
-		Rules:          types.ListNull(types.ObjectType{AttrTypes: ffeRuleAttrTypes}),
-		Prerequisites:  types.ListNull(types.ObjectType{AttrTypes: ffePrerequisiteAttrTypes}),
+		Rules:          types.ListNull(types.ObjectType{AttrTypes: ffeResourceRuleAttrTypes}),
+		Prerequisites:  types.ListNull(types.ObjectType{AttrTypes: ffeResourcePrerequisiteAttrTypes}),
 		Targets:        types.SetNull(types.ObjectType{AttrTypes: ffeTargetAttrTypes}),
 		ContextTargets: types.SetNull(types.ObjectType{AttrTypes: ffeTargetAttrTypes}),
 		Fallthrough:    fallthrough_,
@@ -0,0 +1,309 @@
+package launchdarkly
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ldapi "github.com/launchdarkly/api-client-go/v24"
+)
+
+// A launchdarkly_feature_flag can configure the targeting of its
+// environments inline, keyed by environment key, instead of in separate
+// launchdarkly_feature_flag_environment resources. The flag and all its
+// environments are then read with a single GET and changed with a single
+// JSON Patch. Environments left out of the map are not managed.
+
+// flagImportEnvironmentsPrivateKey marks a flag that was just imported with
+// flagImportEnvironmentsSuffix, so the next read fills environments with
+// every environment of the flag. Without the suffix, an import leaves
+// environments unset, matching configurations that do not use it.
+const (
+	flagImportEnvironmentsPrivateKey = "import_environments"
+	flagImportEnvironmentsSuffix     = "/environments"
+)
+
+var flagEnvironmentObjectType = schema.NestedAttributeObject{Attributes: flagEnvironmentSchemaAttributes()}.Type().(types.ObjectType)
+
+func flagEnvironmentsAttribute() schema.MapNestedAttribute {
+	return schema.MapNestedAttribute{
+		Optional:    true,
+		Description: "The targeting of the flag's environments, keyed by environment key, with the attributes of `launchdarkly_feature_flag_environment`. The flag and all its environments are read and updated together in a single request. Environments left out are not managed by this resource. Do not also manage an environment listed here with `launchdarkly_feature_flag_environment`. Changes are applied directly and are never submitted for approval, so manage environments that require approvals with `launchdarkly_feature_flag_environment` and its `approval_mode`. To fill this attribute with every environment on import, append `/environments` to the import ID.",
+		Validators: []validator.Map{
+			mapvalidator.KeysAre(keyValidator()),
+		},
+		NestedObject: schema.NestedAttributeObject{Attributes: flagEnvironmentSchemaAttributes()},
+	}
+}
+
+// flagEnvironmentSchemaAttributes returns the attributes of an environment in
+// environments: those of launchdarkly_feature_flag_environment that configure
+// targeting.
+func flagEnvironmentSchemaAttributes() map[string]schema.Attribute {
+	attrs := featureFlagEnvironmentSchemaAttributes()
+	for _, name := range []string{"id", FLAG_ID, ENV_KEY, MANAGED_FIELDS, APPROVAL_MODE, COMMENT, ACKNOWLEDGE_CRITICAL} {
+		delete(attrs, name)
+	}
+	fallthroughAttr := attrs[FALLTHROUGH].(schema.SingleNestedAttribute)
+	fallthroughAttr.Optional = false
+	fallthroughAttr.Required = true
+	fallthroughAttr.Description = "The default variation to serve if no `prerequisites`, `target`, or `rules` apply."
+	attrs[FALLTHROUGH] = fallthroughAttr
+	return attrs
+}
+
+// flagEnvironmentModel is an environment in environments.
+type flagEnvironmentModel struct {
+	On                     types.Bool   `tfsdk:"on"`
+	Targets                types.Set    `tfsdk:"targets"`
+	ContextTargets         types.Set    `tfsdk:"context_targets"`
+	Rules                  types.List   `tfsdk:"rules"`
+	Prerequisites          types.List   `tfsdk:"prerequisites"`
+	Fallthrough            types.Object `tfsdk:"fallthrough"`
+	TrackEvents            types.Bool   `tfsdk:"track_events"`
+	TrackEventsFallthrough types.Bool   `tfsdk:"track_events_fallthrough"`
+	OffVariation           types.Int64  `tfsdk:"off_variation"`
+	OffVariationValue      types.String `tfsdk:"off_variation_value"`
+	OffVariationName       types.String `tfsdk:"off_variation_name"`
+}
+
+// ffe returns m as a launchdarkly_feature_flag_environment managing every
+// setting, so that resource's helpers apply to it.
+func (m flagEnvironmentModel) ffe() FeatureFlagEnvironmentResourceModel {
+	return FeatureFlagEnvironmentResourceModel{
+		On:                     m.On,
+		Targets:                m.Targets,
+		ContextTargets:         m.ContextTargets,
+		Rules:                  m.Rules,
+		Prerequisites:          m.Prerequisites,
+		Fallthrough:            m.Fallthrough,
+		TrackEvents:            m.TrackEvents,
+		TrackEventsFallthrough: m.TrackEventsFallthrough,
+		OffVariation:           m.OffVariation,
+		OffVariationValue:      m.OffVariationValue,
+		OffVariationName:       m.OffVariationName,
+		ManagedFields:          types.SetNull(types.StringType),
+	}
+}
+
+func flagEnvironmentModelOf(m FeatureFlagEnvironmentResourceModel) flagEnvironmentModel {
+	return flagEnvironmentModel{
+		On:                     m.On,
+		Targets:                m.Targets,
+		ContextTargets:         m.ContextTargets,
+		Rules:                  m.Rules,
+		Prerequisites:          m.Prerequisites,
+		Fallthrough:            m.Fallthrough,
+		TrackEvents:            m.TrackEvents,
+		TrackEventsFallthrough: m.TrackEventsFallthrough,
+		OffVariation:           m.OffVariation,
+		OffVariationValue:      m.OffVariationValue,
+		OffVariationName:       m.OffVariationName,
+	}
+}
+
+// flagEnvironmentsFromMap returns the environments in m by key, or nil when
+// m is null or unknown.
+func flagEnvironmentsFromMap(ctx context.Context, m types.Map) (map[string]FeatureFlagEnvironmentResourceModel, diag.Diagnostics) {
+	if m.IsNull() || m.IsUnknown() {
+		return nil, nil
+	}
+	var models map[string]flagEnvironmentModel
+	diags := m.ElementsAs(ctx, &models, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+	envs := make(map[string]FeatureFlagEnvironmentResourceModel, len(models))
+	for key, model := range models {
+		envs[key] = model.ffe()
+	}
+	return envs, diags
+}
+
+func flagEnvironmentsMap(ctx context.Context, envs map[string]FeatureFlagEnvironmentResourceModel) (types.Map, diag.Diagnostics) {
+	models := make(map[string]flagEnvironmentModel, len(envs))
+	for key, env := range envs {
+		models[key] = flagEnvironmentModelOf(env)
+	}
+	return types.MapValueFrom(ctx, flagEnvironmentObjectType, models)
+}
+
+// flagEnvironmentsFromAPI reads the environments of prior from flag. When
+// prior is unknown, as it is right after an import that asks for
+// environments, it reads every environment of the flag.
+func flagEnvironmentsFromAPI(ctx context.Context, flag *ldapi.FeatureFlag, prior types.Map, diags *diag.Diagnostics) types.Map {
+	if prior.IsNull() {
+		return prior
+	}
+	priorEnvs, d := flagEnvironmentsFromMap(ctx, prior)
+	diags.Append(d...)
+	if prior.IsUnknown() {
+		priorEnvs = make(map[string]FeatureFlagEnvironmentResourceModel)
+		if flag.Environments != nil {
+			for key := range *flag.Environments {
+				priorEnvs[key] = FeatureFlagEnvironmentResourceModel{}
+			}
+		}
+	}
+	envs := make(map[string]FeatureFlagEnvironmentResourceModel, len(priorEnvs))
+	for key, env := range priorEnvs {
+		if ffeReadEnvironment(ctx, flag, key, &env, diags) {
+			envs[key] = env
+		}
+	}
+	m, d := flagEnvironmentsMap(ctx, envs)
+	diags.Append(d...)
+	return m
+}
+
+// flagEnvironmentPatches returns the JSON Patch operations that change the
+// environments in state to those in plan. offVariationSet reports whether an
+// environment state does not hold has an off variation in LaunchDarkly,
+// which only a remove operation unsets.
+func flagEnvironmentPatches(ctx context.Context, plan, state types.Map, offVariationSet func(envKey string) (bool, error)) ([]ldapi.PatchOperation, diag.Diagnostics) {
+	planEnvs, diags := flagEnvironmentsFromMap(ctx, plan)
+	stateEnvs, d := flagEnvironmentsFromMap(ctx, state)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	var patches []ldapi.PatchOperation
+	for _, key := range slices.Sorted(maps.Keys(planEnvs)) {
+		env := planEnvs[key]
+		prior, managed := stateEnvs[key]
+		liveSet := !prior.OffVariation.IsNull()
+		if !managed && env.OffVariation.IsNull() {
+			var err error
+			if liveSet, err = offVariationSet(key); err != nil {
+				diags.AddAttributeError(path.Root(ENVIRONMENTS).AtMapKey(key), "Failed to read environment", err.Error())
+				return nil, diags
+			}
+		}
+		envPatches, d := buildFFEPatches(ctx, key, env, prior, !managed, liveSet)
+		diags.Append(d...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		patches = append(patches, envPatches...)
+	}
+	return patches, diags
+}
+
+// flagEnvironmentsLookup looks up the variations of the flags environments
+// refer to: flagKey's planned variations, and for prerequisites, those of
+// the other flags of the project.
+func (c *Client) flagEnvironmentsLookup(ctx context.Context, projectKey, flagKey string, variations types.List, diags *diag.Diagnostics) variationLookup {
+	own, d := variationKeysFromList(ctx, variations)
+	diags.Append(d...)
+	others := c.flagVariationLookup(projectKey)
+	return func(key string) ([]variationKey, error) {
+		if key == flagKey {
+			return own, nil
+		}
+		return others(key)
+	}
+}
+
+// resolveFlagEnvironmentRefs returns the planned environments with the
+// variation references set in config resolved.
+func resolveFlagEnvironmentRefs(ctx context.Context, plan, config types.Map, flagKey string, v *variationRefResolver) types.Map {
+	planEnvs, d := flagEnvironmentsFromMap(ctx, plan)
+	v.diags.Append(d...)
+	configEnvs, d := flagEnvironmentsFromMap(ctx, config)
+	v.diags.Append(d...)
+	if planEnvs == nil || configEnvs == nil || v.diags.HasError() {
+		return plan
+	}
+	for key, env := range planEnvs {
+		if configured, ok := configEnvs[key]; ok {
+			planEnvs[key] = resolveFFEVariationRefs(ctx, path.Root(ENVIRONMENTS).AtMapKey(key), env, configured, flagKey, v)
+		}
+	}
+	m, d := flagEnvironmentsMap(ctx, planEnvs)
+	v.diags.Append(d...)
+	return m
+}
+
+// planFlagEnvironments resolves the variation references of the planned
+// environments and keeps the refs and experiment settings of rules and
+// fallthroughs that already exist.
+func (r *FeatureFlagResource) planFlagEnvironments(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var plan FeatureFlagResourceModel
+	var config types.Map
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(ENVIRONMENTS), &config)...)
+	if resp.Diagnostics.HasError() || plan.Environments.IsNull() || plan.Environments.IsUnknown() {
+		return
+	}
+	v := &variationRefResolver{lookup: r.client.flagEnvironmentsLookup(ctx, plan.ProjectKey.ValueString(), plan.Key.ValueString(), plan.Variations, &resp.Diagnostics)}
+	planned := resolveFlagEnvironmentRefs(ctx, plan.Environments, config, plan.Key.ValueString(), v)
+	resp.Diagnostics.Append(v.diags...)
+
+	var prior types.Map
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(ENVIRONMENTS), &prior)...)
+	}
+	planEnvs, d := flagEnvironmentsFromMap(ctx, planned)
+	resp.Diagnostics.Append(d...)
+	priorEnvs, d := flagEnvironmentsFromMap(ctx, prior)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for key, env := range planEnvs {
+		priorEnv, ok := priorEnvs[key]
+		if ok && !env.Rules.IsNull() && !env.Rules.IsUnknown() && !priorEnv.Rules.IsNull() {
+			env.Rules, d = rulesWithPriorRefs(ctx, env.Rules, priorEnv.Rules)
+			resp.Diagnostics.Append(d...)
+		}
+		planEnvs[key] = ffeWithPriorExperimentSettings(ctx, env, priorEnv, &resp.Diagnostics)
+	}
+	planned, d = flagEnvironmentsMap(ctx, planEnvs)
+	resp.Diagnostics.Append(d...)
+	if !resp.Diagnostics.HasError() && !planned.Equal(plan.Environments) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(ENVIRONMENTS), planned)...)
+	}
+}
+
+// applyFlagEnvironmentRefs resolves the variation references of the
+// environments being applied.
+func (c *Client) applyFlagEnvironmentRefs(ctx context.Context, config tfsdk.Config, plan FeatureFlagResourceModel, diags *diag.Diagnostics) types.Map {
+	var configured types.Map
+	diags.Append(config.GetAttribute(ctx, path.Root(ENVIRONMENTS), &configured)...)
+	if diags.HasError() {
+		return plan.Environments
+	}
+	v := &variationRefResolver{lookup: c.flagEnvironmentsLookup(ctx, plan.ProjectKey.ValueString(), plan.Key.ValueString(), plan.Variations, diags), apply: true}
+	resolved := resolveFlagEnvironmentRefs(ctx, plan.Environments, configured, plan.Key.ValueString(), v)
+	diags.Append(v.diags...)
+	return resolved
+}
+
+// flagOffVariationSet returns a function that reports whether an environment
+// of a flag has an off variation, fetching the flag at most once.
+func (c *Client) flagOffVariationSet(projectKey, flagKey string) func(envKey string) (bool, error) {
+	var flag *ldapi.FeatureFlag
+	return func(envKey string) (bool, error) {
+		if flag == nil {
+			var err error
+			flag, _, err = getFeatureFlagEnvironment(c, projectKey, flagKey, envKey)
+			if err != nil {
+				return false, fmt.Errorf("failed to get flag %q of project %q: %s", flagKey, projectKey, handleLdapiErr(err).Error())
+			}
+		}
+		if flag.Environments == nil {
+			return false, nil
+		}
+		env, ok := (*flag.Environments)[envKey]
+		return ok && env.OffVariation != nil, nil
+	}
+}
@@ -0,0 +1,69 @@
+package launchdarkly
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testFlagEnvironment(t *testing.T, on bool, offVariation attr.Value) attr.Value {
+	ctx := context.Background()
+	attrs := make(map[string]attr.Value)
+	for name, attrType := range flagEnvironmentObjectType.AttrTypes {
+		null, err := attrType.ValueFromTerraform(ctx, tftypes.NewValue(attrType.TerraformType(ctx), nil))
+		require.NoError(t, err)
+		attrs[name] = null
+	}
+	attrs[ON] = types.BoolValue(on)
+	attrs[TRACK_EVENTS] = types.BoolValue(false)
+	attrs[OFF_VARIATION] = offVariation
+	fallthroughAttrs := attrs[FALLTHROUGH].(types.Object).AttributeTypes(ctx)
+	fallthroughValues := make(map[string]attr.Value)
+	for name, attrType := range fallthroughAttrs {
+		null, err := attrType.ValueFromTerraform(ctx, tftypes.NewValue(attrType.TerraformType(ctx), nil))
+		require.NoError(t, err)
+		fallthroughValues[name] = null
+	}
+	fallthroughValues[VARIATION] = types.Int64Value(0)
+	fallthroughValues[CONTEXT_KIND] = types.StringValue("user")
+	attrs[FALLTHROUGH] = types.ObjectValueMust(fallthroughAttrs, fallthroughValues)
+	return types.ObjectValueMust(flagEnvironmentObjectType.AttrTypes, attrs)
+}
+
+func TestFlagEnvironmentPatches(t *testing.T) {
+	ctx := context.Background()
+	production := testFlagEnvironment(t, true, types.Int64Value(1))
+	state := types.MapValueMust(flagEnvironmentObjectType, map[string]attr.Value{"production": production})
+	plan := types.MapValueMust(flagEnvironmentObjectType, map[string]attr.Value{
+		"production": production,
+		"test":       testFlagEnvironment(t, false, types.Int64Null()),
+	})
+
+	var looked []string
+	offVariationSet := func(envKey string) (bool, error) {
+		looked = append(looked, envKey)
+		return true, nil
+	}
+	patches, diags := flagEnvironmentPatches(ctx, plan, state, offVariationSet)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, []string{"test"}, looked, "only environments new to state are looked up")
+	paths := make([]string, 0, len(patches))
+	for _, patch := range patches {
+		paths = append(paths, patch.Path)
+	}
+	assert.Contains(t, paths, ffePatchPath("test", "on"))
+	assert.Contains(t, paths, ffePatchPath("test", "offVariation"), "a null off variation is removed")
+	assert.Contains(t, paths, ffePatchPath("test", "fallthrough"))
+	for _, p := range paths {
+		assert.NotContains(t, p, "/environments/production/", "unchanged environments are not patched")
+	}
+
+	patches, diags = flagEnvironmentPatches(ctx, types.MapNull(flagEnvironmentObjectType), state, offVariationSet)
+	require.False(t, diags.HasError())
+	assert.Empty(t, patches, "environments left out are not managed")
+}
@@ -1,7 +1,8 @@
 package launchdarkly
 
 // framework_helpers.go centralises the small utilities that every
-// terraform-plugin-framework resource and data source in this provider needs:
+// terraform-plugin-framework resource, data source and ephemeral resource in
+// this provider needs:
 // extracting the configured *Client, converting between framework
 // types.Set / types.List and Go []string, surfacing LD API errors as
 // framework diagnostics. Keep the surface narrow — anything that needs
@@ -10,10 +11,12 @@ package launchdarkly
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -56,6 +59,21 @@ func configureDataSourceClient(req datasource.ConfigureRequest, resp *datasource
 	return client
 }
 
+func configureEphemeralResourceClient(req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) *Client {
+	if req.ProviderData == nil {
+		return nil
+	}
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *launchdarkly.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return nil
+	}
+	return client
+}
+
 func addLdapiError(diags diagnosticsSink, summary string, err error) {
 	if err == nil {
 		return
@@ -202,6 +220,23 @@ func mapStringFromAttr(ctx context.Context, m types.Map) (map[string]string, dia
 	return out, d
 }
 
+// rfc3339Value returns prior if it names the instant ms, in milliseconds
+// since the epoch, so a timestamp keeps the offset it was written with.
+// Otherwise it returns the instant in UTC.
+func rfc3339Value(prior types.String, ms int64) types.String {
+	if t, err := time.Parse(time.RFC3339, prior.ValueString()); err == nil && t.UnixMilli() == ms {
+		return prior
+	}
+	return types.StringValue(time.UnixMilli(ms).UTC().Format(time.RFC3339))
+}
+
+// rfc3339Passed reports whether the RFC 3339 timestamp value is no later
+// than now. Null and malformed values have not passed.
+func rfc3339Passed(value types.String, now time.Time) bool {
+	t, err := time.Parse(time.RFC3339, value.ValueString())
+	return err == nil && !t.After(now)
+}
+
 // pinMapKeysToMapKey rewrites a planned MapNestedAttribute value so each
 // element's Optional+Computed `key` attribute equals its map key. For a
 // new map entry whose config omits `key`, the framework plans it as
@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestStringSliceFromSet_RoundTrip(t *testing.T) {
@@ -214,3 +216,16 @@ type diagSinkAdapter struct {
 func (s diagSinkAdapter) AddError(summary, detail string) {
 	s.diags.AddError(summary, detail)
 }
+
+func TestRFC3339Value(t *testing.T) {
+	eastern := types.StringValue("2026-03-02T06:00:00-05:00")
+	instant := time.Date(2026, 3, 2, 11, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, eastern, rfc3339Value(eastern, instant.UnixMilli()), "the configured offset is kept")
+	assert.Equal(t, types.StringValue("2026-03-02T12:00:00Z"), rfc3339Value(eastern, instant.Add(time.Hour).UnixMilli()))
+	assert.Equal(t, types.StringValue("2026-03-02T11:00:00Z"), rfc3339Value(types.StringNull(), instant.UnixMilli()), "imports read UTC")
+
+	assert.False(t, rfc3339Passed(eastern, instant.Add(-time.Minute)))
+	assert.True(t, rfc3339Passed(eastern, instant))
+	assert.False(t, rfc3339Passed(types.StringNull(), instant))
+}
@@ -80,8 +80,12 @@ func TestDefaultsObjectFromV0List(t *testing.T) {
 			t.Fatalf("expected object, diags=%v null=%v", diags, obj.IsNull())
 		}
 		var m struct {
-			OnVariation  types.Int64 `tfsdk:"on_variation"`
-			OffVariation types.Int64 `tfsdk:"off_variation"`
+			OnVariation       types.Int64  `tfsdk:"on_variation"`
+			OnVariationValue  types.String `tfsdk:"on_variation_value"`
+			OnVariationName   types.String `tfsdk:"on_variation_name"`
+			OffVariation      types.Int64  `tfsdk:"off_variation"`
+			OffVariationValue types.String `tfsdk:"off_variation_value"`
+			OffVariationName  types.String `tfsdk:"off_variation_name"`
 		}
 		obj.As(ctx, &m, basetypes.ObjectAsOptions{})
 		if m.OnVariation.ValueInt64() != 0 || m.OffVariation.ValueInt64() != 2 {
@@ -213,6 +217,9 @@ func TestFFEFallthroughObjectFromV0List(t *testing.T) {
 		BucketBy       types.String `tfsdk:"bucket_by"`
 		ContextKind    types.String `tfsdk:"context_kind"`
 		RolloutWeights types.List   `tfsdk:"rollout_weights"`
+		VariationValue types.String `tfsdk:"variation_value"`
+		VariationName  types.String `tfsdk:"variation_name"`
+		Rollout        types.Map    `tfsdk:"rollout"`
 	}
 	obj.As(ctx, &m, basetypes.ObjectAsOptions{})
 	if m.Variation.ValueInt64() != 1 || m.ContextKind.ValueString() != "user" {
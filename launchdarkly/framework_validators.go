@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 )
@@ -205,6 +206,32 @@ func (v caseValidator) ValidateString(_ context.Context, req validator.StringReq
 	resp.Diagnostics.AddAttributeError(req.Path, "Invalid value", detail)
 }
 
+// rfc3339Validator enforces an RFC 3339 timestamp such as
+// 2026-03-01T06:00:00-05:00. The offset is required, so a timestamp always
+// names one instant whatever timezone Terraform runs in.
+type rfc3339Validator struct{}
+
+func (rfc3339Validator) Description(context.Context) string {
+	return "must be an RFC 3339 timestamp, such as 2026-03-01T06:00:00-05:00"
+}
+
+func (v rfc3339Validator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (rfc3339Validator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid timestamp",
+			fmt.Sprintf("expected %s to be an RFC 3339 timestamp with a UTC offset, such as 2026-03-01T06:00:00-05:00, got %q", req.Path, req.ConfigValue.ValueString()),
+		)
+	}
+}
+
 // oneOfValidator restricts a string attribute to a fixed enum.
 type oneOfValidator struct {
 	allowed []string
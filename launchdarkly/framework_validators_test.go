@@ -192,3 +192,19 @@ func TestOpValidator(t *testing.T) {
 		}
 	}
 }
+
+func TestRFC3339Validator(t *testing.T) {
+	v := rfc3339Validator{}
+	for _, ts := range []string{"2026-03-01T06:00:00-05:00", "2026-03-01T11:00:00Z"} {
+		resp := runStringValidator(t, v, types.StringValue(ts))
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("expected %q to be valid, got %v", ts, resp.Diagnostics)
+		}
+	}
+	for _, ts := range []string{"2026-03-01", "2026-03-01T06:00:00", "1772362800000"} {
+		resp := runStringValidator(t, v, types.StringValue(ts))
+		if !resp.Diagnostics.HasError() {
+			t.Fatalf("expected %q to be rejected", ts)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package launchdarkly
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &FlagIDFunction{}
+
+type FlagIDFunction struct{}
+
+func NewFlagIDFunction() function.Function {
+	return &FlagIDFunction{}
+}
+
+func (f *FlagIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "flag_id"
+}
+
+func (f *FlagIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Builds the ID of a feature flag",
+		MarkdownDescription: `Builds the ID of a ` + "`launchdarkly_feature_flag`" + `, ` + "`project_key/flag_key`" + `, as used to import it. The keys must not be empty or contain slashes.
+
+-> **Note:** Provider-defined functions require Terraform 1.8 or later.`,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "project_key",
+				Description: "The key of the flag's project.",
+			},
+			function.StringParameter{
+				Name:        "flag_key",
+				Description: "The flag's key.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *FlagIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var projectKey, flagKey string
+	resp.Error = req.Arguments.Get(ctx, &projectKey, &flagKey)
+	if resp.Error != nil {
+		return
+	}
+	id, err := buildResourceID("launchdarkly_feature_flag", projectKey, flagKey)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, id)
+}
@@ -0,0 +1,78 @@
+package launchdarkly
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccFunctionResourceID(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "flag_id" {
+	value = provider::launchdarkly::flag_id("default", "checkout")
+}
+
+output "parsed_flag_id" {
+	value = provider::launchdarkly::parse_flag_id("default/checkout")
+}
+
+output "segment_id" {
+	value = provider::launchdarkly::segment_id("default", "production", "beta-users")
+}
+
+output "parsed_id" {
+	value = provider::launchdarkly::parse_resource_id("launchdarkly_feature_flag_environment", "default/production/checkout")
+}
+
+output "specifier" {
+	value = provider::launchdarkly::resource_specifier("launchdarkly_segment", "default/production/beta-users")
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("flag_id", knownvalue.StringExact("default/checkout")),
+					statecheck.ExpectKnownOutputValue("parsed_flag_id", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						PROJECT_KEY: knownvalue.StringExact("default"),
+						KEY:         knownvalue.StringExact("checkout"),
+					})),
+					statecheck.ExpectKnownOutputValue("segment_id", knownvalue.StringExact("default/production/beta-users")),
+					statecheck.ExpectKnownOutputValue("parsed_id", knownvalue.MapExact(map[string]knownvalue.Check{
+						PROJECT_KEY: knownvalue.StringExact("default"),
+						ENV_KEY:     knownvalue.StringExact("production"),
+						FLAG_KEY:    knownvalue.StringExact("checkout"),
+					})),
+					statecheck.ExpectKnownOutputValue("specifier", knownvalue.StringExact("proj/default:env/production:segment/beta-users")),
+				},
+			},
+			{
+				Config: `
+output "parsed_flag_id" {
+	value = provider::launchdarkly::parse_flag_id("default/production/checkout")
+}
+`,
+				ExpectError: regexp.MustCompile(`expected format: 'project_key/flag_key'`),
+			},
+			{
+				Config: `
+output "flag_id" {
+	value = provider::launchdarkly::flag_id("default", "a/b")
+}
+`,
+				ExpectError: regexp.MustCompile(`key must be a non-empty key without slashes`),
+			},
+		},
+	})
+}
@@ -0,0 +1,52 @@
+package launchdarkly
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccFunctionRolloutWeights(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "weights" {
+	value = provider::launchdarkly::rollout_weights([33.333, 33.333, 33.334])
+}
+
+output "remainders" {
+	value = provider::launchdarkly::rollout_weights([12.3456, 12.3454, 75.309])
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("weights", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.Int64Exact(33333), knownvalue.Int64Exact(33333), knownvalue.Int64Exact(33334),
+					})),
+					statecheck.ExpectKnownOutputValue("remainders", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.Int64Exact(12346), knownvalue.Int64Exact(12345), knownvalue.Int64Exact(75309),
+					})),
+				},
+			},
+			{
+				Config: `
+output "weights" {
+	value = provider::launchdarkly::rollout_weights([33.333, 33.333, 33.333])
+}
+`,
+				ExpectError: regexp.MustCompile(`add up to 99.999, but they must add up to exactly 100`),
+			},
+		},
+	})
+}
@@ -0,0 +1,64 @@
+package launchdarkly
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &ParseFlagIDFunction{}
+
+var parsedFlagIDAttrTypes = map[string]attr.Type{
+	PROJECT_KEY: types.StringType,
+	KEY:         types.StringType,
+}
+
+type ParseFlagIDFunction struct{}
+
+func NewParseFlagIDFunction() function.Function {
+	return &ParseFlagIDFunction{}
+}
+
+func (f *ParseFlagIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_flag_id"
+}
+
+func (f *ParseFlagIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parses the ID of a feature flag",
+		MarkdownDescription: `Parses the ID of a ` + "`launchdarkly_feature_flag`" + `, ` + "`project_key/flag_key`" + `, into an object with the ` + "`project_key`" + ` and ` + "`key`" + ` attributes of the flag. The ID is parsed the same way as when importing the flag.
+
+-> **Note:** Provider-defined functions require Terraform 1.8 or later.`,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: "The flag's ID.",
+			},
+		},
+		Return: function.ObjectReturn{AttributeTypes: parsedFlagIDAttrTypes},
+	}
+}
+
+func (f *ParseFlagIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+	resp.Error = req.Arguments.Get(ctx, &id)
+	if resp.Error != nil {
+		return
+	}
+	parts, err := parseResourceID("launchdarkly_feature_flag", id)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	parsed, diags := types.ObjectValue(parsedFlagIDAttrTypes, map[string]attr.Value{
+		PROJECT_KEY: types.StringValue(parts[PROJECT_KEY]),
+		KEY:         types.StringValue(parts[KEY]),
+	})
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, parsed)
+}
@@ -0,0 +1,60 @@
+package launchdarkly
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &ParseResourceIDFunction{}
+
+type ParseResourceIDFunction struct{}
+
+func NewParseResourceIDFunction() function.Function {
+	return &ParseResourceIDFunction{}
+}
+
+func (f *ParseResourceIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_resource_id"
+}
+
+func (f *ParseResourceIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parses the ID of a LaunchDarkly resource",
+		MarkdownDescription: `Parses the ID of a resource of the given type, such as ` + "`launchdarkly_feature_flag_environment`" + `, into a map from the names of the resource's key attributes to their values. The ID is parsed the same way as when importing the resource.
+
+The supported resource types are ` + "`launchdarkly_project`" + `, ` + "`launchdarkly_environment`" + `, ` + "`launchdarkly_feature_flag`" + `, ` + "`launchdarkly_feature_flag_environment`" + `, ` + "`launchdarkly_segment`" + `, ` + "`launchdarkly_metric`" + `, ` + "`launchdarkly_metric_group`" + `, ` + "`launchdarkly_context_kind`" + `, ` + "`launchdarkly_view`" + `, ` + "`launchdarkly_release_policy`" + `, ` + "`launchdarkly_sdk_key`" + `, ` + "`launchdarkly_ai_config`" + `, ` + "`launchdarkly_ai_config_variation`" + `, ` + "`launchdarkly_ai_tool`" + `, ` + "`launchdarkly_ai_agent_graph`" + ` and ` + "`launchdarkly_model_config`" + `.
+
+-> **Note:** Provider-defined functions require Terraform 1.8 or later.`,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "resource_type",
+				Description: "The type of the resource, such as `launchdarkly_segment`.",
+			},
+			function.StringParameter{
+				Name:        "id",
+				Description: "The resource's ID.",
+			},
+		},
+		Return: function.MapReturn{ElementType: types.StringType},
+	}
+}
+
+func (f *ParseResourceIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var resourceType, id string
+	resp.Error = req.Arguments.Get(ctx, &resourceType, &id)
+	if resp.Error != nil {
+		return
+	}
+	if _, err := resourceIDFormatOf(resourceType); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	parts, err := parseResourceID(resourceType, id)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, parts)
+}
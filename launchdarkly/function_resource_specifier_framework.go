@@ -0,0 +1,60 @@
+package launchdarkly
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &ResourceSpecifierFunction{}
+
+type ResourceSpecifierFunction struct{}
+
+func NewResourceSpecifierFunction() function.Function {
+	return &ResourceSpecifierFunction{}
+}
+
+func (f *ResourceSpecifierFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "resource_specifier"
+}
+
+func (f *ResourceSpecifierFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Builds the resource specifier of a LaunchDarkly resource",
+		MarkdownDescription: `Builds the resource specifier that custom role policies use for a resource from its type and ID, such as ` + "`proj/default:env/production:flag/my-flag`" + ` for the ` + "`launchdarkly_feature_flag_environment`" + ` with ID ` + "`default/production/my-flag`" + `. A ` + "`launchdarkly_feature_flag`" + ` is specified in every environment, as ` + "`proj/default:env/*:flag/my-flag`" + `.
+
+The supported resource types are ` + "`launchdarkly_project`" + `, ` + "`launchdarkly_environment`" + `, ` + "`launchdarkly_feature_flag`" + `, ` + "`launchdarkly_feature_flag_environment`" + `, ` + "`launchdarkly_segment`" + `, ` + "`launchdarkly_metric`" + `, ` + "`launchdarkly_metric_group`" + `, ` + "`launchdarkly_context_kind`" + ` and ` + "`launchdarkly_view`" + `.
+
+-> **Note:** Provider-defined functions require Terraform 1.8 or later.`,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "resource_type",
+				Description: "The type of the resource, such as `launchdarkly_segment`.",
+			},
+			function.StringParameter{
+				Name:        "id",
+				Description: "The resource's ID.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ResourceSpecifierFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var resourceType, id string
+	resp.Error = req.Arguments.Get(ctx, &resourceType, &id)
+	if resp.Error != nil {
+		return
+	}
+	if format, err := resourceIDFormatOf(resourceType); err != nil || format.specifier == nil {
+		_, err = resourceSpecifier(resourceType, id)
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	specifier, err := resourceSpecifier(resourceType, id)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, specifier)
+}
@@ -0,0 +1,53 @@
+package launchdarkly
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &RolloutWeightsFunction{}
+
+type RolloutWeightsFunction struct{}
+
+func NewRolloutWeightsFunction() function.Function {
+	return &RolloutWeightsFunction{}
+}
+
+func (f *RolloutWeightsFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "rollout_weights"
+}
+
+func (f *RolloutWeightsFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Converts rollout percentages to rollout weights",
+		MarkdownDescription: `Converts a list of percentages, such as ` + "`[33.333, 33.333, 33.334]`" + `, to the rollout weights LaunchDarkly uses, in thousandths of a percent. The percentages must add up to 100. Each percentage gets the whole thousandths of a percent it covers, and those left over go to the percentages with the largest remainders, the earliest first, so the weights always add up to exactly 100000.
+
+This is the conversion ` + "`rollout_percentages`" + ` in ` + "`launchdarkly_feature_flag_environment`" + ` uses.
+
+-> **Note:** Provider-defined functions require Terraform 1.8 or later.`,
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:        "percentages",
+				ElementType: types.Float64Type,
+				Description: "The percentage of each variation, in variation order.",
+			},
+		},
+		Return: function.ListReturn{ElementType: types.Int64Type},
+	}
+}
+
+func (f *RolloutWeightsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var percentages []float64
+	resp.Error = req.Arguments.Get(ctx, &percentages)
+	if resp.Error != nil {
+		return
+	}
+	weights, err := rolloutWeightsFromPercentages(percentages)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, weights)
+}
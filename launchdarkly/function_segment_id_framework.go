@@ -0,0 +1,57 @@
+package launchdarkly
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &SegmentIDFunction{}
+
+type SegmentIDFunction struct{}
+
+func NewSegmentIDFunction() function.Function {
+	return &SegmentIDFunction{}
+}
+
+func (f *SegmentIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "segment_id"
+}
+
+func (f *SegmentIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Builds the ID of a segment",
+		MarkdownDescription: `Builds the ID of a ` + "`launchdarkly_segment`" + `, ` + "`project_key/env_key/segment_key`" + `, as used to import it. The keys must not be empty or contain slashes.
+
+-> **Note:** Provider-defined functions require Terraform 1.8 or later.`,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "project_key",
+				Description: "The key of the segment's project.",
+			},
+			function.StringParameter{
+				Name:        "env_key",
+				Description: "The key of the segment's environment.",
+			},
+			function.StringParameter{
+				Name:        "segment_key",
+				Description: "The segment's key.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *SegmentIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var projectKey, envKey, segmentKey string
+	resp.Error = req.Arguments.Get(ctx, &projectKey, &envKey, &segmentKey)
+	if resp.Error != nil {
+		return
+	}
+	id, err := buildResourceID("launchdarkly_segment", projectKey, envKey, segmentKey)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, id)
+}
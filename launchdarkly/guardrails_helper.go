@@ -0,0 +1,286 @@
+package launchdarkly
+
+import (
+	"context"
+	"fmt"
+	pathpkg "path"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Guardrails restrict which projects and environments a provider instance may
+// change. They are configured with the provider's allowed_projects,
+// allowed_environments and denied_environments glob patterns and checked in
+// ModifyPlan, so a change outside them fails the plan instead of the apply.
+// Terraform plans again during apply, by which point keys that were unknown at
+// plan time are known, so those are checked then.
+
+// guardrailConfig is the provider-level guardrail configuration. Empty allow
+// lists allow everything; denied_environments wins over allowed_environments.
+type guardrailConfig struct {
+	allowedProjects     []string
+	allowedEnvironments []string
+	deniedEnvironments  []string
+}
+
+func guardrailConfigFromProviderModel(ctx context.Context, data launchdarklyProviderModel) (guardrailConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var cfg guardrailConfig
+	for _, attr := range []struct {
+		name  string
+		value types.Set
+		dest  *[]string
+	}{
+		{ALLOWED_PROJECTS, data.AllowedProjects, &cfg.allowedProjects},
+		{ALLOWED_ENVIRONMENTS, data.AllowedEnvironments, &cfg.allowedEnvironments},
+		{DENIED_ENVIRONMENTS, data.DeniedEnvironments, &cfg.deniedEnvironments},
+	} {
+		patterns, d := stringSliceFromSet(ctx, attr.value)
+		diags.Append(d...)
+		for _, p := range patterns {
+			if _, err := pathpkg.Match(p, ""); err != nil {
+				diags.AddAttributeError(path.Root(attr.name), "Invalid guardrail pattern", fmt.Sprintf("%q is not a valid glob pattern: %s", p, err))
+			}
+		}
+		*attr.dest = patterns
+	}
+	return cfg, diags
+}
+
+func (g guardrailConfig) enabled() bool {
+	return len(g.allowedProjects) > 0 || len(g.allowedEnvironments) > 0 || len(g.deniedEnvironments) > 0
+}
+
+// matchGuardrailPattern reports whether any pattern matches value, returning
+// the pattern that did. Patterns are validated at configure time.
+func matchGuardrailPattern(patterns []string, value string) (string, bool) {
+	for _, p := range patterns {
+		if ok, _ := pathpkg.Match(p, value); ok {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+// matchEnvironmentPattern matches environment patterns. A pattern containing
+// a "/" is matched against "<project_key>/<env_key>", any other pattern
+// against the environment key alone.
+func matchEnvironmentPattern(patterns []string, projectKey, envKey string) (string, bool) {
+	for _, p := range patterns {
+		value := envKey
+		if strings.Contains(p, "/") {
+			value = projectKey + "/" + envKey
+		}
+		if ok, _ := pathpkg.Match(p, value); ok {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+// check returns why the guardrails block changes to the given project and
+// environment, or "" when they allow them. envKey is "" for resources that
+// are not scoped to an environment.
+func (g guardrailConfig) check(projectKey, envKey string) string {
+	if len(g.allowedProjects) > 0 {
+		if _, ok := matchGuardrailPattern(g.allowedProjects, projectKey); !ok {
+			return fmt.Sprintf("%s does not match any of %q", ALLOWED_PROJECTS, g.allowedProjects)
+		}
+	}
+	if envKey == "" {
+		return ""
+	}
+	if p, ok := matchEnvironmentPattern(g.deniedEnvironments, projectKey, envKey); ok {
+		return fmt.Sprintf("%s pattern %q matches it", DENIED_ENVIRONMENTS, p)
+	}
+	if len(g.allowedEnvironments) > 0 {
+		if _, ok := matchEnvironmentPattern(g.allowedEnvironments, projectKey, envKey); !ok {
+			return fmt.Sprintf("%s does not match any of %q", ALLOWED_ENVIRONMENTS, g.allowedEnvironments)
+		}
+	}
+	return ""
+}
+
+// guardrailScope names the attributes that hold a resource's project and
+// environment keys. env is "" for resources that are not scoped to an
+// environment. When projectFromFlagID is set, project names a
+// "<project_key>/<flag_key>" flag ID attribute instead.
+type guardrailScope struct {
+	project           string
+	env               string
+	projectFromFlagID bool
+}
+
+// keys reads the project and environment keys from a plan or state. known is
+// false while either key is unknown.
+func (s guardrailScope) keys(ctx context.Context, get func(context.Context, path.Path, interface{}) diag.Diagnostics) (projectKey, envKey string, known bool, diags diag.Diagnostics) {
+	var project, env types.String
+	diags.Append(get(ctx, path.Root(s.project), &project)...)
+	if s.env != "" {
+		diags.Append(get(ctx, path.Root(s.env), &env)...)
+	}
+	if diags.HasError() || project.IsUnknown() || env.IsUnknown() {
+		return "", "", false, diags
+	}
+	projectKey = project.ValueString()
+	if s.projectFromFlagID {
+		var err error
+		projectKey, _, err = flagIdToKeys(projectKey)
+		if err != nil {
+			// The flag ID validator reports malformed IDs.
+			return "", "", false, diags
+		}
+	}
+	return projectKey, env.ValueString(), true, diags
+}
+
+// planGuardrails fails the plan when a resource would be created, updated or
+// destroyed in a project or environment the provider's guardrails do not
+// allow. Both the planned and the prior keys are checked, so moving a
+// resource out of a guarded environment is blocked as well. Plans that change
+// nothing are never blocked.
+func (c *Client) planGuardrails(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, scope guardrailScope) {
+	if c == nil || !c.guardrails.enabled() || req.Plan.Raw.Equal(req.State.Raw) {
+		return
+	}
+	operation := "update"
+	switch {
+	case req.State.Raw.IsNull():
+		operation = "create"
+	case req.Plan.Raw.IsNull():
+		operation = "destroy"
+	}
+
+	var sources []func(context.Context, path.Path, interface{}) diag.Diagnostics
+	if !req.Plan.Raw.IsNull() {
+		sources = append(sources, req.Plan.GetAttribute)
+	}
+	if !req.State.Raw.IsNull() {
+		sources = append(sources, req.State.GetAttribute)
+	}
+	checked := make(map[string]bool)
+	for _, get := range sources {
+		projectKey, envKey, known, diags := scope.keys(ctx, get)
+		resp.Diagnostics.Append(diags...)
+		if !known || checked[projectKey+"/"+envKey] {
+			continue
+		}
+		checked[projectKey+"/"+envKey] = true
+		reason := c.guardrails.check(projectKey, envKey)
+		if reason == "" {
+			continue
+		}
+		where := fmt.Sprintf("project %q", projectKey)
+		if envKey != "" {
+			where = fmt.Sprintf("environment %q of project %q", envKey, projectKey)
+		}
+		resp.Diagnostics.AddError(
+			"Change blocked by provider guardrails",
+			fmt.Sprintf("This plan would %s a resource in %s, which the provider does not allow: %s.", operation, where, reason),
+		)
+	}
+}
+
+// planEnvironmentMapGuardrails fails the plan when a resource would change,
+// through its environments map, an environment the provider's guardrails do
+// not allow. Entries that are added or changed are checked against the
+// project in projectAttr. When removesEnvironments is set, removing an entry,
+// or destroying or replacing the resource, deletes the environment, so
+// those entries are checked against the prior project as well.
+func (c *Client) planEnvironmentMapGuardrails(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, projectAttr string, removesEnvironments bool) {
+	if c == nil || !c.guardrails.enabled() || req.Plan.Raw.Equal(req.State.Raw) {
+		return
+	}
+	var plannedProject, priorProject types.String
+	var planned, prior types.Map
+	if !req.Plan.Raw.IsNull() {
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(projectAttr), &plannedProject)...)
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(ENVIRONMENTS), &planned)...)
+	}
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(projectAttr), &priorProject)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(ENVIRONMENTS), &prior)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// A change of project replaces the resource.
+	replaced := !req.Plan.Raw.IsNull() && !req.State.Raw.IsNull() && !plannedProject.IsUnknown() && !plannedProject.Equal(priorProject)
+
+	block := func(operation, projectKey, envKey string) {
+		reason := c.guardrails.check(projectKey, envKey)
+		if reason == "" {
+			return
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root(ENVIRONMENTS).AtMapKey(envKey),
+			"Change blocked by provider guardrails",
+			fmt.Sprintf("This plan would %s environment %q of project %q, which the provider does not allow: %s.", operation, envKey, projectKey, reason),
+		)
+	}
+
+	priorEnvs := prior.Elements()
+	if !plannedProject.IsUnknown() && !planned.IsUnknown() {
+		for _, envKey := range sortedMapKeys(planned) {
+			before, ok := priorEnvs[envKey]
+			switch {
+			case !ok || replaced:
+				operation := "update"
+				if removesEnvironments {
+					operation = "create"
+				}
+				block(operation, plannedProject.ValueString(), envKey)
+			case environmentEntryChanged(planned.Elements()[envKey], before):
+				block("update", plannedProject.ValueString(), envKey)
+			}
+		}
+	}
+	if !removesEnvironments || req.State.Raw.IsNull() || planned.IsUnknown() {
+		return
+	}
+	plannedEnvs := planned.Elements()
+	for _, envKey := range sortedMapKeys(prior) {
+		if _, ok := plannedEnvs[envKey]; ok && !replaced {
+			continue
+		}
+		block("delete", priorProject.ValueString(), envKey)
+	}
+}
+
+// sortedMapKeys returns the keys of m in sorted order.
+func sortedMapKeys(m types.Map) []string {
+	keys := make([]string, 0, len(m.Elements()))
+	for k := range m.Elements() {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// environmentEntryChanged reports whether a planned environments entry
+// differs from its prior state. Values the plan leaves unknown, such as
+// computed attributes of nested objects, do not count as changes.
+func environmentEntryChanged(planned, prior attr.Value) bool {
+	if planned.IsUnknown() {
+		return false
+	}
+	plannedObj, ok := planned.(types.Object)
+	priorObj, priorOk := prior.(types.Object)
+	if !ok || !priorOk || plannedObj.IsNull() || priorObj.IsNull() {
+		return !planned.Equal(prior)
+	}
+	priorAttrs := priorObj.Attributes()
+	for name, value := range plannedObj.Attributes() {
+		before, ok := priorAttrs[name]
+		if !ok || environmentEntryChanged(value, before) {
+			return true
+		}
+	}
+	return false
+}
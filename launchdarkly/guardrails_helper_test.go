@@ -0,0 +1,192 @@
+package launchdarkly
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuardrailConfigCheck(t *testing.T) {
+	g := guardrailConfig{
+		allowedProjects:     []string{"mobile-*", "web"},
+		allowedEnvironments: []string{"staging*", "web/test"},
+		deniedEnvironments:  []string{"staging-prod"},
+	}
+
+	assert.Empty(t, g.check("mobile-ios", ""))
+	assert.Empty(t, g.check("mobile-ios", "staging"))
+	assert.Empty(t, g.check("web", "test"), "project-qualified patterns match <project_key>/<env_key>")
+	assert.Contains(t, g.check("mobile-ios", "test"), ALLOWED_ENVIRONMENTS)
+	assert.Contains(t, g.check("checkout", ""), ALLOWED_PROJECTS)
+	assert.Contains(t, g.check("mobile-ios", "staging-prod"), `denied_environments pattern "staging-prod"`, "denied_environments wins over allowed_environments")
+
+	assert.Empty(t, guardrailConfig{}.check("anything", "production"))
+	assert.False(t, guardrailConfig{}.enabled())
+}
+
+func TestGuardrailConfigFromProviderModel(t *testing.T) {
+	ctx := context.Background()
+	denied, d := setFromStringSlice(ctx, []string{"prod["})
+	require.False(t, d.HasError())
+
+	_, diags := guardrailConfigFromProviderModel(ctx, launchdarklyProviderModel{
+		AllowedProjects:     types.SetNull(types.StringType),
+		AllowedEnvironments: types.SetNull(types.StringType),
+		DeniedEnvironments:  denied,
+	})
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Detail(), `"prod["`)
+}
+
+func TestPlanGuardrails(t *testing.T) {
+	ctx := context.Background()
+	s := schema.Schema{Attributes: map[string]schema.Attribute{
+		PROJECT_KEY: schema.StringAttribute{Required: true},
+		ENV_KEY:     schema.StringAttribute{Required: true},
+		NAME:        schema.StringAttribute{Optional: true},
+	}}
+	objType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{PROJECT_KEY: tftypes.String, ENV_KEY: tftypes.String, NAME: tftypes.String}}
+	value := func(projectKey, envKey, name string) tftypes.Value {
+		return tftypes.NewValue(objType, map[string]tftypes.Value{
+			PROJECT_KEY: tftypes.NewValue(tftypes.String, projectKey),
+			ENV_KEY:     tftypes.NewValue(tftypes.String, envKey),
+			NAME:        tftypes.NewValue(tftypes.String, name),
+		})
+	}
+	null := tftypes.NewValue(objType, nil)
+	client := &Client{guardrails: guardrailConfig{deniedEnvironments: []string{"production"}}}
+	scope := guardrailScope{project: PROJECT_KEY, env: ENV_KEY}
+
+	plan := func(prior, planned tftypes.Value) resource.ModifyPlanResponse {
+		req := resource.ModifyPlanRequest{
+			State: tfsdk.State{Schema: s, Raw: prior},
+			Plan:  tfsdk.Plan{Schema: s, Raw: planned},
+		}
+		resp := resource.ModifyPlanResponse{Plan: req.Plan}
+		client.planGuardrails(ctx, req, &resp, scope)
+		return resp
+	}
+
+	t.Run("allows changes to other environments", func(t *testing.T) {
+		resp := plan(value("p", "staging", "a"), value("p", "staging", "b"))
+		assert.False(t, resp.Diagnostics.HasError())
+	})
+
+	t.Run("blocks creating in a denied environment", func(t *testing.T) {
+		resp := plan(null, value("p", "production", "a"))
+		require.True(t, resp.Diagnostics.HasError())
+		assert.Contains(t, resp.Diagnostics[0].Detail(), `create a resource in environment "production" of project "p"`)
+	})
+
+	t.Run("blocks destroying in a denied environment", func(t *testing.T) {
+		resp := plan(value("p", "production", "a"), null)
+		require.True(t, resp.Diagnostics.HasError())
+		assert.Contains(t, resp.Diagnostics[0].Detail(), "destroy")
+	})
+
+	t.Run("blocks moving a resource out of a denied environment", func(t *testing.T) {
+		resp := plan(value("p", "production", "a"), value("p", "staging", "a"))
+		require.True(t, resp.Diagnostics.HasError())
+	})
+
+	t.Run("does not block plans without changes", func(t *testing.T) {
+		resp := plan(value("p", "production", "a"), value("p", "production", "a"))
+		assert.False(t, resp.Diagnostics.HasError())
+	})
+
+	t.Run("defers unknown keys to apply", func(t *testing.T) {
+		unknown := tftypes.NewValue(objType, map[string]tftypes.Value{
+			PROJECT_KEY: tftypes.NewValue(tftypes.String, "p"),
+			ENV_KEY:     tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			NAME:        tftypes.NewValue(tftypes.String, "a"),
+		})
+		resp := plan(null, unknown)
+		assert.False(t, resp.Diagnostics.HasError())
+	})
+}
+
+func TestPlanEnvironmentMapGuardrails(t *testing.T) {
+	ctx := context.Background()
+	s := schema.Schema{Attributes: map[string]schema.Attribute{
+		PROJECT_KEY: schema.StringAttribute{Required: true},
+		ENVIRONMENTS: schema.MapNestedAttribute{
+			Optional: true,
+			NestedObject: schema.NestedAttributeObject{Attributes: map[string]schema.Attribute{
+				NAME: schema.StringAttribute{Optional: true},
+				ID:   schema.StringAttribute{Computed: true},
+			}},
+		},
+	}}
+	envType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{NAME: tftypes.String, ID: tftypes.String}}
+	objType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{PROJECT_KEY: tftypes.String, ENVIRONMENTS: tftypes.Map{ElementType: envType}}}
+	env := func(name string, id interface{}) tftypes.Value {
+		return tftypes.NewValue(envType, map[string]tftypes.Value{
+			NAME: tftypes.NewValue(tftypes.String, name),
+			ID:   tftypes.NewValue(tftypes.String, id),
+		})
+	}
+	value := func(projectKey string, envs map[string]tftypes.Value) tftypes.Value {
+		return tftypes.NewValue(objType, map[string]tftypes.Value{
+			PROJECT_KEY:  tftypes.NewValue(tftypes.String, projectKey),
+			ENVIRONMENTS: tftypes.NewValue(tftypes.Map{ElementType: envType}, envs),
+		})
+	}
+	null := tftypes.NewValue(objType, nil)
+	client := &Client{guardrails: guardrailConfig{deniedEnvironments: []string{"production"}}}
+
+	plan := func(prior, planned tftypes.Value, removesEnvironments bool) resource.ModifyPlanResponse {
+		req := resource.ModifyPlanRequest{
+			State: tfsdk.State{Schema: s, Raw: prior},
+			Plan:  tfsdk.Plan{Schema: s, Raw: planned},
+		}
+		resp := resource.ModifyPlanResponse{Plan: req.Plan}
+		client.planEnvironmentMapGuardrails(ctx, req, &resp, PROJECT_KEY, removesEnvironments)
+		return resp
+	}
+	both := map[string]tftypes.Value{"staging": env("Staging", "1"), "production": env("Production", "2")}
+
+	t.Run("allows changes to other environments", func(t *testing.T) {
+		planned := map[string]tftypes.Value{"staging": env("Renamed", "1"), "production": env("Production", "2")}
+		resp := plan(value("p", both), value("p", planned), true)
+		assert.False(t, resp.Diagnostics.HasError())
+	})
+
+	t.Run("ignores unknown computed attributes", func(t *testing.T) {
+		planned := map[string]tftypes.Value{"staging": env("Renamed", "1"), "production": env("Production", tftypes.UnknownValue)}
+		resp := plan(value("p", both), value("p", planned), true)
+		assert.False(t, resp.Diagnostics.HasError())
+	})
+
+	t.Run("blocks changing a denied environment", func(t *testing.T) {
+		planned := map[string]tftypes.Value{"staging": env("Staging", "1"), "production": env("Renamed", "2")}
+		resp := plan(value("p", both), value("p", planned), false)
+		require.True(t, resp.Diagnostics.HasError())
+		assert.Contains(t, resp.Diagnostics[0].Detail(), `update environment "production" of project "p"`)
+	})
+
+	t.Run("blocks adding a denied environment", func(t *testing.T) {
+		resp := plan(null, value("p", both), true)
+		require.True(t, resp.Diagnostics.HasError())
+		assert.Contains(t, resp.Diagnostics[0].Detail(), `create environment "production"`)
+	})
+
+	t.Run("blocks removing a denied environment only when that deletes it", func(t *testing.T) {
+		planned := map[string]tftypes.Value{"staging": env("Staging", "1")}
+		resp := plan(value("p", both), value("p", planned), false)
+		assert.False(t, resp.Diagnostics.HasError())
+
+		resp = plan(value("p", both), value("p", planned), true)
+		require.True(t, resp.Diagnostics.HasError())
+		assert.Contains(t, resp.Diagnostics[0].Detail(), `delete environment "production"`)
+
+		resp = plan(value("p", both), null, true)
+		require.True(t, resp.Diagnostics.HasError())
+	})
+}
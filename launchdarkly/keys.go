@@ -5,10 +5,13 @@ package launchdarkly
 const (
 	//gofmts:sort
 	ACCOUNT_ID                                = "account_id"
+	ACKNOWLEDGE_CRITICAL                      = "acknowledge_critical"
+	ACTION                                    = "action"
 	ACTIONS                                   = "actions"
 	ACTION_SET                                = "action_set"
 	AI_CONFIG_KEY                             = "config_key"
 	ALLOCATION                                = "allocation"
+	ALLOWED                                   = "allowed"
 	ANALYSIS_TYPE                             = "analysis_type"
 	ANALYSIS_UNITS                            = "analysis_units"
 	API_KEY                                   = "api_key"
@@ -17,9 +20,11 @@ const (
 	ATTRIBUTE                                 = "attribute"
 	AUTO_APPLY_APPROVED_CHANGES               = "auto_apply_approved_changes"
 	BASE_PERMISSIONS                          = "base_permissions"
+	BEFORE_RULE_REF                           = "before_rule_ref"
 	BOOLEAN_DEFAULTS                          = "boolean_defaults"
 	BUCKET_BY                                 = "bucket_by"
 	CAN_APPLY_DECLINED_CHANGES                = "can_apply_declined_changes"
+	CAN_RESHUFFLE                             = "can_reshuffle"
 	CAN_REVIEW_OWN_REQUEST                    = "can_review_own_request"
 	CLAUSES                                   = "clauses"
 	CLIENT_ID                                 = "client_id"
@@ -27,8 +32,11 @@ const (
 	CLIENT_SIDE_AVAILABILITY                  = "client_side_availability"
 	CLIENT_SIDE_ID                            = "client_side_id"
 	COLOR                                     = "color"
+	COMMENT                                   = "comment"
 	CONFIG                                    = "config"
 	CONFIG_ID                                 = "config_id"
+	CONFIG_WO                                 = "config_wo"
+	CONFIG_WO_VERSION                         = "config_wo_version"
 	CONFIRM_CHANGES                           = "confirm_changes"
 	CONTENT                                   = "content"
 	CONTEXT_KIND                              = "context_kind"
@@ -41,7 +49,9 @@ const (
 	CUSTOM_PARAMETERS                         = "custom_parameters"
 	CUSTOM_PROPERTIES                         = "custom_properties"
 	CUSTOM_ROLES                              = "custom_roles"
+	CUSTOM_ROLE_KEY                           = "custom_role_key"
 	CUSTOM_ROLE_KEYS                          = "custom_role_keys"
+	DECIDED_BY                                = "decided_by"
 	DEFAULTS                                  = "defaults"
 	DEFAULT_API_VERSION                       = "default_api_version"
 	DEFAULT_CLIENT_SIDE_AVAILABILITY          = "default_client_side_availability"
@@ -49,6 +59,7 @@ const (
 	DEFAULT_ON_VARIATION                      = "default_on_variation"
 	DEFAULT_TRACK_EVENTS                      = "default_track_events"
 	DEFAULT_TTL                               = "default_ttl"
+	DEFAULT_VARIATION                         = "default_variation"
 	DEPRECATED                                = "deprecated"
 	DESCRIPTION                               = "description"
 	DISPLAY_KEY                               = "display_key"
@@ -66,6 +77,9 @@ const (
 	EVENT_KEY                                 = "event_key"
 	EXCLUDED                                  = "excluded"
 	EXCLUDED_CONTEXTS                         = "excluded_contexts"
+	EXECUTION_DATE                            = "execution_date"
+	EXPERIMENT_ALLOCATION                     = "experiment_allocation"
+	EXPIRATIONS                               = "expirations"
 	EXPIRE                                    = "expire"
 	EXPIRY                                    = "expiry"
 	FALLTHROUGH                               = "fallthrough"
@@ -84,6 +98,7 @@ const (
 	HIDE_IN_TARGETING                         = "hide_in_targeting"
 	ICON                                      = "icon"
 	ID                                        = "id"
+	IGNORE_CONFLICTS                          = "ignore_conflicts"
 	IGNORE_MISSING                            = "ignore_missing"
 	INCLUDED                                  = "included"
 	INCLUDED_CONTEXTS                         = "included_contexts"
@@ -99,6 +114,7 @@ const (
 	IS_DISMISSIBLE                            = "is_dismissible"
 	IS_INVERTED                               = "is_inverted"
 	IS_NUMERIC                                = "is_numeric"
+	JSON                                      = "json"
 	JUDGES                                    = "judges"
 	KEY                                       = "key"
 	KIND                                      = "kind"
@@ -110,12 +126,15 @@ const (
 	MAINTAINERS                               = "maintainers"
 	MAINTAINER_ID                             = "maintainer_id"
 	MAINTAINER_TEAM_KEY                       = "maintainer_team_key"
+	MANAGED_FIELDS                            = "managed_fields"
+	MEMBERS                                   = "members"
 	MEMBER_IDS                                = "member_ids"
 	MESSAGE                                   = "message"
 	MESSAGES                                  = "messages"
 	METRICS                                   = "metrics"
 	METRIC_GROUP_KEYS                         = "metric_group_keys"
 	METRIC_KEYS                               = "metric_keys"
+	MIGRATION_SETTINGS                        = "migration_settings"
 	MIN_NUM_APPROVALS                         = "min_num_approvals"
 	MIN_SAMPLE_SIZE                           = "min_sample_size"
 	MOBILE_KEY                                = "mobile_key"
@@ -129,15 +148,21 @@ const (
 	NOT_ACTIONS                               = "not_actions"
 	NOT_RESOURCES                             = "not_resources"
 	OFF_VARIATION                             = "off_variation"
+	OFF_VARIATION_NAME                        = "off_variation_name"
+	OFF_VARIATION_VALUE                       = "off_variation_value"
 	ON                                        = "on"
 	ON_VARIATION                              = "on_variation"
+	ON_VARIATION_NAME                         = "on_variation_name"
+	ON_VARIATION_VALUE                        = "on_variation_value"
 	OP                                        = "op"
+	OVERRIDE_POLICY_DOCUMENTS                 = "override_policy_documents"
 	PARAMS                                    = "params"
 	PATTERN                                   = "pattern"
 	PERCENTILE_VALUE                          = "percentile_value"
 	POLICY                                    = "policy"
 	POLICY_STATEMENTS                         = "policy_statements"
 	POLICY_STATEMENTS_JSON                    = "policy_statements_json"
+	POSITION                                  = "position"
 	PREREQUISITES                             = "prerequisites"
 	PROGRESSIVE_RELEASE_CONFIG                = "progressive_release_config"
 	PROJECT_KEY                               = "project_key"
@@ -145,7 +170,9 @@ const (
 	PROVIDER_NAME                             = "model_provider"
 	RANDOMIZATION_UNITS                       = "randomization_units"
 	RANK                                      = "rank"
+	REASON                                    = "reason"
 	RECONCILE_ON_APPLY                        = "reconcile_on_apply"
+	REF                                       = "ref"
 	REDIRECT_URI                              = "redirect_uri"
 	RELEASE_METHOD                            = "release_method"
 	RELEASE_POLICY_KEYS                       = "release_policy_keys"
@@ -160,7 +187,9 @@ const (
 	ROLE                                      = "role"
 	ROLE_ATTRIBUTES                           = "role_attributes"
 	ROLLBACK_ON_REGRESSION                    = "rollback_on_regression"
+	ROLLOUT                                   = "rollout"
 	ROLLOUT_CONTEXT_KIND                      = "rollout_context_kind"
+	ROLLOUT_PERCENTAGES                       = "rollout_percentages"
 	ROLLOUT_WEIGHTS                           = "rollout_weights"
 	ROOT_CONFIG_KEY                           = "root_config_key"
 	RULES                                     = "rules"
@@ -170,7 +199,10 @@ const (
 	SCOPED_ALLOWLIST_ENABLED                  = "scoped_allowlist_enabled"
 	SCOPE_ENVIRONMENT_KEYS                    = "environment_keys"
 	SECRET                                    = "secret"
+	SECRET_WO                                 = "secret_wo"
+	SECRET_WO_VERSION                         = "secret_wo_version"
 	SECURE_MODE                               = "secure_mode"
+	SEED                                      = "seed"
 	SEGMENTS                                  = "segments"
 	SEGMENT_APPROVAL_SETTINGS                 = "segment_approval_settings"
 	SEGMENT_ENVIRONMENT_ID                    = "environment_id"
@@ -183,23 +215,31 @@ const (
 	SERVICE_TOKEN                             = "service_token"
 	SESSION_ALLOWLIST_ENABLED                 = "session_allowlist_enabled"
 	SEVERITY                                  = "severity"
+	SID                                       = "sid"
+	SOURCE_POLICY_DOCUMENTS                   = "source_policy_documents"
 	SOURCE_CONFIG                             = "source_config"
 	STAGES                                    = "stages"
+	STAGE_COUNT                               = "stage_count"
 	START_TIME                                = "start_time"
 	STATE                                     = "state"
 	STATEMENTS                                = "statements"
+	STATEMENT_INDEX                           = "statement_index"
 	STATUS                                    = "status"
 	SUBSTRING                                 = "substring"
 	SUCCESS_CRITERIA                          = "success_criteria"
 	TAGS                                      = "tags"
+	TAGS_ALL                                  = "tags_all"
 	TARGETS                                   = "targets"
 	TARGET_CONFIG                             = "target_config"
+	TEAMS                                     = "teams"
+	TEAM_KEY                                  = "team_key"
 	TEAM_MEMBERS                              = "team_members"
 	TEMPORARY                                 = "temporary"
 	TITLE                                     = "title"
 	TOKEN                                     = "token"
 	TOOL_KEYS                                 = "tool_keys"
 	TRACK_EVENTS                              = "track_events"
+	TRACK_EVENTS_FALLTHROUGH                  = "track_events_fallthrough"
 	TRIGGER_URL                               = "trigger_url"
 	TRUE_DESCRIPTION                          = "true_description"
 	TRUE_DISPLAY_NAME                         = "true_display_name"
@@ -207,6 +247,7 @@ const (
 	UNBOUNDED_CONTEXT_KIND                    = "unbounded_context_kind"
 	UNIT                                      = "unit"
 	UNIT_AGGREGATION_TYPE                     = "unit_aggregation_type"
+	UNTRACKED_VARIATIONS                      = "untracked_variations"
 	URL                                       = "url"
 	URLS                                      = "urls"
 	USING_ENVIRONMENT_ID                      = "using_environment_id"
@@ -217,10 +258,13 @@ const (
 	VARIATION                                 = "variation"
 	VARIATIONS                                = "variations"
 	VARIATION_ID                              = "variation_id"
+	VARIATION_NAME                            = "variation_name"
 	VARIATION_TYPE                            = "variation_type"
+	VARIATION_VALUE                           = "variation_value"
 	VERSION                                   = "version"
 	VIEWS                                     = "views"
 	VIEW_KEY                                  = "view_key"
 	VIEW_KEYS                                 = "view_keys"
 	WEIGHT                                    = "weight"
+	WEIGHT_PERCENTAGE                         = "weight_percentage"
 )
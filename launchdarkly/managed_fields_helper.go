@@ -0,0 +1,220 @@
+package launchdarkly
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ldapi "github.com/launchdarkly/api-client-go/v24"
+)
+
+// A launchdarkly_feature_flag_environment can manage some of an
+// environment's settings and leave the others to the LaunchDarkly UI. Fields
+// it does not manage are null in plan and state, are never patched, and are
+// not reset on destroy.
+
+// ffeFieldPaths maps each setting managed_fields can list to the names of
+// its environment attributes in the LaunchDarkly API.
+var ffeFieldPaths = map[string][]string{
+	ON:              {"on"},
+	TRACK_EVENTS:    {"trackEvents"},
+	OFF_VARIATION:   {"offVariation"},
+	TARGETS:         {"targets"},
+	CONTEXT_TARGETS: {"contextTargets"},
+	PREREQUISITES:   {"prerequisites"},
+	RULES:           {"rules"},
+	FALLTHROUGH:     {"fallthrough", "trackEventsFallthrough"},
+}
+
+// ffeFields lists the keys of ffeFieldPaths in schema order.
+var ffeFields = []string{ON, TRACK_EVENTS, OFF_VARIATION, TARGETS, CONTEXT_TARGETS, PREREQUISITES, RULES, FALLTHROUGH}
+
+// ffeManagedFields is the set of settings a feature flag environment resource
+// manages. A nil set manages every setting.
+type ffeManagedFields map[string]bool
+
+// ffeManagedFieldsFrom reads managed_fields. A null value manages every
+// setting.
+func ffeManagedFieldsFrom(ctx context.Context, set types.Set) (ffeManagedFields, diag.Diagnostics) {
+	if set.IsNull() || set.IsUnknown() {
+		return nil, nil
+	}
+	fields, diags := stringSliceFromSet(ctx, set)
+	managed := make(ffeManagedFields, len(fields))
+	for _, field := range fields {
+		managed[field] = true
+	}
+	return managed, diags
+}
+
+func (f ffeManagedFields) has(field string) bool {
+	return f == nil || f[field]
+}
+
+// addedSince returns the settings f manages that prior does not.
+func (f ffeManagedFields) addedSince(prior ffeManagedFields) ffeManagedFields {
+	added := make(ffeManagedFields)
+	for _, field := range ffeFields {
+		if f.has(field) && !prior.has(field) {
+			added[field] = true
+		}
+	}
+	return added
+}
+
+// overlay returns base with the settings f manages taken from m.
+func (f ffeManagedFields) overlay(base, m FeatureFlagEnvironmentResourceModel) FeatureFlagEnvironmentResourceModel {
+	if f.has(ON) {
+		base.On = m.On
+	}
+	if f.has(TRACK_EVENTS) {
+		base.TrackEvents = m.TrackEvents
+	}
+	if f.has(OFF_VARIATION) {
+		base.OffVariation = m.OffVariation
+	}
+	if f.has(TARGETS) {
+		base.Targets = m.Targets
+	}
+	if f.has(CONTEXT_TARGETS) {
+		base.ContextTargets = m.ContextTargets
+	}
+	if f.has(PREREQUISITES) {
+		base.Prerequisites = m.Prerequisites
+	}
+	if f.has(RULES) {
+		base.Rules = m.Rules
+	}
+	if f.has(FALLTHROUGH) {
+		base.Fallthrough = m.Fallthrough
+		base.TrackEventsFallthrough = m.TrackEventsFallthrough
+	}
+	return base
+}
+
+// only returns m with the settings f does not manage set to null.
+func (f ffeManagedFields) only(m FeatureFlagEnvironmentResourceModel) FeatureFlagEnvironmentResourceModel {
+	base := m
+	base.On = types.BoolNull()
+	base.TrackEvents = types.BoolNull()
+	base.OffVariation = types.Int64Null()
+	base.Targets = types.SetNull(types.ObjectType{AttrTypes: ffeTargetAttrTypes})
+	base.ContextTargets = types.SetNull(types.ObjectType{AttrTypes: ffeContextTargetAttrTypes})
+	base.Prerequisites = types.ListNull(types.ObjectType{AttrTypes: ffeResourcePrerequisiteAttrTypes})
+	base.Rules = types.ListNull(types.ObjectType{AttrTypes: ffeResourceRuleAttrTypes})
+	base.Fallthrough = types.ObjectNull(ffeResourceFallthroughAttrTypes)
+	base.TrackEventsFallthrough = types.BoolNull()
+	return f.overlay(base, m)
+}
+
+// patches keeps the patch operations on settings f manages.
+func (f ffeManagedFields) patches(envKey string, patches []ldapi.PatchOperation) []ldapi.PatchOperation {
+	if f == nil {
+		return patches
+	}
+	kept := make([]ldapi.PatchOperation, 0, len(patches))
+	for _, p := range patches {
+		for field, names := range ffeFieldPaths {
+			if f[field] && slices.ContainsFunc(names, func(name string) bool { return p.Path == ffePatchPath(envKey, name) }) {
+				kept = append(kept, p)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+// ffeManagedFieldsValidator rejects configurations that set a setting
+// managed_fields leaves out, which would otherwise be silently ignored, and
+// requires fallthrough whenever it is managed.
+type ffeManagedFieldsValidator struct{}
+
+func (ffeManagedFieldsValidator) Description(context.Context) string {
+	return "only the settings listed in managed_fields may be set; fallthrough is required when managed"
+}
+func (ffeManagedFieldsValidator) MarkdownDescription(context.Context) string { return "" }
+
+func (ffeManagedFieldsValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data FeatureFlagEnvironmentResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.ManagedFields.IsUnknown() {
+		return
+	}
+	managed, d := ffeManagedFieldsFrom(ctx, data.ManagedFields)
+	resp.Diagnostics.Append(d...)
+	values := ffeFieldValues(data)
+	for _, field := range ffeFields {
+		if managed.has(field) {
+			if field == FALLTHROUGH && data.Fallthrough.IsNull() {
+				resp.Diagnostics.AddAttributeError(path.Root(FALLTHROUGH), "Missing fallthrough", fmt.Sprintf("%s is required unless %s leaves it out.", FALLTHROUGH, MANAGED_FIELDS))
+			}
+			continue
+		}
+		for _, name := range ffeFieldAttributes(field) {
+			if !values[name].IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(name),
+					"Unmanaged field is set",
+					fmt.Sprintf("%s is not in %s, so Terraform does not manage it. Remove %s from the configuration or add %s to %s.", field, MANAGED_FIELDS, name, field, MANAGED_FIELDS),
+				)
+			}
+		}
+	}
+}
+
+// ffeFieldAttributes returns the attributes that configure the setting field.
+func ffeFieldAttributes(field string) []string {
+	switch field {
+	case OFF_VARIATION:
+		return []string{OFF_VARIATION, OFF_VARIATION_VALUE, OFF_VARIATION_NAME}
+	case FALLTHROUGH:
+		return []string{FALLTHROUGH, TRACK_EVENTS_FALLTHROUGH}
+	}
+	return []string{field}
+}
+
+// ffeFieldValues returns the attributes that configure the settings of m by
+// name.
+func ffeFieldValues(m FeatureFlagEnvironmentResourceModel) map[string]attr.Value {
+	return map[string]attr.Value{
+		ON:                       m.On,
+		TRACK_EVENTS:             m.TrackEvents,
+		OFF_VARIATION:            m.OffVariation,
+		OFF_VARIATION_VALUE:      m.OffVariationValue,
+		OFF_VARIATION_NAME:       m.OffVariationName,
+		TARGETS:                  m.Targets,
+		CONTEXT_TARGETS:          m.ContextTargets,
+		PREREQUISITES:            m.Prerequisites,
+		RULES:                    m.Rules,
+		FALLTHROUGH:              m.Fallthrough,
+		TRACK_EVENTS_FALLTHROUGH: m.TrackEventsFallthrough,
+	}
+}
+
+// planManagedFields nulls the settings managed_fields leaves out that the
+// schema would otherwise default or compute, so they stay null in state.
+func planManagedFields(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var managedFields types.Set
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root(MANAGED_FIELDS), &managedFields)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	managed, d := ffeManagedFieldsFrom(ctx, managedFields)
+	resp.Diagnostics.Append(d...)
+	for name, field := range map[string]string{ON: ON, TRACK_EVENTS: TRACK_EVENTS, TRACK_EVENTS_FALLTHROUGH: FALLTHROUGH} {
+		switch {
+		case managedFields.IsUnknown():
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(name), types.BoolUnknown())...)
+		case !managed.has(field):
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(name), types.BoolNull())...)
+		}
+	}
+}
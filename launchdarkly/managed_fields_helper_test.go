@@ -0,0 +1,59 @@
+package launchdarkly
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ldapi "github.com/launchdarkly/api-client-go/v24"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFFEManagedFields(t *testing.T) {
+	ctx := context.Background()
+	set, d := setFromStringSlice(ctx, []string{RULES, ON})
+	require.False(t, d.HasError())
+	managed, d := ffeManagedFieldsFrom(ctx, set)
+	require.False(t, d.HasError())
+
+	all, d := ffeManagedFieldsFrom(ctx, types.SetNull(types.StringType))
+	require.False(t, d.HasError())
+	assert.True(t, all.has(FALLTHROUGH), "a null managed_fields manages every setting")
+	assert.True(t, managed.has(RULES))
+	assert.False(t, managed.has(FALLTHROUGH))
+
+	live := FeatureFlagEnvironmentResourceModel{On: types.BoolValue(true), TrackEvents: types.BoolValue(true), OffVariation: types.Int64Value(1)}
+	plan := FeatureFlagEnvironmentResourceModel{On: types.BoolValue(false), TrackEvents: types.BoolNull(), OffVariation: types.Int64Null()}
+	overlaid := managed.overlay(live, plan)
+	assert.Equal(t, types.BoolValue(false), overlaid.On)
+	assert.Equal(t, types.BoolValue(true), overlaid.TrackEvents, "unmanaged settings keep the base value")
+	assert.Equal(t, types.Int64Value(1), overlaid.OffVariation)
+
+	only := managed.only(live)
+	assert.Equal(t, types.BoolValue(true), only.On)
+	assert.True(t, only.TrackEvents.IsNull())
+	assert.True(t, only.OffVariation.IsNull())
+	assert.True(t, only.Fallthrough.IsNull())
+
+	assert.Equal(t, ffeManagedFields{TRACK_EVENTS: true}, ffeManagedFields{ON: true, RULES: true, TRACK_EVENTS: true}.addedSince(managed))
+	assert.Empty(t, managed.addedSince(nil), "nothing is added to a resource that managed every setting")
+
+	patches := []ldapi.PatchOperation{
+		patchReplace(ffePatchPath("test", "on"), false),
+		patchReplace(ffePatchPath("test", "trackEvents"), false),
+		patchReplace(ffePatchPath("test", "rules"), []ldapi.Rule{}),
+	}
+	assert.Equal(t, []ldapi.PatchOperation{patches[0], patches[2]}, managed.patches("test", patches))
+	assert.Equal(t, patches, all.patches("test", patches))
+
+	// Each reset patch names a setting managed_fields can list, so none is
+	// dropped when every setting is listed.
+	reset := ffeResetPatches("test", 1)
+	every := make(ffeManagedFields, len(ffeFields))
+	for _, field := range ffeFields {
+		every[field] = true
+	}
+	assert.Equal(t, reset, every.patches("test", reset))
+	assert.Len(t, ffeManagedFields{FALLTHROUGH: true}.patches("test", reset), 2, "fallthrough resets fallthrough and trackEventsFallthrough")
+}
@@ -0,0 +1,216 @@
+package launchdarkly
+
+// Migration flags serve the stages of a migration rather than variations
+// the user defines. LaunchDarkly creates them with one string variation per
+// stage, whose value is the stage's name, so launchdarkly_feature_flag plans
+// those variations itself and targeting can refer to stages by value.
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	ldapi "github.com/launchdarkly/api-client-go/v24"
+)
+
+const MIGRATION_FLAG_PURPOSE = "migration"
+
+// migrationFlagStages are the variation values of a migration flag with each
+// supported number of stages, in order.
+var migrationFlagStages = map[int64][]string{
+	2: {"off", "complete"},
+	4: {"off", "dualwrite", "shadow", "live"},
+	6: {"off", "dualwrite", "shadow", "live", "rampdown", "complete"},
+}
+
+var migrationSettingsAttrTypes = map[string]attr.Type{
+	CONTEXT_KIND: types.StringType,
+	STAGE_COUNT:  types.Int64Type,
+}
+
+type migrationSettingsModel struct {
+	ContextKind types.String `tfsdk:"context_kind"`
+	StageCount  types.Int64  `tfsdk:"stage_count"`
+}
+
+func migrationSettingsAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional:      true,
+		Description:   addForceNewDescription("Makes the flag a migration flag. A migration flag's `variation_type` must be `string`. Its variations are the stages of the migration, in order, and are created for you: `variations` can be left out, and if it is set its values must be the stage names. In `launchdarkly_feature_flag_environment`, use the stage names as `variation_value`s, such as `variation_value = \"dualwrite\"`.", true),
+		PlanModifiers: []planmodifier.Object{objectplanmodifier.RequiresReplace()},
+		Attributes: map[string]schema.Attribute{
+			CONTEXT_KIND: schema.StringAttribute{
+				Required:    true,
+				Description: "The context kind the migration is tracked by.",
+				Validators:  []validator.String{keyValidator()},
+			},
+			STAGE_COUNT: schema.Int64Attribute{
+				Required:    true,
+				Description: "The number of stages of the migration: `2` for `off` and `complete`, `4` for `off`, `dualwrite`, `shadow` and `live`, or `6` for `off`, `dualwrite`, `shadow`, `live`, `rampdown` and `complete`.",
+				Validators:  []validator.Int64{int64validator.OneOf(2, 4, 6)},
+			},
+		},
+	}
+}
+
+// migrationFlagStagesOf returns the stages of the migration settings, or nil
+// when they are null or not yet known.
+func migrationFlagStagesOf(ctx context.Context, settings types.Object) []string {
+	if settings.IsNull() || settings.IsUnknown() {
+		return nil
+	}
+	var m migrationSettingsModel
+	if settings.As(ctx, &m, basetypes.ObjectAsOptions{}).HasError() || m.StageCount.IsUnknown() {
+		return nil
+	}
+	return migrationFlagStages[m.StageCount.ValueInt64()]
+}
+
+// validateMigrationFlagConfig requires variations unless the flag is a
+// migration flag, and checks that a migration flag's configuration agrees
+// with the variations LaunchDarkly gives it.
+func validateMigrationFlagConfig(ctx context.Context, config FeatureFlagResourceModel, diags *diag.Diagnostics) {
+	if config.MigrationSettings.IsNull() {
+		if config.Variations.IsNull() {
+			diags.AddAttributeError(path.Root(VARIATIONS), "Missing required argument", fmt.Sprintf("%s is required unless %s is set.", VARIATIONS, MIGRATION_SETTINGS))
+		}
+		return
+	}
+	if !config.VariationType.IsUnknown() && config.VariationType.ValueString() != STRING_VARIATION {
+		diags.AddAttributeError(path.Root(VARIATION_TYPE), "Invalid migration flag", fmt.Sprintf("Migration flags must have a %s of %q.", VARIATION_TYPE, STRING_VARIATION))
+	}
+	stages := migrationFlagStagesOf(ctx, config.MigrationSettings)
+	if stages == nil || config.Variations.IsNull() || config.Variations.IsUnknown() {
+		return
+	}
+	values := make([]types.String, 0, len(config.Variations.Elements()))
+	for _, element := range config.Variations.Elements() {
+		obj, ok := element.(types.Object)
+		if !ok || obj.IsUnknown() {
+			return
+		}
+		value, _ := obj.Attributes()[VALUE].(types.String)
+		if value.IsUnknown() {
+			return
+		}
+		values = append(values, value)
+	}
+	if !slices.EqualFunc(values, stages, func(v types.String, stage string) bool { return v.ValueString() == stage }) {
+		diags.AddAttributeError(path.Root(VARIATIONS), "Invalid migration flag", fmt.Sprintf("The values of a migration flag's %s must be its stages, %s, in that order. Leave %s out to have them created for you.", VARIATIONS, oxfordCommaJoin(stages), VARIATIONS))
+	}
+}
+
+// planMigrationVariations plans the variations of a migration flag whose
+// configuration leaves them out: the prior variations while the migration
+// settings are unchanged, and otherwise one per stage, whose names and
+// descriptions LaunchDarkly fills in.
+func (r *FeatureFlagResource) planMigrationVariations(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var settings types.Object
+	var configured types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(MIGRATION_SETTINGS), &settings)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(VARIATIONS), &configured)...)
+	if resp.Diagnostics.HasError() || !configured.IsNull() {
+		return
+	}
+	stages := migrationFlagStagesOf(ctx, settings)
+	if stages == nil {
+		return
+	}
+	if !req.State.Raw.IsNull() {
+		var priorSettings types.Object
+		var prior types.List
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(MIGRATION_SETTINGS), &priorSettings)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(VARIATIONS), &prior)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if priorSettings.Equal(settings) && !prior.IsNull() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(VARIATIONS), prior)...)
+			return
+		}
+	}
+	elements := make([]attr.Value, 0, len(stages))
+	for _, stage := range stages {
+		obj, d := types.ObjectValue(featureFlagVariationAttrTypes, map[string]attr.Value{
+			NAME:        types.StringUnknown(),
+			DESCRIPTION: types.StringUnknown(),
+			VALUE:       types.StringValue(stage),
+		})
+		resp.Diagnostics.Append(d...)
+		elements = append(elements, obj)
+	}
+	list, d := types.ListValue(types.ObjectType{AttrTypes: featureFlagVariationAttrTypes}, elements)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(VARIATIONS), list)...)
+}
+
+// migrationVariationPatches patches the names and descriptions the
+// configuration gives a migration flag's variations. Their values are the
+// stages, which cannot change.
+func migrationVariationPatches(ctx context.Context, variations types.List) ([]ldapi.PatchOperation, diag.Diagnostics) {
+	var patches []ldapi.PatchOperation
+	if variations.IsNull() || variations.IsUnknown() {
+		return patches, nil
+	}
+	var models []struct {
+		Name        types.String `tfsdk:"name"`
+		Description types.String `tfsdk:"description"`
+		Value       types.String `tfsdk:"value"`
+	}
+	diags := variations.ElementsAs(ctx, &models, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+	for idx, m := range models {
+		if m.Name.ValueString() != "" {
+			patches = append(patches, patchReplace(fmt.Sprintf("/variations/%d/name", idx), m.Name.ValueString()))
+		}
+		if m.Description.ValueString() != "" {
+			patches = append(patches, patchReplace(fmt.Sprintf("/variations/%d/description", idx), m.Description.ValueString()))
+		}
+	}
+	return patches, diags
+}
+
+// migrationSettingsPost returns the migration settings to create a flag
+// with, or nil for an ordinary flag.
+func migrationSettingsPost(ctx context.Context, settings types.Object) (*ldapi.MigrationSettingsPost, diag.Diagnostics) {
+	if settings.IsNull() || settings.IsUnknown() {
+		return nil, nil
+	}
+	var m migrationSettingsModel
+	diags := settings.As(ctx, &m, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+	return &ldapi.MigrationSettingsPost{
+		ContextKind: ldapi.PtrString(m.ContextKind.ValueString()),
+		StageCount:  int32(m.StageCount.ValueInt64()),
+	}, diags
+}
+
+// migrationSettingsFromAPI returns the migration settings of flag, or null
+// for an ordinary flag.
+func migrationSettingsFromAPI(flag *ldapi.FeatureFlag) types.Object {
+	if flag.MigrationSettings == nil {
+		return types.ObjectNull(migrationSettingsAttrTypes)
+	}
+	return types.ObjectValueMust(migrationSettingsAttrTypes, map[string]attr.Value{
+		CONTEXT_KIND: stringValueFromPointer(flag.MigrationSettings.ContextKind),
+		STAGE_COUNT:  types.Int64Value(int64(flag.MigrationSettings.GetStageCount())),
+	})
+}
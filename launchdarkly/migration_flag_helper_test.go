@@ -0,0 +1,85 @@
+package launchdarkly
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMigrationSettings(stageCount int64) types.Object {
+	return types.ObjectValueMust(migrationSettingsAttrTypes, map[string]attr.Value{
+		CONTEXT_KIND: types.StringValue("user"),
+		STAGE_COUNT:  types.Int64Value(stageCount),
+	})
+}
+
+func testStringVariations(names []string, values ...string) types.List {
+	elements := make([]attr.Value, 0, len(values))
+	for i, value := range values {
+		name := types.StringNull()
+		if i < len(names) {
+			name = types.StringValue(names[i])
+		}
+		elements = append(elements, types.ObjectValueMust(featureFlagVariationAttrTypes, map[string]attr.Value{
+			NAME:        name,
+			DESCRIPTION: types.StringNull(),
+			VALUE:       types.StringValue(value),
+		}))
+	}
+	return types.ListValueMust(types.ObjectType{AttrTypes: featureFlagVariationAttrTypes}, elements)
+}
+
+func TestValidateMigrationFlagConfig(t *testing.T) {
+	noVariations := types.ListNull(types.ObjectType{AttrTypes: featureFlagVariationAttrTypes})
+	cases := []struct {
+		name          string
+		settings      types.Object
+		variationType string
+		variations    types.List
+		valid         bool
+	}{
+		{"ordinary flag", types.ObjectNull(migrationSettingsAttrTypes), STRING_VARIATION, testStringVariations(nil, "a", "b"), true},
+		{"ordinary flag without variations", types.ObjectNull(migrationSettingsAttrTypes), BOOL_VARIATION, noVariations, false},
+		{"migration flag without variations", testMigrationSettings(4), STRING_VARIATION, noVariations, true},
+		{"migration flag naming its stages", testMigrationSettings(2), STRING_VARIATION, testStringVariations([]string{"Old", "New"}, "off", "complete"), true},
+		{"migration flag with other values", testMigrationSettings(2), STRING_VARIATION, testStringVariations(nil, "off", "on"), false},
+		{"migration flag with stages out of order", testMigrationSettings(4), STRING_VARIATION, testStringVariations(nil, "off", "shadow", "dualwrite", "live"), false},
+		{"migration flag with too few stages", testMigrationSettings(6), STRING_VARIATION, testStringVariations(nil, "off", "dualwrite", "shadow", "live"), false},
+		{"boolean migration flag", testMigrationSettings(4), BOOL_VARIATION, noVariations, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			config := FeatureFlagResourceModel{
+				MigrationSettings: c.settings,
+				VariationType:     types.StringValue(c.variationType),
+				Variations:        c.variations,
+			}
+			var diags diag.Diagnostics
+			validateMigrationFlagConfig(context.Background(), config, &diags)
+			assert.Equal(t, c.valid, !diags.HasError(), "%v", diags)
+		})
+	}
+}
+
+func TestMigrationFlagStages(t *testing.T) {
+	ctx := context.Background()
+	for count, stages := range migrationFlagStages {
+		assert.Len(t, stages, int(count))
+		assert.Equal(t, "off", stages[0])
+		assert.Equal(t, stages, migrationFlagStagesOf(ctx, testMigrationSettings(count)))
+	}
+	assert.Nil(t, migrationFlagStagesOf(ctx, types.ObjectNull(migrationSettingsAttrTypes)))
+	assert.Nil(t, migrationFlagStagesOf(ctx, types.ObjectUnknown(migrationSettingsAttrTypes)))
+}
+
+func TestMigrationVariationPatches(t *testing.T) {
+	patches, diags := migrationVariationPatches(context.Background(), testStringVariations([]string{"", "Complete"}, "off", "complete"))
+	require.False(t, diags.HasError(), "%v", diags)
+	require.Len(t, patches, 1, "only configured names are patched, never the stage values")
+	assert.Equal(t, "/variations/1/name", patches[0].Path)
+}
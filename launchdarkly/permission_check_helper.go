@@ -0,0 +1,173 @@
+package launchdarkly
+
+// permission_check_helper.go evaluates policy statements locally, the way
+// LaunchDarkly decides whether a member may take an action on a resource: a
+// statement applies when both its resources and its actions match, any
+// applicable deny statement wins over every allow statement, and nothing is
+// allowed unless an allow statement applies.
+
+import (
+	"fmt"
+	"strings"
+)
+
+// policyCheckStatement is a statement to evaluate, with where it came from:
+// the custom role it belongs to, or "" for inline statements, and its index.
+type policyCheckStatement struct {
+	customRoleKey string
+	index         int
+	statement     frameworkPolicyStatementModel
+}
+
+func (s policyCheckStatement) String() string {
+	if s.customRoleKey == "" {
+		return fmt.Sprintf("statements[%d]", s.index)
+	}
+	return fmt.Sprintf("statement %d of custom role %q", s.index, s.customRoleKey)
+}
+
+// policyCheckDecision is the outcome of evaluating statements. decidedBy is
+// the statement that decided it, or nil when no statement applied.
+type policyCheckDecision struct {
+	allowed   bool
+	decidedBy *policyCheckStatement
+}
+
+// checkPolicy decides whether statements allow action on resource, with
+// roleAttributes substituted for the role-attribute placeholders of their
+// resource specifiers. The first applicable deny statement decides, then the
+// first applicable allow statement.
+func checkPolicy(statements []policyCheckStatement, action string, resource policyResource, roleAttributes map[string][]string) (policyCheckDecision, error) {
+	var allowedBy *policyCheckStatement
+	for i := range statements {
+		s := &statements[i]
+		applies, err := policyStatementApplies(s.statement, action, resource, roleAttributes)
+		if err != nil {
+			return policyCheckDecision{}, fmt.Errorf("%s: %w", s, err)
+		}
+		if !applies {
+			continue
+		}
+		if s.statement.Effect == "deny" {
+			return policyCheckDecision{allowed: false, decidedBy: s}, nil
+		}
+		if allowedBy == nil {
+			allowedBy = s
+		}
+	}
+	return policyCheckDecision{allowed: allowedBy != nil, decidedBy: allowedBy}, nil
+}
+
+func policyStatementApplies(s frameworkPolicyStatementModel, action string, resource policyResource, roleAttributes map[string][]string) (bool, error) {
+	var resourceMatches bool
+	if len(s.Resources) > 0 {
+		matches, err := anyPolicyResourceMatches(s.Resources, resource, roleAttributes)
+		if err != nil {
+			return false, err
+		}
+		resourceMatches = matches
+	} else {
+		matches, err := anyPolicyResourceMatches(s.NotResources, resource, roleAttributes)
+		if err != nil {
+			return false, err
+		}
+		resourceMatches = !matches
+	}
+	if !resourceMatches {
+		return false, nil
+	}
+	if len(s.Actions) > 0 {
+		return anyGlobMatches(s.Actions, action), nil
+	}
+	return !anyGlobMatches(s.NotActions, action), nil
+}
+
+func anyPolicyResourceMatches(specifiers []string, resource policyResource, roleAttributes map[string][]string) (bool, error) {
+	for _, specifier := range specifiers {
+		for _, expanded := range expandRoleAttributes(specifier, roleAttributes) {
+			pattern, err := parsePolicyResource(expanded)
+			if err != nil {
+				return false, err
+			}
+			if policyResourceMatches(pattern, resource) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// expandRoleAttributes returns specifier with each role-attribute
+// placeholder replaced by each value of its attribute. A placeholder whose
+// attribute has no values matches nothing, so its specifier expands to none.
+func expandRoleAttributes(specifier string, roleAttributes map[string][]string) []string {
+	loc := roleAttributePattern.FindStringIndex(specifier)
+	if loc == nil {
+		return []string{specifier}
+	}
+	placeholder := specifier[loc[0]:loc[1]]
+	key := strings.TrimSuffix(strings.TrimPrefix(placeholder, "${roleAttribute/"), "}")
+	var expanded []string
+	for _, value := range roleAttributes[key] {
+		expanded = append(expanded, expandRoleAttributes(specifier[:loc[0]]+value+specifier[loc[1]:], roleAttributes)...)
+	}
+	return expanded
+}
+
+// policyResourceMatches reports whether resource is one of the resources
+// pattern specifies: it has the same kinds, names matching pattern's
+// wildcards and, where pattern has tags, at least one of them.
+func policyResourceMatches(pattern, resource policyResource) bool {
+	if len(pattern) != len(resource) {
+		return false
+	}
+	for i, p := range pattern {
+		r := resource[i]
+		if p.kind != r.kind || !globMatches(p.name, r.name) {
+			return false
+		}
+		if len(p.tags) > 0 && !anyTagMatches(p.tags, r.tags) {
+			return false
+		}
+	}
+	return true
+}
+
+func anyTagMatches(patterns, tags []string) bool {
+	for _, tag := range tags {
+		if anyGlobMatches(patterns, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+func anyGlobMatches(patterns []string, s string) bool {
+	for _, p := range patterns {
+		if globMatches(p, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// globMatches reports whether s matches pattern, in which `*` matches any
+// sequence of characters and everything else matches itself.
+func globMatches(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+	return strings.HasSuffix(s, parts[len(parts)-1])
+}
@@ -0,0 +1,79 @@
+package launchdarkly
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckPolicy(t *testing.T) {
+	statements := []policyCheckStatement{
+		{index: 0, statement: frameworkPolicyStatementModel{
+			Effect:    "allow",
+			Resources: []string{"proj/${roleAttribute/projects}:env/*:flag/*"},
+			Actions:   []string{"*"},
+		}},
+		{customRoleKey: "no-production", index: 0, statement: frameworkPolicyStatementModel{
+			Effect:    "deny",
+			Resources: []string{"proj/*:env/production:flag/*;critical"},
+			Actions:   []string{"update*"},
+		}},
+		{customRoleKey: "no-production", index: 1, statement: frameworkPolicyStatementModel{
+			Effect:       "allow",
+			NotResources: []string{"proj/*:env/production"},
+			NotActions:   []string{"deleteEnvironment"},
+		}},
+	}
+	roleAttributes := map[string][]string{"projects": {"web", "mobile"}}
+
+	cases := []struct {
+		name      string
+		action    string
+		resource  string
+		allowed   bool
+		decidedBy *policyCheckStatement
+	}{
+		{"allowed by role attribute", "updateOn", "proj/web:env/production:flag/checkout", true, &statements[0]},
+		{"deny wins", "updateOn", "proj/web:env/production:flag/checkout;critical,mobile", false, &statements[1]},
+		{"deny only for matching actions", "createFlag", "proj/web:env/production:flag/checkout;critical", true, &statements[0]},
+		{"not_resources match only their own kind", "updateOn", "proj/api:env/production:flag/checkout", true, &statements[2]},
+		{"not_resources and not_actions", "updateName", "proj/api:env/staging", true, &statements[2]},
+		{"excluded by not_resources", "updateName", "proj/api:env/production", false, nil},
+		{"excluded by not_actions", "deleteEnvironment", "proj/api:env/staging", false, nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			resource, err := parsePolicyResource(c.resource)
+			require.NoError(t, err)
+			decision, err := checkPolicy(statements, c.action, resource, roleAttributes)
+			require.NoError(t, err)
+			assert.Equal(t, c.allowed, decision.allowed)
+			assert.Equal(t, c.decidedBy, decision.decidedBy)
+		})
+	}
+
+	resource, err := parsePolicyResource("proj/api:env/production:flag/checkout")
+	require.NoError(t, err)
+	decision, err := checkPolicy(statements[:1], "updateOn", resource, roleAttributes)
+	require.NoError(t, err)
+	assert.False(t, decision.allowed, "role attribute without the value")
+	assert.Nil(t, decision.decidedBy)
+
+	resource, err = parsePolicyResource("proj/web")
+	require.NoError(t, err)
+	_, err = checkPolicy([]policyCheckStatement{{statement: frameworkPolicyStatementModel{
+		Effect: "allow", Resources: []string{"proj/${roleAttribute/p}:flags/*"}, Actions: []string{"*"},
+	}}}, "viewProject", resource, map[string][]string{"p": {"web"}})
+	assert.ErrorContains(t, err, `statements[0]: invalid resource specifier "proj/web:flags/*"`)
+}
+
+func TestGlobMatches(t *testing.T) {
+	assert.True(t, globMatches("*", ""))
+	assert.True(t, globMatches("update*", "updateOn"))
+	assert.True(t, globMatches("*-prod-*", "web-prod-1"))
+	assert.True(t, globMatches("a*b*c", "abbc"))
+	assert.False(t, globMatches("a*b*c", "acb"))
+	assert.False(t, globMatches("update*", "createFlag"))
+	assert.False(t, globMatches("web", "web2"))
+}
@@ -8,7 +8,10 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -17,7 +20,9 @@ import (
 )
 
 var (
-	_ provider.Provider = &launchdarklyProvider{}
+	_ provider.Provider                       = &launchdarklyProvider{}
+	_ provider.ProviderWithEphemeralResources = &launchdarklyProvider{}
+	_ provider.ProviderWithFunctions          = &launchdarklyProvider{}
 )
 
 type launchdarklyProvider struct {
@@ -31,6 +36,17 @@ type launchdarklyProviderModel struct {
 	HttpTimeout           types.Int64  `tfsdk:"http_timeout"`
 	MaxConcurrency        types.Int64  `tfsdk:"max_concurrency"`
 	ArchiveFlagsOnDestroy types.Bool   `tfsdk:"archive_flags_on_destroy"`
+	ApprovalMode          types.String `tfsdk:"approval_mode"`
+	ApprovalNotifyMembers types.Set    `tfsdk:"approval_notify_member_ids"`
+	ApprovalNotifyTeams   types.Set    `tfsdk:"approval_notify_team_keys"`
+	ApprovalWaitTimeout   types.Int64  `tfsdk:"approval_wait_timeout"`
+	ChangeComment         types.String `tfsdk:"change_comment"`
+	DefaultTags           types.Object `tfsdk:"default_tags"`
+	ReadOnly              types.Bool   `tfsdk:"read_only"`
+	AllowedProjects       types.Set    `tfsdk:"allowed_projects"`
+	AllowedEnvironments   types.Set    `tfsdk:"allowed_environments"`
+	DeniedEnvironments    types.Set    `tfsdk:"denied_environments"`
+	AllowCriticalChanges  types.Bool   `tfsdk:"allow_critical_changes"`
 }
 
 // Metadata returns the provider type name.
@@ -70,6 +86,70 @@ func (p *launchdarklyProvider) Schema(_ context.Context, _ provider.SchemaReques
 				Optional:    true,
 				Description: "When `true`, removing a `launchdarkly_feature_flag` resource from your Terraform configuration archives the flag in LaunchDarkly instead of deleting it. The flag's key is retained on the server, so re-applying a configuration that recreates the same flag key will fail with an error directing you to `terraform import` the archived flag. Defaults to `false`, which preserves the existing destroy-deletes behavior. This setting affects only `launchdarkly_feature_flag`. Other resources continue to be deleted on destroy.",
 			},
+			APPROVAL_MODE: schema.StringAttribute{
+				Optional:    true,
+				Description: "How the provider handles changes that LaunchDarkly rejects because the environment requires approvals. `fail` (the default) returns the API error. `request` submits the planned change as an approval request to the reviewers in `approval_notify_member_ids` and `approval_notify_team_keys`, then fails the apply with the request's ID; re-run the apply once the request has been applied. `request_and_wait` submits the request and waits up to `approval_wait_timeout` seconds for it to be reviewed, applying it once approved. Applies to `launchdarkly_feature_flag_environment` and `launchdarkly_segment`, which can override it with their own `approval_mode` attribute.",
+				Validators: []validator.String{
+					oneOfValidator{allowed: approvalModes},
+				},
+			},
+			APPROVAL_NOTIFY_MEMBER_IDS: schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The IDs of the members to request a review from when `approval_mode` submits an approval request.",
+			},
+			APPROVAL_NOTIFY_TEAM_KEYS: schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The keys of the teams to request a review from when `approval_mode` submits an approval request.",
+			},
+			APPROVAL_WAIT_TIMEOUT: schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("The maximum time (in seconds) that `approval_mode = \"request_and_wait\"` waits for an approval request to be reviewed and applied. Defaults to %d seconds.", DEFAULT_APPROVAL_WAIT_TIMEOUT_S),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			CHANGE_COMMENT: schema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("The comment recorded in the LaunchDarkly audit log for every change the provider makes. References to environment variables in the form `$NAME` or `${NAME}` are replaced with their values, so the comment can identify the CI run, commit, or workspace that made the change. In HCL, write `${NAME}` as `$${NAME}` to prevent Terraform from interpolating it. Resources that support a `comment` attribute can override this value. Defaults to `%s`.", DEFAULT_CHANGE_COMMENT),
+			},
+			READ_ONLY: schema.BoolAttribute{
+				Optional:    true,
+				Description: "When `true`, the provider only reads from LaunchDarkly. Creating, updating, or destroying a resource fails with an error naming the resource and the operation, and any other request that would change LaunchDarkly is rejected before it is sent. Use it to run `terraform plan` safely with a token that also has write access, for example in pull request pipelines. Defaults to `false`.",
+			},
+			ALLOWED_PROJECTS: schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Glob patterns, such as `mobile-*`, for the keys of the projects the provider may change. A plan that would create, update, or destroy a resource in any other project fails. If not set, all projects are allowed.",
+			},
+			ALLOWED_ENVIRONMENTS: schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Glob patterns for the environments the provider may change. A pattern without a `/` matches environment keys, such as `staging*`; a pattern with one matches `<project_key>/<env_key>`, such as `mobile-*/staging`. A plan that would create, update, or destroy a resource scoped to any other environment, or change one through the `environments` attribute of `launchdarkly_project` or `launchdarkly_feature_flag`, fails. If not set, all environments are allowed.",
+			},
+			DENIED_ENVIRONMENTS: schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Glob patterns, in the same format as `allowed_environments`, for environments the provider must not change. Takes precedence over `allowed_environments`.",
+			},
+			ALLOW_CRITICAL_CHANGES: schema.BoolAttribute{
+				Optional:    true,
+				Description: "When `true`, the provider changes environments that are critical or require confirmation of changes without each resource setting `acknowledge_critical`. Defaults to `false`, which fails any plan that changes flag targeting, segments, or SDK keys in such an environment, or that deletes one, unless the resource acknowledges it.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			DEFAULT_TAGS: schema.SingleNestedBlock{
+				Description: "Tags to apply to every resource that has a `tags_all` attribute. Default tags are merged with each resource's own `tags`, and `tags_all` shows the merged set. A tag declared on a resource as well as in `default_tags` is managed by the resource.",
+				Attributes: map[string]schema.Attribute{
+					TAGS: schema.SetAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+						Description: "The tags to apply to every taggable resource.",
+						Validators:  []validator.Set{setvalidator.ValueStringsAre(tagValidator())},
+					},
+				},
+			},
 		},
 	}
 }
@@ -123,28 +203,38 @@ func (p *launchdarklyProvider) Configure(ctx context.Context, req provider.Confi
 		return
 	}
 
-	archiveOnDestroy := data.ArchiveFlagsOnDestroy.ValueBool()
-
-	if oauthToken != "" {
-		client, err := newClient(oauthToken, host, true, httpTimeoutSeconds, maxConcurrency)
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to create LaunchDarkly client", err.Error())
-			return
-		}
-		client.archiveFlagsOnDestroy = archiveOnDestroy
-		resp.ResourceData = client
-		resp.DataSourceData = client
+	approvals, diags := approvalConfigFromProviderModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	defaultTags, diags := defaultTagsFromProviderModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	guardrails, diags := guardrailConfigFromProviderModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	client, err := newClient(accessToken, host, false, httpTimeoutSeconds, maxConcurrency)
+	isOauth := oauthToken != ""
+	token := accessToken
+	if isOauth {
+		token = oauthToken
+	}
+	client, err := newClient(token, host, isOauth, httpTimeoutSeconds, maxConcurrency)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to create LaunchDarkly client", err.Error())
 		return
 	}
-	client.archiveFlagsOnDestroy = archiveOnDestroy
+	client.archiveFlagsOnDestroy = data.ArchiveFlagsOnDestroy.ValueBool()
+	client.approvals = approvals
+	client.changeComment = expandChangeComment(data.ChangeComment.ValueString())
+	client.defaultTags = defaultTags
+	client.guardrails = guardrails
+	client.allowCriticalChanges = data.AllowCriticalChanges.ValueBool()
+	if data.ReadOnly.ValueBool() {
+		client.enableReadOnly()
+	}
 	resp.ResourceData = client
 	resp.DataSourceData = client
+	resp.EphemeralResourceData = client
 }
 
 // DataSources defines the data sources implemented in the provider.
@@ -168,6 +258,8 @@ func (p *launchdarklyProvider) DataSources(_ context.Context) []func() datasourc
 		NewMetricGroupDataSource,
 		NewModelConfigDataSource,
 		NewOAuthClientDataSource,
+		NewPermissionCheckDataSource,
+		NewPolicyDocumentDataSource,
 		NewProjectDataSource,
 		NewRelayProxyConfigurationDataSource,
 		NewReleasePolicyDataSource,
@@ -216,10 +308,39 @@ func (p *launchdarklyProvider) Resources(_ context.Context) []func() resource.Re
 		NewModelConfigResource,
 		NewOAuthClientResource,
 		NewTeamRoleMappingResource,
+		NewTeamMembershipResource,
+		NewTeamMembersBulkResource,
 		NewProjectResource,
 		NewSegmentResource,
 		NewFeatureFlagResource,
 		NewFeatureFlagEnvironmentResource,
+		NewFeatureFlagTargetResource,
+		NewFeatureFlagRuleResource,
+		NewFlagScheduledChangeResource,
+	}
+}
+
+// EphemeralResources defines the ephemeral resources implemented in the
+// provider. Ephemeral resources return secrets at apply time without
+// persisting them to plan or state.
+func (p *launchdarklyProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewAccessTokenEphemeralResource,
+		NewEnvironmentCredentialsEphemeralResource,
+		NewSdkKeyEphemeralResource,
+	}
+}
+
+// Functions defines the provider-defined functions implemented in the
+// provider.
+func (p *launchdarklyProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewRolloutWeightsFunction,
+		NewFlagIDFunction,
+		NewParseFlagIDFunction,
+		NewSegmentIDFunction,
+		NewParseResourceIDFunction,
+		NewResourceSpecifierFunction,
 	}
 }
 
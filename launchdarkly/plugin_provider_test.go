@@ -3,6 +3,7 @@ package launchdarkly
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
@@ -16,25 +17,48 @@ func newPluginProviderConfigureRequest(t *testing.T, maxConcurrency int64) provi
 	pluginProvider := NewPluginProvider("test")()
 	schemaResponse := provider.SchemaResponse{}
 	pluginProvider.Schema(context.Background(), provider.SchemaRequest{}, &schemaResponse)
+	defaultTagsType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{TAGS: tftypes.Set{ElementType: tftypes.String}}}
 
 	return provider.ConfigureRequest{
 		Config: tfsdk.Config{
 			Raw: tftypes.NewValue(tftypes.Object{
 				AttributeTypes: map[string]tftypes.Type{
-					API_HOST:                 tftypes.String,
-					ACCESS_TOKEN:             tftypes.String,
-					OAUTH_TOKEN:              tftypes.String,
-					HTTP_TIMEOUT:             tftypes.Number,
-					MAX_CONCURRENCY:          tftypes.Number,
-					ARCHIVE_FLAGS_ON_DESTROY: tftypes.Bool,
+					API_HOST:                   tftypes.String,
+					ACCESS_TOKEN:               tftypes.String,
+					OAUTH_TOKEN:                tftypes.String,
+					HTTP_TIMEOUT:               tftypes.Number,
+					MAX_CONCURRENCY:            tftypes.Number,
+					ARCHIVE_FLAGS_ON_DESTROY:   tftypes.Bool,
+					APPROVAL_MODE:              tftypes.String,
+					APPROVAL_NOTIFY_MEMBER_IDS: tftypes.Set{ElementType: tftypes.String},
+					APPROVAL_NOTIFY_TEAM_KEYS:  tftypes.Set{ElementType: tftypes.String},
+					APPROVAL_WAIT_TIMEOUT:      tftypes.Number,
+					CHANGE_COMMENT:             tftypes.String,
+					DEFAULT_TAGS:               defaultTagsType,
+					READ_ONLY:                  tftypes.Bool,
+					ALLOWED_PROJECTS:           tftypes.Set{ElementType: tftypes.String},
+					ALLOWED_ENVIRONMENTS:       tftypes.Set{ElementType: tftypes.String},
+					DENIED_ENVIRONMENTS:        tftypes.Set{ElementType: tftypes.String},
+					ALLOW_CRITICAL_CHANGES:     tftypes.Bool,
 				},
 			}, map[string]tftypes.Value{
-				API_HOST:                 tftypes.NewValue(tftypes.String, "https://test.com"),
-				ACCESS_TOKEN:             tftypes.NewValue(tftypes.String, "test-token"),
-				HTTP_TIMEOUT:             tftypes.NewValue(tftypes.Number, 0),
-				OAUTH_TOKEN:              tftypes.NewValue(tftypes.String, ""),
-				MAX_CONCURRENCY:          tftypes.NewValue(tftypes.Number, maxConcurrency),
-				ARCHIVE_FLAGS_ON_DESTROY: tftypes.NewValue(tftypes.Bool, nil),
+				API_HOST:                   tftypes.NewValue(tftypes.String, "https://test.com"),
+				ACCESS_TOKEN:               tftypes.NewValue(tftypes.String, "test-token"),
+				HTTP_TIMEOUT:               tftypes.NewValue(tftypes.Number, 0),
+				OAUTH_TOKEN:                tftypes.NewValue(tftypes.String, ""),
+				MAX_CONCURRENCY:            tftypes.NewValue(tftypes.Number, maxConcurrency),
+				ARCHIVE_FLAGS_ON_DESTROY:   tftypes.NewValue(tftypes.Bool, nil),
+				APPROVAL_MODE:              tftypes.NewValue(tftypes.String, nil),
+				APPROVAL_NOTIFY_MEMBER_IDS: tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil),
+				APPROVAL_NOTIFY_TEAM_KEYS:  tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil),
+				APPROVAL_WAIT_TIMEOUT:      tftypes.NewValue(tftypes.Number, nil),
+				CHANGE_COMMENT:             tftypes.NewValue(tftypes.String, nil),
+				DEFAULT_TAGS:               tftypes.NewValue(defaultTagsType, nil),
+				READ_ONLY:                  tftypes.NewValue(tftypes.Bool, nil),
+				ALLOWED_PROJECTS:           tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil),
+				ALLOWED_ENVIRONMENTS:       tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil),
+				DENIED_ENVIRONMENTS:        tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil),
+				ALLOW_CRITICAL_CHANGES:     tftypes.NewValue(tftypes.Bool, nil),
 			}),
 			Schema: schemaResponse.Schema,
 		},
@@ -68,3 +92,14 @@ func TestPluginProviderMaxConcurrency(t *testing.T) {
 		require.True(t, configureResp.Diagnostics.HasError())
 	})
 }
+
+func TestPluginProviderApprovalModeDefaultsToFail(t *testing.T) {
+	pluginProvider := NewPluginProvider("test")()
+	configureResp := provider.ConfigureResponse{}
+	pluginProvider.Configure(context.Background(), newPluginProviderConfigureRequest(t, 0), &configureResp)
+	require.Len(t, configureResp.Diagnostics, 0)
+
+	client := configureResp.ResourceData.(*Client)
+	assert.Equal(t, APPROVAL_MODE_FAIL, client.approvals.mode)
+	assert.Equal(t, DEFAULT_APPROVAL_WAIT_TIMEOUT_S*time.Second, client.approvals.waitTimeout)
+}
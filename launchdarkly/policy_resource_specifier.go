@@ -0,0 +1,276 @@
+package launchdarkly
+
+// policy_resource_specifier.go parses the resource specifiers of policy
+// statements, such as `proj/*:env/production:flag/*;mobile`, so that typos
+// surface at plan time rather than as API errors. A specifier is a
+// colon-separated path of `kind/name` segments, each optionally followed by
+// `;tag1,tag2`. Names may use `*` wildcards and role-attribute placeholders
+// such as `${roleAttribute/projects}`.
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// policyResourceKinds maps each resource kind LaunchDarkly documents to the
+// kind it must be nested in, or "" for kinds that start a specifier. Kinds
+// not listed here are accepted anywhere, so new kinds do not need a provider
+// release, unless they look like a misspelling of a listed one.
+var policyResourceKinds = map[string]string{
+	"acct":                      "",
+	"application":               "",
+	"code-reference-repository": "",
+	"domain-verification":       "",
+	"integration":               "",
+	"member":                    "",
+	"pending-request":           "",
+	"proj":                      "",
+	"relay-proxy-config":        "",
+	"role":                      "",
+	"service-token":             "",
+	"team":                      "",
+	"template":                  "",
+	"webhook":                   "",
+	"token":                     "member",
+	"env":                       "proj",
+	"context-kind":              "proj",
+	"layer":                     "proj",
+	"metric":                    "proj",
+	"metric-group":              "proj",
+	"release-pipeline":          "proj",
+	"view":                      "proj",
+	"destination":               "env",
+	"experiment":                "env",
+	"flag":                      "env",
+	"holdout":                   "env",
+	"segment":                   "env",
+}
+
+// policyResourceKindAliases are kinds written as words rather than as
+// LaunchDarkly's abbreviations.
+var policyResourceKindAliases = map[string]string{
+	"account":      "acct",
+	"environment":  "env",
+	"environments": "env",
+	"project":      "proj",
+	"projects":     "proj",
+}
+
+// policyResourceNamesWithSlashes are the kinds whose names are paths, such
+// as `integration/datadog/*`.
+var policyResourceNamesWithSlashes = map[string]bool{
+	"integration":               true,
+	"code-reference-repository": true,
+}
+
+var (
+	policyResourceKindPattern   = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+	roleAttributePattern        = regexp.MustCompile(`\$\{roleAttribute/[A-Za-z0-9._-]+\}`)
+	roleAttributeMisusePattern  = regexp.MustCompile(`\$\{[^}]*\}?`)
+	policyResourceReservedChars = " \t\n,;${}"
+)
+
+// policyResourceSegment is one `kind/name;tags` segment of a resource
+// specifier.
+type policyResourceSegment struct {
+	kind string
+	name string
+	tags []string
+}
+
+// policyResource is a parsed resource specifier.
+type policyResource []policyResourceSegment
+
+func (r policyResource) String() string {
+	segments := make([]string, 0, len(r))
+	for _, s := range r {
+		segment := s.kind
+		if s.name != "" {
+			segment += "/" + s.name
+		}
+		if len(s.tags) > 0 {
+			segment += ";" + strings.Join(s.tags, ",")
+		}
+		segments = append(segments, segment)
+	}
+	return strings.Join(segments, ":")
+}
+
+// parsePolicyResource parses a resource specifier, checking that every
+// segment is well formed, that known kinds are nested where LaunchDarkly
+// expects them, and that role-attribute placeholders are complete.
+func parsePolicyResource(specifier string) (policyResource, error) {
+	if specifier == "" {
+		return nil, fmt.Errorf("resource specifier is empty")
+	}
+	var resource policyResource
+	for i, raw := range strings.Split(specifier, ":") {
+		segment, err := parsePolicyResourceSegment(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid resource specifier %q: %w", specifier, err)
+		}
+		parent, known := policyResourceKinds[segment.kind]
+		switch {
+		case !known:
+		case parent == "" && i > 0:
+			return nil, fmt.Errorf("invalid resource specifier %q: %q must come first, not after %q", specifier, segment.kind, resource[i-1].kind)
+		case parent != "" && i == 0:
+			return nil, fmt.Errorf("invalid resource specifier %q: %q must come after %q, such as %q", specifier, segment.kind, parent, parent+"/*:"+raw)
+		case parent != "" && resource[i-1].kind != parent:
+			return nil, fmt.Errorf("invalid resource specifier %q: %q must come after %q, not %q", specifier, segment.kind, parent, resource[i-1].kind)
+		}
+		resource = append(resource, segment)
+	}
+	return resource, nil
+}
+
+func parsePolicyResourceSegment(raw string) (policyResourceSegment, error) {
+	var segment policyResourceSegment
+	body, tags, hasTags := strings.Cut(raw, ";")
+	kind, name, hasName := strings.Cut(body, "/")
+	if !policyResourceKindPattern.MatchString(kind) {
+		return segment, fmt.Errorf("%q is not a resource kind, which must be lowercase letters, numbers and '-', such as \"proj\" or \"flag\"", kind)
+	}
+	if suggestion := policyResourceKindSuggestion(kind); suggestion != "" {
+		return segment, fmt.Errorf("unknown resource kind %q, did you mean %q?", kind, suggestion)
+	}
+	segment.kind = kind
+	if !hasName {
+		if kind != "acct" {
+			return segment, fmt.Errorf("%q is missing a name, such as %q", kind, kind+"/*")
+		}
+	} else {
+		if kind == "acct" {
+			return segment, fmt.Errorf("\"acct\" does not take a name")
+		}
+		if err := validatePolicyResourceName(kind, name); err != nil {
+			return segment, err
+		}
+		segment.name = name
+	}
+	if hasTags {
+		for _, tag := range strings.Split(tags, ",") {
+			if tag == "" {
+				return segment, fmt.Errorf("the tags of %q contain an empty tag", body)
+			}
+			if err := validatePolicyResourceName("", tag); err != nil {
+				return segment, fmt.Errorf("tag %q: %w", tag, err)
+			}
+			segment.tags = append(segment.tags, tag)
+		}
+	}
+	return segment, nil
+}
+
+// validatePolicyResourceName checks the name of a kind segment. Names may use
+// `*` wildcards and role-attribute placeholders, but not the characters that
+// separate segments and tags.
+func validatePolicyResourceName(kind, name string) error {
+	if name == "" {
+		return fmt.Errorf("%q has an empty name, use %q to match every %s", kind+"/", kind+"/*", kind)
+	}
+	literal := roleAttributePattern.ReplaceAllString(name, "*")
+	if strings.ContainsAny(literal, "${}") {
+		placeholder := roleAttributeMisusePattern.FindString(name)
+		if placeholder == "" {
+			placeholder = name
+		}
+		return fmt.Errorf("%q is not a valid role attribute placeholder, which must be written as ${roleAttribute/<attribute key>}", placeholder)
+	}
+	if i := strings.IndexAny(literal, policyResourceReservedChars); i >= 0 {
+		return fmt.Errorf("name %q must not contain %q", name, literal[i])
+	}
+	if strings.Contains(literal, "/") && !policyResourceNamesWithSlashes[kind] {
+		return fmt.Errorf("name %q must not contain '/', separate %q from the next kind with ':'", name, kind)
+	}
+	return nil
+}
+
+// policyResourceKindSuggestion returns the known kind that kind looks like a
+// misspelling of, if any.
+func policyResourceKindSuggestion(kind string) string {
+	if alias, ok := policyResourceKindAliases[kind]; ok {
+		return alias
+	}
+	if _, ok := policyResourceKinds[kind]; ok {
+		return ""
+	}
+	maxDistance := 2
+	if len(kind) <= 4 {
+		maxDistance = 1
+	}
+	suggestion, best := "", maxDistance+1
+	for known := range policyResourceKinds {
+		if d := editDistance(kind, known); d < best || (d == best && known < suggestion) {
+			suggestion, best = known, d
+		}
+	}
+	if best > maxDistance {
+		return ""
+	}
+	return suggestion
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// validatePolicyResources checks each of specifiers, returning the first
+// error.
+func validatePolicyResources(specifiers []string) error {
+	for _, s := range specifiers {
+		if _, err := parsePolicyResource(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// policyResourcesValidator validates the resource specifiers of a policy
+// statement's resources or not_resources.
+type policyResourcesValidator struct{}
+
+func (policyResourcesValidator) Description(context.Context) string {
+	return "each element must be a valid resource specifier"
+}
+
+func (v policyResourcesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (policyResourcesValidator) ValidateList(_ context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	for i, element := range req.ConfigValue.Elements() {
+		s, ok := element.(types.String)
+		if !ok || s.IsNull() || s.IsUnknown() {
+			continue
+		}
+		if _, err := parsePolicyResource(s.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(req.Path.AtListIndex(i), "Invalid resource specifier", err.Error())
+		}
+	}
+}
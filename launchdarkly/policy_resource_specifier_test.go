@@ -0,0 +1,95 @@
+package launchdarkly
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePolicyResource(t *testing.T) {
+	valid := []string{
+		"proj/*",
+		"proj/*:env/production",
+		"proj/*:env/*:flag/*;terraform-managed",
+		"proj/mobile-*;ios,android:env/*:segment/beta-users",
+		"proj/${roleAttribute/projects}:env/${roleAttribute/envs}:flag/*",
+		"proj/app-${roleAttribute/team}-*",
+		"proj/p:metric-group/g",
+		"proj/p:context-kind/user",
+		"member/*:token/*",
+		"integration/datadog/*",
+		"acct",
+		"role/*",
+		// Kinds the provider does not know about yet are accepted.
+		"proj/*:env/*:aiconfig-targeting/*",
+	}
+	for _, s := range valid {
+		r, err := parsePolicyResource(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, s, r.String())
+	}
+
+	r, err := parsePolicyResource("proj/p;a,b:env/e:flag/f")
+	require.NoError(t, err)
+	assert.Equal(t, policyResource{
+		{kind: "proj", name: "p", tags: []string{"a", "b"}},
+		{kind: "env", name: "e"},
+		{kind: "flag", name: "f"},
+	}, r)
+
+	invalid := map[string]string{
+		"":                               "resource specifier is empty",
+		"proj/*:env/:flag/*":             `has an empty name, use "env/*"`,
+		"proj/*:env/*:flags/*":           `unknown resource kind "flags", did you mean "flag"?`,
+		"project/*":                      `did you mean "proj"?`,
+		"proj/*:flag/*":                  `"flag" must come after "env", not "proj"`,
+		"env/production":                 `"env" must come after "proj", such as "proj/*:env/production"`,
+		"proj/*:role/*":                  `"role" must come first, not after "proj"`,
+		"proj":                           `"proj" is missing a name`,
+		"acct/foo":                       `"acct" does not take a name`,
+		"proj/*:env/production/flag/*":   "must not contain '/'",
+		"proj/${roleattribute/projects}": `"${roleattribute/projects}" is not a valid role attribute placeholder`,
+		"proj/${roleAttribute/projects":  "is not a valid role attribute placeholder",
+		"proj/$roleAttribute/projects":   "is not a valid role attribute placeholder",
+		"proj/my project":                `must not contain ' '`,
+		"proj/*;":                        "contain an empty tag",
+		"proj/*:env/*:flag/*;a,,b":       "contain an empty tag",
+		"Proj/*":                         "is not a resource kind",
+		"proj/*:env/production:flag/checkout:env/e": `"env" must come after "proj", not "flag"`,
+	}
+	for s, message := range invalid {
+		_, err := parsePolicyResource(s)
+		assert.ErrorContains(t, err, message, s)
+	}
+}
+
+func TestMergePolicyDocumentStatements(t *testing.T) {
+	source, err := policyDocumentStatementsFromJSON(`[
+		{"sid": "read", "effect": "allow", "resources": ["proj/*"], "actions": ["viewProject"]},
+		{"effect": "allow", "resources": ["proj/*:env/*:flag/*"], "actions": ["*"]}
+	]`, SOURCE_POLICY_DOCUMENTS)
+	require.NoError(t, err)
+	override, err := policyDocumentStatementsFromJSON(`[
+		{"sid": "read", "effect": "deny", "resources": ["proj/secret"], "actions": ["viewProject"]},
+		{"sid": "new", "effect": "deny", "resources": ["proj/*:env/production:flag/*"], "actions": ["updateOn"]}
+	]`, OVERRIDE_POLICY_DOCUMENTS)
+	require.NoError(t, err)
+
+	merged, err := mergePolicyDocumentStatements(nil, source)
+	require.NoError(t, err)
+	merged, err = mergePolicyDocumentStatements(merged, override)
+	require.NoError(t, err)
+	require.Len(t, merged, 3)
+	assert.Equal(t, "read", merged[0].sid)
+	assert.Equal(t, "deny", merged[0].statement.Effect)
+	assert.Equal(t, []string{"proj/secret"}, merged[0].statement.Resources)
+	assert.Equal(t, "", merged[1].sid)
+	assert.Equal(t, "new", merged[2].sid)
+
+	_, err = mergePolicyDocumentStatements(nil, append(override, override[0]))
+	assert.ErrorContains(t, err, `sid "read" is used by more than one statement`)
+
+	_, err = policyDocumentStatementsFromJSON(`[{"effect": "allow", "resources": ["proj/*:flag/*"], "actions": ["*"]}]`, SOURCE_POLICY_DOCUMENTS)
+	assert.ErrorContains(t, err, `source_policy_documents[0]: invalid resource specifier "proj/*:flag/*"`)
+}
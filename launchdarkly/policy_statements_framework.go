@@ -10,6 +10,7 @@ package launchdarkly
 import (
 	"context"
 	"errors"
+	"slices"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -71,6 +72,51 @@ func frameworkPolicyStatementsDataSourceAttribute(description string) dsschema.L
 	}
 }
 
+// frameworkPolicyStatementsDataSourceInputAttribute returns an Optional
+// ListNestedAttribute for data sources that take policy statements as
+// input rather than reading them, such as launchdarkly_policy_document.
+func frameworkPolicyStatementsDataSourceInputAttribute(description string) dsschema.ListNestedAttribute {
+	return dsschema.ListNestedAttribute{
+		Optional:    true,
+		Description: description,
+		NestedObject: dsschema.NestedAttributeObject{
+			Attributes: map[string]dsschema.Attribute{
+				RESOURCES: dsschema.ListAttribute{
+					Optional:    true,
+					ElementType: types.StringType,
+					Description: "The list of resource specifiers defining the resources to which the statement applies.",
+					Validators:  []validator.List{listvalidator.SizeAtLeast(1), policyResourcesValidator{}},
+				},
+				NOT_RESOURCES: dsschema.ListAttribute{
+					Optional:    true,
+					ElementType: types.StringType,
+					Description: "The list of resource specifiers defining the resources to which the statement does not apply.",
+					Validators:  []validator.List{listvalidator.SizeAtLeast(1), policyResourcesValidator{}},
+				},
+				ACTIONS: dsschema.ListAttribute{
+					Optional:    true,
+					ElementType: types.StringType,
+					Description: "The list of action specifiers defining the actions to which the statement applies.\nEither `actions` or `not_actions` must be specified. For a list of available actions read [Actions reference](https://launchdarkly.com/docs/home/account/roles/role-actions#actions-reference).",
+					Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
+				},
+				NOT_ACTIONS: dsschema.ListAttribute{
+					Optional:    true,
+					ElementType: types.StringType,
+					Description: "The list of action specifiers defining the actions to which the statement does not apply.",
+					Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
+				},
+				EFFECT: dsschema.StringAttribute{
+					Required:    true,
+					Description: "Either `allow` or `deny`. This argument defines whether the statement allows or denies access to the named resources and actions.",
+					Validators: []validator.String{
+						oneOfValidator{allowed: []string{"allow", "deny"}},
+					},
+				},
+			},
+		},
+	}
+}
+
 // frameworkPolicyStatementsResourceAttribute returns a ListNestedAttribute
 // for use in resource.Schema. The required flag controls whether the
 // attribute itself is required; inner attrs are Optional with a
@@ -87,6 +133,7 @@ func frameworkPolicyStatementsResourceAttribute(required bool, description strin
 					Description: "The list of resource specifiers defining the resources to which the statement applies.",
 					Validators: []validator.List{
 						listvalidator.SizeAtLeast(1),
+						policyResourcesValidator{},
 					},
 				},
 				NOT_RESOURCES: rsschema.ListAttribute{
@@ -95,6 +142,7 @@ func frameworkPolicyStatementsResourceAttribute(required bool, description strin
 					Description: "The list of resource specifiers defining the resources to which the statement does not apply.",
 					Validators: []validator.List{
 						listvalidator.SizeAtLeast(1),
+						policyResourcesValidator{},
 					},
 				},
 				ACTIONS: rsschema.ListAttribute{
@@ -181,6 +229,9 @@ func (m frameworkPolicyStatementModel) toLDAPI() (ldapi.StatementPost, diag.Diag
 	if len(m.Actions) == 0 && len(m.NotActions) == 0 {
 		diags.AddError("Invalid policy statement", errors.New("policy statements must contain either 'actions' or 'not_actions'").Error())
 	}
+	if err := validatePolicyResources(append(slices.Clone(m.Resources), m.NotResources...)); err != nil {
+		diags.AddError("Invalid policy statement", err.Error())
+	}
 	if diags.HasError() {
 		return ldapi.StatementPost{}, diags
 	}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	ldapi "github.com/launchdarkly/api-client-go/v24"
@@ -24,23 +25,36 @@ func statementPostsToStatementReps(policies []ldapi.StatementPost) []ldapi.State
 // block schema (snake_case keys: resources, not_resources, actions,
 // not_actions, effect). Returns (nil, nil) for empty input.
 func policyStatementsFromJSON(raw string) ([]ldapi.StatementPost, error) {
+	statements, _, err := policyStatementsFromJSONDocument(raw, POLICY_STATEMENTS_JSON)
+	return statements, err
+}
+
+// policyStatementsFromJSONDocument is policyStatementsFromJSON for the
+// document in attribute name. It also returns the optional "sid" of each
+// statement, which LaunchDarkly ignores but policy documents merge by.
+func policyStatementsFromJSONDocument(raw string, name string) ([]ldapi.StatementPost, []string, error) {
 	raw = strings.TrimSpace(raw)
 	if raw == "" {
-		return nil, nil
+		return nil, nil, nil
 	}
 	var decoded []map[string]interface{}
 	if err := json.Unmarshal([]byte(raw), &decoded); err != nil {
-		return nil, fmt.Errorf("%s: invalid JSON: %w", POLICY_STATEMENTS_JSON, err)
+		return nil, nil, fmt.Errorf("%s: invalid JSON: %w", name, err)
 	}
 	statements := make([]ldapi.StatementPost, 0, len(decoded))
+	sids := make([]string, 0, len(decoded))
 	for i, stmt := range decoded {
 		effectRaw, ok := stmt[EFFECT]
 		if !ok {
-			return nil, fmt.Errorf("%s[%d]: 'effect' is required", POLICY_STATEMENTS_JSON, i)
+			return nil, nil, fmt.Errorf("%s[%d]: 'effect' is required", name, i)
 		}
 		effect, ok := effectRaw.(string)
 		if !ok {
-			return nil, fmt.Errorf("%s[%d]: 'effect' must be a string", POLICY_STATEMENTS_JSON, i)
+			return nil, nil, fmt.Errorf("%s[%d]: 'effect' must be a string", name, i)
+		}
+		sid, ok := stmt[SID].(string)
+		if !ok && stmt[SID] != nil {
+			return nil, nil, fmt.Errorf("%s[%d]: 'sid' must be a string", name, i)
 		}
 		resources := jsonStringSliceField(stmt, RESOURCES)
 		notResources := jsonStringSliceField(stmt, NOT_RESOURCES)
@@ -48,11 +62,12 @@ func policyStatementsFromJSON(raw string) ([]ldapi.StatementPost, error) {
 		notActions := jsonStringSliceField(stmt, NOT_ACTIONS)
 		s, err := jsonPolicyStatementToLDAPI(effect, resources, notResources, actions, notActions)
 		if err != nil {
-			return nil, fmt.Errorf("%s[%d]: %w", POLICY_STATEMENTS_JSON, i, err)
+			return nil, nil, fmt.Errorf("%s[%d]: %w", name, i, err)
 		}
 		statements = append(statements, s)
+		sids = append(sids, sid)
 	}
-	return statements, nil
+	return statements, sids, nil
 }
 
 func jsonPolicyStatementToLDAPI(effect string, resources, notResources, actions, notActions []string) (ldapi.StatementPost, error) {
@@ -68,6 +83,9 @@ func jsonPolicyStatementToLDAPI(effect string, resources, notResources, actions,
 	if len(actions) == 0 && len(notActions) == 0 {
 		return ldapi.StatementPost{}, errors.New("policy statements must contain either 'actions' or 'not_actions'")
 	}
+	if err := validatePolicyResources(append(slices.Clone(resources), notResources...)); err != nil {
+		return ldapi.StatementPost{}, err
+	}
 	stmt := ldapi.StatementPost{Effect: effect}
 	if len(resources) > 0 {
 		stmt.SetResources(resources)
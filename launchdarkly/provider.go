@@ -16,10 +16,21 @@ const (
 
 // Provider keys
 const (
-	ACCESS_TOKEN             = "access_token"
-	OAUTH_TOKEN              = "oauth_token"
-	API_HOST                 = "api_host"
-	HTTP_TIMEOUT             = "http_timeout"
-	MAX_CONCURRENCY          = "max_concurrency"
-	ARCHIVE_FLAGS_ON_DESTROY = "archive_flags_on_destroy"
+	ACCESS_TOKEN               = "access_token"
+	OAUTH_TOKEN                = "oauth_token"
+	API_HOST                   = "api_host"
+	HTTP_TIMEOUT               = "http_timeout"
+	MAX_CONCURRENCY            = "max_concurrency"
+	ARCHIVE_FLAGS_ON_DESTROY   = "archive_flags_on_destroy"
+	APPROVAL_MODE              = "approval_mode"
+	APPROVAL_NOTIFY_MEMBER_IDS = "approval_notify_member_ids"
+	APPROVAL_NOTIFY_TEAM_KEYS  = "approval_notify_team_keys"
+	APPROVAL_WAIT_TIMEOUT      = "approval_wait_timeout"
+	CHANGE_COMMENT             = "change_comment"
+	DEFAULT_TAGS               = "default_tags"
+	READ_ONLY                  = "read_only"
+	ALLOWED_PROJECTS           = "allowed_projects"
+	ALLOWED_ENVIRONMENTS       = "allowed_environments"
+	DENIED_ENVIRONMENTS        = "denied_environments"
+	ALLOW_CRITICAL_CHANGES     = "allow_critical_changes"
 )
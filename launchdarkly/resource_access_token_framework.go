@@ -219,6 +219,9 @@ func (r *AccessTokenResource) ModifyPlan(ctx context.Context, req resource.Modif
 }
 
 func (r *AccessTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_access_token", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan AccessTokenResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -299,6 +302,9 @@ func (r *AccessTokenResource) Read(ctx context.Context, req resource.ReadRequest
 }
 
 func (r *AccessTokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_access_token", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan, state AccessTokenResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -319,6 +325,9 @@ func (r *AccessTokenResource) Update(ctx context.Context, req resource.UpdateReq
 
 	inline, diags := frameworkPolicyStatementsFromList(ctx, plan.InlineRoles)
 	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	patch := []ldapi.PatchOperation{patchReplace("/name", &name)}
 
@@ -372,6 +381,9 @@ func (r *AccessTokenResource) Update(ctx context.Context, req resource.UpdateReq
 }
 
 func (r *AccessTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_access_token", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data AccessTokenResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
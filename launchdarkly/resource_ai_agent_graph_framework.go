@@ -309,6 +309,7 @@ func requiresReplaceOnClearMap(_ context.Context, req planmodifier.MapRequest, r
 // it as null and Read then fills it in, tripping the plan-vs-apply
 // consistency check ("was null, but now ...").
 func (r *AIAgentGraphResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.client.planGuardrails(ctx, req, resp, guardrailScope{project: PROJECT_KEY})
 	if req.Plan.Raw.IsNull() {
 		return
 	}
@@ -345,6 +346,9 @@ func (r *AIAgentGraphResource) betaClient() (*Client, error) {
 }
 
 func (r *AIAgentGraphResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_ai_agent_graph", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan AIAgentGraphResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -432,6 +436,9 @@ func (r *AIAgentGraphResource) Read(ctx context.Context, req resource.ReadReques
 }
 
 func (r *AIAgentGraphResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_ai_agent_graph", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan AIAgentGraphResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	var state AIAgentGraphResourceModel
@@ -512,6 +519,9 @@ func (r *AIAgentGraphResource) Update(ctx context.Context, req resource.UpdateRe
 }
 
 func (r *AIAgentGraphResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_ai_agent_graph", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data AIAgentGraphResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -23,6 +23,7 @@ import (
 
 var (
 	_ resource.Resource                     = &AIConfigResource{}
+	_ resource.ResourceWithModifyPlan       = &AIConfigResource{}
 	_ resource.ResourceWithImportState      = &AIConfigResource{}
 	_ resource.ResourceWithConfigValidators = &AIConfigResource{}
 	_ resource.ResourceWithUpgradeState     = &AIConfigResource{}
@@ -40,6 +41,7 @@ type AIConfigResourceModel struct {
 	Description         types.String `tfsdk:"description"`
 	Mode                types.String `tfsdk:"mode"`
 	Tags                types.Set    `tfsdk:"tags"`
+	TagsAll             types.Set    `tfsdk:"tags_all"`
 	MaintainerID        types.String `tfsdk:"maintainer_id"`
 	MaintainerTeamKey   types.String `tfsdk:"maintainer_team_key"`
 	EvaluationMetricKey types.String `tfsdk:"evaluation_metric_key"`
@@ -105,6 +107,7 @@ func aiConfigSchemaAttributes() map[string]schema.Attribute {
 			ElementType: types.StringType,
 			Description: "Tags associated with this AgentControl config.",
 		},
+		TAGS_ALL: tagsAllAttribute(),
 		MAINTAINER_ID: schema.StringAttribute{
 			Optional:    true,
 			Computed:    true,
@@ -210,7 +213,15 @@ func (r *AIConfigResource) Configure(_ context.Context, req resource.ConfigureRe
 	r.client = configureResourceClient(req, resp)
 }
 
+func (r *AIConfigResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.client.planGuardrails(ctx, req, resp, guardrailScope{project: PROJECT_KEY})
+	r.client.planTagsAll(ctx, req, resp)
+}
+
 func (r *AIConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_ai_config", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan AIConfigResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -252,7 +263,7 @@ func (r *AIConfigResource) Create(ctx context.Context, req resource.CreateReques
 			post.IsInverted = &isInverted
 		}
 	}
-	tags, d := stringSliceFromSet(ctx, plan.Tags)
+	tags, d := stringSliceFromSet(ctx, plan.TagsAll)
 	resp.Diagnostics.Append(d...)
 	if len(tags) > 0 {
 		post.Tags = tags
@@ -297,6 +308,9 @@ func (r *AIConfigResource) Read(ctx context.Context, req resource.ReadRequest, r
 }
 
 func (r *AIConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_ai_config", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan, state AIConfigResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -330,8 +344,8 @@ func (r *AIConfigResource) Update(ctx context.Context, req resource.UpdateReques
 		patch.MaintainerTeamKey = &v
 		hasChanges = true
 	}
-	if !plan.Tags.Equal(state.Tags) {
-		tags, d := stringSliceFromSet(ctx, plan.Tags)
+	if !plan.TagsAll.Equal(state.TagsAll) {
+		tags, d := stringSliceFromSet(ctx, plan.TagsAll)
 		resp.Diagnostics.Append(d...)
 		patch.Tags = tags
 		hasChanges = true
@@ -373,6 +387,9 @@ func (r *AIConfigResource) Update(ctx context.Context, req resource.UpdateReques
 // LD API needs all variations dereferenced before the config itself
 // can go.
 func (r *AIConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_ai_config", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data AIConfigResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -474,9 +491,12 @@ func (r *AIConfigResource) readIntoModel(
 		data.MaintainerTeamKey = types.StringValue(maintainer.AiConfigsMaintainerTeam.GetKey())
 	}
 
-	tagsSet, d := setFromStringSlice(ctx, cfg.Tags)
+	ownTags, tagsAll, d := r.client.splitDefaultTags(ctx, cfg.Tags, data.Tags)
+	diags.Append(d...)
+	tagsSet, d := setFromStringSlice(ctx, ownTags)
 	diags.Append(d...)
 	data.Tags = tagsSet
+	data.TagsAll = tagsAll
 
 	// Variations summary
 	objectType := types.ObjectType{AttrTypes: aiConfigVariationSummaryAttrTypes}
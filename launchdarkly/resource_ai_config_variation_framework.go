@@ -29,6 +29,7 @@ import (
 
 var (
 	_ resource.Resource                     = &AIConfigVariationResource{}
+	_ resource.ResourceWithModifyPlan       = &AIConfigVariationResource{}
 	_ resource.ResourceWithImportState      = &AIConfigVariationResource{}
 	_ resource.ResourceWithConfigValidators = &AIConfigVariationResource{}
 	_ resource.ResourceWithUpgradeState     = &AIConfigVariationResource{}
@@ -266,7 +267,14 @@ func (r *AIConfigVariationResource) Configure(_ context.Context, req resource.Co
 	r.client = configureResourceClient(req, resp)
 }
 
+func (r *AIConfigVariationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.client.planGuardrails(ctx, req, resp, guardrailScope{project: PROJECT_KEY})
+}
+
 func (r *AIConfigVariationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_ai_config_variation", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan AIConfigVariationResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -369,6 +377,9 @@ func (r *AIConfigVariationResource) Read(ctx context.Context, req resource.ReadR
 }
 
 func (r *AIConfigVariationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_ai_config_variation", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan, state AIConfigVariationResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -466,6 +477,9 @@ func (r *AIConfigVariationResource) Update(ctx context.Context, req resource.Upd
 }
 
 func (r *AIConfigVariationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_ai_config_variation", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data AIConfigVariationResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -18,6 +18,7 @@ import (
 
 var (
 	_ resource.Resource                 = &AIToolResource{}
+	_ resource.ResourceWithModifyPlan   = &AIToolResource{}
 	_ resource.ResourceWithImportState  = &AIToolResource{}
 	_ resource.ResourceWithUpgradeState = &AIToolResource{}
 )
@@ -181,7 +182,14 @@ func (r *AIToolResource) Configure(_ context.Context, req resource.ConfigureRequ
 	r.client = configureResourceClient(req, resp)
 }
 
+func (r *AIToolResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.client.planGuardrails(ctx, req, resp, guardrailScope{project: PROJECT_KEY})
+}
+
 func (r *AIToolResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_ai_tool", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan AIToolResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -254,6 +262,9 @@ func (r *AIToolResource) Read(ctx context.Context, req resource.ReadRequest, res
 }
 
 func (r *AIToolResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_ai_tool", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan AIToolResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	var state AIToolResourceModel
@@ -314,6 +325,9 @@ func (r *AIToolResource) Update(ctx context.Context, req resource.UpdateRequest,
 }
 
 func (r *AIToolResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_ai_tool", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data AIToolResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -103,6 +103,9 @@ func (r *AnnouncementResource) Configure(_ context.Context, req resource.Configu
 }
 
 func (r *AnnouncementResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_announcement", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan AnnouncementResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -154,6 +157,9 @@ func (r *AnnouncementResource) Read(ctx context.Context, req resource.ReadReques
 }
 
 func (r *AnnouncementResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_announcement", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan, state AnnouncementResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -198,6 +204,9 @@ func (r *AnnouncementResource) Update(ctx context.Context, req resource.UpdateRe
 }
 
 func (r *AnnouncementResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_announcement", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data AnnouncementResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
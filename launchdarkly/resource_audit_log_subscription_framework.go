@@ -7,6 +7,8 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -20,8 +22,10 @@ import (
 )
 
 var (
-	_ resource.Resource                = &AuditLogSubscriptionResource{}
-	_ resource.ResourceWithImportState = &AuditLogSubscriptionResource{}
+	_ resource.Resource                     = &AuditLogSubscriptionResource{}
+	_ resource.ResourceWithModifyPlan       = &AuditLogSubscriptionResource{}
+	_ resource.ResourceWithImportState      = &AuditLogSubscriptionResource{}
+	_ resource.ResourceWithConfigValidators = &AuditLogSubscriptionResource{}
 )
 
 type AuditLogSubscriptionResource struct {
@@ -29,13 +33,16 @@ type AuditLogSubscriptionResource struct {
 }
 
 type AuditLogSubscriptionResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	IntegrationKey types.String `tfsdk:"integration_key"`
-	Name           types.String `tfsdk:"name"`
-	Config         types.Map    `tfsdk:"config"`
-	Statements     types.List   `tfsdk:"statements"`
-	On             types.Bool   `tfsdk:"on"`
-	Tags           types.Set    `tfsdk:"tags"`
+	ID              types.String `tfsdk:"id"`
+	IntegrationKey  types.String `tfsdk:"integration_key"`
+	Name            types.String `tfsdk:"name"`
+	Config          types.Map    `tfsdk:"config"`
+	ConfigWO        types.Map    `tfsdk:"config_wo"`
+	ConfigWOVersion types.Int64  `tfsdk:"config_wo_version"`
+	Statements      types.List   `tfsdk:"statements"`
+	On              types.Bool   `tfsdk:"on"`
+	Tags            types.Set    `tfsdk:"tags"`
+	TagsAll         types.Set    `tfsdk:"tags_all"`
 }
 
 func NewAuditLogSubscriptionResource() resource.Resource {
@@ -65,10 +72,21 @@ func (r *AuditLogSubscriptionResource) Schema(_ context.Context, _ resource.Sche
 				Description: "A human-friendly name for your audit log subscription viewable from within the LaunchDarkly Integrations page.",
 			},
 			CONFIG: schema.MapAttribute{
-				Required:    true,
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The set of configuration fields corresponding to the value defined for `integration_key`. Refer to the `formVariables` field in the corresponding `integrations/<integration_key>/manifest.json` file in [this repo](https://github.com/launchdarkly/integration-framework/tree/master/integrations) for a full list of fields for the integration you wish to configure. **IMPORTANT**: Terraform accepts these only in snake case, regardless of the case shown in the manifest. This value is stored in the Terraform state; use `config_wo` to keep credentials out of state. Exactly one of `config` or `config_wo` must be set.",
+			},
+			CONFIG_WO: schema.MapAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
 				ElementType: types.StringType,
-				Description: "The set of configuration fields corresponding to the value defined for `integration_key`. Refer to the `formVariables` field in the corresponding `integrations/<integration_key>/manifest.json` file in [this repo](https://github.com/launchdarkly/integration-framework/tree/master/integrations) for a full list of fields for the integration you wish to configure. **IMPORTANT**: Terraform accepts these only in snake case, regardless of the case shown in the manifest.",
+				Description: writeOnlyDescription("The set of configuration fields corresponding to the value defined for `integration_key`, in the same format as `config`.", CONFIG_WO_VERSION),
+				Validators: []validator.Map{
+					mapvalidator.AlsoRequires(path.MatchRoot(CONFIG_WO_VERSION)),
+				},
 			},
+			CONFIG_WO_VERSION: writeOnlyVersionAttribute(CONFIG_WO),
 			ON: schema.BoolAttribute{
 				Required:    true,
 				Description: "Whether your subscription is enabled and actively sending events.",
@@ -79,16 +97,33 @@ func (r *AuditLogSubscriptionResource) Schema(_ context.Context, _ resource.Sche
 				ElementType: types.StringType,
 				Description: "Tags associated with your resource.",
 			},
+			TAGS_ALL:   tagsAllAttribute(),
 			STATEMENTS: frameworkPolicyStatementsResourceAttribute(true, "The resources to which you wish to subscribe.", ""),
 		},
 	}
 }
 
+func (r *AuditLogSubscriptionResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot(CONFIG),
+			path.MatchRoot(CONFIG_WO),
+		),
+	}
+}
+
 func (r *AuditLogSubscriptionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	r.client = configureResourceClient(req, resp)
 }
 
+func (r *AuditLogSubscriptionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.client.planTagsAll(ctx, req, resp)
+}
+
 func (r *AuditLogSubscriptionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_audit_log_subscription", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan AuditLogSubscriptionResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -99,10 +134,14 @@ func (r *AuditLogSubscriptionResource) Create(ctx context.Context, req resource.
 	name := plan.Name.ValueString()
 	on := plan.On.ValueBool()
 
-	tags, d := stringSliceFromSet(ctx, plan.Tags)
+	tags, d := stringSliceFromSet(ctx, plan.TagsAll)
 	resp.Diagnostics.Append(d...)
 
-	rawConfig, d := mapStringFromAttr(ctx, plan.Config)
+	configAttr := plan.Config
+	if writeOnlyInUse(plan.ConfigWOVersion) {
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(CONFIG_WO), &configAttr)...)
+	}
+	rawConfig, d := mapStringFromAttr(ctx, configAttr)
 	resp.Diagnostics.Append(d...)
 
 	statements, d := frameworkPolicyStatementsFromList(ctx, plan.Statements)
@@ -168,8 +207,12 @@ func (r *AuditLogSubscriptionResource) Read(ctx context.Context, req resource.Re
 }
 
 func (r *AuditLogSubscriptionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan AuditLogSubscriptionResourceModel
+	if r.client.rejectReadOnly("launchdarkly_audit_log_subscription", "update", &resp.Diagnostics) {
+		return
+	}
+	var plan, state AuditLogSubscriptionResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -179,10 +222,18 @@ func (r *AuditLogSubscriptionResource) Update(ctx context.Context, req resource.
 	on := plan.On.ValueBool()
 	id := plan.ID.ValueString()
 
-	tags, d := stringSliceFromSet(ctx, plan.Tags)
+	tags, d := stringSliceFromSet(ctx, plan.TagsAll)
 	resp.Diagnostics.Append(d...)
 
-	rawConfig, d := mapStringFromAttr(ctx, plan.Config)
+	// A config_wo value is only re-sent when its version changes; otherwise
+	// the stored subscription config is left untouched.
+	sendConfig := !writeOnlyInUse(plan.ConfigWOVersion)
+	configAttr := plan.Config
+	if writeOnlyVersionChanged(plan.ConfigWOVersion, state.ConfigWOVersion) {
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(CONFIG_WO), &configAttr)...)
+		sendConfig = true
+	}
+	rawConfig, d := mapStringFromAttr(ctx, configAttr)
 	resp.Diagnostics.Append(d...)
 
 	statements, d := frameworkPolicyStatementsFromList(ctx, plan.Statements)
@@ -191,21 +242,22 @@ func (r *AuditLogSubscriptionResource) Update(ctx context.Context, req resource.
 		return
 	}
 
-	apiConfig, err := convertSubscriptionConfigToAPI(integrationKey, rawConfig)
-	if err != nil {
-		resp.Diagnostics.AddError(fmt.Sprintf("failed to update %s integration %q", integrationKey, id), err.Error())
-		return
-	}
-
 	patch := []ldapi.PatchOperation{
 		patchReplace("/name", &name),
 		patchReplace("/tags", &tags),
-		patchReplace("/config", &apiConfig),
 		patchReplace("/on", &on),
 		patchReplace("/statements", &statements),
 	}
+	if sendConfig {
+		apiConfig, err := convertSubscriptionConfigToAPI(integrationKey, rawConfig)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("failed to update %s integration %q", integrationKey, id), err.Error())
+			return
+		}
+		patch = append(patch, patchReplace("/config", &apiConfig))
+	}
 
-	err = r.client.withConcurrency(r.client.ctx, func() error {
+	err := r.client.withConcurrency(r.client.ctx, func() error {
 		_, _, e := r.client.ld.IntegrationAuditLogSubscriptionsApi.UpdateSubscription(r.client.ctx, integrationKey, id).PatchOperation(patch).Execute()
 		return e
 	})
@@ -222,6 +274,9 @@ func (r *AuditLogSubscriptionResource) Update(ctx context.Context, req resource.
 }
 
 func (r *AuditLogSubscriptionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_audit_log_subscription", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data AuditLogSubscriptionResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -282,26 +337,36 @@ func (r *AuditLogSubscriptionResource) readIntoModel(ctx context.Context, data *
 		data.On = types.BoolValue(false)
 	}
 
-	// Reconstruct config map: API returns camelCase / kebab-case keys;
-	// terraform schema uses snake_case. Preserve secrets from prior state
-	// so a re-plan doesn't surface drift on obfuscated server responses.
-	priorConfig, _ := mapStringFromAttr(ctx, data.Config)
-	updated, cerr := convertSubscriptionConfigFromAPI(integrationKey, sub.Config, priorConfig)
-	if cerr != nil {
-		diags.AddError(fmt.Sprintf("failed to convert config for integration %q", id), cerr.Error())
-		return
+	// When the config is managed through config_wo it must never land in
+	// state, so `config` stays null.
+	data.ConfigWO = types.MapNull(types.StringType)
+	if writeOnlyInUse(data.ConfigWOVersion) {
+		data.Config = types.MapNull(types.StringType)
+	} else {
+		// Reconstruct config map: API returns camelCase / kebab-case keys;
+		// terraform schema uses snake_case. Preserve secrets from prior state
+		// so a re-plan doesn't surface drift on obfuscated server responses.
+		priorConfig, _ := mapStringFromAttr(ctx, data.Config)
+		updated, cerr := convertSubscriptionConfigFromAPI(integrationKey, sub.Config, priorConfig)
+		if cerr != nil {
+			diags.AddError(fmt.Sprintf("failed to convert config for integration %q", id), cerr.Error())
+			return
+		}
+		configVal, d := types.MapValueFrom(ctx, types.StringType, updated)
+		diags.Append(d...)
+		data.Config = configVal
 	}
-	configVal, d := types.MapValueFrom(ctx, types.StringType, updated)
-	diags.Append(d...)
-	data.Config = configVal
 
 	stmtList, d := frameworkPolicyStatementsValue(ctx, sub.Statements)
 	diags.Append(d...)
 	data.Statements = stmtList
 
-	tagsSet, d := setFromStringSlice(ctx, sub.Tags)
+	ownTags, tagsAll, d := r.client.splitDefaultTags(ctx, sub.Tags, data.Tags)
+	diags.Append(d...)
+	tagsSet, d := setFromStringSlice(ctx, ownTags)
 	diags.Append(d...)
 	data.Tags = tagsSet
+	data.TagsAll = tagsAll
 }
 
 // convertSubscriptionConfigToAPI translates user-facing snake_case keys
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -19,8 +21,10 @@ import (
 )
 
 var (
-	_ resource.Resource                = &BigSegmentStoreIntegrationResource{}
-	_ resource.ResourceWithImportState = &BigSegmentStoreIntegrationResource{}
+	_ resource.Resource                     = &BigSegmentStoreIntegrationResource{}
+	_ resource.ResourceWithModifyPlan       = &BigSegmentStoreIntegrationResource{}
+	_ resource.ResourceWithImportState      = &BigSegmentStoreIntegrationResource{}
+	_ resource.ResourceWithConfigValidators = &BigSegmentStoreIntegrationResource{}
 )
 
 type BigSegmentStoreIntegrationResource struct {
@@ -29,16 +33,19 @@ type BigSegmentStoreIntegrationResource struct {
 }
 
 type BigSegmentStoreIntegrationResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	ProjectKey     types.String `tfsdk:"project_key"`
-	EnvironmentKey types.String `tfsdk:"environment_key"`
-	IntegrationKey types.String `tfsdk:"integration_key"`
-	IntegrationID  types.String `tfsdk:"integration_id"`
-	Name           types.String `tfsdk:"name"`
-	On             types.Bool   `tfsdk:"on"`
-	Config         types.String `tfsdk:"config"`
-	Tags           types.Set    `tfsdk:"tags"`
-	Version        types.Int64  `tfsdk:"version"`
+	ID              types.String `tfsdk:"id"`
+	ProjectKey      types.String `tfsdk:"project_key"`
+	EnvironmentKey  types.String `tfsdk:"environment_key"`
+	IntegrationKey  types.String `tfsdk:"integration_key"`
+	IntegrationID   types.String `tfsdk:"integration_id"`
+	Name            types.String `tfsdk:"name"`
+	On              types.Bool   `tfsdk:"on"`
+	Config          types.String `tfsdk:"config"`
+	ConfigWO        types.String `tfsdk:"config_wo"`
+	ConfigWOVersion types.Int64  `tfsdk:"config_wo_version"`
+	Tags            types.Set    `tfsdk:"tags"`
+	TagsAll         types.Set    `tfsdk:"tags_all"`
+	Version         types.Int64  `tfsdk:"version"`
 }
 
 func NewBigSegmentStoreIntegrationResource() resource.Resource {
@@ -101,14 +108,25 @@ func bigSegmentStoreIntegrationSchemaAttributes() map[string]schema.Attribute {
 			Description: "Whether the integration is turned on. Defaults to `false`.",
 		},
 		CONFIG: schema.StringAttribute{
-			Required:    true,
+			Optional:    true,
 			Sensitive:   true,
-			Description: "A JSON string holding the store-specific configuration. All values are strings except `tlsEnabled`, which is a boolean. For `redis`, the fields are `host`, `port`, `tlsEnabled`, `username`, and `password`. For `dynamodb`, they are `tableName`, `region`, `roleArn`, and `externalId`, a UUID. Marked sensitive because it carries credentials. The API redacts secrets and normalizes this value on read, so it is treated as write-only: Terraform stores and diffs the value you provide and does not reconcile it against the server, so configuration changes made outside Terraform are not detected as drift.",
+			Description: "A JSON string holding the store-specific configuration. All values are strings except `tlsEnabled`, which is a boolean. For `redis`, the fields are `host`, `port`, `tlsEnabled`, `username`, and `password`. For `dynamodb`, they are `tableName`, `region`, `roleArn`, and `externalId`, a UUID. Marked sensitive because it carries credentials. The API redacts secrets and normalizes this value on read, so Terraform stores and diffs the value you provide and does not reconcile it against the server, so configuration changes made outside Terraform are not detected as drift. Use `config_wo` to keep the value out of state entirely. Exactly one of `config` or `config_wo` must be set.",
 			Validators:  []validator.String{jsonStringValidator{}},
 			PlanModifiers: []planmodifier.String{
 				jsonNormalizePlanModifier{},
 			},
 		},
+		CONFIG_WO: schema.StringAttribute{
+			Optional:    true,
+			Sensitive:   true,
+			WriteOnly:   true,
+			Description: writeOnlyDescription("A JSON string holding the store-specific configuration, in the same format as `config`.", CONFIG_WO_VERSION),
+			Validators: []validator.String{
+				jsonStringValidator{},
+				stringvalidator.AlsoRequires(path.MatchRoot(CONFIG_WO_VERSION)),
+			},
+		},
+		CONFIG_WO_VERSION: writeOnlyVersionAttribute(CONFIG_WO),
 		TAGS: schema.SetAttribute{
 			Optional:    true,
 			ElementType: types.StringType,
@@ -117,6 +135,7 @@ func bigSegmentStoreIntegrationSchemaAttributes() map[string]schema.Attribute {
 				setvalidator.ValueStringsAre(tagValidator()),
 			},
 		},
+		TAGS_ALL: tagsAllAttribute(),
 		VERSION: schema.Int64Attribute{
 			Computed:    true,
 			Description: "The version of the integration configuration.",
@@ -124,6 +143,15 @@ func bigSegmentStoreIntegrationSchemaAttributes() map[string]schema.Attribute {
 	}
 }
 
+func (r *BigSegmentStoreIntegrationResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot(CONFIG),
+			path.MatchRoot(CONFIG_WO),
+		),
+	}
+}
+
 func (r *BigSegmentStoreIntegrationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	r.client = configureResourceClient(req, resp)
 	if r.client == nil {
@@ -137,6 +165,11 @@ func (r *BigSegmentStoreIntegrationResource) Configure(_ context.Context, req re
 	r.beta = beta
 }
 
+func (r *BigSegmentStoreIntegrationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.client.planGuardrails(ctx, req, resp, guardrailScope{project: PROJECT_KEY, env: ENVIRONMENT_KEY})
+	r.client.planTagsAll(ctx, req, resp)
+}
+
 func (r *BigSegmentStoreIntegrationResource) betaClient() (*Client, error) {
 	if r.beta != nil {
 		return r.beta, nil
@@ -145,6 +178,9 @@ func (r *BigSegmentStoreIntegrationResource) betaClient() (*Client, error) {
 }
 
 func (r *BigSegmentStoreIntegrationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_big_segment_store_integration", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan BigSegmentStoreIntegrationResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -170,13 +206,20 @@ func (r *BigSegmentStoreIntegrationResource) Create(ctx context.Context, req res
 	environmentKey := plan.EnvironmentKey.ValueString()
 	integrationKey := plan.IntegrationKey.ValueString()
 
-	config, err := jsonStringToMap(plan.Config.ValueString())
+	configJSON := plan.Config
+	if writeOnlyInUse(plan.ConfigWOVersion) {
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(CONFIG_WO), &configJSON)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	config, err := jsonStringToMap(configJSON.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid config", err.Error())
 		return
 	}
 
-	tags, d := stringSliceFromSet(ctx, plan.Tags)
+	tags, d := stringSliceFromSet(ctx, plan.TagsAll)
 	resp.Diagnostics.Append(d...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -238,6 +281,9 @@ func (r *BigSegmentStoreIntegrationResource) Read(ctx context.Context, req resou
 }
 
 func (r *BigSegmentStoreIntegrationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_big_segment_store_integration", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan, state BigSegmentStoreIntegrationResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -263,16 +309,25 @@ func (r *BigSegmentStoreIntegrationResource) Update(ctx context.Context, req res
 	if !plan.On.Equal(state.On) {
 		patch = append(patch, patchReplace("/on", plan.On.ValueBool()))
 	}
-	if !plan.Config.Equal(state.Config) {
-		config, err := jsonStringToMap(plan.Config.ValueString())
+	configJSON := plan.Config
+	sendConfig := !writeOnlyInUse(plan.ConfigWOVersion) && !plan.Config.Equal(state.Config)
+	if writeOnlyVersionChanged(plan.ConfigWOVersion, state.ConfigWOVersion) {
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(CONFIG_WO), &configJSON)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		sendConfig = true
+	}
+	if sendConfig {
+		config, err := jsonStringToMap(configJSON.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError("Invalid config", err.Error())
 			return
 		}
 		patch = append(patch, patchReplace("/config", config))
 	}
-	if !plan.Tags.Equal(state.Tags) {
-		tags, d := stringSliceFromSet(ctx, plan.Tags)
+	if !plan.TagsAll.Equal(state.TagsAll) {
+		tags, d := stringSliceFromSet(ctx, plan.TagsAll)
 		resp.Diagnostics.Append(d...)
 		if tags == nil {
 			tags = []string{}
@@ -303,6 +358,9 @@ func (r *BigSegmentStoreIntegrationResource) Update(ctx context.Context, req res
 }
 
 func (r *BigSegmentStoreIntegrationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_big_segment_store_integration", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data BigSegmentStoreIntegrationResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -395,11 +453,16 @@ func (r *BigSegmentStoreIntegrationResource) readIntoModel(
 	// state on read) instead — mirroring how the destination resource keeps
 	// obfuscated secrets via preserveObfuscatedDestinationAttributes. The
 	// trade-off is no server-side drift detection for `config`, which is
-	// acceptable for a write-mostly, secret-bearing attribute.
+	// acceptable for a write-mostly, secret-bearing attribute. config_wo is
+	// never stored, so it is always null here.
+	data.ConfigWO = types.StringNull()
 
 	// Optional-only Set attr: preserve the config's null-vs-empty intent so an
 	// omitted `tags` reads back as null, not an empty set.
-	tagsSet, d := setFromStringSlicePreservingPlan(ctx, integration.Tags, data.Tags)
+	ownTags, tagsAll, d := r.client.splitDefaultTags(ctx, integration.Tags, data.Tags)
+	diags.Append(d...)
+	tagsSet, d := setFromStringSlicePreservingPlan(ctx, ownTags, data.Tags)
 	diags.Append(d...)
 	data.Tags = tagsSet
+	data.TagsAll = tagsAll
 }
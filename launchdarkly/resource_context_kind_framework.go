@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"log"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -23,6 +22,7 @@ import (
 
 var (
 	_ resource.Resource                = &ContextKindResource{}
+	_ resource.ResourceWithModifyPlan  = &ContextKindResource{}
 	_ resource.ResourceWithConfigure   = &ContextKindResource{}
 	_ resource.ResourceWithImportState = &ContextKindResource{}
 )
@@ -146,7 +146,14 @@ func (r *ContextKindResource) Configure(_ context.Context, req resource.Configur
 	r.client = configureResourceClient(req, resp)
 }
 
+func (r *ContextKindResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.client.planGuardrails(ctx, req, resp, guardrailScope{project: PROJECT_KEY})
+}
+
 func (r *ContextKindResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_context_kind", "create", &resp.Diagnostics) {
+		return
+	}
 	var data ContextKindResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -251,6 +258,9 @@ func (r *ContextKindResource) Read(ctx context.Context, req resource.ReadRequest
 }
 
 func (r *ContextKindResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_context_kind", "update", &resp.Diagnostics) {
+		return
+	}
 	var data ContextKindResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -306,6 +316,9 @@ func (r *ContextKindResource) Update(ctx context.Context, req resource.UpdateReq
 }
 
 func (r *ContextKindResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_context_kind", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data ContextKindResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -338,16 +351,13 @@ func (r *ContextKindResource) Delete(ctx context.Context, req resource.DeleteReq
 }
 
 func (r *ContextKindResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	parts := strings.SplitN(req.ID, "/", 2)
-	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
-		resp.Diagnostics.AddError(
-			"Invalid import ID",
-			fmt.Sprintf("Expected import ID in the form <project_key>/<context_kind_key>, got %q", req.ID),
-		)
+	projectKey, key, err := contextKindIdToKeys(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
 		return
 	}
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(PROJECT_KEY), parts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(KEY), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(PROJECT_KEY), projectKey)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(KEY), key)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(ID), req.ID)...)
 }
 
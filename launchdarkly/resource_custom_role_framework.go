@@ -2,6 +2,7 @@ package launchdarkly
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"sort"
 	"strings"
@@ -37,6 +38,7 @@ type CustomRoleResourceModel struct {
 	BasePermissions      types.String `tfsdk:"base_permissions"`
 	PolicyStatements     types.List   `tfsdk:"policy_statements"`
 	PolicyStatementsJSON types.String `tfsdk:"policy_statements_json"`
+	Comment              types.String `tfsdk:"comment"`
 }
 
 func NewCustomRoleResource() resource.Resource {
@@ -95,6 +97,7 @@ func customRoleSchemaAttributes() map[string]schema.Attribute {
 				jsonNormalizePlanModifier{},
 			},
 		},
+		COMMENT: resourceCommentAttribute(),
 	}
 }
 
@@ -212,6 +215,11 @@ func (customRolePolicyConflictValidator) ValidateResource(ctx context.Context, r
 			"policy_statements_json and policy_statements cannot both be set.",
 		)
 	}
+	if jsonSet && json.Valid([]byte(data.PolicyStatementsJSON.ValueString())) {
+		if _, err := policyStatementsFromJSON(data.PolicyStatementsJSON.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(POLICY_STATEMENTS_JSON), "Invalid policy_statements_json", err.Error())
+		}
+	}
 }
 
 func (r *CustomRoleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -232,13 +240,17 @@ func (r *CustomRoleResource) policiesFromModel(ctx context.Context, data *Custom
 		}
 	}
 	if !data.PolicyStatements.IsNull() && len(data.PolicyStatements.Elements()) > 0 {
-		out, _ := frameworkPolicyStatementsFromList(ctx, data.PolicyStatements)
+		out, d := frameworkPolicyStatementsFromList(ctx, data.PolicyStatements)
+		diags.Append(d...)
 		return out
 	}
 	return nil
 }
 
 func (r *CustomRoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_custom_role", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan CustomRoleResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -306,6 +318,9 @@ func (r *CustomRoleResource) Read(ctx context.Context, req resource.ReadRequest,
 }
 
 func (r *CustomRoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_custom_role", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan CustomRoleResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -321,11 +336,14 @@ func (r *CustomRoleResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
-	patch := ldapi.PatchWithComment{Patch: []ldapi.PatchOperation{
-		patchReplace("/name", &name),
-		patchReplace("/description", &desc),
-		patchReplace("/policy", &policies),
-	}}
+	patch := ldapi.PatchWithComment{
+		Comment: ldapi.PtrString(r.client.changeCommentFor(plan.Comment)),
+		Patch: []ldapi.PatchOperation{
+			patchReplace("/name", &name),
+			patchReplace("/description", &desc),
+			patchReplace("/policy", &policies),
+		},
+	}
 	if basePerms != "" {
 		patch.Patch = append(patch.Patch, patchReplace("/basePermissions", &basePerms))
 	}
@@ -347,6 +365,9 @@ func (r *CustomRoleResource) Update(ctx context.Context, req resource.UpdateRequ
 }
 
 func (r *CustomRoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_custom_role", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data CustomRoleResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
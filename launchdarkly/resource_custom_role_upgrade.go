@@ -25,6 +25,7 @@ type CustomRoleResourceModelV0 struct {
 
 func customRoleSchemaAttributesV0() map[string]schema.Attribute {
 	attrs := customRoleSchemaAttributes()
+	delete(attrs, COMMENT)
 	attrs[POLICY] = schema.SetNestedAttribute{
 		Optional:           true,
 		DeprecationMessage: "'policy' is now deprecated. Please migrate to 'policy_statements' to maintain future compatability.",
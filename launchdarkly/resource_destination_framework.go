@@ -8,6 +8,8 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -20,8 +22,10 @@ import (
 )
 
 var (
-	_ resource.Resource                = &DestinationResource{}
-	_ resource.ResourceWithImportState = &DestinationResource{}
+	_ resource.Resource                     = &DestinationResource{}
+	_ resource.ResourceWithModifyPlan       = &DestinationResource{}
+	_ resource.ResourceWithImportState      = &DestinationResource{}
+	_ resource.ResourceWithConfigValidators = &DestinationResource{}
 )
 
 type DestinationResource struct {
@@ -29,14 +33,16 @@ type DestinationResource struct {
 }
 
 type DestinationResourceModel struct {
-	ID         types.String `tfsdk:"id"`
-	ProjectKey types.String `tfsdk:"project_key"`
-	EnvKey     types.String `tfsdk:"env_key"`
-	Name       types.String `tfsdk:"name"`
-	Kind       types.String `tfsdk:"kind"`
-	Config     types.Map    `tfsdk:"config"`
-	On         types.Bool   `tfsdk:"on"`
-	Tags       types.Set    `tfsdk:"tags"`
+	ID              types.String `tfsdk:"id"`
+	ProjectKey      types.String `tfsdk:"project_key"`
+	EnvKey          types.String `tfsdk:"env_key"`
+	Name            types.String `tfsdk:"name"`
+	Kind            types.String `tfsdk:"kind"`
+	Config          types.Map    `tfsdk:"config"`
+	ConfigWO        types.Map    `tfsdk:"config_wo"`
+	ConfigWOVersion types.Int64  `tfsdk:"config_wo_version"`
+	On              types.Bool   `tfsdk:"on"`
+	Tags            types.Set    `tfsdk:"tags"`
 }
 
 func NewDestinationResource() resource.Resource {
@@ -83,10 +89,21 @@ To learn more, read [Data Export](https://launchdarkly.com/docs/integrations/dat
 				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
 			},
 			CONFIG: schema.MapAttribute{
-				Required:    true,
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The destination-specific configuration. To learn more, read [Destination-Specific Configs](#destination-specific-configs). This value is stored in the Terraform state; use `config_wo` to keep credentials out of state. Exactly one of `config` or `config_wo` must be set.",
+			},
+			CONFIG_WO: schema.MapAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
 				ElementType: types.StringType,
-				Description: "The destination-specific configuration. To learn more, read [Destination-Specific Configs](#destination-specific-configs)",
+				Description: writeOnlyDescription("The destination-specific configuration, in the same format as `config`.", CONFIG_WO_VERSION),
+				Validators: []validator.Map{
+					mapvalidator.AlsoRequires(path.MatchRoot(CONFIG_WO_VERSION)),
+				},
 			},
+			CONFIG_WO_VERSION: writeOnlyVersionAttribute(CONFIG_WO),
 			ON: schema.BoolAttribute{
 				Optional:    true,
 				Computed:    true,
@@ -102,18 +119,38 @@ To learn more, read [Data Export](https://launchdarkly.com/docs/integrations/dat
 	}
 }
 
+func (r *DestinationResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot(CONFIG),
+			path.MatchRoot(CONFIG_WO),
+		),
+	}
+}
+
 func (r *DestinationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	r.client = configureResourceClient(req, resp)
 }
 
+func (r *DestinationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.client.planGuardrails(ctx, req, resp, guardrailScope{project: PROJECT_KEY, env: ENV_KEY})
+}
+
 func (r *DestinationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_destination", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan DestinationResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	rawConfig, d := mapStringFromAttr(ctx, plan.Config)
+	configAttr := plan.Config
+	if writeOnlyInUse(plan.ConfigWOVersion) {
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(CONFIG_WO), &configAttr)...)
+	}
+	rawConfig, d := mapStringFromAttr(ctx, configAttr)
 	resp.Diagnostics.Append(d...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -180,23 +217,39 @@ func (r *DestinationResource) Read(ctx context.Context, req resource.ReadRequest
 }
 
 func (r *DestinationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan DestinationResourceModel
+	if r.client.rejectReadOnly("launchdarkly_destination", "update", &resp.Diagnostics) {
+		return
+	}
+	var plan, state DestinationResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	rawConfig, d := mapStringFromAttr(ctx, plan.Config)
+	// A config_wo value is only re-sent when its version changes; otherwise
+	// the stored destination config is left untouched.
+	sendConfig := !writeOnlyInUse(plan.ConfigWOVersion)
+	configAttr := plan.Config
+	if writeOnlyVersionChanged(plan.ConfigWOVersion, state.ConfigWOVersion) {
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(CONFIG_WO), &configAttr)...)
+		sendConfig = true
+	}
+	rawConfig, d := mapStringFromAttr(ctx, configAttr)
 	resp.Diagnostics.Append(d...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	kind := plan.Kind.ValueString()
-	apiConfig, err := destinationConfigMapToAPI(kind, rawConfig)
-	if err != nil {
-		resp.Diagnostics.AddError(err.Error(), "")
-		return
+	var apiConfig map[string]interface{}
+	if sendConfig {
+		var err error
+		apiConfig, err = destinationConfigMapToAPI(kind, rawConfig)
+		if err != nil {
+			resp.Diagnostics.AddError(err.Error(), "")
+			return
+		}
 	}
 
 	_, _, destID, err := destinationImportIDtoKeys(plan.ID.ValueString())
@@ -213,7 +266,9 @@ func (r *DestinationResource) Update(ctx context.Context, req resource.UpdateReq
 		patchReplace("/name", &name),
 		patchReplace("/kind", &kind),
 		patchReplace("/on", &on),
-		patchReplace("/config", &apiConfig),
+	}
+	if sendConfig {
+		patch = append(patch, patchReplace("/config", &apiConfig))
 	}
 
 	err = r.client.withConcurrency(r.client.ctx, func() error {
@@ -233,6 +288,9 @@ func (r *DestinationResource) Update(ctx context.Context, req resource.UpdateReq
 }
 
 func (r *DestinationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_destination", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data DestinationResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -292,25 +350,32 @@ func (r *DestinationResource) readIntoModel(ctx context.Context, data *Destinati
 		return
 	}
 
-	priorConfig, _ := mapStringFromAttr(ctx, data.Config)
-	apiCfg := destinationConfigFromAPI(*dest.Kind, dest.Config)
-	preserved := preserveObfuscatedDestinationAttributes(priorConfig, apiCfg)
-
-	// mparticle compat: when the array-form user_identities is in play
-	// the server also returns the legacy `user_identity` scalar. Drop
-	// it from state so it doesn't surface as drift; framework Map<String>
-	// can't keep API-only keys the user didn't set without tripping the
-	// plan-apply consistency check. Key off the API response (not prior
-	// state) so import + create produce the same shape.
-	if *dest.Kind == "mparticle" {
-		if _, fromAPI := preserved["user_identities"]; fromAPI {
-			delete(preserved, "user_identity")
+	// When the config is managed through config_wo it must never land in
+	// state, so `config` stays null.
+	data.ConfigWO = types.MapNull(types.StringType)
+	if writeOnlyInUse(data.ConfigWOVersion) {
+		data.Config = types.MapNull(types.StringType)
+	} else {
+		priorConfig, _ := mapStringFromAttr(ctx, data.Config)
+		apiCfg := destinationConfigFromAPI(*dest.Kind, dest.Config)
+		preserved := preserveObfuscatedDestinationAttributes(priorConfig, apiCfg)
+
+		// mparticle compat: when the array-form user_identities is in play
+		// the server also returns the legacy `user_identity` scalar. Drop
+		// it from state so it doesn't surface as drift; framework Map<String>
+		// can't keep API-only keys the user didn't set without tripping the
+		// plan-apply consistency check. Key off the API response (not prior
+		// state) so import + create produce the same shape.
+		if *dest.Kind == "mparticle" {
+			if _, fromAPI := preserved["user_identities"]; fromAPI {
+				delete(preserved, "user_identity")
+			}
 		}
-	}
 
-	cfgVal, d := types.MapValueFrom(ctx, types.StringType, preserved)
-	diags.Append(d...)
-	data.Config = cfgVal
+		cfgVal, d := types.MapValueFrom(ctx, types.StringType, preserved)
+		diags.Append(d...)
+		data.Config = cfgVal
+	}
 
 	if dest.Name != nil {
 		data.Name = types.StringValue(*dest.Name)
@@ -22,6 +22,7 @@ import (
 
 var (
 	_ resource.Resource                 = &EnvironmentResource{}
+	_ resource.ResourceWithModifyPlan   = &EnvironmentResource{}
 	_ resource.ResourceWithImportState  = &EnvironmentResource{}
 	_ resource.ResourceWithUpgradeState = &EnvironmentResource{}
 )
@@ -46,8 +47,10 @@ type EnvironmentResourceModel struct {
 	ConfirmChanges          types.Bool   `tfsdk:"confirm_changes"`
 	Critical                types.Bool   `tfsdk:"critical"`
 	Tags                    types.Set    `tfsdk:"tags"`
+	TagsAll                 types.Set    `tfsdk:"tags_all"`
 	ApprovalSettings        types.Object `tfsdk:"approval_settings"`
 	SegmentApprovalSettings types.Object `tfsdk:"segment_approval_settings"`
+	AcknowledgeCritical     types.Bool   `tfsdk:"acknowledge_critical"`
 }
 
 func NewEnvironmentResource() resource.Resource {
@@ -119,6 +122,8 @@ func (r *EnvironmentResource) Schema(_ context.Context, _ resource.SchemaRequest
 				Optional:    true,
 				ElementType: types.StringType,
 			},
+			TAGS_ALL:                  tagsAllAttribute(),
+			ACKNOWLEDGE_CRITICAL:      acknowledgeCriticalAttribute(),
 			APPROVAL_SETTINGS:         frameworkApprovalSettingsResourceAttribute(),
 			SEGMENT_APPROVAL_SETTINGS: frameworkSegmentApprovalSettingsResourceAttribute(),
 		},
@@ -159,7 +164,16 @@ func (r *EnvironmentResource) Configure(_ context.Context, req resource.Configur
 	r.client = configureResourceClient(req, resp)
 }
 
+func (r *EnvironmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.client.planGuardrails(ctx, req, resp, guardrailScope{project: PROJECT_KEY, env: KEY})
+	r.client.planCriticalEnvironmentDestroy(ctx, req, resp)
+	r.client.planTagsAll(ctx, req, resp)
+}
+
 func (r *EnvironmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_environment", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan EnvironmentResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -174,7 +188,7 @@ func (r *EnvironmentResource) Create(ctx context.Context, req resource.CreateReq
 	requireComments := plan.RequireComments.ValueBool()
 	confirmChanges := plan.ConfirmChanges.ValueBool()
 	critical := plan.Critical.ValueBool()
-	tags, diags := stringSliceFromSet(ctx, plan.Tags)
+	tags, diags := stringSliceFromSet(ctx, plan.TagsAll)
 	resp.Diagnostics.Append(diags...)
 
 	envPost := ldapi.EnvironmentPost{
@@ -241,6 +255,9 @@ func (r *EnvironmentResource) Read(ctx context.Context, req resource.ReadRequest
 }
 
 func (r *EnvironmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_environment", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan, state EnvironmentResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -258,7 +275,7 @@ func (r *EnvironmentResource) Update(ctx context.Context, req resource.UpdateReq
 	requireComments := plan.RequireComments.ValueBool()
 	confirmChanges := plan.ConfirmChanges.ValueBool()
 	critical := plan.Critical.ValueBool()
-	tags, diags := stringSliceFromSet(ctx, plan.Tags)
+	tags, diags := stringSliceFromSet(ctx, plan.TagsAll)
 	resp.Diagnostics.Append(diags...)
 
 	patch := []ldapi.PatchOperation{
@@ -308,6 +325,9 @@ func (r *EnvironmentResource) Update(ctx context.Context, req resource.UpdateReq
 }
 
 func (r *EnvironmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_environment", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data EnvironmentResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -323,16 +343,25 @@ func (r *EnvironmentResource) Delete(ctx context.Context, req resource.DeleteReq
 }
 
 func (r *EnvironmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	parts := strings.SplitN(req.ID, "/", 2)
-	if len(parts) != 2 {
-		resp.Diagnostics.AddError("Invalid import ID", "expected project_key/env_key")
+	projectKey, envKey, err := environmentIdToKeys(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
 		return
 	}
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(PROJECT_KEY), parts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(KEY), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(PROJECT_KEY), projectKey)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(KEY), envKey)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
 }
 
+// environmentIdToKeys parses a `project_key/env_key` composite id.
+func environmentIdToKeys(id string) (projectKey, envKey string, err error) {
+	if strings.Count(id, "/") != 1 {
+		return "", "", fmt.Errorf("found unexpected environment id format: %q expected format: 'project_key/env_key'", id)
+	}
+	parts := strings.SplitN(id, "/", 2)
+	return parts[0], parts[1], nil
+}
+
 // applyApprovalPatch applies the diff between the planned and stored
 // approval_settings as a JSON-patch against the environment. Returns
 // nil on success.
@@ -521,9 +550,12 @@ func (r *EnvironmentResource) readIntoModel(
 	data.ConfirmChanges = types.BoolValue(env.ConfirmChanges)
 	data.Critical = types.BoolValue(env.Critical)
 
-	tagsSet, d := setFromStringSlicePreservingPlan(ctx, env.Tags, data.Tags)
+	ownTags, tagsAll, d := r.client.splitDefaultTags(ctx, env.Tags, data.Tags)
+	diags.Append(d...)
+	tagsSet, d := setFromStringSlicePreservingPlan(ctx, ownTags, data.Tags)
 	diags.Append(d...)
 	data.Tags = tagsSet
+	data.TagsAll = tagsAll
 
 	approvals, d := frameworkApprovalSettingsValue(ctx, env.ApprovalSettings, data.ApprovalSettings)
 	diags.Append(d...)
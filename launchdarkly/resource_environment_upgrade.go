@@ -101,6 +101,7 @@ func (r *EnvironmentResource) UpgradeState(_ context.Context) map[int64]resource
 					ConfirmChanges:          prior.ConfirmChanges,
 					Critical:                prior.Critical,
 					Tags:                    prior.Tags,
+					TagsAll:                 types.SetNull(types.StringType),
 					ApprovalSettings:        approvalsObj,
 					SegmentApprovalSettings: segmentApprovalsObj,
 				}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -26,9 +27,11 @@ import (
 )
 
 var (
-	_ resource.Resource                 = &FeatureFlagEnvironmentResource{}
-	_ resource.ResourceWithImportState  = &FeatureFlagEnvironmentResource{}
-	_ resource.ResourceWithUpgradeState = &FeatureFlagEnvironmentResource{}
+	_ resource.Resource                     = &FeatureFlagEnvironmentResource{}
+	_ resource.ResourceWithConfigValidators = &FeatureFlagEnvironmentResource{}
+	_ resource.ResourceWithModifyPlan       = &FeatureFlagEnvironmentResource{}
+	_ resource.ResourceWithImportState      = &FeatureFlagEnvironmentResource{}
+	_ resource.ResourceWithUpgradeState     = &FeatureFlagEnvironmentResource{}
 )
 
 type FeatureFlagEnvironmentResource struct {
@@ -36,17 +39,24 @@ type FeatureFlagEnvironmentResource struct {
 }
 
 type FeatureFlagEnvironmentResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	FlagID         types.String `tfsdk:"flag_id"`
-	EnvKey         types.String `tfsdk:"env_key"`
-	On             types.Bool   `tfsdk:"on"`
-	Targets        types.Set    `tfsdk:"targets"`
-	ContextTargets types.Set    `tfsdk:"context_targets"`
-	Rules          types.List   `tfsdk:"rules"`
-	Prerequisites  types.List   `tfsdk:"prerequisites"`
-	Fallthrough    types.Object `tfsdk:"fallthrough"`
-	TrackEvents    types.Bool   `tfsdk:"track_events"`
-	OffVariation   types.Int64  `tfsdk:"off_variation"`
+	ID                     types.String `tfsdk:"id"`
+	FlagID                 types.String `tfsdk:"flag_id"`
+	EnvKey                 types.String `tfsdk:"env_key"`
+	On                     types.Bool   `tfsdk:"on"`
+	Targets                types.Set    `tfsdk:"targets"`
+	ContextTargets         types.Set    `tfsdk:"context_targets"`
+	Rules                  types.List   `tfsdk:"rules"`
+	Prerequisites          types.List   `tfsdk:"prerequisites"`
+	Fallthrough            types.Object `tfsdk:"fallthrough"`
+	TrackEvents            types.Bool   `tfsdk:"track_events"`
+	TrackEventsFallthrough types.Bool   `tfsdk:"track_events_fallthrough"`
+	OffVariation           types.Int64  `tfsdk:"off_variation"`
+	OffVariationValue      types.String `tfsdk:"off_variation_value"`
+	OffVariationName       types.String `tfsdk:"off_variation_name"`
+	ManagedFields          types.Set    `tfsdk:"managed_fields"`
+	ApprovalMode           types.String `tfsdk:"approval_mode"`
+	Comment                types.String `tfsdk:"comment"`
+	AcknowledgeCritical    types.Bool   `tfsdk:"acknowledge_critical"`
 }
 
 func NewFeatureFlagEnvironmentResource() resource.Resource {
@@ -60,7 +70,7 @@ func (r *FeatureFlagEnvironmentResource) Metadata(_ context.Context, req resourc
 func (r *FeatureFlagEnvironmentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Version:     1,
-		Description: "Provides a LaunchDarkly environment-specific feature flag resource.\n\nThis resource allows you to create and manage environment-specific feature flags attributes within your LaunchDarkly organization.\n\n-> **Note:** If you intend to attach a feature flag to any experiments, we do _not_ recommend configuring environment-specific flag settings using Terraform. Subsequent applies may overwrite the changes made by experiments and break your experiment. An alternate workaround is to use the [lifecycle.ignore_changes](https://developer.hashicorp.com/terraform/language/meta-arguments/lifecycle#ignore_changes) Terraform meta-argument on the `fallthrough` field to prevent potential overwrites.",
+		Description: "Provides a LaunchDarkly environment-specific feature flag resource.\n\nThis resource allows you to create and manage environment-specific feature flags attributes within your LaunchDarkly organization.\n\nChanges to an existing resource are applied as individual instructions, such as adding a rule or removing a target, computed from the difference between your configuration and the last refreshed state. Changes made in LaunchDarkly since the last refresh that your configuration does not touch are kept, and approval requests and the audit log show exactly what changed.\n\n-> **Note:** Experiment settings your configuration leaves out, such as the `seed` and `experiment_allocation` of a percentage rollout and the `track_events` of a rule, keep the values LaunchDarkly has, so an apply does not stop an experiment started in the LaunchDarkly UI. Changing the rollout weights of a running experiment from Terraform changes how its traffic is allocated.",
 		Attributes:  featureFlagEnvironmentSchemaAttributes(),
 	}
 }
@@ -95,11 +105,15 @@ func featureFlagEnvironmentSchemaAttributes() map[string]schema.Attribute {
 			Default:     booldefault.StaticBool(false),
 			Description: "Whether to send event data back to LaunchDarkly. Defaults to `false` if not set.",
 		},
+		TRACK_EVENTS_FALLTHROUGH: trackEventsFallthroughAttribute(),
 		OFF_VARIATION: schema.Int64Attribute{
 			Optional:    true,
+			Computed:    true,
 			Validators:  []validator.Int64{int64validator.AtLeast(0)},
-			Description: "The index of the variation to serve when targeting is off. Omitting this attribute leaves the off variation unset (the UI's \"Not set\" state), which is distinct from setting it to `0`. When it is unset and targeting is off, LaunchDarkly serves no variation: SDKs return the application-provided default value and the evaluation carries a null variation index, which affects Data Export and Experimentation.",
+			Description: "The index of the variation to serve when targeting is off. Omitting this attribute, `off_variation_value` and `off_variation_name` leaves the off variation unset (the UI's \"Not set\" state), which is distinct from setting it to `0`. When it is unset and targeting is off, LaunchDarkly serves no variation: SDKs return the application-provided default value and the evaluation carries a null variation index, which affects Data Export and Experimentation.",
 		},
+		OFF_VARIATION_VALUE: variationValueAttribute(OFF_VARIATION, siblings(OFF_VARIATION, OFF_VARIATION_NAME)...),
+		OFF_VARIATION_NAME:  variationNameAttribute(OFF_VARIATION, siblings(OFF_VARIATION, OFF_VARIATION_VALUE)...),
 		TARGETS: schema.SetNestedAttribute{
 			Optional:    true,
 			Description: "Individual user targets for each variation.",
@@ -151,10 +165,16 @@ func featureFlagEnvironmentSchemaAttributes() map[string]schema.Attribute {
 						Validators:  []validator.String{keyValidator()},
 					},
 					VARIATION: schema.Int64Attribute{
-						Required:    true,
-						Validators:  []validator.Int64{int64validator.AtLeast(0)},
-						Description: "The index of the prerequisite feature flag's variation to target.",
+						Optional: true,
+						Computed: true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+							int64validator.ExactlyOneOf(siblings(VARIATION_VALUE, VARIATION_NAME)...),
+						},
+						Description: "The index of the prerequisite feature flag's variation to target. You must specify exactly one of `variation`, `variation_value` or `variation_name`.",
 					},
+					VARIATION_VALUE: variationValueAttribute(VARIATION),
+					VARIATION_NAME:  variationNameAttribute(VARIATION),
 				},
 			},
 		},
@@ -170,9 +190,14 @@ func featureFlagEnvironmentSchemaAttributes() map[string]schema.Attribute {
 					},
 					VARIATION: schema.Int64Attribute{
 						Optional:    true,
+						Computed:    true,
 						Validators:  []validator.Int64{int64validator.AtLeast(0)},
-						Description: "The integer variation index to serve if the rule clauses evaluate to `true`. You must specify either `variation` or `rollout_weights`",
+						Description: "The integer variation index to serve if the rule clauses evaluate to `true`. You must specify one of `variation`, `variation_value`, `variation_name`, `rollout_weights`, `rollout_percentages` or `rollout`.",
 					},
+					VARIATION_VALUE:     variationValueAttribute(VARIATION, siblings(VARIATION, VARIATION_NAME, ROLLOUT_WEIGHTS)...),
+					VARIATION_NAME:      variationNameAttribute(VARIATION, siblings(VARIATION, VARIATION_VALUE, ROLLOUT_WEIGHTS)...),
+					ROLLOUT:             rolloutAttribute(),
+					ROLLOUT_PERCENTAGES: rolloutPercentagesAttribute(),
 					BUCKET_BY: schema.StringAttribute{
 						Optional:    true,
 						Description: "Group percentage rollout by a custom attribute. This argument is only valid if `rollout_weights` is also specified.",
@@ -185,27 +210,40 @@ func featureFlagEnvironmentSchemaAttributes() map[string]schema.Attribute {
 					},
 					ROLLOUT_WEIGHTS: schema.ListAttribute{
 						Optional:    true,
+						Computed:    true,
 						ElementType: types.Int64Type,
 						Validators: []validator.List{
 							listvalidator.ValueInt64sAre(int64validator.Between(0, 100000)),
 						},
-						Description: "List of integer percentage rollout weights (in thousandths of a percent) to apply to each variation if the rule clauses evaluates to `true`. The sum of the `rollout_weights` must equal 100000 and the number of rollout weights specified in the array must match the number of flag variations. You must specify either `variation` or `rollout_weights`.",
+						Description: "List of integer percentage rollout weights (in thousandths of a percent) to apply to each variation if the rule clauses evaluates to `true`. The sum of the `rollout_weights` must equal 100000 and the number of rollout weights specified in the array must match the number of flag variations. You must specify one of `variation`, `variation_value`, `variation_name`, `rollout_weights`, `rollout_percentages` or `rollout`.",
+					},
+					SEED:                  rolloutSeedAttribute(),
+					EXPERIMENT_ALLOCATION: experimentAllocationAttribute(),
+					TRACK_EVENTS:          ruleTrackEventsAttribute(),
+					REF: schema.StringAttribute{
+						Optional:    true,
+						Computed:    true,
+						Description: "A stable identifier for the rule. Rules are matched by `ref` when planning, so a rule that moves or changes keeps its LaunchDarkly ID and evaluation history. If omitted, LaunchDarkly generates one and Terraform matches the rule by its content. To track an existing rule, set `ref` to the value in state.",
 					},
 					CLAUSES: frameworkClausesResourceAttribute(),
 				},
 			},
 		},
 		FALLTHROUGH: schema.SingleNestedAttribute{
-			Required:    true,
-			Description: "The default variation to serve if no `prerequisites`, `target`, or `rules` apply.",
+			Optional:    true,
+			Description: "The default variation to serve if no `prerequisites`, `target`, or `rules` apply. Required unless `managed_fields` leaves it out.",
 			Attributes: map[string]schema.Attribute{
 				VARIATION: schema.Int64Attribute{
 					Optional:    true,
 					Computed:    true,
 					Default:     int64default.StaticInt64(0),
 					Validators:  []validator.Int64{int64validator.AtLeast(0)},
-					Description: "The default integer variation index to serve if no `prerequisites`, `target`, or `rules` apply. You must specify either `variation` or `rollout_weights`.",
+					Description: "The default integer variation index to serve if no `prerequisites`, `target`, or `rules` apply. You must specify one of `variation`, `variation_value`, `variation_name`, `rollout_weights`, `rollout_percentages` or `rollout`.",
 				},
+				VARIATION_VALUE:     variationValueAttribute(VARIATION, siblings(VARIATION, VARIATION_NAME, ROLLOUT_WEIGHTS)...),
+				VARIATION_NAME:      variationNameAttribute(VARIATION, siblings(VARIATION, VARIATION_VALUE, ROLLOUT_WEIGHTS)...),
+				ROLLOUT:             rolloutAttribute(),
+				ROLLOUT_PERCENTAGES: rolloutPercentagesAttribute(),
 				BUCKET_BY: schema.StringAttribute{
 					Optional:    true,
 					Description: "Group percentage rollout by a custom attribute. This argument is only valid if rollout_weights is also specified.",
@@ -218,14 +256,29 @@ func featureFlagEnvironmentSchemaAttributes() map[string]schema.Attribute {
 				},
 				ROLLOUT_WEIGHTS: schema.ListAttribute{
 					Optional:    true,
+					Computed:    true,
 					ElementType: types.Int64Type,
 					Validators: []validator.List{
 						listvalidator.ValueInt64sAre(int64validator.Between(0, 100000)),
 					},
-					Description: "List of integer percentage rollout weights (in thousandths of a percent) to apply to each variation if the rule clauses evaluates to `true`. The sum of the `rollout_weights` must equal 100000 and the number of rollout weights specified in the array must match the number of flag variations. You must specify either `variation` or `rollout_weights`.",
+					Description: "List of integer percentage rollout weights (in thousandths of a percent) to apply to each variation if the rule clauses evaluates to `true`. The sum of the `rollout_weights` must equal 100000 and the number of rollout weights specified in the array must match the number of flag variations. You must specify one of `variation`, `variation_value`, `variation_name`, `rollout_weights`, `rollout_percentages` or `rollout`.",
 				},
+				SEED:                  rolloutSeedAttribute(),
+				EXPERIMENT_ALLOCATION: experimentAllocationAttribute(),
+			},
+		},
+		MANAGED_FIELDS: schema.SetAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Description: "The environment settings this resource manages, out of `on`, `track_events`, `off_variation`, `targets`, `context_targets`, `prerequisites`, `rules` and `fallthrough`. `fallthrough` includes `track_events_fallthrough`. Settings left out are not read into state, compared, patched, or reset when the resource is destroyed, so they can be changed in LaunchDarkly without Terraform reverting them, and must not be set in the configuration. If omitted, every setting is managed.",
+			Validators: []validator.Set{
+				setvalidator.SizeAtLeast(1),
+				setvalidator.ValueStringsAre(oneOfValidator{allowed: ffeFields}),
 			},
 		},
+		APPROVAL_MODE:        resourceApprovalModeAttribute(),
+		COMMENT:              resourceCommentAttribute(),
+		ACKNOWLEDGE_CRITICAL: acknowledgeCriticalAttribute(),
 	}
 }
 
@@ -248,17 +301,21 @@ func (r *FeatureFlagEnvironmentResource) UpgradeState(_ context.Context) map[int
 					return
 				}
 				data := FeatureFlagEnvironmentResourceModel{
-					ID:             prior.ID,
-					FlagID:         prior.FlagID,
-					EnvKey:         prior.EnvKey,
-					On:             prior.On,
-					Targets:        nullIfEmptySet(ctx, prior.Targets),
-					ContextTargets: nullIfEmptySet(ctx, prior.ContextTargets),
-					Rules:          nullIfEmptyList(ctx, prior.Rules),
-					Prerequisites:  nullIfEmptyList(ctx, prior.Prerequisites),
-					Fallthrough:    fallthroughObj,
-					TrackEvents:    prior.TrackEvents,
-					OffVariation:   prior.OffVariation,
+					ID:                     prior.ID,
+					FlagID:                 prior.FlagID,
+					EnvKey:                 prior.EnvKey,
+					On:                     prior.On,
+					Targets:                nullIfEmptySet(ctx, prior.Targets),
+					ContextTargets:         nullIfEmptySet(ctx, prior.ContextTargets),
+					Rules:                  nullIfEmptyList(ctx, prior.Rules),
+					Prerequisites:          nullIfEmptyList(ctx, prior.Prerequisites),
+					Fallthrough:            fallthroughObj,
+					TrackEvents:            prior.TrackEvents,
+					TrackEventsFallthrough: types.BoolNull(),
+					OffVariation:           prior.OffVariation,
+					OffVariationValue:      types.StringNull(),
+					OffVariationName:       types.StringNull(),
+					ManagedFields:          types.SetNull(types.StringType),
 				}
 				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 			},
@@ -266,11 +323,28 @@ func (r *FeatureFlagEnvironmentResource) UpgradeState(_ context.Context) map[int
 	}
 }
 
+func (r *FeatureFlagEnvironmentResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{ffeManagedFieldsValidator{}}
+}
+
 func (r *FeatureFlagEnvironmentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	r.client = configureResourceClient(req, resp)
 }
 
+func (r *FeatureFlagEnvironmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	scope := guardrailScope{project: FLAG_ID, env: ENV_KEY, projectFromFlagID: true}
+	r.client.planGuardrails(ctx, req, resp, scope)
+	r.client.planCriticalEnvironment(ctx, req, resp, scope)
+	r.planVariationRefs(ctx, req, resp)
+	planRuleRefs(ctx, req, resp)
+	planExperimentSettings(ctx, req, resp)
+	planManagedFields(ctx, req, resp)
+}
+
 func (r *FeatureFlagEnvironmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_feature_flag_environment", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan FeatureFlagEnvironmentResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -310,13 +384,19 @@ func (r *FeatureFlagEnvironmentResource) Create(ctx context.Context, req resourc
 		)
 		return
 	}
+	plan = r.client.applyFFEVariationRefs(ctx, req.Config, projectKey, flagKey, plan, &resp.Diagnostics)
+	managed, d := ffeManagedFieldsFrom(ctx, plan.ManagedFields)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// When off_variation is omitted we may need to remove the offVariation LD
 	// seeded from the flag default. Determine whether one is actually present
 	// first — a remove of an absent path returns 400 invalid_patch, which
 	// would otherwise block create for an environment already in "Not set".
 	offVariationLiveSet := false
-	if plan.OffVariation.IsNull() {
+	if plan.OffVariation.IsNull() && managed.has(OFF_VARIATION) {
 		flag, _, gerr := getFeatureFlagEnvironment(r.client, projectKey, flagKey, envKey)
 		if gerr != nil {
 			resp.Diagnostics.AddError(fmt.Sprintf("failed to read flag %q of project %q before create: %s", flagKey, projectKey, handleLdapiErr(gerr).Error()), "")
@@ -334,8 +414,9 @@ func (r *FeatureFlagEnvironmentResource) Create(ctx context.Context, req resourc
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	patches = managed.patches(envKey, patches)
 	if len(patches) > 0 {
-		comment := "Terraform"
+		comment := r.client.changeCommentFor(plan.Comment)
 		patch := ldapi.PatchWithComment{Comment: &comment, Patch: patches}
 		log.Printf("[DEBUG] %+v\n", patch)
 		err = r.client.withConcurrency(r.client.ctx, func() error {
@@ -343,8 +424,14 @@ func (r *FeatureFlagEnvironmentResource) Create(ctx context.Context, req resourc
 			return e
 		})
 		if err != nil {
-			resp.Diagnostics.AddError(fmt.Sprintf("failed to update flag %q in project %q: %s", flagKey, projectKey, handleLdapiErr(err).Error()), "")
-			return
+			if mode := r.client.approvalModeFor(plan.ApprovalMode); isApprovalRequiredErr(err) && mode != APPROVAL_MODE_FAIL {
+				resp.Diagnostics.Append(r.requestApproval(ctx, mode, projectKey, flagKey, envKey, managed, plan, nil, comment)...)
+			} else {
+				resp.Diagnostics.AddError(fmt.Sprintf("failed to update flag %q in project %q: %s", flagKey, projectKey, handleLdapiErr(err).Error()), "")
+			}
+			if resp.Diagnostics.HasError() {
+				return
+			}
 		}
 	}
 
@@ -379,6 +466,9 @@ func (r *FeatureFlagEnvironmentResource) Read(ctx context.Context, req resource.
 }
 
 func (r *FeatureFlagEnvironmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_feature_flag_environment", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan, state FeatureFlagEnvironmentResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -416,26 +506,102 @@ func (r *FeatureFlagEnvironmentResource) Update(ctx context.Context, req resourc
 		return
 	}
 
-	// state is post-refresh, so its off_variation presence mirrors the live
-	// environment — a safe basis for deciding whether a remove is valid.
-	patches, d := buildFFEPatches(ctx, envKey, plan, state, false, !state.OffVariation.IsNull())
+	plan = r.client.applyFFEVariationRefs(ctx, req.Config, projectKey, flagKey, plan, &resp.Diagnostics)
+
+	// Settings managed_fields leaves out are taken from state, so they are
+	// not changed. Settings it newly lists are diffed against LaunchDarkly,
+	// as state does not hold them.
+	managed, d := ffeManagedFieldsFrom(ctx, plan.ManagedFields)
+	resp.Diagnostics.Append(d...)
+	priorManaged, d := ffeManagedFieldsFrom(ctx, state.ManagedFields)
 	resp.Diagnostics.Append(d...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	if len(patches) > 0 {
-		comment := "Terraform"
-		patch := ldapi.PatchWithComment{Comment: &comment, Patch: patches}
+	prior := state
+	if added := managed.addedSince(priorManaged); len(added) > 0 {
+		live := FeatureFlagEnvironmentResourceModel{}
+		r.readIntoModel(ctx, projectKey, flagKey, envKey, &live, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		prior = added.overlay(prior, live)
+	}
+	instructionPlan := managed.overlay(prior, plan)
+
+	comment := r.client.changeCommentFor(plan.Comment)
+	failed := func(err error) bool {
+		if mode := r.client.approvalModeFor(plan.ApprovalMode); isApprovalRequiredErr(err) && mode != APPROVAL_MODE_FAIL {
+			resp.Diagnostics.Append(r.requestApproval(ctx, mode, projectKey, flagKey, envKey, managed, plan, nil, comment)...)
+		} else {
+			resp.Diagnostics.AddError(fmt.Sprintf("failed to update flag %q in project %q, environment %q: %s", flagKey, projectKey, envKey, handleLdapiErr(err).Error()), "")
+		}
+		return resp.Diagnostics.HasError()
+	}
+
+	// Semantic patch cannot unset the off variation, so that is removed with
+	// a JSON Patch first. prior is post-refresh, so its off_variation mirrors
+	// the live environment and the remove targets a path that exists.
+	if instructionPlan.OffVariation.IsNull() && !prior.OffVariation.IsNull() {
+		instructionPlan.OffVariation = prior.OffVariation
+		patch := ldapi.PatchWithComment{Comment: &comment, Patch: []ldapi.PatchOperation{patchRemove(ffePatchPath(envKey, "offVariation"))}}
 		log.Printf("[DEBUG] %+v\n", patch)
 		err = r.client.withConcurrency(r.client.ctx, func() error {
 			_, _, e := r.client.ld.FeatureFlagsApi.PatchFeatureFlag(r.client.ctx, projectKey, flagKey).PatchWithComment(patch).Execute()
 			return e
 		})
+		if err != nil && failed(err) {
+			return
+		}
+	}
+
+	// The rest is sent as semantic-patch instructions computed from the
+	// difference between plan and state, so changes made in LaunchDarkly
+	// since the last refresh are not overwritten.
+	flag, _, err := getFeatureFlagEnvironment(r.client, projectKey, flagKey, envKey)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("failed to get flag %q of project %q: %s", flagKey, projectKey, handleLdapiErr(err).Error()), "")
+		return
+	}
+	ids := newFlagVariationIDs(r.client, projectKey)
+	ids.seed(flag)
+	instructions, d := buildFFEInstructions(ctx, flagKey, instructionPlan, prior, ffeLiveRules(flag, envKey), ids)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(instructions) > 0 {
+		err = patchFeatureFlagSemantic(r.client.ctx, r.client, projectKey, flagKey, envKey, comment, instructions)
+		if err != nil && failed(err) {
+			return
+		}
+		flag, _, err = getFeatureFlagEnvironment(r.client, projectKey, flagKey, envKey)
 		if err != nil {
-			resp.Diagnostics.AddError(fmt.Sprintf("failed to update flag %q in project %q, environment %q: %s", flagKey, projectKey, envKey, handleLdapiErr(err).Error()), "")
+			resp.Diagnostics.AddError(fmt.Sprintf("failed to get flag %q of project %q: %s", flagKey, projectKey, handleLdapiErr(err).Error()), "")
 			return
 		}
 	}
+
+	// Instructions cannot set experiment settings, so those are patched
+	// against the environment as the instructions left it.
+	if flag.Environments != nil {
+		patches, d := ffeExperimentPatches(ctx, envKey, instructionPlan, (*flag.Environments)[envKey])
+		resp.Diagnostics.Append(d...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if len(patches) > 0 {
+			patch := ldapi.PatchWithComment{Comment: &comment, Patch: patches}
+			log.Printf("[DEBUG] %+v\n", patch)
+			err = r.client.withConcurrency(r.client.ctx, func() error {
+				_, _, e := r.client.ld.FeatureFlagsApi.PatchFeatureFlag(r.client.ctx, projectKey, flagKey).PatchWithComment(patch).Execute()
+				return e
+			})
+			if err != nil && failed(err) {
+				return
+			}
+		}
+	}
 	r.readIntoModel(ctx, projectKey, flagKey, envKey, &plan, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
@@ -445,6 +611,9 @@ func (r *FeatureFlagEnvironmentResource) Update(ctx context.Context, req resourc
 }
 
 func (r *FeatureFlagEnvironmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_feature_flag_environment", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data FeatureFlagEnvironmentResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -492,20 +661,19 @@ func (r *FeatureFlagEnvironmentResource) Delete(ctx context.Context, req resourc
 		offVariation = flag.Defaults.OffVariation
 	}
 
-	comment := "Terraform"
-	zeroVar := int32(0)
+	managed, d := ffeManagedFieldsFrom(ctx, data.ManagedFields)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	comment := r.client.changeCommentFor(data.Comment)
 	patch := ldapi.PatchWithComment{
 		Comment: &comment,
-		Patch: []ldapi.PatchOperation{
-			patchReplace(ffePatchPath(envKey, "on"), false),
-			patchReplace(ffePatchPath(envKey, "rules"), []ldapi.Rule{}),
-			patchReplace(ffePatchPath(envKey, "trackEvents"), false),
-			patchReplace(ffePatchPath(envKey, "prerequisites"), []ldapi.Prerequisite{}),
-			patchReplace(ffePatchPath(envKey, "offVariation"), offVariation),
-			patchReplace(ffePatchPath(envKey, "targets"), []ldapi.Target{}),
-			patchReplace(ffePatchPath(envKey, "contextTargets"), []ldapi.Target{}),
-			patchReplace(ffePatchPath(envKey, "fallthough"), ffeFallthroughPayload{Variation: &zeroVar}),
-		},
+		// Only the settings this resource manages are reset.
+		Patch: managed.patches(envKey, ffeResetPatches(envKey, offVariation)),
+	}
+	if len(patch.Patch) == 0 {
+		return
 	}
 	log.Printf("[DEBUG] %+v\n", patch)
 
@@ -514,17 +682,69 @@ func (r *FeatureFlagEnvironmentResource) Delete(ctx context.Context, req resourc
 		return err
 	})
 	if err != nil {
+		if mode := r.client.approvalModeFor(data.ApprovalMode); isApprovalRequiredErr(err) && mode != APPROVAL_MODE_FAIL {
+			reset, d := ffeResetModel(offVariation)
+			resp.Diagnostics.Append(d...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			resp.Diagnostics.Append(r.requestApproval(ctx, mode, projectKey, flagKey, envKey, managed, reset, flag, comment)...)
+			return
+		}
 		resp.Diagnostics.AddError(fmt.Sprintf("failed to update flag %q in project %q, environment %q: %s", flagKey, projectKey, envKey, handleLdapiErr(err).Error()), "")
 	}
 }
 
+// requestApproval submits the change from the live environment
+// configuration to desired as an approval request, after LaunchDarkly has
+// rejected the direct patch because the environment requires approvals.
+// Diffing against the live configuration rather than the prior state keeps
+// a re-run after the request was applied from asking for approval again:
+// if nothing is left to change, there is nothing to request. flag may be
+// nil, in which case it is fetched for its variation and rule IDs. Only the
+// settings in managed are requested.
+func (r *FeatureFlagEnvironmentResource) requestApproval(ctx context.Context, mode, projectKey, flagKey, envKey string, managed ffeManagedFields, desired FeatureFlagEnvironmentResourceModel, flag *ldapi.FeatureFlag, comment string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	live := FeatureFlagEnvironmentResourceModel{}
+	r.readIntoModel(ctx, projectKey, flagKey, envKey, &live, &diags)
+	if diags.HasError() {
+		return diags
+	}
+	if live.ID.IsNull() {
+		diags.AddError(fmt.Sprintf("flag %q not found in environment %q of project %q", flagKey, envKey, projectKey), "")
+		return diags
+	}
+	desired = managed.overlay(live, desired)
+
+	if flag == nil {
+		var err error
+		flag, _, err = getFeatureFlagEnvironment(r.client, projectKey, flagKey, envKey)
+		if err != nil {
+			diags.AddError(fmt.Sprintf("failed to get flag %q of project %q: %s", flagKey, projectKey, handleLdapiErr(err).Error()), "")
+			return diags
+		}
+	}
+	ids := newFlagVariationIDs(r.client, projectKey)
+	ids.seed(flag)
+	instructions, d := buildFFEInstructions(ctx, flagKey, desired, live, ffeLiveRules(flag, envKey), ids)
+	diags.Append(d...)
+	if diags.HasError() || len(instructions) == 0 {
+		return diags
+	}
+	return r.client.submitForApproval(mode, approvalRequestSpec{
+		resourceID:   flagApprovalResourceID(projectKey, envKey, flagKey),
+		summary:      fmt.Sprintf("flag %q in environment %q of project %q", flagKey, envKey, projectKey),
+		instructions: instructions,
+		comment:      comment,
+	})
+}
+
 func (r *FeatureFlagEnvironmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	if strings.Count(req.ID, "/") != 2 {
-		resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("expected project_key/env_key/flag_key, got %q", req.ID))
+	projectKey, envKey, flagKey, err := featureFlagEnvironmentIdToKeys(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
 		return
 	}
-	parts := strings.SplitN(req.ID, "/", 3)
-	projectKey, envKey, flagKey := parts[0], parts[1], parts[2]
 
 	// The import ID order changed in v3: v2 used project_key/flag_key/env_key,
 	// v3 uses project_key/env_key/flag_key. A v2-ordered ID still splits into
@@ -573,20 +793,34 @@ func (r *FeatureFlagEnvironmentResource) readIntoModel(ctx context.Context, proj
 		diags.AddError(fmt.Sprintf("failed to get flag %q of project %q: %s", flagKey, projectKey, handleLdapiErr(err).Error()), "")
 		return
 	}
-	if flag.Environments == nil {
+	if !ffeReadEnvironment(ctx, flag, envKey, data, diags) {
 		data.ID = types.StringNull()
 		return
 	}
+	data.ID = types.StringValue(projectKey + "/" + envKey + "/" + flagKey)
+	data.FlagID = types.StringValue(projectKey + "/" + flag.Key)
+
+	managed, d := ffeManagedFieldsFrom(ctx, data.ManagedFields)
+	diags.Append(d...)
+	*data = managed.only(*data)
+}
+
+// ffeReadEnvironment reads the targeting of the environment envKey of flag
+// into data, keeping the variation references of data, and reports whether
+// the flag has that environment.
+func ffeReadEnvironment(ctx context.Context, flag *ldapi.FeatureFlag, envKey string, data *FeatureFlagEnvironmentResourceModel, diags *diag.Diagnostics) bool {
+	if flag.Environments == nil {
+		return false
+	}
 	environment, ok := (*flag.Environments)[envKey]
 	if !ok {
-		data.ID = types.StringNull()
-		return
+		return false
 	}
+	prior := *data
 
-	data.ID = types.StringValue(projectKey + "/" + envKey + "/" + flagKey)
-	data.FlagID = types.StringValue(projectKey + "/" + flag.Key)
 	data.On = types.BoolValue(environment.On)
 	data.TrackEvents = types.BoolValue(environment.TrackEvents)
+	data.TrackEventsFallthrough = types.BoolValue(environment.TrackEventsFallthrough)
 	if environment.OffVariation != nil {
 		data.OffVariation = types.Int64Value(int64(*environment.OffVariation))
 	} else {
@@ -596,18 +830,18 @@ func (r *FeatureFlagEnvironmentResource) readIntoModel(ctx context.Context, proj
 	}
 
 	noopDiags := noopDiagSink{}
-	data.Targets = ffeTargetsValue(ctx, environment.Targets, false, noopDiags)
-	data.ContextTargets = ffeTargetsValue(ctx, environment.ContextTargets, true, noopDiags)
+	data.Targets = ffeTargetsValue(ctx, ffeTargetsInOrder(ctx, environment.Targets, prior.Targets, false), false, noopDiags)
+	data.ContextTargets = ffeTargetsValue(ctx, ffeTargetsInOrder(ctx, environment.ContextTargets, prior.ContextTargets, true), true, noopDiags)
 	data.Rules = ffeResourceRulesValue(ctx, environment.Rules, diags)
 	data.Fallthrough = ffeResourceFallthroughValue(ctx, environment.Fallthrough, diags)
 
-	prereqObjectType := types.ObjectType{AttrTypes: ffePrerequisiteAttrTypes}
+	prereqObjectType := types.ObjectType{AttrTypes: ffeResourcePrerequisiteAttrTypes}
 	prereqElements := make([]attr.Value, 0, len(environment.Prerequisites))
 	for _, p := range environment.Prerequisites {
-		obj, d := types.ObjectValue(ffePrerequisiteAttrTypes, map[string]attr.Value{
+		obj, d := types.ObjectValue(ffeResourcePrerequisiteAttrTypes, withNullVariationRefs(map[string]attr.Value{
 			FLAG_KEY:  types.StringValue(p.Key),
 			VARIATION: types.Int64Value(int64(p.Variation)),
-		})
+		}, false))
 		diags.Append(d...)
 		prereqElements = append(prereqElements, obj)
 	}
@@ -618,6 +852,11 @@ func (r *FeatureFlagEnvironmentResource) readIntoModel(ctx context.Context, proj
 		diags.Append(d...)
 		data.Prerequisites = prereqList
 	}
+
+	// LaunchDarkly only stores indexes, so variation references are kept as
+	// configured.
+	*data = ffeWithPriorVariationRefs(ctx, prior, *data, diags)
+	return true
 }
 
 // noopDiagSink absorbs diags from ffe* helpers that take a sink
@@ -627,15 +866,24 @@ type noopDiagSink struct{}
 
 func (noopDiagSink) AddError(string, string) {}
 
+// ffeRuleRef is the identifier the ref attribute exposes: the rule's ref, or
+// its ID for rules without one.
+func ffeRuleRef(r ldapi.Rule) string {
+	if ref := r.GetRef(); ref != "" {
+		return ref
+	}
+	return r.GetId()
+}
+
 // ffeResourceRulesValue emits null for Optional-only attributes the
 // user did not configure: variation is null when a rollout is present,
-// bucket_by / context_kind are null when not a rollout, description is
-// null when nil. The data-source-side ffeRulesValue emits zero values
+// bucket_by / context_kind / seed / experiment_allocation are null when not
+// a rollout, description is null when nil. The data-source-side ffeRulesValue emits zero values
 // instead — fine for Computed-only data source attrs but would trip
 // the plan-apply consistency check on the resource's Optional-only
 // attrs.
 func ffeResourceRulesValue(ctx context.Context, rules []ldapi.Rule, diags *diag.Diagnostics) types.List {
-	objectType := types.ObjectType{AttrTypes: ffeRuleAttrTypes}
+	objectType := types.ObjectType{AttrTypes: ffeResourceRuleAttrTypes}
 	elements := make([]attr.Value, 0, len(rules))
 	for _, r := range rules {
 		clauses, d := frameworkClausesValue(ctx, r.Clauses)
@@ -674,14 +922,23 @@ func ffeResourceRulesValue(ctx context.Context, rules []ldapi.Rule, diags *diag.
 		if r.Description != nil {
 			description = types.StringValue(*r.Description)
 		}
-		obj, d := types.ObjectValue(ffeRuleAttrTypes, map[string]attr.Value{
-			DESCRIPTION:     description,
-			CLAUSES:         clauses,
-			VARIATION:       variation,
-			ROLLOUT_WEIGHTS: weights,
-			BUCKET_BY:       bucketBy,
-			CONTEXT_KIND:    contextKind,
-		})
+		ref := types.StringNull()
+		if id := ffeRuleRef(r); id != "" {
+			ref = types.StringValue(id)
+		}
+		seed, allocation := ffeRolloutExperimentValues(r.Rollout, diags)
+		obj, d := types.ObjectValue(ffeResourceRuleAttrTypes, withNullVariationRefs(map[string]attr.Value{
+			DESCRIPTION:           description,
+			CLAUSES:               clauses,
+			VARIATION:             variation,
+			ROLLOUT_WEIGHTS:       weights,
+			BUCKET_BY:             bucketBy,
+			CONTEXT_KIND:          contextKind,
+			SEED:                  seed,
+			EXPERIMENT_ALLOCATION: allocation,
+			TRACK_EVENTS:          types.BoolValue(r.TrackEvents),
+			REF:                   ref,
+		}, true))
 		diags.Append(d...)
 		elements = append(elements, obj)
 	}
@@ -700,7 +957,7 @@ func ffeResourceRulesValue(ctx context.Context, rules []ldapi.Rule, diags *diag.
 // plan; Read must emit matching values).
 func ffeResourceFallthroughValue(_ context.Context, fallthroughRep *ldapi.VariationOrRolloutRep, diags *diag.Diagnostics) types.Object {
 	if fallthroughRep == nil {
-		return types.ObjectNull(ffeFallthroughAttrTypes)
+		return types.ObjectNull(ffeResourceFallthroughAttrTypes)
 	}
 	variation := types.Int64Value(0)
 	bucketBy := types.StringNull()
@@ -724,18 +981,40 @@ func ffeResourceFallthroughValue(_ context.Context, fallthroughRep *ldapi.Variat
 	if fallthroughRep.Variation != nil {
 		variation = types.Int64Value(int64(*fallthroughRep.Variation))
 	}
-	obj, d := types.ObjectValue(ffeFallthroughAttrTypes, map[string]attr.Value{
-		VARIATION:       variation,
-		ROLLOUT_WEIGHTS: weights,
-		BUCKET_BY:       bucketBy,
-		CONTEXT_KIND:    contextKind,
-	})
+	seed, allocation := ffeRolloutExperimentValues(fallthroughRep.Rollout, diags)
+	obj, d := types.ObjectValue(ffeResourceFallthroughAttrTypes, withNullVariationRefs(map[string]attr.Value{
+		VARIATION:             variation,
+		ROLLOUT_WEIGHTS:       weights,
+		BUCKET_BY:             bucketBy,
+		CONTEXT_KIND:          contextKind,
+		SEED:                  seed,
+		EXPERIMENT_ALLOCATION: allocation,
+	}, true))
 	diags.Append(d...)
 	return obj
 }
 
 // ffePatchPath returns the JSON-Pointer path for an environment-scoped
 // attribute of a feature flag (e.g. /environments/<envKey>/on).
+// ffeResetPatches returns the patches that reset every setting of the
+// environment envKey when the resource is destroyed: targeting off and
+// emptied, the off variation set to offVariation and the fallthrough set to
+// the first variation.
+func ffeResetPatches(envKey string, offVariation int32) []ldapi.PatchOperation {
+	zeroVar := int32(0)
+	return []ldapi.PatchOperation{
+		patchReplace(ffePatchPath(envKey, "on"), false),
+		patchReplace(ffePatchPath(envKey, "rules"), []ldapi.Rule{}),
+		patchReplace(ffePatchPath(envKey, "trackEvents"), false),
+		patchReplace(ffePatchPath(envKey, "trackEventsFallthrough"), false),
+		patchReplace(ffePatchPath(envKey, "prerequisites"), []ldapi.Prerequisite{}),
+		patchReplace(ffePatchPath(envKey, "offVariation"), offVariation),
+		patchReplace(ffePatchPath(envKey, "targets"), []ldapi.Target{}),
+		patchReplace(ffePatchPath(envKey, "contextTargets"), []ldapi.Target{}),
+		patchReplace(ffePatchPath(envKey, "fallthrough"), ffeFallthroughPayload{Variation: &zeroVar}),
+	}
+}
+
 func ffePatchPath(envKey, op string) string {
 	return "/environments/" + envKey + "/" + op
 }
@@ -747,13 +1026,13 @@ type ffeFallthroughPayload struct {
 	Rollout   *ldapi.Rollout `json:"rollout,omitempty"`
 }
 
-// buildFFEPatches assembles the JSON-Patch document applied at
-// Create/Update. Each attribute is patched only when it differs from
-// state (or unconditionally on create).
+// buildFFEPatches assembles the JSON-Patch document applied at Create,
+// which takes over the whole environment configuration. Each attribute is
+// patched only when it differs from state (or unconditionally on create).
+// Update sends semantic-patch instructions instead; see buildFFEInstructions.
 // offVariationLiveSet reports whether the live environment currently has an
 // offVariation. The caller supplies it because a JSON Patch remove of an
-// absent path returns 400 invalid_patch from LaunchDarkly: Update derives it
-// from the post-refresh state, Create reads it from the API.
+// absent path returns 400 invalid_patch from LaunchDarkly.
 func buildFFEPatches(ctx context.Context, envKey string, plan, state FeatureFlagEnvironmentResourceModel, isCreate, offVariationLiveSet bool) ([]ldapi.PatchOperation, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	patches := make([]ldapi.PatchOperation, 0)
@@ -815,6 +1094,17 @@ func buildFFEPatches(ctx context.Context, envKey string, plan, state FeatureFlag
 		patches = append(patches, patchReplace(ffePatchPath(envKey, "contextTargets"), ctxTargets))
 	}
 
+	// track_events_fallthrough is unknown when the configuration leaves it
+	// out on create, and LaunchDarkly's value is kept.
+	known := !plan.TrackEventsFallthrough.IsNull() && !plan.TrackEventsFallthrough.IsUnknown()
+	if known && (isCreate || !plan.TrackEventsFallthrough.Equal(state.TrackEventsFallthrough)) {
+		patches = append(patches, patchReplace(ffePatchPath(envKey, "trackEventsFallthrough"), plan.TrackEventsFallthrough.ValueBool()))
+	}
+
+	// fallthrough is only null when managed_fields leaves it out.
+	if plan.Fallthrough.IsNull() {
+		return patches, diags
+	}
 	fall, d := ffeFallthroughFromObject(ctx, plan.Fallthrough)
 	diags.Append(d...)
 	if diags.HasError() {
@@ -833,6 +1123,8 @@ type ffeRulePayload struct {
 	Variation   *int32         `json:"variation,omitempty"`
 	Rollout     *ldapi.Rollout `json:"rollout,omitempty"`
 	Clauses     []ldapi.Clause `json:"clauses,omitempty"`
+	TrackEvents *bool          `json:"trackEvents,omitempty"`
+	Ref         *string        `json:"ref,omitempty"`
 }
 
 func ffeRulesFromList(ctx context.Context, list types.List) ([]ffeRulePayload, diag.Diagnostics) {
@@ -841,12 +1133,20 @@ func ffeRulesFromList(ctx context.Context, list types.List) ([]ffeRulePayload, d
 		return []ffeRulePayload{}, diags
 	}
 	type ruleModel struct {
-		Description    types.String `tfsdk:"description"`
-		Variation      types.Int64  `tfsdk:"variation"`
-		BucketBy       types.String `tfsdk:"bucket_by"`
-		ContextKind    types.String `tfsdk:"context_kind"`
-		RolloutWeights types.List   `tfsdk:"rollout_weights"`
-		Clauses        types.List   `tfsdk:"clauses"`
+		Description          types.String `tfsdk:"description"`
+		Variation            types.Int64  `tfsdk:"variation"`
+		BucketBy             types.String `tfsdk:"bucket_by"`
+		ContextKind          types.String `tfsdk:"context_kind"`
+		RolloutWeights       types.List   `tfsdk:"rollout_weights"`
+		Seed                 types.Int64  `tfsdk:"seed"`
+		ExperimentAllocation types.Object `tfsdk:"experiment_allocation"`
+		TrackEvents          types.Bool   `tfsdk:"track_events"`
+		Ref                  types.String `tfsdk:"ref"`
+		Clauses              types.List   `tfsdk:"clauses"`
+		VariationValue       types.String `tfsdk:"variation_value"`
+		VariationName        types.String `tfsdk:"variation_name"`
+		Rollout              types.Map    `tfsdk:"rollout"`
+		RolloutPercentages   types.List   `tfsdk:"rollout_percentages"`
 	}
 	var models []ruleModel
 	d := list.ElementsAs(ctx, &models, false)
@@ -874,9 +1174,19 @@ func ffeRulesFromList(ctx context.Context, list types.List) ([]ffeRulePayload, d
 			diags.AddError("rules: cannot use context_kind argument with variation, only with rollout_weights", "")
 			return nil, diags
 		}
+		checkRolloutExperiment("rules", hasRollout, m.Seed, m.ExperimentAllocation, &diags)
+		if diags.HasError() {
+			return nil, diags
+		}
 		p := ffeRulePayload{Clauses: clauses}
 		descStr := m.Description.ValueString()
 		p.Description = &descStr
+		if ref := m.Ref.ValueString(); ref != "" {
+			p.Ref = &ref
+		}
+		if !m.TrackEvents.IsNull() && !m.TrackEvents.IsUnknown() {
+			p.TrackEvents = m.TrackEvents.ValueBoolPointer()
+		}
 		if hasRollout {
 			rollout := &ldapi.Rollout{
 				Variations: make([]ldapi.WeightedVariation, 0, len(weights)),
@@ -893,6 +1203,7 @@ func ffeRulesFromList(ctx context.Context, list types.List) ([]ffeRulePayload, d
 			if ck != "" {
 				rollout.ContextKind = &ck
 			}
+			diags.Append(withRolloutExperiment(ctx, rollout, m.Seed, m.ExperimentAllocation)...)
 			p.Rollout = rollout
 		} else {
 			v := int32(m.Variation.ValueInt64())
@@ -909,8 +1220,10 @@ func ffePrerequisitesFromList(ctx context.Context, list types.List) ([]ldapi.Pre
 		return []ldapi.Prerequisite{}, diags
 	}
 	type prereqModel struct {
-		FlagKey   types.String `tfsdk:"flag_key"`
-		Variation types.Int64  `tfsdk:"variation"`
+		FlagKey        types.String `tfsdk:"flag_key"`
+		Variation      types.Int64  `tfsdk:"variation"`
+		VariationValue types.String `tfsdk:"variation_value"`
+		VariationName  types.String `tfsdk:"variation_name"`
 	}
 	var models []prereqModel
 	d := list.ElementsAs(ctx, &models, false)
@@ -928,6 +1241,36 @@ func ffePrerequisitesFromList(ctx context.Context, list types.List) ([]ldapi.Pre
 	return out, diags
 }
 
+// ffeTargetsInOrder returns targets with the values of each target in the
+// order of the matching target of prior, followed by the values prior lacks.
+// LaunchDarkly appends added values, so this reads a target back in the
+// order it is configured in.
+func ffeTargetsInOrder(ctx context.Context, targets []ldapi.Target, prior types.Set, isContextTarget bool) []ldapi.Target {
+	priorTargets, _ := ffeTargetsFromSet(ctx, prior, isContextTarget)
+	if len(priorTargets) == 0 {
+		return targets
+	}
+	key := func(t ldapi.Target) ffeTargetKey {
+		k := ffeTargetKey{variation: t.Variation}
+		if isContextTarget {
+			k.contextKind = t.GetContextKind()
+		}
+		return k
+	}
+	order := make(map[ffeTargetKey][]string)
+	for _, t := range priorTargets {
+		order[key(t)] = append(order[key(t)], t.Values...)
+	}
+	out := make([]ldapi.Target, 0, len(targets))
+	for _, t := range targets {
+		kept, _ := partitionStrings(order[key(t)], t.Values)
+		_, others := partitionStrings(t.Values, order[key(t)])
+		t.Values = append(kept, others...)
+		out = append(out, t)
+	}
+	return out
+}
+
 func ffeTargetsFromSet(ctx context.Context, set types.Set, isContextTarget bool) ([]ldapi.Target, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	if set.IsNull() || set.IsUnknown() {
@@ -989,10 +1332,16 @@ func ffeFallthroughFromObject(ctx context.Context, obj types.Object) (ffeFallthr
 		return ffeFallthroughPayload{}, diags
 	}
 	type fallthroughModel struct {
-		Variation      types.Int64  `tfsdk:"variation"`
-		BucketBy       types.String `tfsdk:"bucket_by"`
-		ContextKind    types.String `tfsdk:"context_kind"`
-		RolloutWeights types.List   `tfsdk:"rollout_weights"`
+		Variation            types.Int64  `tfsdk:"variation"`
+		BucketBy             types.String `tfsdk:"bucket_by"`
+		ContextKind          types.String `tfsdk:"context_kind"`
+		RolloutWeights       types.List   `tfsdk:"rollout_weights"`
+		Seed                 types.Int64  `tfsdk:"seed"`
+		ExperimentAllocation types.Object `tfsdk:"experiment_allocation"`
+		VariationValue       types.String `tfsdk:"variation_value"`
+		VariationName        types.String `tfsdk:"variation_name"`
+		Rollout              types.Map    `tfsdk:"rollout"`
+		RolloutPercentages   types.List   `tfsdk:"rollout_percentages"`
 	}
 	var m fallthroughModel
 	d := obj.As(ctx, &m, basetypes.ObjectAsOptions{})
@@ -1012,6 +1361,10 @@ func ffeFallthroughFromObject(ctx context.Context, obj types.Object) (ffeFallthr
 			diags.AddError("flag fallthrough: cannot use bucket_by argument with variation, only with rollout_weights", "")
 			return ffeFallthroughPayload{}, diags
 		}
+		checkRolloutExperiment("flag fallthrough", false, m.Seed, m.ExperimentAllocation, &diags)
+		if diags.HasError() {
+			return ffeFallthroughPayload{}, diags
+		}
 		v := int32(m.Variation.ValueInt64())
 		return ffeFallthroughPayload{Variation: &v}, diags
 	}
@@ -1030,6 +1383,7 @@ func ffeFallthroughFromObject(ctx context.Context, obj types.Object) (ffeFallthr
 	if ck != "" {
 		rollout.ContextKind = &ck
 	}
+	diags.Append(withRolloutExperiment(ctx, rollout, m.Seed, m.ExperimentAllocation)...)
 	return ffeFallthroughPayload{Rollout: rollout}, diags
 }
 
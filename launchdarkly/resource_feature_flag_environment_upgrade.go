@@ -35,6 +35,12 @@ type FeatureFlagEnvironmentResourceModelV0 struct {
 // decodes. All other attributes are unchanged from the current schema.
 func featureFlagEnvironmentSchemaAttributesV0() map[string]schema.Attribute {
 	attrs := featureFlagEnvironmentSchemaAttributes()
+	delete(attrs, APPROVAL_MODE)
+	delete(attrs, COMMENT)
+	delete(attrs, ACKNOWLEDGE_CRITICAL)
+	delete(attrs, MANAGED_FIELDS)
+	delete(attrs, OFF_VARIATION_VALUE)
+	delete(attrs, OFF_VARIATION_NAME)
 	attrs[FALLTHROUGH] = schema.ListNestedAttribute{
 		Required: true,
 		NestedObject: schema.NestedAttributeObject{
@@ -55,7 +61,7 @@ func featureFlagEnvironmentSchemaAttributesV0() map[string]schema.Attribute {
 func ffeFallthroughObjectFromV0List(ctx context.Context, l types.List) (types.Object, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	if l.IsNull() || l.IsUnknown() || len(l.Elements()) == 0 {
-		return types.ObjectNull(ffeFallthroughAttrTypes), diags
+		return types.ObjectNull(ffeResourceFallthroughAttrTypes), diags
 	}
 	type fallthroughModel struct {
 		Variation      types.Int64  `tfsdk:"variation"`
@@ -66,19 +72,19 @@ func ffeFallthroughObjectFromV0List(ctx context.Context, l types.List) (types.Ob
 	var models []fallthroughModel
 	diags.Append(l.ElementsAs(ctx, &models, false)...)
 	if diags.HasError() || len(models) == 0 {
-		return types.ObjectNull(ffeFallthroughAttrTypes), diags
+		return types.ObjectNull(ffeResourceFallthroughAttrTypes), diags
 	}
 	m := models[0]
 	weights := m.RolloutWeights
 	if weights.IsNull() || weights.IsUnknown() {
 		weights = types.ListNull(types.Int64Type)
 	}
-	obj, d := types.ObjectValue(ffeFallthroughAttrTypes, map[string]attr.Value{
+	obj, d := types.ObjectValue(ffeResourceFallthroughAttrTypes, withNullExperimentSettings(withNullVariationRefs(map[string]attr.Value{
 		VARIATION:       m.Variation,
 		BUCKET_BY:       m.BucketBy,
 		CONTEXT_KIND:    m.ContextKind,
 		ROLLOUT_WEIGHTS: weights,
-	})
+	}, true), false))
 	diags.Append(d...)
 	return obj, diags
 }
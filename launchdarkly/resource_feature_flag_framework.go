@@ -53,6 +53,7 @@ type FeatureFlagResourceModel struct {
 	MaintainerID           types.String `tfsdk:"maintainer_id"`
 	MaintainerTeamKey      types.String `tfsdk:"maintainer_team_key"`
 	Tags                   types.Set    `tfsdk:"tags"`
+	TagsAll                types.Set    `tfsdk:"tags_all"`
 	VariationType          types.String `tfsdk:"variation_type"`
 	Variations             types.List   `tfsdk:"variations"`
 	Temporary              types.Bool   `tfsdk:"temporary"`
@@ -62,6 +63,10 @@ type FeatureFlagResourceModel struct {
 	Archived               types.Bool   `tfsdk:"archived"`
 	Deprecated             types.Bool   `tfsdk:"deprecated"`
 	ViewKeys               types.Set    `tfsdk:"view_keys"`
+	Comment                types.String `tfsdk:"comment"`
+	Environments           types.Map    `tfsdk:"environments"`
+	AcknowledgeCritical    types.Bool   `tfsdk:"acknowledge_critical"`
+	MigrationSettings      types.Object `tfsdk:"migration_settings"`
 }
 
 var (
@@ -155,6 +160,7 @@ func featureFlagSchemaAttributes() map[string]schema.Attribute {
 			Validators:  []validator.Set{setvalidator.ValueStringsAre(tagValidator())},
 			Description: "Tags associated with your resource.",
 		},
+		TAGS_ALL: tagsAllAttribute(),
 		ARCHIVED: schema.BoolAttribute{
 			Optional:    true,
 			Computed:    true,
@@ -175,9 +181,14 @@ func featureFlagSchemaAttributes() map[string]schema.Attribute {
 			Validators:    []validator.Set{setvalidator.ValueStringsAre(viewKeyValidator())},
 			PlanModifiers: []planmodifier.Set{setplanmodifier.UseStateForUnknown()},
 		},
+		COMMENT:              resourceCommentAttribute(),
+		ENVIRONMENTS:         flagEnvironmentsAttribute(),
+		ACKNOWLEDGE_CRITICAL: acknowledgeCriticalAttribute(),
+		MIGRATION_SETTINGS:   migrationSettingsAttribute(),
 		VARIATIONS: schema.ListNestedAttribute{
-			Required:    true,
-			Description: "An array of possible variations for the flag.",
+			Optional:    true,
+			Computed:    true,
+			Description: "An array of possible variations for the flag. Required unless `migration_settings` is set.",
 			Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
 			NestedObject: schema.NestedAttributeObject{
 				Attributes: map[string]schema.Attribute{
@@ -252,18 +263,30 @@ func featureFlagSchemaAttributes() map[string]schema.Attribute {
 		},
 		DEFAULTS: schema.SingleNestedAttribute{
 			Optional:    true,
-			Description: "The indices of the variations to use as the default on and off variations in all new environments. The provider does not change flag configurations in existing environments if you remove this field.",
+			Description: "The variations to use as the default on and off variations in all new environments, by index, value or name. The provider does not change flag configurations in existing environments if you remove this field.",
 			Attributes: map[string]schema.Attribute{
 				ON_VARIATION: schema.Int64Attribute{
-					Required:    true,
-					Validators:  []validator.Int64{int64validator.AtLeast(0)},
-					Description: "The index of the variation the flag defaults to in all new environments when on.",
+					Optional: true,
+					Computed: true,
+					Validators: []validator.Int64{
+						int64validator.AtLeast(0),
+						int64validator.ExactlyOneOf(siblings(ON_VARIATION_VALUE, ON_VARIATION_NAME)...),
+					},
+					Description: "The index of the variation the flag defaults to in all new environments when on. You must specify exactly one of `on_variation`, `on_variation_value` or `on_variation_name`.",
 				},
+				ON_VARIATION_VALUE: variationValueAttribute(ON_VARIATION),
+				ON_VARIATION_NAME:  variationNameAttribute(ON_VARIATION),
 				OFF_VARIATION: schema.Int64Attribute{
-					Required:    true,
-					Validators:  []validator.Int64{int64validator.AtLeast(0)},
-					Description: "The index of the variation the flag defaults to in all new environments when off.",
+					Optional: true,
+					Computed: true,
+					Validators: []validator.Int64{
+						int64validator.AtLeast(0),
+						int64validator.ExactlyOneOf(siblings(OFF_VARIATION_VALUE, OFF_VARIATION_NAME)...),
+					},
+					Description: "The index of the variation the flag defaults to in all new environments when off. You must specify exactly one of `off_variation`, `off_variation_value` or `off_variation_name`.",
 				},
+				OFF_VARIATION_VALUE: variationValueAttribute(OFF_VARIATION),
+				OFF_VARIATION_NAME:  variationNameAttribute(OFF_VARIATION),
 			},
 		},
 	}
@@ -281,8 +304,12 @@ func featureFlagDefaultsMatchesAPIShape(ctx context.Context, defaults types.Obje
 		return false
 	}
 	type defaultsItem struct {
-		OnVariation  int64 `tfsdk:"on_variation"`
-		OffVariation int64 `tfsdk:"off_variation"`
+		OnVariation       int64        `tfsdk:"on_variation"`
+		OnVariationValue  types.String `tfsdk:"on_variation_value"`
+		OnVariationName   types.String `tfsdk:"on_variation_name"`
+		OffVariation      int64        `tfsdk:"off_variation"`
+		OffVariationValue types.String `tfsdk:"off_variation_value"`
+		OffVariationName  types.String `tfsdk:"off_variation_name"`
 	}
 	var item defaultsItem
 	d := defaults.As(ctx, &item, basetypes.ObjectAsOptions{})
@@ -314,8 +341,14 @@ func (r *FeatureFlagResource) UpgradeState(_ context.Context) map[int64]resource
 	priorSchema := schema.Schema{Attributes: featureFlagSchemaAttributesV0()}
 	// v1 (3.0.0-beta) state differs from v2 (current) only in the
 	// custom_properties shape: a set whose elements carried the property
-	// key inline, re-keyed here into the map.
+	// key inline, re-keyed here into the map. Attributes added since are
+	// dropped so the prior state decodes into FeatureFlagResourceModelV1.
 	v1Attrs := featureFlagSchemaAttributes()
+	delete(v1Attrs, COMMENT)
+	delete(v1Attrs, TAGS_ALL)
+	delete(v1Attrs, ENVIRONMENTS)
+	delete(v1Attrs, ACKNOWLEDGE_CRITICAL)
+	delete(v1Attrs, MIGRATION_SETTINGS)
 	v1Attrs[CUSTOM_PROPERTIES] = customPropertiesSetAttributeV0()
 	v1Schema := schema.Schema{Attributes: v1Attrs}
 	return map[int64]resource.StateUpgrader{
@@ -341,6 +374,7 @@ func (r *FeatureFlagResource) UpgradeState(_ context.Context) map[int64]resource
 					MaintainerID:           prior.MaintainerID,
 					MaintainerTeamKey:      prior.MaintainerTeamKey,
 					Tags:                   prior.Tags,
+					TagsAll:                types.SetNull(types.StringType),
 					VariationType:          prior.VariationType,
 					Variations:             prior.Variations,
 					Temporary:              prior.Temporary,
@@ -350,6 +384,8 @@ func (r *FeatureFlagResource) UpgradeState(_ context.Context) map[int64]resource
 					Archived:               prior.Archived,
 					Deprecated:             prior.Deprecated,
 					ViewKeys:               prior.ViewKeys,
+					Environments:           types.MapNull(flagEnvironmentObjectType),
+					MigrationSettings:      types.ObjectNull(migrationSettingsAttrTypes),
 				}
 				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 			},
@@ -385,6 +421,7 @@ func (r *FeatureFlagResource) UpgradeState(_ context.Context) map[int64]resource
 					MaintainerID:           prior.MaintainerID,
 					MaintainerTeamKey:      prior.MaintainerTeamKey,
 					Tags:                   nullIfEmptySet(ctx, prior.Tags),
+					TagsAll:                types.SetNull(types.StringType),
 					VariationType:          prior.VariationType,
 					Variations:             prior.Variations,
 					Temporary:              prior.Temporary,
@@ -394,9 +431,11 @@ func (r *FeatureFlagResource) UpgradeState(_ context.Context) map[int64]resource
 					Archived:               prior.Archived,
 					Deprecated:             prior.Deprecated,
 					ViewKeys:               nullIfEmptySet(ctx, prior.ViewKeys),
+					Environments:           types.MapNull(flagEnvironmentObjectType),
+					MigrationSettings:      types.ObjectNull(migrationSettingsAttrTypes),
 				}
 				if featureFlagDefaultsMatchesAPIShape(ctx, data.Defaults, data.Variations) {
-					data.Defaults = types.ObjectNull(featureFlagDefaultsAttrTypes)
+					data.Defaults = types.ObjectNull(featureFlagResourceDefaultsAttrTypes)
 				}
 				// IIS->CSA migration: when prior state set include_in_snippet
 				// and left client_side_availability empty, materialize the
@@ -440,6 +479,8 @@ func (r *FeatureFlagResource) Configure(_ context.Context, req resource.Configur
 // * Whether project requires view association on flag creation
 // * Whether flag has dependent flags on flag deletion
 func (r *FeatureFlagResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.client.planGuardrails(ctx, req, resp, guardrailScope{project: PROJECT_KEY})
+	r.client.planEnvironmentMapGuardrails(ctx, req, resp, PROJECT_KEY, false)
 	if r.client == nil {
 		return
 	}
@@ -485,6 +526,7 @@ func (r *FeatureFlagResource) ModifyPlan(ctx context.Context, req resource.Modif
 	if req.Plan.Raw.IsNull() {
 		return
 	}
+	defer r.client.planTagsAll(ctx, req, resp)
 	// Pin each planned custom property's Optional+Computed `key` to its
 	// map key. For a new map entry whose config omits `key`, the
 	// framework plans it as null and Read then fills it in, tripping the
@@ -499,6 +541,11 @@ func (r *FeatureFlagResource) ModifyPlan(ctx context.Context, req resource.Modif
 	if !resp.Diagnostics.HasError() && !pinned.Equal(planModel.CustomProperties) {
 		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(CUSTOM_PROPERTIES), pinned)...)
 	}
+	r.planMigrationVariations(ctx, req, resp)
+	planDefaultsVariationRefs(ctx, req, resp)
+	r.planVariationMoves(ctx, req, resp)
+	r.planFlagEnvironments(ctx, req, resp)
+	r.client.planCriticalFlagEnvironments(ctx, req, resp)
 	if !req.State.Raw.IsNull() {
 		return
 	}
@@ -532,6 +579,9 @@ func (r *FeatureFlagResource) ModifyPlan(ctx context.Context, req resource.Modif
 }
 
 func (r *FeatureFlagResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_feature_flag", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan FeatureFlagResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -573,7 +623,7 @@ func (r *FeatureFlagResource) Create(ctx context.Context, req resource.CreateReq
 	}
 
 	desc := plan.Description.ValueString()
-	tags, d := stringSliceFromSet(ctx, plan.Tags)
+	tags, d := stringSliceFromSet(ctx, plan.TagsAll)
 	resp.Diagnostics.Append(d...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -589,6 +639,19 @@ func (r *FeatureFlagResource) Create(ctx context.Context, req resource.CreateReq
 		t, f := true, false
 		variations = []ldapi.Variation{{Value: &t}, {Value: &f}}
 	}
+	// LaunchDarkly creates a migration flag's variations from its stages.
+	migrationSettings, d := migrationSettingsPost(ctx, plan.MigrationSettings)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var purpose string
+	if migrationSettings != nil {
+		purpose = MIGRATION_FLAG_PURPOSE
+		variations = nil
+	}
+	plan.Defaults = applyDefaultsVariationRefs(ctx, req.Config, plan.Defaults, plan.Variations, &resp.Diagnostics)
+	plan.Environments = r.client.applyFlagEnvironmentRefs(ctx, req.Config, plan, &resp.Diagnostics)
 	defaults, d := defaultsFromObject(ctx, plan.Defaults)
 	resp.Diagnostics.Append(d...)
 	if resp.Diagnostics.HasError() {
@@ -647,6 +710,8 @@ func (r *FeatureFlagResource) Create(ctx context.Context, req resource.CreateReq
 			Defaults:               defaults,
 			ClientSideAvailability: finalCSA,
 			ViewKeys:               viewKeys,
+			Purpose:                purpose,
+			MigrationSettings:      migrationSettings,
 		}
 		err = r.client.withConcurrency(ctx, func() error {
 			return createFeatureFlagWithViewKeys(ctx, r.client, projectKey, body)
@@ -661,6 +726,10 @@ func (r *FeatureFlagResource) Create(ctx context.Context, req resource.CreateReq
 			Tags:                   tags,
 			Defaults:               defaults,
 			ClientSideAvailability: finalCSA,
+			MigrationSettings:      migrationSettings,
+		}
+		if purpose != "" {
+			body.Purpose = &purpose
 		}
 		err = r.client.withConcurrency(ctx, func() error {
 			_, _, e := r.client.ld.FeatureFlagsApi.PostFeatureFlag(r.client.ctx, projectKey).FeatureFlagBody(body).Execute()
@@ -695,6 +764,12 @@ func (r *FeatureFlagResource) Read(ctx context.Context, req resource.ReadRequest
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	importing, d := req.Private.GetKey(ctx, flagImportEnvironmentsPrivateKey)
+	resp.Diagnostics.Append(d...)
+	if importing != nil {
+		data.Environments = types.MapUnknown(flagEnvironmentObjectType)
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, flagImportEnvironmentsPrivateKey, nil)...)
+	}
 	r.readIntoModel(ctx, &data, &resp.Diagnostics)
 	if data.ID.IsNull() {
 		resp.State.RemoveResource(ctx)
@@ -707,9 +782,14 @@ func (r *FeatureFlagResource) Read(ctx context.Context, req resource.ReadRequest
 }
 
 func (r *FeatureFlagResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_feature_flag", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan, state FeatureFlagResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	plan.Defaults = applyDefaultsVariationRefs(ctx, req.Config, plan.Defaults, plan.Variations, &resp.Diagnostics)
+	plan.Environments = r.client.applyFlagEnvironmentRefs(ctx, req.Config, plan, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -726,6 +806,9 @@ func (r *FeatureFlagResource) Update(ctx context.Context, req resource.UpdateReq
 }
 
 func (r *FeatureFlagResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_feature_flag", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data FeatureFlagResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -737,7 +820,10 @@ func (r *FeatureFlagResource) Delete(ctx context.Context, req resource.DeleteReq
 	if r.client.archiveFlagsOnDestroy {
 		patch := []ldapi.PatchOperation{patchReplace("/archived", true)}
 		err := r.client.withConcurrency(ctx, func() error {
-			_, _, e := r.client.ld.FeatureFlagsApi.PatchFeatureFlag(r.client.ctx, projectKey, key).PatchWithComment(ldapi.PatchWithComment{Patch: patch}).Execute()
+			_, _, e := r.client.ld.FeatureFlagsApi.PatchFeatureFlag(r.client.ctx, projectKey, key).PatchWithComment(ldapi.PatchWithComment{
+				Comment: ldapi.PtrString(r.client.changeCommentFor(data.Comment)),
+				Patch:   patch,
+			}).Execute()
 			return e
 		})
 		if err != nil {
@@ -756,14 +842,18 @@ func (r *FeatureFlagResource) Delete(ctx context.Context, req resource.DeleteReq
 }
 
 func (r *FeatureFlagResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	projectKey, flagKey, err := flagIdToKeys(req.ID)
+	id, withEnvironments := strings.CutSuffix(req.ID, flagImportEnvironmentsSuffix)
+	projectKey, flagKey, err := flagIdToKeys(id)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid import ID", err.Error())
 		return
 	}
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(PROJECT_KEY), projectKey)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(KEY), flagKey)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	if withEnvironments {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, flagImportEnvironmentsPrivateKey, []byte("true"))...)
+	}
 }
 
 func (r *FeatureFlagResource) applyFlagUpdate(ctx context.Context, plan, state FeatureFlagResourceModel, isCreate bool) diag.Diagnostics {
@@ -772,7 +862,7 @@ func (r *FeatureFlagResource) applyFlagUpdate(ctx context.Context, plan, state F
 	key := plan.Key.ValueString()
 	desc := plan.Description.ValueString()
 
-	tags, d := stringSliceFromSet(ctx, plan.Tags)
+	tags, d := stringSliceFromSet(ctx, plan.TagsAll)
 	diags.Append(d...)
 	customProps, d := customPropertiesFromMap(ctx, plan.CustomProperties)
 	diags.Append(d...)
@@ -780,7 +870,7 @@ func (r *FeatureFlagResource) applyFlagUpdate(ctx context.Context, plan, state F
 		return diags
 	}
 
-	comment := "Terraform"
+	comment := r.client.changeCommentFor(plan.Comment)
 	patch := ldapi.PatchWithComment{
 		Comment: &comment,
 		Patch: []ldapi.PatchOperation{
@@ -812,7 +902,14 @@ func (r *FeatureFlagResource) applyFlagUpdate(ctx context.Context, plan, state F
 		patch.Patch = append(patch.Patch, patchReplace("/clientSideAvailability", csa))
 	}
 
-	if !isCreate {
+	if !plan.MigrationSettings.IsNull() {
+		variationPatches, d := migrationVariationPatches(ctx, plan.Variations)
+		diags.Append(d...)
+		if diags.HasError() {
+			return diags
+		}
+		patch.Patch = append(patch.Patch, variationPatches...)
+	} else if !isCreate {
 		variationType := plan.VariationType.ValueString()
 		variationPatches, d := variationPatchesFromLists(ctx, state.Variations, plan.Variations, variationType)
 		diags.Append(d...)
@@ -828,6 +925,13 @@ func (r *FeatureFlagResource) applyFlagUpdate(ctx context.Context, plan, state F
 		patch.Patch = append(patch.Patch, patchReplace("/defaults", defaults))
 	}
 
+	envPatches, d := flagEnvironmentPatches(ctx, plan.Environments, state.Environments, r.client.flagOffVariationSet(projectKey, key))
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	patch.Patch = append(patch.Patch, envPatches...)
+
 	maintainerChanged := isCreate || !plan.MaintainerID.Equal(state.MaintainerID) || !plan.MaintainerTeamKey.Equal(state.MaintainerTeamKey)
 	if maintainerChanged {
 		flag, _, fErr := r.client.ld.FeatureFlagsApi.GetFeatureFlag(r.client.ctx, projectKey, key).Execute()
@@ -854,7 +958,11 @@ func (r *FeatureFlagResource) applyFlagUpdate(ctx context.Context, plan, state F
 		return e
 	})
 	if err != nil {
-		diags.AddError(fmt.Sprintf("failed to update flag %q in project %q: %s", key, projectKey, handleLdapiErr(err).Error()), "")
+		detail := ""
+		if len(envPatches) > 0 && isApprovalRequiredErr(err) {
+			detail = fmt.Sprintf("An environment in %s requires approval for changes, which %s does not request. Manage that environment with launchdarkly_feature_flag_environment and set its %s instead.", ENVIRONMENTS, ENVIRONMENTS, APPROVAL_MODE)
+		}
+		diags.AddError(fmt.Sprintf("failed to update flag %q in project %q: %s", key, projectKey, handleLdapiErr(err).Error()), detail)
 		return diags
 	}
 
@@ -947,10 +1055,14 @@ func (r *FeatureFlagResource) readIntoModel(ctx context.Context, data *FeatureFl
 	data.Temporary = types.BoolValue(flag.Temporary)
 	data.Archived = types.BoolValue(flag.Archived)
 	data.Deprecated = types.BoolValue(flag.GetDeprecated())
+	data.MigrationSettings = migrationSettingsFromAPI(flag)
 
-	tagsSet, d := setFromStringSlicePreservingPlan(ctx, flag.Tags, data.Tags)
+	ownTags, tagsAll, d := r.client.splitDefaultTags(ctx, flag.Tags, data.Tags)
+	diags.Append(d...)
+	tagsSet, d := setFromStringSlicePreservingPlan(ctx, ownTags, data.Tags)
 	diags.Append(d...)
 	data.Tags = tagsSet
+	data.TagsAll = tagsAll
 
 	csaObj, d := featureFlagCSAObjectFromAPI(ctx, flag.ClientSideAvailability, data.ClientSideAvailability)
 	diags.Append(d...)
@@ -992,6 +1104,9 @@ func (r *FeatureFlagResource) readIntoModel(ctx context.Context, data *FeatureFl
 	diags.Append(d...)
 	data.Defaults = defaultsObj
 
+	// Inline environments, from the same GET.
+	data.Environments = flagEnvironmentsFromAPI(ctx, flag, data.Environments, diags)
+
 	// View associations — best-effort.
 	betaClient, bcErr := r.client.betaClientFromConfig()
 	if bcErr != nil {
@@ -1256,6 +1371,7 @@ func (r *FeatureFlagResource) ValidateConfig(ctx context.Context, req resource.V
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	validateMigrationFlagConfig(ctx, config, &resp.Diagnostics)
 	if config.CustomProperties.IsNull() || config.CustomProperties.IsUnknown() {
 		return
 	}
@@ -1375,8 +1491,12 @@ func defaultsFromObject(ctx context.Context, obj types.Object) (*ldapi.Defaults,
 		return nil, diags
 	}
 	type defaultsModel struct {
-		OnVariation  types.Int64 `tfsdk:"on_variation"`
-		OffVariation types.Int64 `tfsdk:"off_variation"`
+		OnVariation       types.Int64  `tfsdk:"on_variation"`
+		OnVariationValue  types.String `tfsdk:"on_variation_value"`
+		OnVariationName   types.String `tfsdk:"on_variation_name"`
+		OffVariation      types.Int64  `tfsdk:"off_variation"`
+		OffVariationValue types.String `tfsdk:"off_variation_value"`
+		OffVariationName  types.String `tfsdk:"off_variation_name"`
 	}
 	var m defaultsModel
 	d := obj.As(ctx, &m, basetypes.ObjectAsOptions{})
@@ -1392,12 +1512,13 @@ func defaultsFromObject(ctx context.Context, obj types.Object) (*ldapi.Defaults,
 
 // defaultsObjectFromAPI flattens LD-API Defaults into the single-object
 // shape. Mirrors prior-state attribute presence: emit null when the
-// user did not declare `defaults`, populated when they did.
+// user did not declare `defaults`, populated when they did. Variation
+// references are not stored by LaunchDarkly and are kept from prior.
 func defaultsObjectFromAPI(_ context.Context, defaults *ldapi.Defaults, variationCount int, prior types.Object) (types.Object, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	priorEmpty := prior.IsNull() || prior.IsUnknown()
 	if priorEmpty {
-		return types.ObjectNull(featureFlagDefaultsAttrTypes), diags
+		return types.ObjectNull(featureFlagResourceDefaultsAttrTypes), diags
 	}
 	var on, off int64
 	if defaults != nil {
@@ -1410,10 +1531,10 @@ func defaultsObjectFromAPI(_ context.Context, defaults *ldapi.Defaults, variatio
 			off = 0
 		}
 	}
-	obj, d := types.ObjectValue(featureFlagDefaultsAttrTypes, map[string]attr.Value{
-		ON_VARIATION:  types.Int64Value(on),
-		OFF_VARIATION: types.Int64Value(off),
-	})
+	attrs := prior.Attributes()
+	attrs[ON_VARIATION] = types.Int64Value(on)
+	attrs[OFF_VARIATION] = types.Int64Value(off)
+	obj, d := types.ObjectValue(featureFlagResourceDefaultsAttrTypes, attrs)
 	diags.Append(d...)
 	return obj, diags
 }
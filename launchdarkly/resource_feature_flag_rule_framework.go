@@ -0,0 +1,499 @@
+package launchdarkly
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ldapi "github.com/launchdarkly/api-client-go/v24"
+)
+
+// Positions a launchdarkly_feature_flag_rule can keep its rule in.
+const (
+	RULE_POSITION_FIRST = "first"
+	RULE_POSITION_LAST  = "last"
+)
+
+var (
+	_ resource.Resource                     = &FeatureFlagRuleResource{}
+	_ resource.ResourceWithConfigValidators = &FeatureFlagRuleResource{}
+	_ resource.ResourceWithModifyPlan       = &FeatureFlagRuleResource{}
+	_ resource.ResourceWithImportState      = &FeatureFlagRuleResource{}
+)
+
+// FeatureFlagRuleResource manages one targeting rule of a flag in one
+// environment, identified by its ref. Unlike
+// launchdarkly_feature_flag_environment it does not own the environment's
+// rules: rules it does not manage, whether added by another configuration or
+// in the LaunchDarkly UI, keep their content and positions.
+type FeatureFlagRuleResource struct {
+	client *Client
+}
+
+type FeatureFlagRuleResourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	ProjectKey          types.String `tfsdk:"project_key"`
+	EnvKey              types.String `tfsdk:"env_key"`
+	FlagKey             types.String `tfsdk:"flag_key"`
+	Ref                 types.String `tfsdk:"ref"`
+	Description         types.String `tfsdk:"description"`
+	Clauses             types.List   `tfsdk:"clauses"`
+	Variation           types.Int64  `tfsdk:"variation"`
+	RolloutWeights      types.List   `tfsdk:"rollout_weights"`
+	BucketBy            types.String `tfsdk:"bucket_by"`
+	ContextKind         types.String `tfsdk:"context_kind"`
+	Position            types.String `tfsdk:"position"`
+	BeforeRuleRef       types.String `tfsdk:"before_rule_ref"`
+	ApprovalMode        types.String `tfsdk:"approval_mode"`
+	Comment             types.String `tfsdk:"comment"`
+	AcknowledgeCritical types.Bool   `tfsdk:"acknowledge_critical"`
+}
+
+func NewFeatureFlagRuleResource() resource.Resource {
+	return &FeatureFlagRuleResource{}
+}
+
+func (r *FeatureFlagRuleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_feature_flag_rule"
+}
+
+func (r *FeatureFlagRuleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `Provides a LaunchDarkly feature flag rule resource.
+
+This resource allows you to manage a single targeting rule of a feature flag in one environment, without managing the rest of the flag's targeting. The rule is identified by its ` + "`ref`" + `. Other rules, whether managed by another ` + "`launchdarkly_feature_flag_rule`" + ` or in the LaunchDarkly UI, are left alone and do not show up as changes.
+
+-> **Note:** Do not manage the same flag environment's ` + "`rules`" + ` with a ` + "`launchdarkly_feature_flag_environment`" + ` resource as well. That resource owns every rule, so it would remove the rules this resource adds. Use [lifecycle.ignore_changes](https://developer.hashicorp.com/terraform/language/meta-arguments/lifecycle#ignore_changes) on ` + "`rules`" + ` if you need both.`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			PROJECT_KEY: schema.StringAttribute{
+				Required:      true,
+				Description:   addForceNewDescription("The key of the project the flag belongs to.", true),
+				Validators:    []validator.String{keyValidator()},
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			ENV_KEY: schema.StringAttribute{
+				Required:      true,
+				Description:   addForceNewDescription("The key of the environment the rule applies in.", true),
+				Validators:    []validator.String{keyValidator()},
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			FLAG_KEY: schema.StringAttribute{
+				Required:      true,
+				Description:   addForceNewDescription("The key of the feature flag.", true),
+				Validators:    []validator.String{keyValidator()},
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			REF: schema.StringAttribute{
+				Required:      true,
+				Description:   addForceNewDescription("A stable identifier for the rule, unique among the rules of the flag environment. The resource finds its rule by `ref`, so the rule keeps its LaunchDarkly ID and evaluation history when it moves or changes. If a rule with this `ref` already exists, it is updated to match the configuration.", true),
+				Validators:    []validator.String{stringvalidator.LengthAtLeast(1)},
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			DESCRIPTION: schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				Description: "A human-readable description of the targeting rule.",
+			},
+			CLAUSES: frameworkClausesResourceAttribute(),
+			VARIATION: schema.Int64Attribute{
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+				Description: "The integer variation index to serve if the rule clauses evaluate to `true`. You must specify either `variation` or `rollout_weights`.",
+			},
+			ROLLOUT_WEIGHTS: schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.Int64Type,
+				Validators: []validator.List{
+					listvalidator.ValueInt64sAre(int64validator.Between(0, 100000)),
+				},
+				Description: "List of integer percentage rollout weights (in thousandths of a percent) to apply to each variation if the rule clauses evaluates to `true`. The sum of the `rollout_weights` must equal 100000 and the number of rollout weights specified in the array must match the number of flag variations. You must specify either `variation` or `rollout_weights`.",
+			},
+			BUCKET_BY: schema.StringAttribute{
+				Optional:    true,
+				Description: "Group percentage rollout by a custom attribute. This argument is only valid if `rollout_weights` is also specified.",
+			},
+			CONTEXT_KIND: schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("user"),
+				Description: "The context kind associated with the specified rollout. This argument is only valid if `rollout_weights` is also specified. Defaults to `user` if omitted.",
+			},
+			POSITION: schema.StringAttribute{
+				Optional:    true,
+				Description: "Keeps the rule first or last among the flag environment's rules. Must be one of `first` or `last`. If neither `position` nor `before_rule_ref` is set, a new rule is added last and its position is not managed afterwards.",
+				Validators: []validator.String{
+					oneOfValidator{allowed: []string{RULE_POSITION_FIRST, RULE_POSITION_LAST}},
+					stringvalidator.ConflictsWith(path.MatchRoot(BEFORE_RULE_REF)),
+				},
+			},
+			BEFORE_RULE_REF: schema.StringAttribute{
+				Optional:    true,
+				Description: "Keeps the rule ahead of the rule with this `ref`. A rule that is out of place is moved directly before it. Conflicts with `position`.",
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			APPROVAL_MODE:        resourceApprovalModeAttribute(),
+			COMMENT:              resourceCommentAttribute(),
+			ACKNOWLEDGE_CRITICAL: acknowledgeCriticalAttribute(),
+		},
+	}
+}
+
+func (r *FeatureFlagRuleResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot(VARIATION),
+			path.MatchRoot(ROLLOUT_WEIGHTS),
+		),
+	}
+}
+
+func (r *FeatureFlagRuleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = configureResourceClient(req, resp)
+}
+
+func (r *FeatureFlagRuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	scope := guardrailScope{project: PROJECT_KEY, env: ENV_KEY}
+	r.client.planGuardrails(ctx, req, resp, scope)
+	r.client.planCriticalEnvironment(ctx, req, resp, scope)
+}
+
+func (r *FeatureFlagRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_feature_flag_rule", "create", &resp.Diagnostics) {
+		return
+	}
+	var plan FeatureFlagRuleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.apply(ctx, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.readIntoModel(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.ID.IsNull() {
+		resp.Diagnostics.AddError(fmt.Sprintf("rule %q of flag %q not found in environment %q of project %q after create", plan.Ref.ValueString(), plan.FlagKey.ValueString(), plan.EnvKey.ValueString(), plan.ProjectKey.ValueString()), "")
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *FeatureFlagRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data FeatureFlagRuleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.readIntoModel(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.ID.IsNull() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FeatureFlagRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_feature_flag_rule", "update", &resp.Diagnostics) {
+		return
+	}
+	var plan FeatureFlagRuleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.apply(ctx, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.readIntoModel(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.ID.IsNull() {
+		resp.Diagnostics.AddError(fmt.Sprintf("rule %q of flag %q not found in environment %q of project %q after update", plan.Ref.ValueString(), plan.FlagKey.ValueString(), plan.EnvKey.ValueString(), plan.ProjectKey.ValueString()), "")
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *FeatureFlagRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_feature_flag_rule", "destroy", &resp.Diagnostics) {
+		return
+	}
+	var data FeatureFlagRuleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	projectKey, envKey, flagKey := data.ProjectKey.ValueString(), data.EnvKey.ValueString(), data.FlagKey.ValueString()
+	flag, res, err := getFeatureFlagEnvironment(r.client, projectKey, flagKey, envKey)
+	if isStatusNotFound(res) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("failed to get flag %q of project %q: %s", flagKey, projectKey, handleLdapiErr(err).Error()), "")
+		return
+	}
+	rules := ffeLiveRules(flag, envKey)
+	k := findFlagRule(rules, data.Ref.ValueString())
+	if k < 0 {
+		// The rule was already deleted in LaunchDarkly.
+		return
+	}
+	instructions := []map[string]interface{}{{"kind": "removeRule", "ruleId": rules[k].GetId()}}
+	comment := r.client.changeCommentFor(data.Comment)
+	resp.Diagnostics.Append(r.client.applyFlagInstructions(projectKey, envKey, flagKey, r.client.approvalModeFor(data.ApprovalMode), comment, instructions)...)
+}
+
+// apply makes the live rule match data, adding it if no live rule has its
+// ref. Instructions are computed against the live rule rather than the prior
+// state, so a re-run after an approval request was applied has nothing left
+// to request, and only the rule's own position is changed.
+func (r *FeatureFlagRuleResource) apply(ctx context.Context, data FeatureFlagRuleResourceModel, diags *diag.Diagnostics) {
+	projectKey, envKey, flagKey := data.ProjectKey.ValueString(), data.EnvKey.ValueString(), data.FlagKey.ValueString()
+	ref := data.Ref.ValueString()
+	flag, res, err := getFeatureFlagEnvironment(r.client, projectKey, flagKey, envKey)
+	if isStatusNotFound(res) {
+		diags.AddError(fmt.Sprintf("cannot find flag %q in project %q", flagKey, projectKey), "")
+		return
+	}
+	if err != nil {
+		diags.AddError(fmt.Sprintf("failed to get flag %q of project %q: %s", flagKey, projectKey, handleLdapiErr(err).Error()), "")
+		return
+	}
+	if flag.Environments == nil {
+		diags.AddError(fmt.Sprintf("environment %q not found in project %q", envKey, projectKey), "")
+		return
+	}
+	env, ok := (*flag.Environments)[envKey]
+	if !ok {
+		diags.AddError(fmt.Sprintf("environment %q not found in project %q", envKey, projectKey), "")
+		return
+	}
+
+	planned, d := data.ruleObject()
+	diags.Append(d...)
+	if diags.HasError() {
+		return
+	}
+	list, d := types.ListValue(types.ObjectType{AttrTypes: ffeResourceRuleAttrTypes}, []attr.Value{planned})
+	diags.Append(d...)
+	if diags.HasError() {
+		return
+	}
+	payloads, d := ffeRulesFromList(ctx, list)
+	diags.Append(d...)
+	if diags.HasError() {
+		return
+	}
+
+	liveIDs := make([]string, 0, len(env.Rules))
+	for _, rule := range env.Rules {
+		liveIDs = append(liveIDs, rule.GetId())
+	}
+	var beforeID string
+	if before := data.BeforeRuleRef.ValueString(); before != "" {
+		if before == ref {
+			diags.AddError(fmt.Sprintf("%s of rule %q cannot be its own ref", BEFORE_RULE_REF, ref), "")
+			return
+		}
+		j := findFlagRule(env.Rules, before)
+		if j < 0 {
+			diags.AddError(fmt.Sprintf("rule %q of flag %q not found in environment %q of project %q", before, flagKey, envKey, projectKey), fmt.Sprintf("%s must be the ref of an existing rule.", BEFORE_RULE_REF))
+			return
+		}
+		beforeID = liveIDs[j]
+	}
+	position := data.Position.ValueString()
+
+	ids := newFlagVariationIDs(r.client, projectKey)
+	ids.seed(flag)
+	var instructions []map[string]interface{}
+	if k := findFlagRule(env.Rules, ref); k < 0 {
+		if position == RULE_POSITION_FIRST && len(liveIDs) > 0 {
+			beforeID = liveIDs[0]
+		}
+		in, err := ffeAddRuleInstruction(flagKey, payloads[0], beforeID, ids)
+		if err != nil {
+			diags.AddError(err.Error(), "")
+			return
+		}
+		instructions = append(instructions, in)
+	} else {
+		live := ffeResourceRulesValue(ctx, env.Rules[k:k+1], diags)
+		if diags.HasError() {
+			return
+		}
+		updates, err := ffeUpdateRuleInstructions(flagKey, env.Rules[k], planned.Attributes(), live.Elements()[0].(types.Object).Attributes(), payloads[0], ids)
+		if err != nil {
+			diags.AddError(err.Error(), "")
+			return
+		}
+		instructions = append(instructions, updates...)
+		if order := flagRuleOrder(liveIDs, liveIDs[k], position, beforeID); order != nil {
+			instructions = append(instructions, map[string]interface{}{"kind": "reorderRules", "ruleIds": order})
+		}
+	}
+	if len(instructions) == 0 {
+		return
+	}
+
+	comment := r.client.changeCommentFor(data.Comment)
+	diags.Append(r.client.applyFlagInstructions(projectKey, envKey, flagKey, r.client.approvalModeFor(data.ApprovalMode), comment, instructions)...)
+}
+
+func (r *FeatureFlagRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.SplitN(req.ID, "/", 4)
+	if len(parts) != 4 || parts[3] == "" {
+		resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("expected project_key/env_key/flag_key/ref, got %q", req.ID))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(PROJECT_KEY), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(ENV_KEY), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(FLAG_KEY), parts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(REF), parts[3])...)
+}
+
+// readIntoModel refreshes data from the live rule with data's ref. ID is set
+// to null when the flag, the environment or the rule is gone. position and
+// before_rule_ref are not read from LaunchDarkly: they are set to null when
+// the rule is out of place, so the next plan moves it back.
+func (r *FeatureFlagRuleResource) readIntoModel(ctx context.Context, data *FeatureFlagRuleResourceModel, diags *diag.Diagnostics) {
+	projectKey, envKey, flagKey := data.ProjectKey.ValueString(), data.EnvKey.ValueString(), data.FlagKey.ValueString()
+	ref := data.Ref.ValueString()
+	flag, res, err := getFeatureFlagEnvironment(r.client, projectKey, flagKey, envKey)
+	if isStatusNotFound(res) {
+		data.ID = types.StringNull()
+		return
+	}
+	if err != nil {
+		diags.AddError(fmt.Sprintf("failed to get flag %q of project %q: %s", flagKey, projectKey, handleLdapiErr(err).Error()), "")
+		return
+	}
+	if flag.Environments == nil {
+		data.ID = types.StringNull()
+		return
+	}
+	env, ok := (*flag.Environments)[envKey]
+	if !ok {
+		data.ID = types.StringNull()
+		return
+	}
+	k := findFlagRule(env.Rules, ref)
+	if k < 0 {
+		data.ID = types.StringNull()
+		return
+	}
+
+	live := ffeResourceRulesValue(ctx, env.Rules[k:k+1], diags)
+	if diags.HasError() {
+		return
+	}
+	attrs := live.Elements()[0].(types.Object).Attributes()
+	data.Description = attrs[DESCRIPTION].(types.String)
+	data.Clauses = attrs[CLAUSES].(types.List)
+	data.Variation = attrs[VARIATION].(types.Int64)
+	data.RolloutWeights = attrs[ROLLOUT_WEIGHTS].(types.List)
+	data.BucketBy = attrs[BUCKET_BY].(types.String)
+	data.ContextKind = attrs[CONTEXT_KIND].(types.String)
+
+	liveIDs := make([]string, 0, len(env.Rules))
+	for _, rule := range env.Rules {
+		liveIDs = append(liveIDs, rule.GetId())
+	}
+	if position := data.Position.ValueString(); position != "" && flagRuleOrder(liveIDs, liveIDs[k], position, "") != nil {
+		data.Position = types.StringNull()
+	}
+	if before := data.BeforeRuleRef.ValueString(); before != "" {
+		if j := findFlagRule(env.Rules, before); j < 0 || flagRuleOrder(liveIDs, liveIDs[k], "", liveIDs[j]) != nil {
+			data.BeforeRuleRef = types.StringNull()
+		}
+	}
+	data.ID = types.StringValue(flagRuleID(projectKey, envKey, flagKey, ref))
+}
+
+// ruleObject returns the rule data describes in the shape of an element of
+// launchdarkly_feature_flag_environment's rules, so the rule payload and
+// instruction helpers of that resource apply to it. This resource does not
+// manage experiment settings, so they are unknown and left as they are.
+func (m FeatureFlagRuleResourceModel) ruleObject() (types.Object, diag.Diagnostics) {
+	return types.ObjectValue(ffeResourceRuleAttrTypes, withNullVariationRefs(map[string]attr.Value{
+		DESCRIPTION:           m.Description,
+		CLAUSES:               m.Clauses,
+		VARIATION:             m.Variation,
+		ROLLOUT_WEIGHTS:       m.RolloutWeights,
+		BUCKET_BY:             m.BucketBy,
+		CONTEXT_KIND:          m.ContextKind,
+		SEED:                  types.Int64Unknown(),
+		EXPERIMENT_ALLOCATION: types.ObjectUnknown(experimentAllocationAttrTypes),
+		TRACK_EVENTS:          types.BoolUnknown(),
+		REF:                   m.Ref,
+	}, true))
+}
+
+func flagRuleID(projectKey, envKey, flagKey, ref string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", projectKey, envKey, flagKey, ref)
+}
+
+// findFlagRule returns the index of the rule with ref in rules, or -1.
+func findFlagRule(rules []ldapi.Rule, ref string) int {
+	return slices.IndexFunc(rules, func(r ldapi.Rule) bool {
+		return ffeRuleRef(r) == ref
+	})
+}
+
+// flagRuleOrder returns liveIDs reordered so that the rule with ID id is
+// first or last, as position asks, or ahead of the rule with ID beforeID,
+// which must be in liveIDs. It returns nil when the rule is already in place
+// or neither is asked for. A rule ahead of beforeID is in place even when
+// other rules come between them; a rule that is not is moved directly before
+// it. Other rules keep their order.
+func flagRuleOrder(liveIDs []string, id, position, beforeID string) []string {
+	i := slices.Index(liveIDs, id)
+	rest := slices.Delete(slices.Clone(liveIDs), i, i+1)
+	switch {
+	case position == RULE_POSITION_FIRST:
+		if i == 0 {
+			return nil
+		}
+		return append([]string{id}, rest...)
+	case position == RULE_POSITION_LAST:
+		if i == len(liveIDs)-1 {
+			return nil
+		}
+		return append(rest, id)
+	case beforeID != "":
+		if i < slices.Index(liveIDs, beforeID) {
+			return nil
+		}
+		return slices.Insert(rest, slices.Index(rest, beforeID), id)
+	}
+	return nil
+}
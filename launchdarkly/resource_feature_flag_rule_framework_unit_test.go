@@ -0,0 +1,36 @@
+package launchdarkly
+
+import (
+	"testing"
+
+	ldapi "github.com/launchdarkly/api-client-go/v24"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindFlagRule(t *testing.T) {
+	rules := []ldapi.Rule{
+		{Id: ldapi.PtrString("id-1"), Ref: ldapi.PtrString("employees")},
+		{Id: ldapi.PtrString("id-2")},
+	}
+	assert.Equal(t, 0, findFlagRule(rules, "employees"))
+	assert.Equal(t, 1, findFlagRule(rules, "id-2"), "rules without a ref are found by ID")
+	assert.Equal(t, -1, findFlagRule(rules, "id-1"), "rules with a ref are not found by ID")
+	assert.Equal(t, -1, findFlagRule(rules, "missing"))
+}
+
+func TestFlagRuleOrder(t *testing.T) {
+	live := []string{"a", "b", "c"}
+
+	assert.Nil(t, flagRuleOrder(live, "a", RULE_POSITION_FIRST, ""))
+	assert.Equal(t, []string{"b", "a", "c"}, flagRuleOrder(live, "b", RULE_POSITION_FIRST, ""))
+	assert.Nil(t, flagRuleOrder(live, "c", RULE_POSITION_LAST, ""))
+	assert.Equal(t, []string{"b", "c", "a"}, flagRuleOrder(live, "a", RULE_POSITION_LAST, ""))
+	assert.Nil(t, flagRuleOrder(live, "b", "", ""), "an unmanaged position is never out of place")
+	assert.Equal(t, []string{"a", "b", "c"}, live, "liveIDs is not modified")
+
+	t.Run("before another rule", func(t *testing.T) {
+		assert.Nil(t, flagRuleOrder(live, "a", "", "c"), "rules in between are allowed")
+		assert.Equal(t, []string{"a", "c", "b"}, flagRuleOrder(live, "c", "", "b"))
+		assert.Equal(t, []string{"c", "a", "b"}, flagRuleOrder(live, "c", "", "a"))
+	})
+}
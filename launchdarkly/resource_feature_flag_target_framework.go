@@ -0,0 +1,619 @@
+package launchdarkly
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ldapi "github.com/launchdarkly/api-client-go/v24"
+)
+
+var (
+	_ resource.Resource                   = &FeatureFlagTargetResource{}
+	_ resource.ResourceWithValidateConfig = &FeatureFlagTargetResource{}
+	_ resource.ResourceWithModifyPlan     = &FeatureFlagTargetResource{}
+	_ resource.ResourceWithImportState    = &FeatureFlagTargetResource{}
+)
+
+// FeatureFlagTargetResource manages some of the context keys individually
+// targeted to one variation of a flag in one environment. Unlike
+// launchdarkly_feature_flag_environment it does not own the target: it only
+// adds and removes its own values, so several configurations, or Terraform
+// and the LaunchDarkly UI, can target contexts to the same variation.
+type FeatureFlagTargetResource struct {
+	client *Client
+}
+
+type FeatureFlagTargetResourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	ProjectKey          types.String `tfsdk:"project_key"`
+	EnvKey              types.String `tfsdk:"env_key"`
+	FlagKey             types.String `tfsdk:"flag_key"`
+	ContextKind         types.String `tfsdk:"context_kind"`
+	Variation           types.Int64  `tfsdk:"variation"`
+	Values              types.Set    `tfsdk:"values"`
+	Expirations         types.Map    `tfsdk:"expirations"`
+	ApprovalMode        types.String `tfsdk:"approval_mode"`
+	Comment             types.String `tfsdk:"comment"`
+	AcknowledgeCritical types.Bool   `tfsdk:"acknowledge_critical"`
+}
+
+func NewFeatureFlagTargetResource() resource.Resource {
+	return &FeatureFlagTargetResource{}
+}
+
+func (r *FeatureFlagTargetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_feature_flag_target"
+}
+
+func (r *FeatureFlagTargetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `Provides a LaunchDarkly feature flag target resource.
+
+This resource allows you to individually target contexts to one variation of a feature flag in one environment, without managing the rest of the flag's targeting. It adds and removes only the context keys in its ` + "`values`" + `: other keys targeted to the same variation, whether by another ` + "`launchdarkly_feature_flag_target`" + ` or in the LaunchDarkly UI, are left alone and do not show up as changes.
+
+-> **Note:** Do not manage the same flag environment's ` + "`targets`" + ` or ` + "`context_targets`" + ` with a ` + "`launchdarkly_feature_flag_environment`" + ` resource as well. That resource owns every individual target, so it would remove the values this resource adds. Use [lifecycle.ignore_changes](https://developer.hashicorp.com/terraform/language/meta-arguments/lifecycle#ignore_changes) on those attributes if you need both.`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			PROJECT_KEY: schema.StringAttribute{
+				Required:      true,
+				Description:   addForceNewDescription("The key of the project the flag belongs to.", true),
+				Validators:    []validator.String{keyValidator()},
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			ENV_KEY: schema.StringAttribute{
+				Required:      true,
+				Description:   addForceNewDescription("The key of the environment to target contexts in.", true),
+				Validators:    []validator.String{keyValidator()},
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			FLAG_KEY: schema.StringAttribute{
+				Required:      true,
+				Description:   addForceNewDescription("The key of the feature flag.", true),
+				Validators:    []validator.String{keyValidator()},
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			CONTEXT_KIND: schema.StringAttribute{
+				Optional:      true,
+				Computed:      true,
+				Default:       stringdefault.StaticString("user"),
+				Description:   addForceNewDescription("The context kind of the targeted context keys. Defaults to `user`.", true),
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			VARIATION: schema.Int64Attribute{
+				Required:      true,
+				Description:   addForceNewDescription("The index of the variation to serve the targeted contexts.", true),
+				Validators:    []validator.Int64{int64validator.AtLeast(0)},
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.RequiresReplace()},
+			},
+			VALUES: schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: "The context keys to target. Only these keys are added and removed; other keys targeted to the same variation are not managed by this resource.",
+				Validators:  []validator.Set{setvalidator.SizeAtLeast(1)},
+			},
+			EXPIRATIONS: schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "When LaunchDarkly stops targeting some of the keys in `values`, keyed by context key, as RFC 3339 timestamps with a UTC offset, such as `2026-03-01T06:00:00-05:00`. LaunchDarkly removes a key from the target once it expires. An expired key is not targeted again: it is kept in `values` without showing up as a change until you remove it from the configuration.",
+				Validators:  []validator.Map{mapvalidator.ValueStringsAre(rfc3339Validator{})},
+			},
+			APPROVAL_MODE:        resourceApprovalModeAttribute(),
+			COMMENT:              resourceCommentAttribute(),
+			ACKNOWLEDGE_CRITICAL: acknowledgeCriticalAttribute(),
+		},
+	}
+}
+
+func (r *FeatureFlagTargetResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data FeatureFlagTargetResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Values.IsUnknown() || data.Expirations.IsNull() || data.Expirations.IsUnknown() {
+		return
+	}
+	values, d := stringSliceFromSet(ctx, data.Values)
+	resp.Diagnostics.Append(d...)
+	for key := range data.Expirations.Elements() {
+		if !slices.Contains(values, key) {
+			resp.Diagnostics.AddAttributeError(path.Root(EXPIRATIONS).AtMapKey(key), "Invalid expiration", fmt.Sprintf("%q is not one of the context keys in %s.", key, VALUES))
+		}
+	}
+}
+
+func (r *FeatureFlagTargetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = configureResourceClient(req, resp)
+}
+
+func (r *FeatureFlagTargetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	scope := guardrailScope{project: PROJECT_KEY, env: ENV_KEY}
+	r.client.planGuardrails(ctx, req, resp, scope)
+	r.client.planCriticalEnvironment(ctx, req, resp, scope)
+}
+
+func (r *FeatureFlagTargetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_feature_flag_target", "create", &resp.Diagnostics) {
+		return
+	}
+	var plan FeatureFlagTargetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	values, d := stringSliceFromSet(ctx, plan.Values)
+	resp.Diagnostics.Append(d...)
+	expirations, d := flagTargetExpirations(ctx, plan.Expirations)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	now := time.Now()
+	r.apply(ctx, plan, flagTargetUnexpired(values, expirations, now), nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.applyExpirations(plan, expirations, nil, now, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.readIntoModel(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.ID.IsNull() {
+		resp.Diagnostics.AddError(fmt.Sprintf("flag %q not found in environment %q of project %q after create", plan.FlagKey.ValueString(), plan.EnvKey.ValueString(), plan.ProjectKey.ValueString()), "")
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *FeatureFlagTargetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data FeatureFlagTargetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.readIntoModel(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.ID.IsNull() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FeatureFlagTargetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_feature_flag_target", "update", &resp.Diagnostics) {
+		return
+	}
+	var plan, state FeatureFlagTargetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	values, d := stringSliceFromSet(ctx, plan.Values)
+	resp.Diagnostics.Append(d...)
+	prior, d := stringSliceFromSet(ctx, state.Values)
+	resp.Diagnostics.Append(d...)
+	expirations, d := flagTargetExpirations(ctx, plan.Expirations)
+	resp.Diagnostics.Append(d...)
+	priorExpirations, d := flagTargetExpirations(ctx, state.Expirations)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	now := time.Now()
+	r.apply(ctx, plan, flagTargetUnexpired(values, expirations, now), prior, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.applyExpirations(plan, expirations, slices.Collect(maps.Keys(priorExpirations)), now, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.readIntoModel(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.ID.IsNull() {
+		resp.Diagnostics.AddError(fmt.Sprintf("flag %q not found in environment %q of project %q after update", plan.FlagKey.ValueString(), plan.EnvKey.ValueString(), plan.ProjectKey.ValueString()), "")
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *FeatureFlagTargetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_feature_flag_target", "destroy", &resp.Diagnostics) {
+		return
+	}
+	var data FeatureFlagTargetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	prior, d := stringSliceFromSet(ctx, data.Values)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.apply(ctx, data, nil, prior, &resp.Diagnostics)
+}
+
+// apply changes the live target so it includes values and none of the prior
+// values that values drops. Instructions are computed against the live
+// target, so keys added or removed in LaunchDarkly since the last refresh
+// are not sent again, and a re-run after an approval request was applied
+// has nothing left to request.
+func (r *FeatureFlagTargetResource) apply(ctx context.Context, data FeatureFlagTargetResourceModel, values, prior []string, diags *diag.Diagnostics) {
+	projectKey, envKey, flagKey := data.ProjectKey.ValueString(), data.EnvKey.ValueString(), data.FlagKey.ValueString()
+	flag, res, err := getFeatureFlagEnvironment(r.client, projectKey, flagKey, envKey)
+	if isStatusNotFound(res) {
+		if values == nil {
+			// Nothing to remove from a flag that no longer exists.
+			return
+		}
+		diags.AddError(fmt.Sprintf("cannot find flag %q in project %q", flagKey, projectKey), "")
+		return
+	}
+	if err != nil {
+		diags.AddError(fmt.Sprintf("failed to get flag %q of project %q: %s", flagKey, projectKey, handleLdapiErr(err).Error()), "")
+		return
+	}
+	if flag.Environments == nil {
+		if values == nil {
+			return
+		}
+		diags.AddError(fmt.Sprintf("environment %q not found in project %q", envKey, projectKey), "")
+		return
+	}
+	env, ok := (*flag.Environments)[envKey]
+	if !ok {
+		if values == nil {
+			return
+		}
+		diags.AddError(fmt.Sprintf("environment %q not found in project %q", envKey, projectKey), "")
+		return
+	}
+
+	contextKind := data.ContextKind.ValueString()
+	variation := int32(data.Variation.ValueInt64())
+	live := flagTargetValues(env.Targets, env.ContextTargets, contextKind, variation)
+	ids := newFlagVariationIDs(r.client, projectKey)
+	ids.seed(flag)
+	variationID, err := ids.id(flagKey, variation)
+	if err != nil {
+		diags.AddError(err.Error(), "")
+		return
+	}
+	instructions := flagTargetInstructions(contextKind, variationID, values, prior, live)
+	if len(instructions) == 0 {
+		return
+	}
+
+	comment := r.client.changeCommentFor(data.Comment)
+	diags.Append(r.client.applyFlagInstructions(projectKey, envKey, flagKey, r.client.approvalModeFor(data.ApprovalMode), comment, instructions)...)
+}
+
+// applyExpirations makes the live expiring targets of the keys this resource
+// manages match expirations. Expirations that have passed are left to
+// LaunchDarkly, and expirations of the prior keys that expirations drops are
+// removed. Like apply, it submits the change for approval according to
+// approval_mode when the environment requires approval.
+func (r *FeatureFlagTargetResource) applyExpirations(data FeatureFlagTargetResourceModel, expirations map[string]time.Time, prior []string, now time.Time, diags *diag.Diagnostics) {
+	pending := make(map[string]int64, len(expirations))
+	for key, t := range expirations {
+		if t.After(now) {
+			pending[key] = t.UnixMilli()
+		}
+	}
+	if len(pending) == 0 && len(prior) == 0 {
+		return
+	}
+	projectKey, envKey, flagKey := data.ProjectKey.ValueString(), data.EnvKey.ValueString(), data.FlagKey.ValueString()
+	contextKind := data.ContextKind.ValueString()
+	variationID, err := newFlagVariationIDs(r.client, projectKey).id(flagKey, int32(data.Variation.ValueInt64()))
+	if err != nil {
+		diags.AddError(err.Error(), "")
+		return
+	}
+	live, err := r.liveExpirations(projectKey, envKey, flagKey, contextKind, variationID)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("failed to get expiring targets of flag %q in project %q, environment %q: %s", flagKey, projectKey, envKey, handleLdapiErr(err).Error()), "")
+		return
+	}
+	instructions := flagTargetExpirationInstructions(contextKind, variationID, pending, prior, live)
+	if len(instructions) == 0 {
+		return
+	}
+	comment := r.client.changeCommentFor(data.Comment)
+	input := ldapi.PatchFlagsRequest{
+		Comment:      ldapi.PtrString(comment),
+		Instructions: instructions,
+	}
+	err = r.client.withConcurrency(r.client.ctx, func() error {
+		_, _, e := r.client.ld.FeatureFlagsApi.PatchExpiringTargets(r.client.ctx, projectKey, envKey, flagKey).PatchFlagsRequest(input).Execute()
+		return e
+	})
+	if err == nil {
+		return
+	}
+	if mode := r.client.approvalModeFor(data.ApprovalMode); isApprovalRequiredErr(err) && mode != APPROVAL_MODE_FAIL {
+		diags.Append(r.client.submitForApproval(mode, approvalRequestSpec{
+			resourceID:   flagApprovalResourceID(projectKey, envKey, flagKey),
+			summary:      fmt.Sprintf("expiring targets of flag %q in environment %q of project %q", flagKey, envKey, projectKey),
+			instructions: instructions,
+			comment:      comment,
+		})...)
+		return
+	}
+	diags.AddError(fmt.Sprintf("failed to update expiring targets of flag %q in project %q, environment %q: %s", flagKey, projectKey, envKey, handleLdapiErr(err).Error()), "")
+}
+
+// liveExpirations returns the expiring targets of the flag environment for
+// contextKind and variationID, keyed by context key.
+func (r *FeatureFlagTargetResource) liveExpirations(projectKey, envKey, flagKey, contextKind, variationID string) (map[string]ldapi.ExpiringTarget, error) {
+	var targets *ldapi.ExpiringTargetGetResponse
+	err := r.client.withConcurrency(r.client.ctx, func() error {
+		var e error
+		targets, _, e = r.client.ld.FeatureFlagsApi.GetExpiringContextTargets(r.client.ctx, projectKey, envKey, flagKey).Execute()
+		return e
+	})
+	if err != nil {
+		return nil, err
+	}
+	live := make(map[string]ldapi.ExpiringTarget)
+	for _, t := range targets.Items {
+		if t.ContextKind == contextKind && t.GetVariationId() == variationID {
+			live[t.ContextKey] = t
+		}
+	}
+	return live, nil
+}
+
+func (r *FeatureFlagTargetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 5 {
+		resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("expected project_key/env_key/flag_key/context_kind/variation, got %q", req.ID))
+		return
+	}
+	variation, err := strconv.ParseInt(parts[4], 10, 64)
+	if err != nil || variation < 0 {
+		resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("variation in %q must be a variation index", req.ID))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(PROJECT_KEY), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(ENV_KEY), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(FLAG_KEY), parts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(CONTEXT_KIND), parts[3])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(VARIATION), variation)...)
+}
+
+// readIntoModel refreshes data's values from the live target, keeping only
+// the keys data already manages. After an import data manages nothing yet,
+// so it adopts every key in the target. ID is set to null when the flag, the
+// environment or all of the managed keys are gone.
+func (r *FeatureFlagTargetResource) readIntoModel(ctx context.Context, data *FeatureFlagTargetResourceModel, diags *diag.Diagnostics) {
+	projectKey, envKey, flagKey := data.ProjectKey.ValueString(), data.EnvKey.ValueString(), data.FlagKey.ValueString()
+	flag, res, err := getFeatureFlagEnvironment(r.client, projectKey, flagKey, envKey)
+	if isStatusNotFound(res) {
+		data.ID = types.StringNull()
+		return
+	}
+	if err != nil {
+		diags.AddError(fmt.Sprintf("failed to get flag %q of project %q: %s", flagKey, projectKey, handleLdapiErr(err).Error()), "")
+		return
+	}
+	if flag.Environments == nil {
+		data.ID = types.StringNull()
+		return
+	}
+	env, ok := (*flag.Environments)[envKey]
+	if !ok {
+		data.ID = types.StringNull()
+		return
+	}
+
+	contextKind, variation := data.ContextKind.ValueString(), int32(data.Variation.ValueInt64())
+	live := flagTargetValues(env.Targets, env.ContextTargets, contextKind, variation)
+	prior, d := mapStringFromAttr(ctx, data.Expirations)
+	diags.Append(d...)
+	now := time.Now()
+	importing := data.Values.IsNull()
+	values := live
+	if !importing {
+		managed, d := stringSliceFromSet(ctx, data.Values)
+		diags.Append(d...)
+		var gone []string
+		values, gone = partitionStrings(managed, live)
+		// LaunchDarkly removes keys from the target as they expire. They
+		// stay managed so they are not planned to be targeted again.
+		for _, key := range gone {
+			if rfc3339Passed(types.StringValue(prior[key]), now) {
+				values = append(values, key)
+			}
+		}
+	}
+	if len(values) == 0 {
+		data.ID = types.StringNull()
+		return
+	}
+	set, d := setFromStringSlice(ctx, values)
+	diags.Append(d...)
+	data.Values = set
+	data.ID = types.StringValue(flagTargetID(projectKey, envKey, flagKey, contextKind, data.Variation.ValueInt64()))
+
+	if !importing && data.Expirations.IsNull() {
+		return
+	}
+	ids := newFlagVariationIDs(r.client, projectKey)
+	ids.seed(flag)
+	variationID, err := ids.id(flagKey, variation)
+	if err != nil {
+		diags.AddError(err.Error(), "")
+		return
+	}
+	liveExpirations, err := r.liveExpirations(projectKey, envKey, flagKey, contextKind, variationID)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("failed to get expiring targets of flag %q in project %q, environment %q: %s", flagKey, projectKey, envKey, handleLdapiErr(err).Error()), "")
+		return
+	}
+	data.Expirations = flagTargetExpirationsValue(values, prior, liveExpirations, now, !data.Expirations.IsNull())
+}
+
+func flagTargetID(projectKey, envKey, flagKey, contextKind string, variation int64) string {
+	return fmt.Sprintf("%s/%s/%s/%s/%d", projectKey, envKey, flagKey, contextKind, variation)
+}
+
+// flagTargetValues returns the keys of contextKind individually targeted to
+// variation. LaunchDarkly keeps user targets in targets and the others in
+// contextTargets, where user entries only mark their position.
+func flagTargetValues(targets, contextTargets []ldapi.Target, contextKind string, variation int32) []string {
+	var values []string
+	for _, t := range append(append([]ldapi.Target{}, targets...), contextTargets...) {
+		kind := t.GetContextKind()
+		if kind == "" {
+			kind = "user"
+		}
+		if kind == contextKind && t.Variation == variation {
+			values = append(values, t.Values...)
+		}
+	}
+	return values
+}
+
+// flagTargetInstructions returns the instructions that add the values
+// missing from live and remove the prior values that values drops and that
+// are still live. Keys this resource never managed are left alone.
+func flagTargetInstructions(contextKind, variationID string, values, prior, live []string) []map[string]interface{} {
+	_, added := partitionStrings(values, live)
+	_, dropped := partitionStrings(prior, values)
+	removed, _ := partitionStrings(dropped, live)
+	sort.Strings(added)
+	sort.Strings(removed)
+
+	var instructions []map[string]interface{}
+	if len(removed) > 0 {
+		instructions = append(instructions, map[string]interface{}{"kind": "removeTargets", "contextKind": contextKind, "variationId": variationID, "values": removed})
+	}
+	if len(added) > 0 {
+		instructions = append(instructions, map[string]interface{}{"kind": "addTargets", "contextKind": contextKind, "variationId": variationID, "values": added})
+	}
+	return instructions
+}
+
+// flagTargetExpirations parses the expirations attribute.
+func flagTargetExpirations(ctx context.Context, m types.Map) (map[string]time.Time, diag.Diagnostics) {
+	raw, diags := mapStringFromAttr(ctx, m)
+	expirations := make(map[string]time.Time, len(raw))
+	for key, value := range raw {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			diags.AddAttributeError(path.Root(EXPIRATIONS).AtMapKey(key), "Invalid timestamp", err.Error())
+			continue
+		}
+		expirations[key] = t
+	}
+	return expirations, diags
+}
+
+// flagTargetUnexpired returns the values whose expiration, if any, has not
+// passed.
+func flagTargetUnexpired(values []string, expirations map[string]time.Time, now time.Time) []string {
+	return slices.DeleteFunc(slices.Clone(values), func(v string) bool {
+		t, ok := expirations[v]
+		return ok && !t.After(now)
+	})
+}
+
+// flagTargetExpirationInstructions returns the expiring target instructions
+// for the keys of contextKind targeted to variationID; see
+// expiringTargetInstructions.
+func flagTargetExpirationInstructions(contextKind, variationID string, expirations map[string]int64, prior []string, live map[string]ldapi.ExpiringTarget) []map[string]interface{} {
+	return expiringTargetInstructions(map[string]interface{}{"contextKind": contextKind, "variationId": variationID}, expirations, prior, live)
+}
+
+// expiringTargetInstructions returns the instructions that set the
+// expirations, in milliseconds since the epoch, that live lacks or holds
+// another date for, and remove the live expirations of the prior keys that
+// expirations drops. target holds the parameters that name the target the
+// keys belong to, which every instruction carries.
+func expiringTargetInstructions(target map[string]interface{}, expirations map[string]int64, prior []string, live map[string]ldapi.ExpiringTarget) []map[string]interface{} {
+	instruction := func(kind, key string) map[string]interface{} {
+		in := map[string]interface{}{"kind": kind, "contextKey": key}
+		maps.Copy(in, target)
+		return in
+	}
+	var instructions []map[string]interface{}
+	for _, key := range slices.Sorted(maps.Keys(expirations)) {
+		l, ok := live[key]
+		var in map[string]interface{}
+		switch {
+		case !ok:
+			in = instruction("addExpiringTarget", key)
+		case l.ExpirationDate != expirations[key]:
+			in = instruction("updateExpiringTarget", key)
+			in["version"] = l.Version
+		default:
+			continue
+		}
+		in["value"] = expirations[key]
+		instructions = append(instructions, in)
+	}
+	for _, key := range slices.Sorted(slices.Values(prior)) {
+		if _, ok := expirations[key]; ok {
+			continue
+		}
+		if _, ok := live[key]; ok {
+			instructions = append(instructions, instruction("removeExpiringTarget", key))
+		}
+	}
+	return instructions
+}
+
+// flagTargetExpirationsValue returns the expirations attribute for values
+// from the live expiring targets. A prior timestamp is kept while it names
+// the live expiration, and after it has passed and LaunchDarkly has removed
+// the expiration. An empty result is null unless set is true.
+func flagTargetExpirationsValue(values []string, prior map[string]string, live map[string]ldapi.ExpiringTarget, now time.Time, set bool) types.Map {
+	elements := make(map[string]attr.Value)
+	for _, key := range values {
+		priorValue := types.StringNull()
+		if p, ok := prior[key]; ok {
+			priorValue = types.StringValue(p)
+		}
+		if l, ok := live[key]; ok {
+			elements[key] = rfc3339Value(priorValue, l.ExpirationDate)
+		} else if rfc3339Passed(priorValue, now) {
+			elements[key] = priorValue
+		}
+	}
+	if len(elements) == 0 && !set {
+		return types.MapNull(types.StringType)
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
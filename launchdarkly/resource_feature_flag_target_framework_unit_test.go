@@ -0,0 +1,88 @@
+package launchdarkly
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ldapi "github.com/launchdarkly/api-client-go/v24"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlagTargetValues(t *testing.T) {
+	targets := []ldapi.Target{
+		{Values: []string{"a", "b"}, Variation: 0, ContextKind: ldapi.PtrString("user")},
+		{Values: []string{"c"}, Variation: 1},
+	}
+	contextTargets := []ldapi.Target{
+		{Values: []string{}, Variation: 0, ContextKind: ldapi.PtrString("user")},
+		{Values: []string{"org-1"}, Variation: 0, ContextKind: ldapi.PtrString("organization")},
+	}
+	assert.Equal(t, []string{"a", "b"}, flagTargetValues(targets, contextTargets, "user", 0))
+	assert.Equal(t, []string{"c"}, flagTargetValues(targets, contextTargets, "user", 1), "targets without a context kind are user targets")
+	assert.Equal(t, []string{"org-1"}, flagTargetValues(targets, contextTargets, "organization", 0))
+	assert.Empty(t, flagTargetValues(targets, contextTargets, "organization", 1))
+}
+
+func TestFlagTargetInstructions(t *testing.T) {
+	live := []string{"mine-1", "mine-2", "theirs"}
+
+	assert.Equal(t, []map[string]interface{}{
+		{"kind": "removeTargets", "contextKind": "user", "variationId": "v0", "values": []string{"mine-1"}},
+		{"kind": "addTargets", "contextKind": "user", "variationId": "v0", "values": []string{"mine-3"}},
+	}, flagTargetInstructions("user", "v0", []string{"mine-3", "mine-2"}, []string{"mine-1", "mine-2"}, live))
+
+	t.Run("keys managed elsewhere are left alone", func(t *testing.T) {
+		assert.Equal(t, []map[string]interface{}{
+			{"kind": "removeTargets", "contextKind": "user", "variationId": "v0", "values": []string{"mine-1", "mine-2"}},
+		}, flagTargetInstructions("user", "v0", nil, []string{"mine-1", "mine-2"}, live))
+	})
+
+	t.Run("nothing to do when the live target already matches", func(t *testing.T) {
+		assert.Empty(t, flagTargetInstructions("user", "v0", []string{"mine-1", "theirs"}, []string{"mine-1"}, live))
+		assert.Empty(t, flagTargetInstructions("user", "v0", nil, []string{"gone"}, live), "keys already removed are not removed again")
+	})
+}
+
+func TestFlagTargetExpirationInstructions(t *testing.T) {
+	live := map[string]ldapi.ExpiringTarget{
+		"same":    {ContextKey: "same", ExpirationDate: 1000, Version: 1},
+		"moved":   {ContextKey: "moved", ExpirationDate: 1000, Version: 2},
+		"dropped": {ContextKey: "dropped", ExpirationDate: 1000, Version: 3},
+	}
+	expirations := map[string]int64{"same": 1000, "moved": 2000, "new": 3000}
+
+	assert.Equal(t, []map[string]interface{}{
+		{"kind": "updateExpiringTarget", "contextKey": "moved", "contextKind": "user", "variationId": "v0", "value": int64(2000), "version": int32(2)},
+		{"kind": "addExpiringTarget", "contextKey": "new", "contextKind": "user", "variationId": "v0", "value": int64(3000)},
+		{"kind": "removeExpiringTarget", "contextKey": "dropped", "contextKind": "user", "variationId": "v0"},
+	}, flagTargetExpirationInstructions("user", "v0", expirations, []string{"same", "dropped", "gone"}, live))
+}
+
+func TestFlagTargetExpirationsValue(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	future := now.Add(time.Hour)
+	live := map[string]ldapi.ExpiringTarget{
+		"pending": {ContextKey: "pending", ExpirationDate: future.UnixMilli()},
+	}
+	prior := map[string]string{
+		"pending": future.In(time.FixedZone("EST", -5*60*60)).Format(time.RFC3339),
+		"expired": "2026-03-01T06:00:00-05:00",
+		"removed": "2026-04-01T06:00:00-05:00",
+	}
+
+	value := flagTargetExpirationsValue([]string{"pending", "expired", "removed", "permanent"}, prior, live, now, true)
+	assert.Equal(t, map[string]attr.Value{
+		"pending": types.StringValue(prior["pending"]),
+		"expired": types.StringValue(prior["expired"]),
+	}, value.Elements(), "expirations removed before they passed are dropped")
+
+	assert.True(t, flagTargetExpirationsValue([]string{"permanent"}, nil, live, now, false).IsNull())
+	assert.Equal(t, map[string]attr.Value{
+		"pending": types.StringValue(future.UTC().Format(time.RFC3339)),
+	}, flagTargetExpirationsValue([]string{"pending"}, nil, live, now, false).Elements(), "imports read UTC")
+
+	expirations := map[string]time.Time{"pending": future, "expired": now.Add(-time.Hour)}
+	assert.Equal(t, []string{"pending", "permanent"}, flagTargetUnexpired([]string{"pending", "expired", "permanent"}, expirations, now))
+}
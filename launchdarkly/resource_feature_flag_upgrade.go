@@ -82,6 +82,11 @@ type FeatureFlagResourceModelV0 struct {
 // SDKv2 provider.
 func featureFlagSchemaAttributesV0() map[string]schema.Attribute {
 	attrs := featureFlagSchemaAttributes()
+	delete(attrs, COMMENT)
+	delete(attrs, TAGS_ALL)
+	delete(attrs, ENVIRONMENTS)
+	delete(attrs, ACKNOWLEDGE_CRITICAL)
+	delete(attrs, MIGRATION_SETTINGS)
 	attrs[INCLUDE_IN_SNIPPET] = schema.BoolAttribute{
 		Optional:           true,
 		Computed:           true,
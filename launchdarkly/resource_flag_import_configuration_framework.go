@@ -6,7 +6,9 @@ import (
 	"net/http"
 	"reflect"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -19,9 +21,10 @@ import (
 )
 
 var (
-	_ resource.Resource                = &FlagImportConfigurationResource{}
-	_ resource.ResourceWithImportState = &FlagImportConfigurationResource{}
-	_ resource.ResourceWithModifyPlan  = &FlagImportConfigurationResource{}
+	_ resource.Resource                     = &FlagImportConfigurationResource{}
+	_ resource.ResourceWithImportState      = &FlagImportConfigurationResource{}
+	_ resource.ResourceWithModifyPlan       = &FlagImportConfigurationResource{}
+	_ resource.ResourceWithConfigValidators = &FlagImportConfigurationResource{}
 )
 
 type FlagImportConfigurationResource struct {
@@ -30,14 +33,17 @@ type FlagImportConfigurationResource struct {
 }
 
 type FlagImportConfigurationResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	ProjectKey     types.String `tfsdk:"project_key"`
-	IntegrationKey types.String `tfsdk:"integration_key"`
-	IntegrationID  types.String `tfsdk:"integration_id"`
-	Name           types.String `tfsdk:"name"`
-	Config         types.String `tfsdk:"config"`
-	Tags           types.Set    `tfsdk:"tags"`
-	Version        types.Int64  `tfsdk:"version"`
+	ID              types.String `tfsdk:"id"`
+	ProjectKey      types.String `tfsdk:"project_key"`
+	IntegrationKey  types.String `tfsdk:"integration_key"`
+	IntegrationID   types.String `tfsdk:"integration_id"`
+	Name            types.String `tfsdk:"name"`
+	Config          types.String `tfsdk:"config"`
+	ConfigWO        types.String `tfsdk:"config_wo"`
+	ConfigWOVersion types.Int64  `tfsdk:"config_wo_version"`
+	Tags            types.Set    `tfsdk:"tags"`
+	TagsAll         types.Set    `tfsdk:"tags_all"`
+	Version         types.Int64  `tfsdk:"version"`
 }
 
 func NewFlagImportConfigurationResource() resource.Resource {
@@ -88,14 +94,25 @@ func flagImportConfigurationSchemaAttributes() map[string]schema.Attribute {
 			Description: "A human-friendly name for the flag import configuration. If not set, the LaunchDarkly API assigns a default.",
 		},
 		CONFIG: schema.StringAttribute{
-			Required:    true,
+			Optional:    true,
 			Sensitive:   true,
-			Description: "A JSON-encoded object of configuration values for the integration. The accepted keys vary by `integration_key` and are described by the `formVariables` in the integration's manifest (often including a secret API token). Marked sensitive because it commonly contains credentials.",
+			Description: "A JSON-encoded object of configuration values for the integration. The accepted keys vary by `integration_key` and are described by the `formVariables` in the integration's manifest (often including a secret API token). Marked sensitive because it commonly contains credentials. This value is stored in the Terraform state; use `config_wo` to keep it out of state. Exactly one of `config` or `config_wo` must be set.",
 			Validators:  []validator.String{jsonStringValidator{}},
 			PlanModifiers: []planmodifier.String{
 				jsonNormalizePlanModifier{},
 			},
 		},
+		CONFIG_WO: schema.StringAttribute{
+			Optional:    true,
+			Sensitive:   true,
+			WriteOnly:   true,
+			Description: writeOnlyDescription("A JSON-encoded object of configuration values for the integration, in the same format as `config`.", CONFIG_WO_VERSION),
+			Validators: []validator.String{
+				jsonStringValidator{},
+				stringvalidator.AlsoRequires(path.MatchRoot(CONFIG_WO_VERSION)),
+			},
+		},
+		CONFIG_WO_VERSION: writeOnlyVersionAttribute(CONFIG_WO),
 		TAGS: schema.SetAttribute{
 			Optional:    true,
 			ElementType: types.StringType,
@@ -104,6 +121,7 @@ func flagImportConfigurationSchemaAttributes() map[string]schema.Attribute {
 				setvalidator.ValueStringsAre(tagValidator()),
 			},
 		},
+		TAGS_ALL: tagsAllAttribute(),
 		VERSION: schema.Int64Attribute{
 			Computed:    true,
 			Description: "The version of the flag import configuration.",
@@ -111,6 +129,15 @@ func flagImportConfigurationSchemaAttributes() map[string]schema.Attribute {
 	}
 }
 
+func (r *FlagImportConfigurationResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot(CONFIG),
+			path.MatchRoot(CONFIG_WO),
+		),
+	}
+}
+
 func (r *FlagImportConfigurationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	r.client = configureResourceClient(req, resp)
 	if r.client == nil {
@@ -135,11 +162,14 @@ func (r *FlagImportConfigurationResource) betaClient() (*Client, error) {
 // attribute changes, so the post-apply refresh does not trip "inconsistent
 // result after apply".
 func (r *FlagImportConfigurationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.client.planGuardrails(ctx, req, resp, guardrailScope{project: PROJECT_KEY})
+	// tags_all is planned first so the version check below sees it.
+	r.client.planTagsAll(ctx, req, resp)
 	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
 		return
 	}
 	var plan, state FlagImportConfigurationResourceModel
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -156,6 +186,9 @@ func (r *FlagImportConfigurationResource) ModifyPlan(ctx context.Context, req re
 }
 
 func (r *FlagImportConfigurationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_flag_import_configuration", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan FlagImportConfigurationResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -180,13 +213,21 @@ func (r *FlagImportConfigurationResource) Create(ctx context.Context, req resour
 
 	integrationKey := plan.IntegrationKey.ValueString()
 
-	config, err := configMapFromJSON(plan.Config.ValueString())
+	configJSON, configPath := plan.Config, path.Root(CONFIG)
+	if writeOnlyInUse(plan.ConfigWOVersion) {
+		configPath = path.Root(CONFIG_WO)
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, configPath, &configJSON)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	config, err := configMapFromJSON(configJSON.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddAttributeError(path.Root(CONFIG), "Invalid config", err.Error())
+		resp.Diagnostics.AddAttributeError(configPath, "Invalid config", err.Error())
 		return
 	}
 
-	tags, d := stringSliceFromSet(ctx, plan.Tags)
+	tags, d := stringSliceFromSet(ctx, plan.TagsAll)
 	resp.Diagnostics.Append(d...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -240,6 +281,9 @@ func (r *FlagImportConfigurationResource) Read(ctx context.Context, req resource
 }
 
 func (r *FlagImportConfigurationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_flag_import_configuration", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan, state FlagImportConfigurationResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -261,16 +305,26 @@ func (r *FlagImportConfigurationResource) Update(ctx context.Context, req resour
 	if !plan.Name.Equal(state.Name) && !plan.Name.IsUnknown() {
 		patch = append(patch, patchReplace("/name", plan.Name.ValueString()))
 	}
-	if !plan.Config.Equal(state.Config) {
-		config, cErr := configMapFromJSON(plan.Config.ValueString())
+	configJSON, configPath := plan.Config, path.Root(CONFIG)
+	sendConfig := !writeOnlyInUse(plan.ConfigWOVersion) && !plan.Config.Equal(state.Config)
+	if writeOnlyVersionChanged(plan.ConfigWOVersion, state.ConfigWOVersion) {
+		configPath = path.Root(CONFIG_WO)
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, configPath, &configJSON)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		sendConfig = true
+	}
+	if sendConfig {
+		config, cErr := configMapFromJSON(configJSON.ValueString())
 		if cErr != nil {
-			resp.Diagnostics.AddAttributeError(path.Root(CONFIG), "Invalid config", cErr.Error())
+			resp.Diagnostics.AddAttributeError(configPath, "Invalid config", cErr.Error())
 			return
 		}
 		patch = append(patch, patchReplace("/config", config))
 	}
-	if !plan.Tags.Equal(state.Tags) {
-		tags, d := stringSliceFromSet(ctx, plan.Tags)
+	if !plan.TagsAll.Equal(state.TagsAll) {
+		tags, d := stringSliceFromSet(ctx, plan.TagsAll)
 		resp.Diagnostics.Append(d...)
 		if tags == nil {
 			tags = []string{}
@@ -299,6 +353,9 @@ func (r *FlagImportConfigurationResource) Update(ctx context.Context, req resour
 }
 
 func (r *FlagImportConfigurationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_flag_import_configuration", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data FlagImportConfigurationResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -376,7 +433,11 @@ func (r *FlagImportConfigurationResource) readIntoModel(
 	// and only reconstruct it from the API on import, where state has none yet.
 	// Heads-up for the human reviewer: verify against a real integration whether
 	// non-secret config keys should be read back for drift detection.
-	if data.Config.IsNull() || data.Config.IsUnknown() || data.Config.ValueString() == "" {
+	// A config managed through config_wo must never land in state.
+	data.ConfigWO = types.StringNull()
+	if writeOnlyInUse(data.ConfigWOVersion) {
+		data.Config = types.StringNull()
+	} else if data.Config.IsNull() || data.Config.IsUnknown() || data.Config.ValueString() == "" {
 		configJSON, cErr := configJSONFromMap(cfg.GetConfig())
 		if cErr != nil {
 			diags.AddError("Failed to read flag import configuration config", cErr.Error())
@@ -387,7 +448,10 @@ func (r *FlagImportConfigurationResource) readIntoModel(
 
 	// Optional-only Set attr: preserve the config's null-vs-empty intent so an
 	// omitted `tags` reads back as null, not an empty set.
-	tagsSet, d := setFromStringSlicePreservingPlan(ctx, cfg.GetTags(), data.Tags)
+	ownTags, tagsAll, d := r.client.splitDefaultTags(ctx, cfg.GetTags(), data.Tags)
+	diags.Append(d...)
+	tagsSet, d := setFromStringSlicePreservingPlan(ctx, ownTags, data.Tags)
 	diags.Append(d...)
 	data.Tags = tagsSet
+	data.TagsAll = tagsAll
 }
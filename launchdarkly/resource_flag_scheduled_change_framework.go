@@ -0,0 +1,377 @@
+package launchdarkly
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ldapi "github.com/launchdarkly/api-client-go/v24"
+)
+
+// Values of a launchdarkly_flag_scheduled_change's status.
+const (
+	SCHEDULED_CHANGE_PENDING   = "pending"
+	SCHEDULED_CHANGE_COMPLETED = "completed"
+)
+
+var (
+	_ resource.Resource                   = &FlagScheduledChangeResource{}
+	_ resource.ResourceWithValidateConfig = &FlagScheduledChangeResource{}
+	_ resource.ResourceWithModifyPlan     = &FlagScheduledChangeResource{}
+	_ resource.ResourceWithImportState    = &FlagScheduledChangeResource{}
+)
+
+// FlagScheduledChangeResource manages a change LaunchDarkly applies to a flag
+// environment at a set time. LaunchDarkly deletes a scheduled change once it
+// has been applied, so a change that disappears after its execution date is
+// kept in state as completed rather than planned again.
+type FlagScheduledChangeResource struct {
+	client *Client
+}
+
+type FlagScheduledChangeResourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	ProjectKey          types.String `tfsdk:"project_key"`
+	EnvKey              types.String `tfsdk:"env_key"`
+	FlagKey             types.String `tfsdk:"flag_key"`
+	ExecutionDate       types.String `tfsdk:"execution_date"`
+	Instructions        types.List   `tfsdk:"instructions"`
+	Status              types.String `tfsdk:"status"`
+	IgnoreConflicts     types.Bool   `tfsdk:"ignore_conflicts"`
+	Comment             types.String `tfsdk:"comment"`
+	AcknowledgeCritical types.Bool   `tfsdk:"acknowledge_critical"`
+}
+
+func NewFlagScheduledChangeResource() resource.Resource {
+	return &FlagScheduledChangeResource{}
+}
+
+func (r *FlagScheduledChangeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_flag_scheduled_change"
+}
+
+func (r *FlagScheduledChangeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `Provides a LaunchDarkly flag scheduled change resource.
+
+This resource allows you to schedule changes to a feature flag's targeting in one environment, such as turning the flag on or serving a new fallthrough variation at a set time.
+
+-> **Note:** LaunchDarkly deletes a scheduled change once it has been applied. After its ` + "`execution_date`" + ` has passed, the resource is kept with a ` + "`status`" + ` of ` + "`completed`" + ` instead of being planned again, and destroying it leaves the flag as it is. Changing the instructions or execution date of a completed change replaces it with a new scheduled change.`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			PROJECT_KEY: schema.StringAttribute{
+				Required:      true,
+				Description:   addForceNewDescription("The key of the project the flag belongs to.", true),
+				Validators:    []validator.String{keyValidator()},
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			ENV_KEY: schema.StringAttribute{
+				Required:      true,
+				Description:   addForceNewDescription("The key of the environment the change applies in.", true),
+				Validators:    []validator.String{keyValidator()},
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			FLAG_KEY: schema.StringAttribute{
+				Required:      true,
+				Description:   addForceNewDescription("The key of the feature flag.", true),
+				Validators:    []validator.String{keyValidator()},
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			EXECUTION_DATE: schema.StringAttribute{
+				Required:    true,
+				Description: "When to apply the change, as an RFC 3339 timestamp with a UTC offset, such as `2026-03-01T06:00:00-05:00`. The timestamp is kept as written as long as it names the same instant as the scheduled change in LaunchDarkly.",
+				Validators:  []validator.String{rfc3339Validator{}},
+			},
+			INSTRUCTIONS: scheduledInstructionsAttribute(),
+			STATUS: schema.StringAttribute{
+				Computed:      true,
+				Description:   "Whether the change is still `pending` or has been `completed`.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			IGNORE_CONFLICTS: schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to schedule the change even if it conflicts with other scheduled changes of the flag environment. This value is not read from LaunchDarkly.",
+			},
+			COMMENT:              resourceCommentAttribute(),
+			ACKNOWLEDGE_CRITICAL: acknowledgeCriticalAttribute(),
+		},
+	}
+}
+
+func (r *FlagScheduledChangeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var instructions types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(INSTRUCTIONS), &instructions)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	validateScheduledInstructions(path.Root(INSTRUCTIONS), instructions, &resp.Diagnostics)
+}
+
+func (r *FlagScheduledChangeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = configureResourceClient(req, resp)
+}
+
+func (r *FlagScheduledChangeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	scope := guardrailScope{project: PROJECT_KEY, env: ENV_KEY}
+	r.client.planGuardrails(ctx, req, resp, scope)
+	r.client.planCriticalEnvironment(ctx, req, resp, scope)
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	var plan, state FlagScheduledChangeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || state.Status.ValueString() != SCHEDULED_CHANGE_COMPLETED {
+		return
+	}
+	// A completed change no longer exists in LaunchDarkly to be updated.
+	if !plan.Instructions.Equal(state.Instructions) {
+		resp.RequiresReplace.Append(path.Root(INSTRUCTIONS))
+	}
+	if !plan.ExecutionDate.Equal(state.ExecutionDate) {
+		resp.RequiresReplace.Append(path.Root(EXECUTION_DATE))
+	}
+}
+
+func (r *FlagScheduledChangeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_flag_scheduled_change", "create", &resp.Diagnostics) {
+		return
+	}
+	var plan FlagScheduledChangeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	projectKey, envKey, flagKey := plan.ProjectKey.ValueString(), plan.EnvKey.ValueString(), plan.FlagKey.ValueString()
+	instructions := r.instructionPayloads(ctx, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	executionDate, err := time.Parse(time.RFC3339, plan.ExecutionDate.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root(EXECUTION_DATE), "Invalid timestamp", err.Error())
+		return
+	}
+
+	input := ldapi.PostFlagScheduledChangesInput{
+		Comment:       ldapi.PtrString(r.client.changeCommentFor(plan.Comment)),
+		ExecutionDate: executionDate.UnixMilli(),
+		Instructions:  instructions,
+	}
+	var created *ldapi.FeatureFlagScheduledChange
+	err = r.client.withConcurrency(r.client.ctx, func() error {
+		var e error
+		created, _, e = r.client.ld.ScheduledChangesApi.PostFlagConfigScheduledChanges(r.client.ctx, projectKey, flagKey, envKey).PostFlagScheduledChangesInput(input).IgnoreConflicts(plan.IgnoreConflicts.ValueBool()).Execute()
+		return e
+	})
+	if err != nil {
+		addLdapiError(&resp.Diagnostics, "Failed to create flag scheduled change", err)
+		return
+	}
+	plan.ID = types.StringValue(created.Id)
+
+	r.readIntoModel(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.ID.IsNull() {
+		resp.Diagnostics.AddError(fmt.Sprintf("scheduled change %q of flag %q not found in environment %q of project %q after create", created.Id, flagKey, envKey, projectKey), "")
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *FlagScheduledChangeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data FlagScheduledChangeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.readIntoModel(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.ID.IsNull() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FlagScheduledChangeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_flag_scheduled_change", "update", &resp.Diagnostics) {
+		return
+	}
+	var plan, state FlagScheduledChangeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var patchInstructions []map[string]interface{}
+	if !plan.Instructions.Equal(state.Instructions) {
+		instructions := r.instructionPayloads(ctx, plan, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		patchInstructions = append(patchInstructions, map[string]interface{}{
+			KIND:  "replaceScheduledChangesInstructions",
+			VALUE: instructions,
+		})
+	}
+	executionDate, err := time.Parse(time.RFC3339, plan.ExecutionDate.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root(EXECUTION_DATE), "Invalid timestamp", err.Error())
+		return
+	}
+	// A timestamp rewritten with another offset names the same instant.
+	if !rfc3339Value(state.ExecutionDate, executionDate.UnixMilli()).Equal(state.ExecutionDate) {
+		patchInstructions = append(patchInstructions, map[string]interface{}{
+			KIND:  "updateScheduledChangesExecutionDate",
+			VALUE: executionDate.UnixMilli(),
+		})
+	}
+
+	if len(patchInstructions) > 0 {
+		input := ldapi.FlagScheduledChangesInput{
+			Comment:      ldapi.PtrString(r.client.changeCommentFor(plan.Comment)),
+			Instructions: patchInstructions,
+		}
+		err = r.client.withConcurrency(r.client.ctx, func() error {
+			_, _, e := r.client.ld.ScheduledChangesApi.PatchFlagConfigScheduledChange(r.client.ctx, plan.ProjectKey.ValueString(), plan.FlagKey.ValueString(), plan.EnvKey.ValueString(), plan.ID.ValueString()).FlagScheduledChangesInput(input).IgnoreConflicts(plan.IgnoreConflicts.ValueBool()).Execute()
+			return e
+		})
+		if err != nil {
+			addLdapiError(&resp.Diagnostics, "Failed to update flag scheduled change", err)
+			return
+		}
+	}
+
+	r.readIntoModel(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.ID.IsNull() {
+		resp.Diagnostics.AddError(fmt.Sprintf("scheduled change %q of flag %q not found in environment %q of project %q after update", state.ID.ValueString(), plan.FlagKey.ValueString(), plan.EnvKey.ValueString(), plan.ProjectKey.ValueString()), "")
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *FlagScheduledChangeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_flag_scheduled_change", "destroy", &resp.Diagnostics) {
+		return
+	}
+	var data FlagScheduledChangeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.Status.ValueString() == SCHEDULED_CHANGE_COMPLETED {
+		return
+	}
+	var res *http.Response
+	err := r.client.withConcurrency(r.client.ctx, func() error {
+		var e error
+		res, e = r.client.ld.ScheduledChangesApi.DeleteFlagConfigScheduledChanges(r.client.ctx, data.ProjectKey.ValueString(), data.FlagKey.ValueString(), data.EnvKey.ValueString(), data.ID.ValueString()).Execute()
+		return e
+	})
+	if err != nil && !isStatusNotFound(res) {
+		addLdapiError(&resp.Diagnostics, "Failed to delete flag scheduled change", err)
+	}
+}
+
+func (r *FlagScheduledChangeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if strings.Count(req.ID, "/") != 3 {
+		resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("expected project_key/env_key/flag_key/scheduled_change_id, got %q", req.ID))
+		return
+	}
+	parts := strings.SplitN(req.ID, "/", 4)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(PROJECT_KEY), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(ENV_KEY), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(FLAG_KEY), parts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[3])...)
+}
+
+// instructionPayloads returns data's instructions as sent to LaunchDarkly,
+// resolving variation indexes and rule refs against the live flag.
+func (r *FlagScheduledChangeResource) instructionPayloads(ctx context.Context, data FlagScheduledChangeResourceModel, diags *diag.Diagnostics) []map[string]interface{} {
+	projectKey, envKey, flagKey := data.ProjectKey.ValueString(), data.EnvKey.ValueString(), data.FlagKey.ValueString()
+	flag, res, err := getFeatureFlagEnvironment(r.client, projectKey, flagKey, envKey)
+	if isStatusNotFound(res) {
+		diags.AddError(fmt.Sprintf("cannot find flag %q in project %q", flagKey, projectKey), "")
+		return nil
+	}
+	if err != nil {
+		diags.AddError(fmt.Sprintf("failed to get flag %q of project %q: %s", flagKey, projectKey, handleLdapiErr(err).Error()), "")
+		return nil
+	}
+	ids := newFlagVariationIDs(r.client, projectKey)
+	ids.seed(flag)
+	instructions, d := scheduledInstructionPayloads(ctx, flagKey, data.Instructions, ffeLiveRules(flag, envKey), ids)
+	diags.Append(d...)
+	return instructions
+}
+
+// readIntoModel refreshes data from LaunchDarkly. It sets data.ID to null if
+// the pending change no longer exists. The configured instructions are kept
+// while LaunchDarkly still holds them, since the API returns them with IDs
+// rather than the indexes and refs they were written with.
+func (r *FlagScheduledChangeResource) readIntoModel(ctx context.Context, data *FlagScheduledChangeResourceModel, diags *diag.Diagnostics) {
+	projectKey, envKey, flagKey := data.ProjectKey.ValueString(), data.EnvKey.ValueString(), data.FlagKey.ValueString()
+	var change *ldapi.FeatureFlagScheduledChange
+	var res *http.Response
+	err := r.client.withConcurrency(r.client.ctx, func() error {
+		var e error
+		change, res, e = r.client.ld.ScheduledChangesApi.GetFeatureFlagScheduledChange(r.client.ctx, projectKey, flagKey, envKey, data.ID.ValueString()).Execute()
+		return e
+	})
+	if isStatusNotFound(res) {
+		if rfc3339Passed(data.ExecutionDate, time.Now()) {
+			data.Status = types.StringValue(SCHEDULED_CHANGE_COMPLETED)
+			return
+		}
+		data.ID = types.StringNull()
+		return
+	}
+	if err != nil {
+		diags.AddError("Failed to get flag scheduled change", handleLdapiErr(err).Error())
+		return
+	}
+
+	flag, _, err := getFeatureFlagEnvironment(r.client, projectKey, flagKey, envKey)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("failed to get flag %q of project %q: %s", flagKey, projectKey, handleLdapiErr(err).Error()), "")
+		return
+	}
+	ids := newFlagVariationIDs(r.client, projectKey)
+	ids.seed(flag)
+	rules := ffeLiveRules(flag, envKey)
+
+	data.ID = types.StringValue(change.Id)
+	data.Status = types.StringValue(SCHEDULED_CHANGE_PENDING)
+	data.ExecutionDate = rfc3339Value(data.ExecutionDate, change.ExecutionDate)
+	if !data.Instructions.IsNull() && !data.Instructions.IsUnknown() {
+		sent, d := scheduledInstructionPayloads(ctx, flagKey, data.Instructions, rules, ids)
+		if !d.HasError() && scheduledInstructionsMatch(sent, change.Instructions) {
+			return
+		}
+	}
+	instructions, d := scheduledInstructionsFromAPI(ctx, flagKey, change.Instructions, rules, ids)
+	diags.Append(d...)
+	data.Instructions = instructions
+}
@@ -21,6 +21,7 @@ import (
 
 var (
 	_ resource.Resource                 = &FlagTemplatesResource{}
+	_ resource.ResourceWithModifyPlan   = &FlagTemplatesResource{}
 	_ resource.ResourceWithImportState  = &FlagTemplatesResource{}
 	_ resource.ResourceWithUpgradeState = &FlagTemplatesResource{}
 )
@@ -179,6 +180,10 @@ func (r *FlagTemplatesResource) Configure(_ context.Context, req resource.Config
 	r.client = configureResourceClient(req, resp)
 }
 
+func (r *FlagTemplatesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.client.planGuardrails(ctx, req, resp, guardrailScope{project: PROJECT_KEY})
+}
+
 type flagTemplatesBooleanDefaultsModel struct {
 	TrueDisplayName  string `tfsdk:"true_display_name"`
 	FalseDisplayName string `tfsdk:"false_display_name"`
@@ -225,6 +230,9 @@ func (r *FlagTemplatesResource) upsert(ctx context.Context, plan *FlagTemplatesR
 }
 
 func (r *FlagTemplatesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_flag_templates", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan FlagTemplatesResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -260,6 +268,9 @@ func (r *FlagTemplatesResource) Read(ctx context.Context, req resource.ReadReque
 }
 
 func (r *FlagTemplatesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_flag_templates", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan FlagTemplatesResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
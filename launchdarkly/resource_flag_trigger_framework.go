@@ -21,6 +21,7 @@ import (
 
 var (
 	_ resource.Resource                 = &FlagTriggerResource{}
+	_ resource.ResourceWithModifyPlan   = &FlagTriggerResource{}
 	_ resource.ResourceWithImportState  = &FlagTriggerResource{}
 	_ resource.ResourceWithUpgradeState = &FlagTriggerResource{}
 )
@@ -39,6 +40,7 @@ type FlagTriggerResourceModel struct {
 	TriggerURL     types.String `tfsdk:"trigger_url"`
 	MaintainerID   types.String `tfsdk:"maintainer_id"`
 	Enabled        types.Bool   `tfsdk:"enabled"`
+	Comment        types.String `tfsdk:"comment"`
 }
 
 func NewFlagTriggerResource() resource.Resource {
@@ -111,6 +113,7 @@ This resource allows you to create and manage flag triggers within your LaunchDa
 				Required:    true,
 				Description: "Whether the trigger is currently active or not.",
 			},
+			COMMENT: resourceCommentAttribute(),
 			INSTRUCTIONS: schema.SingleNestedAttribute{
 				Required:    true,
 				Description: "The instruction containing the action to perform when invoking the trigger. Currently supported flag actions are `turnFlagOn` and `turnFlagOff`. This must be passed as the key-value pair `{ kind = \"<flag_action>\" }`.",
@@ -130,11 +133,18 @@ func (r *FlagTriggerResource) Configure(_ context.Context, req resource.Configur
 	r.client = configureResourceClient(req, resp)
 }
 
+func (r *FlagTriggerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.client.planGuardrails(ctx, req, resp, guardrailScope{project: PROJECT_KEY, env: ENV_KEY})
+}
+
 type flagTriggerInstructionModel struct {
 	Kind string `tfsdk:"kind"`
 }
 
 func (r *FlagTriggerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_flag_trigger", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan FlagTriggerResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -177,6 +187,7 @@ func (r *FlagTriggerResource) Create(ctx context.Context, req resource.CreateReq
 	// create endpoint does not accept multiple instructions.
 	if !plan.Enabled.ValueBool() {
 		input := ldapi.FlagTriggerInput{
+			Comment:      ldapi.PtrString(r.client.changeCommentFor(plan.Comment)),
 			Instructions: []map[string]interface{}{{KIND: "disableTrigger"}},
 		}
 		if e := r.client.withConcurrency(r.client.ctx, func() error {
@@ -213,6 +224,9 @@ func (r *FlagTriggerResource) Read(ctx context.Context, req resource.ReadRequest
 }
 
 func (r *FlagTriggerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_flag_trigger", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan, state FlagTriggerResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -245,7 +259,10 @@ func (r *FlagTriggerResource) Update(ctx context.Context, req resource.UpdateReq
 	}
 
 	if len(patchInstructions) > 0 {
-		input := ldapi.FlagTriggerInput{Instructions: patchInstructions}
+		input := ldapi.FlagTriggerInput{
+			Comment:      ldapi.PtrString(r.client.changeCommentFor(plan.Comment)),
+			Instructions: patchInstructions,
+		}
 		err := r.client.withConcurrency(r.client.ctx, func() error {
 			_, _, e := r.client.ld.FlagTriggersApi.PatchTriggerWorkflow(r.client.ctx, plan.ProjectKey.ValueString(), plan.EnvKey.ValueString(), plan.FlagKey.ValueString(), plan.ID.ValueString()).FlagTriggerInput(input).Execute()
 			return e
@@ -264,6 +281,9 @@ func (r *FlagTriggerResource) Update(ctx context.Context, req resource.UpdateReq
 }
 
 func (r *FlagTriggerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_flag_trigger", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data FlagTriggerResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
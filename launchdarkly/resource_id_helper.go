@@ -0,0 +1,199 @@
+package launchdarkly
+
+// Resources identify themselves with composite IDs built from their keys,
+// such as `project_key/env_key/flag_key`. resourceIDFormats describes them for
+// the provider-defined functions that build and parse IDs, using the same
+// parsing as each resource's import.
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// resourceIDFormat is the composite ID of a resource type. parts are the
+// names of the resource's attributes the ID is made of, in order, and parse
+// splits an ID into their values. specifier, when set, returns the resource
+// specifier that custom role policies use for the resource.
+type resourceIDFormat struct {
+	parts     []string
+	parse     func(id string) ([]string, error)
+	specifier func(parts []string) string
+}
+
+// twoPartID and threePartID adapt the resources' IdToKeys functions.
+func twoPartID(idToKeys func(id string) (string, string, error)) func(string) ([]string, error) {
+	return func(id string) ([]string, error) {
+		a, b, err := idToKeys(id)
+		return []string{a, b}, err
+	}
+}
+
+func threePartID(idToKeys func(id string) (string, string, string, error)) func(string) ([]string, error) {
+	return func(id string) ([]string, error) {
+		a, b, c, err := idToKeys(id)
+		return []string{a, b, c}, err
+	}
+}
+
+// projectSpecifier returns the specifier of a project-scoped resource of
+// kind.
+func projectSpecifier(kind string) func(parts []string) string {
+	return func(parts []string) string {
+		return fmt.Sprintf("proj/%s:%s/%s", parts[0], kind, parts[1])
+	}
+}
+
+var resourceIDFormats = map[string]resourceIDFormat{
+	"launchdarkly_project": {
+		parts: []string{KEY},
+		parse: func(id string) ([]string, error) {
+			if id == "" || strings.Contains(id, "/") {
+				return nil, fmt.Errorf("found unexpected project id format: %q expected format: 'project_key'", id)
+			}
+			return []string{id}, nil
+		},
+		specifier: func(parts []string) string { return "proj/" + parts[0] },
+	},
+	"launchdarkly_environment": {
+		parts:     []string{PROJECT_KEY, KEY},
+		parse:     twoPartID(environmentIdToKeys),
+		specifier: projectSpecifier("env"),
+	},
+	"launchdarkly_feature_flag": {
+		parts: []string{PROJECT_KEY, KEY},
+		parse: twoPartID(flagIdToKeys),
+		// Flags are specified in every environment.
+		specifier: func(parts []string) string { return flagApprovalResourceID(parts[0], "*", parts[1]) },
+	},
+	"launchdarkly_feature_flag_environment": {
+		parts:     []string{PROJECT_KEY, ENV_KEY, FLAG_KEY},
+		parse:     threePartID(featureFlagEnvironmentIdToKeys),
+		specifier: func(parts []string) string { return flagApprovalResourceID(parts[0], parts[1], parts[2]) },
+	},
+	"launchdarkly_segment": {
+		parts:     []string{PROJECT_KEY, ENV_KEY, KEY},
+		parse:     threePartID(segmentIdToKeys),
+		specifier: func(parts []string) string { return segmentApprovalResourceID(parts[0], parts[1], parts[2]) },
+	},
+	"launchdarkly_metric": {
+		parts:     []string{PROJECT_KEY, KEY},
+		parse:     twoPartID(metricIdToKeys),
+		specifier: projectSpecifier("metric"),
+	},
+	"launchdarkly_metric_group": {
+		parts:     []string{PROJECT_KEY, KEY},
+		parse:     twoPartID(metricGroupIdToKeys),
+		specifier: projectSpecifier("metric-group"),
+	},
+	"launchdarkly_context_kind": {
+		parts:     []string{PROJECT_KEY, KEY},
+		parse:     twoPartID(contextKindIdToKeys),
+		specifier: projectSpecifier("context-kind"),
+	},
+	"launchdarkly_view": {
+		parts:     []string{PROJECT_KEY, KEY},
+		parse:     twoPartID(viewIdToKeys),
+		specifier: projectSpecifier("view"),
+	},
+	"launchdarkly_release_policy": {
+		parts: []string{PROJECT_KEY, KEY},
+		parse: twoPartID(releasePolicyIdToKeys),
+	},
+	"launchdarkly_sdk_key": {
+		parts: []string{PROJECT_KEY, ENVIRONMENT_KEY, KEY},
+		parse: threePartID(sdkKeyIDToKeys),
+	},
+	"launchdarkly_ai_config": {
+		parts: []string{PROJECT_KEY, KEY},
+		parse: twoPartID(aiConfigIdToKeys),
+	},
+	"launchdarkly_ai_config_variation": {
+		parts: []string{PROJECT_KEY, AI_CONFIG_KEY, KEY},
+		parse: threePartID(variationIdToKeys),
+	},
+	"launchdarkly_ai_tool": {
+		parts: []string{PROJECT_KEY, KEY},
+		parse: twoPartID(aiToolIdToKeys),
+	},
+	"launchdarkly_ai_agent_graph": {
+		parts: []string{PROJECT_KEY, KEY},
+		parse: twoPartID(aiAgentGraphIDToKeys),
+	},
+	"launchdarkly_model_config": {
+		parts: []string{PROJECT_KEY, KEY},
+		parse: twoPartID(modelConfigIdToKeys),
+	},
+}
+
+func resourceIDFormatOf(resourceType string) (resourceIDFormat, error) {
+	format, ok := resourceIDFormats[resourceType]
+	if !ok {
+		return resourceIDFormat{}, fmt.Errorf("unsupported resource type %q, expected one of %s", resourceType, strings.Join(slices.Sorted(maps.Keys(resourceIDFormats)), ", "))
+	}
+	return format, nil
+}
+
+// parseResourceID returns the parts of id, a resourceType ID, keyed by name.
+func parseResourceID(resourceType, id string) (map[string]string, error) {
+	format, err := resourceIDFormatOf(resourceType)
+	if err != nil {
+		return nil, err
+	}
+	values, err := format.parse(id)
+	if err != nil {
+		return nil, err
+	}
+	parts := make(map[string]string, len(values))
+	for i, name := range format.parts {
+		if values[i] == "" {
+			return nil, fmt.Errorf("%s is empty in %s id %q", name, resourceType, id)
+		}
+		parts[name] = values[i]
+	}
+	return parts, nil
+}
+
+// buildResourceID returns the resourceType ID made of keys, which must be as
+// many as the ID has parts and must not contain slashes.
+func buildResourceID(resourceType string, keys ...string) (string, error) {
+	format, err := resourceIDFormatOf(resourceType)
+	if err != nil {
+		return "", err
+	}
+	if len(keys) != len(format.parts) {
+		return "", fmt.Errorf("%s ids have %d parts, got %d", resourceType, len(format.parts), len(keys))
+	}
+	for i, key := range keys {
+		if key == "" || strings.Contains(key, "/") {
+			return "", fmt.Errorf("%s must be a non-empty key without slashes, got %q", format.parts[i], key)
+		}
+	}
+	id := strings.Join(keys, "/")
+	if _, err := format.parse(id); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// resourceSpecifier returns the resource specifier of the resource with id,
+// a resourceType ID.
+func resourceSpecifier(resourceType, id string) (string, error) {
+	format, err := resourceIDFormatOf(resourceType)
+	if err != nil {
+		return "", err
+	}
+	if format.specifier == nil {
+		return "", fmt.Errorf("%s resources have no resource specifier", resourceType)
+	}
+	parts, err := parseResourceID(resourceType, id)
+	if err != nil {
+		return "", err
+	}
+	values := make([]string, 0, len(format.parts))
+	for _, name := range format.parts {
+		values = append(values, parts[name])
+	}
+	return format.specifier(values), nil
+}
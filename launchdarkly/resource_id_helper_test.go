@@ -0,0 +1,71 @@
+package launchdarkly
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildAndParseResourceID(t *testing.T) {
+	cases := []struct {
+		resourceType string
+		keys         []string
+		id           string
+		parts        map[string]string
+	}{
+		{"launchdarkly_project", []string{"p"}, "p", map[string]string{KEY: "p"}},
+		{"launchdarkly_environment", []string{"p", "e"}, "p/e", map[string]string{PROJECT_KEY: "p", KEY: "e"}},
+		{"launchdarkly_feature_flag", []string{"p", "f"}, "p/f", map[string]string{PROJECT_KEY: "p", KEY: "f"}},
+		{"launchdarkly_feature_flag_environment", []string{"p", "e", "f"}, "p/e/f", map[string]string{PROJECT_KEY: "p", ENV_KEY: "e", FLAG_KEY: "f"}},
+		{"launchdarkly_segment", []string{"p", "e", "s"}, "p/e/s", map[string]string{PROJECT_KEY: "p", ENV_KEY: "e", KEY: "s"}},
+		{"launchdarkly_sdk_key", []string{"p", "e", "k"}, "p/e/k", map[string]string{PROJECT_KEY: "p", ENVIRONMENT_KEY: "e", KEY: "k"}},
+		{"launchdarkly_ai_config_variation", []string{"p", "c", "v"}, "p/c/v", map[string]string{PROJECT_KEY: "p", AI_CONFIG_KEY: "c", KEY: "v"}},
+	}
+	for _, c := range cases {
+		t.Run(c.resourceType, func(t *testing.T) {
+			id, err := buildResourceID(c.resourceType, c.keys...)
+			require.NoError(t, err)
+			assert.Equal(t, c.id, id)
+			parts, err := parseResourceID(c.resourceType, id)
+			require.NoError(t, err)
+			assert.Equal(t, c.parts, parts)
+		})
+	}
+
+	_, err := buildResourceID("launchdarkly_feature_flag", "p", "a/b")
+	assert.ErrorContains(t, err, "key must be a non-empty key without slashes")
+	_, err = buildResourceID("launchdarkly_segment", "p", "s")
+	assert.ErrorContains(t, err, "launchdarkly_segment ids have 3 parts, got 2")
+	_, err = parseResourceID("launchdarkly_feature_flag", "p/e/f")
+	assert.ErrorContains(t, err, "expected format: 'project_key/flag_key'")
+	_, err = parseResourceID("launchdarkly_segment", "p//s")
+	assert.ErrorContains(t, err, "env_key is empty")
+	_, err = parseResourceID("launchdarkly_unknown", "p/f")
+	assert.ErrorContains(t, err, `unsupported resource type "launchdarkly_unknown"`)
+}
+
+func TestResourceSpecifier(t *testing.T) {
+	cases := map[string]struct {
+		id        string
+		specifier string
+	}{
+		"launchdarkly_project":                  {"p", "proj/p"},
+		"launchdarkly_environment":              {"p/e", "proj/p:env/e"},
+		"launchdarkly_feature_flag":             {"p/f", "proj/p:env/*:flag/f"},
+		"launchdarkly_feature_flag_environment": {"p/e/f", "proj/p:env/e:flag/f"},
+		"launchdarkly_segment":                  {"p/e/s", "proj/p:env/e:segment/s"},
+		"launchdarkly_metric":                   {"p/m", "proj/p:metric/m"},
+		"launchdarkly_metric_group":             {"p/g", "proj/p:metric-group/g"},
+		"launchdarkly_context_kind":             {"p/k", "proj/p:context-kind/k"},
+		"launchdarkly_view":                     {"p/v", "proj/p:view/v"},
+	}
+	for resourceType, c := range cases {
+		specifier, err := resourceSpecifier(resourceType, c.id)
+		require.NoError(t, err, resourceType)
+		assert.Equal(t, c.specifier, specifier, resourceType)
+	}
+
+	_, err := resourceSpecifier("launchdarkly_ai_tool", "p/t")
+	assert.ErrorContains(t, err, "launchdarkly_ai_tool resources have no resource specifier")
+}
@@ -20,6 +20,7 @@ import (
 
 var (
 	_ resource.Resource                = &IntegrationDeliveryConfigurationResource{}
+	_ resource.ResourceWithModifyPlan  = &IntegrationDeliveryConfigurationResource{}
 	_ resource.ResourceWithImportState = &IntegrationDeliveryConfigurationResource{}
 )
 
@@ -38,6 +39,7 @@ type IntegrationDeliveryConfigurationResourceModel struct {
 	Config         types.String `tfsdk:"config"`
 	On             types.Bool   `tfsdk:"on"`
 	Tags           types.Set    `tfsdk:"tags"`
+	TagsAll        types.Set    `tfsdk:"tags_all"`
 	Version        types.Int64  `tfsdk:"version"`
 }
 
@@ -119,6 +121,7 @@ func integrationDeliveryConfigurationSchemaAttributes() map[string]schema.Attrib
 				setvalidator.ValueStringsAre(tagValidator()),
 			},
 		},
+		TAGS_ALL: tagsAllAttribute(),
 		VERSION: schema.Int64Attribute{
 			Computed:    true,
 			Description: "The version of the delivery configuration.",
@@ -139,6 +142,11 @@ func (r *IntegrationDeliveryConfigurationResource) Configure(_ context.Context,
 	r.beta = beta
 }
 
+func (r *IntegrationDeliveryConfigurationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.client.planGuardrails(ctx, req, resp, guardrailScope{project: PROJECT_KEY, env: ENV_KEY})
+	r.client.planTagsAll(ctx, req, resp)
+}
+
 func (r *IntegrationDeliveryConfigurationResource) betaClient() (*Client, error) {
 	if r.beta != nil {
 		return r.beta, nil
@@ -147,6 +155,9 @@ func (r *IntegrationDeliveryConfigurationResource) betaClient() (*Client, error)
 }
 
 func (r *IntegrationDeliveryConfigurationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_integration_delivery_configuration", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan IntegrationDeliveryConfigurationResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -175,8 +186,8 @@ func (r *IntegrationDeliveryConfigurationResource) Create(ctx context.Context, r
 	if !plan.Name.IsNull() && !plan.Name.IsUnknown() && plan.Name.ValueString() != "" {
 		post.Name = ldapi.PtrString(plan.Name.ValueString())
 	}
-	if !plan.Tags.IsNull() && !plan.Tags.IsUnknown() {
-		tags, d := stringSliceFromSet(ctx, plan.Tags)
+	if !plan.TagsAll.IsNull() && !plan.TagsAll.IsUnknown() {
+		tags, d := stringSliceFromSet(ctx, plan.TagsAll)
 		resp.Diagnostics.Append(d...)
 		if resp.Diagnostics.HasError() {
 			return
@@ -241,6 +252,9 @@ func (r *IntegrationDeliveryConfigurationResource) Read(ctx context.Context, req
 }
 
 func (r *IntegrationDeliveryConfigurationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_integration_delivery_configuration", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan, state IntegrationDeliveryConfigurationResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -278,8 +292,8 @@ func (r *IntegrationDeliveryConfigurationResource) Update(ctx context.Context, r
 		}
 		patch = append(patch, patchReplace("/config", configMap))
 	}
-	if !plan.Tags.Equal(state.Tags) {
-		tags, d := stringSliceFromSet(ctx, plan.Tags)
+	if !plan.TagsAll.Equal(state.TagsAll) {
+		tags, d := stringSliceFromSet(ctx, plan.TagsAll)
 		resp.Diagnostics.Append(d...)
 		if resp.Diagnostics.HasError() {
 			return
@@ -312,6 +326,9 @@ func (r *IntegrationDeliveryConfigurationResource) Update(ctx context.Context, r
 }
 
 func (r *IntegrationDeliveryConfigurationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_integration_delivery_configuration", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data IntegrationDeliveryConfigurationResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -417,7 +434,10 @@ func (r *IntegrationDeliveryConfigurationResource) readIntoModel(
 
 	// Optional-only Set attr: preserve the config's null-vs-empty intent so an
 	// omitted `tags` reads back as null, not an empty set.
-	tagsSet, d := setFromStringSlicePreservingPlan(ctx, cfg.GetTags(), data.Tags)
+	ownTags, tagsAll, d := r.client.splitDefaultTags(ctx, cfg.GetTags(), data.Tags)
+	diags.Append(d...)
+	tagsSet, d := setFromStringSlicePreservingPlan(ctx, ownTags, data.Tags)
 	diags.Append(d...)
 	data.Tags = tagsSet
+	data.TagsAll = tagsAll
 }
@@ -75,6 +75,9 @@ func (r *IPAllowlistConfigResource) Configure(_ context.Context, req resource.Co
 }
 
 func (r *IPAllowlistConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_ip_allowlist_config", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan IPAllowlistConfigResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -114,6 +117,9 @@ func (r *IPAllowlistConfigResource) Read(ctx context.Context, req resource.ReadR
 }
 
 func (r *IPAllowlistConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_ip_allowlist_config", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan IPAllowlistConfigResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -138,6 +144,9 @@ func (r *IPAllowlistConfigResource) Update(ctx context.Context, req resource.Upd
 // singleton; destroying the TF resource reverts the server-side config
 // to defaults rather than deleting anything.
 func (r *IPAllowlistConfigResource) Delete(_ context.Context, _ resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_ip_allowlist_config", "destroy", &resp.Diagnostics) {
+		return
+	}
 	falseVal := false
 	if _, err := patchIpAllowlistConfig(r.client, &falseVal, &falseVal); err != nil {
 		resp.Diagnostics.AddError("Failed to reset IP allowlist config", err.Error())
@@ -217,6 +226,9 @@ func (r *IPAllowlistEntryResource) Configure(_ context.Context, req resource.Con
 }
 
 func (r *IPAllowlistEntryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_ip_allowlist_entry", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan IPAllowlistEntryResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -260,6 +272,9 @@ func (r *IPAllowlistEntryResource) Read(ctx context.Context, req resource.ReadRe
 }
 
 func (r *IPAllowlistEntryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_ip_allowlist_entry", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan, state IPAllowlistEntryResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -284,6 +299,9 @@ func (r *IPAllowlistEntryResource) Update(ctx context.Context, req resource.Upda
 }
 
 func (r *IPAllowlistEntryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_ip_allowlist_entry", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data IPAllowlistEntryResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
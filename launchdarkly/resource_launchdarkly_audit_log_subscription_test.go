@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 const (
@@ -43,6 +44,24 @@ resource "launchdarkly_audit_log_subscription" "%s_tf_test" {
 		resources = ["proj/*:env/production"]
 	}]
 }
+`
+
+	testAccAuditLogSubscriptionWriteOnlyConfig = `
+resource "launchdarkly_audit_log_subscription" "datadog_tf_test" {
+	integration_key = "datadog"
+	name = "terraform write-only test"
+	config_wo = {
+		api_key             = "%s"
+		hide_member_details = true
+	}
+	config_wo_version = %d
+	on = true
+	statements = [{
+		actions = ["*"]
+		effect = "deny"
+		resources = ["proj/*:env/*:flag/*"]
+	}]
+}
 `
 )
 
@@ -257,6 +276,44 @@ func TestAccAuditLogSubscription_CreateSplunk(t *testing.T) {
 	})
 }
 
+func TestAccAuditLogSubscription_WriteOnlyConfig(t *testing.T) {
+	resourceName := "launchdarkly_audit_log_subscription.datadog_tf_test"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccAuditLogSubscriptionWriteOnlyConfig, "firstsecretkey", 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIntegrationExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, CONFIG_WO_VERSION, "1"),
+					resource.TestCheckNoResourceAttr(resourceName, CONFIG),
+					resource.TestCheckNoResourceAttr(resourceName, CONFIG_WO),
+				),
+			},
+			{
+				// Changing only the write-only value is invisible to Terraform.
+				Config:   fmt.Sprintf(testAccAuditLogSubscriptionWriteOnlyConfig, "secondsecretkey", 1),
+				PlanOnly: true,
+			},
+			{
+				Config: fmt.Sprintf(testAccAuditLogSubscriptionWriteOnlyConfig, "secondsecretkey", 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIntegrationExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, CONFIG_WO_VERSION, "2"),
+					resource.TestCheckNoResourceAttr(resourceName, CONFIG),
+					resource.TestCheckNoResourceAttr(resourceName, CONFIG_WO),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAuditLogSubscription_WrongConfigReturnsError(t *testing.T) {
 	integrationKey := "honeycomb"
 	config := `{
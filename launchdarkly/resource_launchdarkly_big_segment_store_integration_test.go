@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 // The environment key "test" is created by withRandomProject. Persistent store
@@ -52,6 +53,23 @@ resource "launchdarkly_big_segment_store_integration" "test" {
 }
 `
 
+const testAccBigSegmentStoreIntegrationRedisWriteOnly = `
+resource "launchdarkly_big_segment_store_integration" "test" {
+	project_key     = launchdarkly_project.test.key
+	environment_key = "test"
+	integration_key = "redis"
+	name            = "Terraform Redis store"
+
+	config_wo = jsonencode({
+		host       = "redis.internal.example.com"
+		port       = "6379"
+		tlsEnabled = true
+		password   = "%s"
+	})
+	config_wo_version = %d
+}
+`
+
 func TestAccBigSegmentStoreIntegration_CreateUpdate(t *testing.T) {
 	projectKey := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
 	resourceName := "launchdarkly_big_segment_store_integration.test"
@@ -97,6 +115,38 @@ func TestAccBigSegmentStoreIntegration_CreateUpdate(t *testing.T) {
 	})
 }
 
+func TestAccBigSegmentStoreIntegration_WriteOnlyConfig(t *testing.T) {
+	projectKey := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resourceName := "launchdarkly_big_segment_store_integration.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBigSegmentStoreIntegrationDestroy,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: withRandomProject(projectKey, fmt.Sprintf(testAccBigSegmentStoreIntegrationRedisWriteOnly, "first-password", 1)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBigSegmentStoreIntegrationExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, CONFIG_WO_VERSION, "1"),
+					resource.TestCheckNoResourceAttr(resourceName, CONFIG),
+					resource.TestCheckNoResourceAttr(resourceName, CONFIG_WO),
+				),
+			},
+			{
+				Config: withRandomProject(projectKey, fmt.Sprintf(testAccBigSegmentStoreIntegrationRedisWriteOnly, "rotated-password", 2)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBigSegmentStoreIntegrationExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, CONFIG_WO_VERSION, "2"),
+					resource.TestCheckNoResourceAttr(resourceName, CONFIG),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckBigSegmentStoreIntegrationExists(resourceName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[resourceName]
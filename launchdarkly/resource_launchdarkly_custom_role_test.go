@@ -1,14 +1,20 @@
 package launchdarkly
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	ldapi "github.com/launchdarkly/api-client-go/v24"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -404,3 +410,20 @@ func testAccCheckCustomRoleDestroy(s *terraform.State) error {
 	}
 	return nil
 }
+
+func TestCustomRolePoliciesFromModel_InvalidResource(t *testing.T) {
+	ctx := context.Background()
+	statements, diags := frameworkPolicyStatementsValue(ctx, []ldapi.Statement{
+		{Resources: []string{"proj/*:env/*:flag/*"}, Actions: []string{"*"}, Effect: "allow"},
+		{Resources: []string{"proj/*:env/*:flags/*"}, Actions: []string{"*"}, Effect: "deny"},
+	})
+	require.False(t, diags.HasError())
+	data := CustomRoleResourceModel{PolicyStatements: statements, PolicyStatementsJSON: types.StringNull()}
+
+	// An invalid specifier must fail the apply, not send an empty policy.
+	var got diag.Diagnostics
+	policies := (&CustomRoleResource{}).policiesFromModel(ctx, &data, &got)
+	assert.Nil(t, policies)
+	require.True(t, got.HasError())
+	assert.Contains(t, got.Errors()[0].Detail(), `did you mean "flag"?`)
+}
@@ -8,9 +8,26 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 const (
+	testAccDestinationWriteOnlyConfigMparticle = `
+resource "launchdarkly_destination" "test" {
+	project_key = launchdarkly_project.test.key
+	env_key     = "%s"
+	name        = "mparticle-dest"
+	kind        = "mparticle"
+	config_wo = {
+		api_key         = "apiKeyfromMParticle"
+		secret          = "%s"
+		user_identities = jsonencode([{ "ldContextKind" : "user", "mparticleUserIdentity" : "customer_id" }])
+		environment     = "production"
+	}
+	config_wo_version = %d
+	on                = true
+}
+`
 	testAccDestinationCreateKinesis = `
 resource "launchdarkly_destination" "test" {
 	project_key = launchdarkly_project.test.key
@@ -639,6 +656,41 @@ func TestAccDestination_CreateAndUpdateAzureEventHubs(t *testing.T) {
 	})
 }
 
+func TestAccDestination_WriteOnlyConfig(t *testing.T) {
+	projectKey := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	envKey := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resourceName := "launchdarkly_destination.test"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: withRandomProjectAndEnv(projectKey, envKey, fmt.Sprintf(testAccDestinationWriteOnlyConfigMparticle, envKey, "mParticleSecret", 1)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDestinationExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, KIND, "mparticle"),
+					resource.TestCheckResourceAttr(resourceName, CONFIG_WO_VERSION, "1"),
+					resource.TestCheckNoResourceAttr(resourceName, CONFIG),
+					resource.TestCheckNoResourceAttr(resourceName, CONFIG_WO),
+				),
+			},
+			{
+				Config: withRandomProjectAndEnv(projectKey, envKey, fmt.Sprintf(testAccDestinationWriteOnlyConfigMparticle, envKey, "rotatedSecret", 2)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDestinationExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, CONFIG_WO_VERSION, "2"),
+					resource.TestCheckNoResourceAttr(resourceName, CONFIG),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckDestinationExists(resourceName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[resourceName]
@@ -1411,3 +1411,409 @@ func testAccProjectWithCustomContextKindScaffold(client *Client, projectKey stri
 	}
 	return nil
 }
+
+const testAccFeatureFlagEnvironmentManagedFields = `
+resource "launchdarkly_feature_flag_environment" "managed_fields" {
+	flag_id = launchdarkly_feature_flag.trigger_flag.id
+	env_key = "test"
+	managed_fields = ["rules", "fallthrough"]
+	fallthrough = {
+		variation = 1
+	}
+	rules = [{
+		ref = "employees"
+		variation = 0
+		clauses = [{
+			attribute = "email"
+			op = "endsWith"
+			values = ["@example.com"]
+		}]
+	}]
+}
+`
+
+func TestAccFeatureFlagEnvironment_ManagedFields(t *testing.T) {
+	projectKey := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	flagKey := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resourceName := "launchdarkly_feature_flag_environment.managed_fields"
+	config := withRandomProject(projectKey, withRandomFlag(flagKey, testAccFeatureFlagEnvironmentManagedFields))
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFeatureFlagEnvironmentExists(resourceName),
+					resource.TestCheckNoResourceAttr(resourceName, ON),
+					resource.TestCheckNoResourceAttr(resourceName, TRACK_EVENTS),
+					resource.TestCheckNoResourceAttr(resourceName, OFF_VARIATION),
+					resource.TestCheckResourceAttr(resourceName, "rules.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "fallthrough.variation", "1"),
+				),
+			},
+			{
+				// Turning targeting on in LaunchDarkly is not a change to the
+				// resource, so the apply leaves it on.
+				PreConfig: func() {
+					client := mustTestAccClient()
+					patch := ldapi.PatchWithComment{Patch: []ldapi.PatchOperation{patchReplace(ffePatchPath("test", "on"), true)}}
+					_, _, err := client.ld.FeatureFlagsApi.PatchFeatureFlag(client.ctx, projectKey, flagKey).PatchWithComment(patch).Execute()
+					require.NoError(t, err)
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr(resourceName, ON),
+					func(s *terraform.State) error {
+						flag, _, err := getFeatureFlagEnvironment(mustTestAccClient(), projectKey, flagKey, "test")
+						if err != nil {
+							return err
+						}
+						if !(*flag.Environments)["test"].On {
+							return fmt.Errorf("expected targeting to stay on")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: withRandomProject(projectKey, withRandomFlag(flagKey, `
+resource "launchdarkly_feature_flag_environment" "managed_fields" {
+	flag_id = launchdarkly_feature_flag.trigger_flag.id
+	env_key = "test"
+	managed_fields = ["rules"]
+	on = true
+}
+`)),
+				ExpectError: regexp.MustCompile("Unmanaged field is set"),
+			},
+		},
+	})
+}
+
+const testAccFeatureFlagEnvironmentVariationRefs = `
+resource "launchdarkly_feature_flag" "refs" {
+	project_key = launchdarkly_project.test.key
+	key = "refs-flag"
+	name = "Variation references"
+	variation_type = "number"
+	variations = [{
+		value = 10
+	}, {
+		value = 20
+		name = "twenty"
+	}, {
+		value = 30
+	}]
+}
+
+resource "launchdarkly_feature_flag_environment" "refs" {
+	flag_id = launchdarkly_feature_flag.refs.id
+	env_key = "test"
+	off_variation_value = "30"
+	fallthrough = {
+		rollout = {
+			"10" = 60000
+			"30" = 40000
+		}
+	}
+	rules = [{
+		variation_name = "twenty"
+		clauses = [{
+			attribute = "email"
+			op = "endsWith"
+			values = ["@example.com"]
+		}]
+	}]
+}
+`
+
+const testAccFeatureFlagEnvironmentVariationRefsUpdate = `
+resource "launchdarkly_feature_flag" "refs" {
+	project_key = launchdarkly_project.test.key
+	key = "refs-flag"
+	name = "Variation references"
+	variation_type = "number"
+	variations = [{
+		value = 10
+	}, {
+		value = 20
+		name = "twenty"
+	}, {
+		value = 30
+	}]
+}
+
+resource "launchdarkly_feature_flag_environment" "refs" {
+	flag_id = launchdarkly_feature_flag.refs.id
+	env_key = "test"
+	off_variation_value = "10"
+	fallthrough = {
+		variation_value = "20"
+	}
+	rules = [{
+		rollout = {
+			"20" = 100000
+		}
+		clauses = [{
+			attribute = "email"
+			op = "endsWith"
+			values = ["@example.com"]
+		}]
+	}]
+}
+`
+
+func TestAccFeatureFlagEnvironment_VariationRefs(t *testing.T) {
+	projectKey := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resourceName := "launchdarkly_feature_flag_environment.refs"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: withRandomProject(projectKey, testAccFeatureFlagEnvironmentVariationRefs),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFeatureFlagEnvironmentExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, OFF_VARIATION, "2"),
+					resource.TestCheckResourceAttr(resourceName, OFF_VARIATION_VALUE, "30"),
+					resource.TestCheckResourceAttr(resourceName, "fallthrough.rollout_weights.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "fallthrough.rollout_weights.0", "60000"),
+					resource.TestCheckResourceAttr(resourceName, "fallthrough.rollout_weights.1", "0"),
+					resource.TestCheckResourceAttr(resourceName, "fallthrough.rollout_weights.2", "40000"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.variation", "1"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.variation_name", "twenty"),
+				),
+			},
+			{
+				Config: withRandomProject(projectKey, testAccFeatureFlagEnvironmentVariationRefsUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, OFF_VARIATION, "0"),
+					resource.TestCheckResourceAttr(resourceName, "fallthrough.variation", "1"),
+					resource.TestCheckNoResourceAttr(resourceName, "fallthrough.rollout_weights.#"),
+					resource.TestCheckNoResourceAttr(resourceName, "rules.0.variation"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.rollout_weights.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.rollout_weights.1", "100000"),
+				),
+			},
+		},
+	})
+}
+
+const testAccFeatureFlagEnvironmentExperiment = `
+resource "launchdarkly_feature_flag" "experiment" {
+	project_key    = launchdarkly_project.test.key
+	key            = "experiment-flag"
+	name           = "Experiment flag"
+	variation_type = "boolean"
+	variations = [{
+		value = true
+	}, {
+		value = false
+	}]
+}
+
+resource "launchdarkly_feature_flag_environment" "experiment" {
+	flag_id = launchdarkly_feature_flag.experiment.id
+	env_key = "test"
+	on      = true
+	track_events_fallthrough = true
+	rules = [{
+		clauses = [{
+			attribute = "country"
+			op        = "in"
+			values    = ["nz"]
+		}]
+		rollout_weights = [50000, 50000]
+		seed            = 7
+		track_events    = true
+	}]
+	fallthrough = {
+		rollout_weights = [50000, 50000]
+		seed            = 42
+		experiment_allocation = {
+			default_variation    = 1
+			untracked_variations = [1]
+		}
+	}
+}
+`
+
+// testAccFeatureFlagEnvironmentExperimentOmitted leaves the experiment
+// settings out, as a configuration written before the experiment started
+// would.
+const testAccFeatureFlagEnvironmentExperimentOmitted = `
+resource "launchdarkly_feature_flag" "experiment" {
+	project_key    = launchdarkly_project.test.key
+	key            = "experiment-flag"
+	name           = "Experiment flag"
+	variation_type = "boolean"
+	variations = [{
+		value = true
+	}, {
+		value = false
+	}]
+}
+
+resource "launchdarkly_feature_flag_environment" "experiment" {
+	flag_id = launchdarkly_feature_flag.experiment.id
+	env_key = "test"
+	on      = false
+	rules = [{
+		clauses = [{
+			attribute = "country"
+			op        = "in"
+			values    = ["nz", "au"]
+		}]
+		rollout_weights = [50000, 50000]
+	}]
+	fallthrough = {
+		rollout_weights = [50000, 50000]
+	}
+}
+`
+
+func TestAccFeatureFlagEnvironment_Experiment(t *testing.T) {
+	projectKey := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resourceName := "launchdarkly_feature_flag_environment.experiment"
+	checks := resource.ComposeTestCheckFunc(
+		resource.TestCheckResourceAttr(resourceName, TRACK_EVENTS_FALLTHROUGH, "true"),
+		resource.TestCheckResourceAttr(resourceName, "rules.0.seed", "7"),
+		resource.TestCheckResourceAttr(resourceName, "rules.0.track_events", "true"),
+		resource.TestCheckResourceAttr(resourceName, "fallthrough.seed", "42"),
+		resource.TestCheckResourceAttr(resourceName, "fallthrough.experiment_allocation.default_variation", "1"),
+		resource.TestCheckResourceAttr(resourceName, "fallthrough.experiment_allocation.can_reshuffle", "false"),
+		resource.TestCheckResourceAttr(resourceName, "fallthrough.experiment_allocation.untracked_variations.#", "1"),
+	)
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: withRandomProject(projectKey, testAccFeatureFlagEnvironmentExperiment),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFeatureFlagEnvironmentExists(resourceName),
+					checks,
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Changing the rule and turning targeting off keeps the
+				// experiment settings.
+				Config: withRandomProject(projectKey, testAccFeatureFlagEnvironmentExperimentOmitted),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, ON, "false"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.clauses.0.values.#", "2"),
+					checks,
+				),
+			},
+		},
+	})
+}
+
+const testAccFeatureFlagEnvironmentRolloutPercentages = `
+resource "launchdarkly_feature_flag" "percentages" {
+	project_key    = launchdarkly_project.test.key
+	key            = "percentages-flag"
+	name           = "Rollout percentages flag"
+	variation_type = "string"
+	variations = [{
+		value = "a"
+	}, {
+		value = "b"
+	}, {
+		value = "c"
+	}]
+}
+
+resource "launchdarkly_feature_flag_environment" "percentages" {
+	flag_id = launchdarkly_feature_flag.percentages.id
+	env_key = "test"
+	on      = true
+	rules = [{
+		clauses = [{
+			attribute = "country"
+			op        = "in"
+			values    = ["nz"]
+		}]
+		rollout_percentages = [33.333, 33.333, 33.334]
+	}]
+	fallthrough = {
+		rollout_percentages = [12.3456, 12.3454, 75.309]
+	}
+}
+`
+
+const testAccFeatureFlagEnvironmentRolloutPercentagesInvalid = `
+resource "launchdarkly_feature_flag" "percentages" {
+	project_key    = launchdarkly_project.test.key
+	key            = "percentages-flag"
+	name           = "Rollout percentages flag"
+	variation_type = "boolean"
+	variations = [{
+		value = true
+	}, {
+		value = false
+	}]
+}
+
+resource "launchdarkly_feature_flag_environment" "percentages" {
+	flag_id = launchdarkly_feature_flag.percentages.id
+	env_key = "test"
+	fallthrough = {
+		rollout_percentages = [33.3, 66.6]
+	}
+}
+`
+
+func TestAccFeatureFlagEnvironment_RolloutPercentages(t *testing.T) {
+	projectKey := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resourceName := "launchdarkly_feature_flag_environment.percentages"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      withRandomProject(projectKey, testAccFeatureFlagEnvironmentRolloutPercentagesInvalid),
+				ExpectError: regexp.MustCompile(`add up to 99.9, but they must add up to exactly 100`),
+			},
+			{
+				Config: withRandomProject(projectKey, testAccFeatureFlagEnvironmentRolloutPercentages),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFeatureFlagEnvironmentExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.rollout_percentages.2", "33.334"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.rollout_weights.0", "33333"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.rollout_weights.1", "33333"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.rollout_weights.2", "33334"),
+					resource.TestCheckResourceAttr(resourceName, "fallthrough.rollout_weights.0", "12346"),
+					resource.TestCheckResourceAttr(resourceName, "fallthrough.rollout_weights.1", "12345"),
+					resource.TestCheckResourceAttr(resourceName, "fallthrough.rollout_weights.2", "75309"),
+				),
+			},
+			{
+				// The percentages are kept in state as configured, so a second
+				// plan is empty.
+				Config:   withRandomProject(projectKey, testAccFeatureFlagEnvironmentRolloutPercentages),
+				PlanOnly: true,
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"rules.0.rollout_percentages", "fallthrough.rollout_percentages"},
+			},
+		},
+	})
+}
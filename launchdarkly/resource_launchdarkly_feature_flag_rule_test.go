@@ -0,0 +1,194 @@
+package launchdarkly
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+const (
+	testAccFeatureFlagRuleCreate = `
+resource "launchdarkly_feature_flag_rule" "employees" {
+	project_key = launchdarkly_project.test.key
+	env_key = "test"
+	flag_key = launchdarkly_feature_flag.trigger_flag.key
+	ref = "internal-employees"
+	description = "Internal employees"
+	variation = 0
+	clauses = [
+		{
+			attribute = "email"
+			op = "endsWith"
+			values = ["@example.com"]
+		},
+	]
+}
+
+resource "launchdarkly_feature_flag_rule" "beta" {
+	project_key = launchdarkly_project.test.key
+	env_key = "test"
+	flag_key = launchdarkly_feature_flag.trigger_flag.key
+	ref = "beta"
+	variation = 1
+	clauses = [
+		{
+			attribute = "beta"
+			op = "in"
+			values = ["true"]
+			value_type = "boolean"
+		},
+	]
+	depends_on = [launchdarkly_feature_flag_rule.employees]
+}
+`
+
+	testAccFeatureFlagRuleUpdate = `
+resource "launchdarkly_feature_flag_rule" "employees" {
+	project_key = launchdarkly_project.test.key
+	env_key = "test"
+	flag_key = launchdarkly_feature_flag.trigger_flag.key
+	ref = "internal-employees"
+	description = "Internal employees and contractors"
+	rollout_weights = [60000, 40000]
+	bucket_by = "email"
+	position = "last"
+	clauses = [
+		{
+			attribute = "email"
+			op = "endsWith"
+			values = ["@example.com", "@contractors.example.com"]
+		},
+	]
+}
+
+resource "launchdarkly_feature_flag_rule" "beta" {
+	project_key = launchdarkly_project.test.key
+	env_key = "test"
+	flag_key = launchdarkly_feature_flag.trigger_flag.key
+	ref = "beta"
+	variation = 1
+	clauses = [
+		{
+			attribute = "beta"
+			op = "in"
+			values = ["true"]
+			value_type = "boolean"
+		},
+	]
+}
+`
+
+	testAccFeatureFlagRuleBefore = `
+resource "launchdarkly_feature_flag_rule" "employees" {
+	project_key = launchdarkly_project.test.key
+	env_key = "test"
+	flag_key = launchdarkly_feature_flag.trigger_flag.key
+	ref = "internal-employees"
+	description = "Internal employees and contractors"
+	rollout_weights = [60000, 40000]
+	bucket_by = "email"
+	before_rule_ref = launchdarkly_feature_flag_rule.beta.ref
+	clauses = [
+		{
+			attribute = "email"
+			op = "endsWith"
+			values = ["@example.com", "@contractors.example.com"]
+		},
+	]
+}
+
+resource "launchdarkly_feature_flag_rule" "beta" {
+	project_key = launchdarkly_project.test.key
+	env_key = "test"
+	flag_key = launchdarkly_feature_flag.trigger_flag.key
+	ref = "beta"
+	variation = 1
+	clauses = [
+		{
+			attribute = "beta"
+			op = "in"
+			values = ["true"]
+			value_type = "boolean"
+		},
+	]
+}
+`
+)
+
+func TestAccFeatureFlagRule_Position(t *testing.T) {
+	projectKey := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	flagKey := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	employees := "launchdarkly_feature_flag_rule.employees"
+	beta := "launchdarkly_feature_flag_rule.beta"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: withRandomProject(projectKey, withRandomFlag(flagKey, testAccFeatureFlagRuleCreate)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(employees, ID, fmt.Sprintf("%s/test/%s/internal-employees", projectKey, flagKey)),
+					resource.TestCheckResourceAttr(employees, DESCRIPTION, "Internal employees"),
+					resource.TestCheckResourceAttr(employees, VARIATION, "0"),
+					resource.TestCheckResourceAttr(employees, "clauses.0.values.#", "1"),
+					resource.TestCheckResourceAttr(beta, DESCRIPTION, ""),
+					resource.TestCheckResourceAttr(beta, CONTEXT_KIND, "user"),
+					testAccCheckFlagRuleRefs(projectKey, flagKey, "internal-employees", "beta"),
+				),
+			},
+			{
+				Config: withRandomProject(projectKey, withRandomFlag(flagKey, testAccFeatureFlagRuleUpdate)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(employees, DESCRIPTION, "Internal employees and contractors"),
+					resource.TestCheckNoResourceAttr(employees, VARIATION),
+					resource.TestCheckResourceAttr(employees, "rollout_weights.#", "2"),
+					resource.TestCheckResourceAttr(employees, BUCKET_BY, "email"),
+					resource.TestCheckResourceAttr(employees, "clauses.0.values.#", "2"),
+					resource.TestCheckResourceAttr(employees, POSITION, "last"),
+					testAccCheckFlagRuleRefs(projectKey, flagKey, "beta", "internal-employees"),
+				),
+			},
+			{
+				Config: withRandomProject(projectKey, withRandomFlag(flagKey, testAccFeatureFlagRuleBefore)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr(employees, POSITION),
+					resource.TestCheckResourceAttr(employees, BEFORE_RULE_REF, "beta"),
+					testAccCheckFlagRuleRefs(projectKey, flagKey, "internal-employees", "beta"),
+				),
+			},
+			{
+				ResourceName:            employees,
+				ImportState:             true,
+				ImportStateId:           fmt.Sprintf("%s/test/%s/internal-employees", projectKey, flagKey),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{BEFORE_RULE_REF},
+			},
+		},
+	})
+}
+
+// testAccCheckFlagRuleRefs checks the refs of the rules in the flag's "test"
+// environment, in order.
+func testAccCheckFlagRuleRefs(projectKey, flagKey string, expected ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := mustTestAccClient()
+		flag, _, err := getFeatureFlagEnvironment(client, projectKey, flagKey, "test")
+		if err != nil {
+			return fmt.Errorf("failed to get flag %q: %s", flagKey, handleLdapiErr(err))
+		}
+		var refs []string
+		for _, r := range ffeLiveRules(flag, "test") {
+			refs = append(refs, ffeRuleRef(r))
+		}
+		if !slices.Equal(refs, expected) {
+			return fmt.Errorf("expected rules %v, got %v", expected, refs)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,205 @@
+package launchdarkly
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+const (
+	testAccFeatureFlagTargetCreate = `
+resource "launchdarkly_feature_flag_target" "team_a" {
+	project_key = launchdarkly_project.test.key
+	env_key = "test"
+	flag_key = launchdarkly_feature_flag.trigger_flag.key
+	variation = 0
+	values = ["team-a-1", "team-a-2"]
+}
+
+resource "launchdarkly_feature_flag_target" "team_b" {
+	project_key = launchdarkly_project.test.key
+	env_key = "test"
+	flag_key = launchdarkly_feature_flag.trigger_flag.key
+	variation = 0
+	values = ["team-b-1"]
+}
+`
+
+	testAccFeatureFlagTargetUpdate = `
+resource "launchdarkly_feature_flag_target" "team_a" {
+	project_key = launchdarkly_project.test.key
+	env_key = "test"
+	flag_key = launchdarkly_feature_flag.trigger_flag.key
+	variation = 0
+	values = ["team-a-2", "team-a-3"]
+}
+
+resource "launchdarkly_feature_flag_target" "team_b" {
+	project_key = launchdarkly_project.test.key
+	env_key = "test"
+	flag_key = launchdarkly_feature_flag.trigger_flag.key
+	variation = 0
+	values = ["team-b-1"]
+}
+`
+
+	testAccFeatureFlagTargetContextKind = `
+resource "launchdarkly_feature_flag_target" "team_b" {
+	project_key = launchdarkly_project.test.key
+	env_key = "test"
+	flag_key = launchdarkly_feature_flag.trigger_flag.key
+	variation = 0
+	values = ["team-b-1"]
+}
+
+resource "launchdarkly_feature_flag_target" "org" {
+	project_key = launchdarkly_project.test.key
+	env_key = "test"
+	flag_key = launchdarkly_feature_flag.trigger_flag.key
+	context_kind = "organization"
+	variation = 1
+	values = ["org-1"]
+}
+`
+
+	testAccFeatureFlagTargetExpirations = `
+resource "launchdarkly_feature_flag_target" "beta" {
+	project_key = launchdarkly_project.test.key
+	env_key = "test"
+	flag_key = launchdarkly_feature_flag.trigger_flag.key
+	variation = 0
+	values = ["customer-1", "customer-2", "customer-3"]
+	expirations = {
+		"customer-1" = "%s"
+		"customer-2" = "2020-01-01T00:00:00Z"
+	}
+}
+`
+)
+
+func TestAccFeatureFlagTarget_SharedVariation(t *testing.T) {
+	projectKey := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	flagKey := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	teamA := "launchdarkly_feature_flag_target.team_a"
+	teamB := "launchdarkly_feature_flag_target.team_b"
+	org := "launchdarkly_feature_flag_target.org"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: withRandomProject(projectKey, withRandomFlag(flagKey, testAccFeatureFlagTargetCreate)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(teamA, ID, fmt.Sprintf("%s/test/%s/user/0", projectKey, flagKey)),
+					resource.TestCheckResourceAttr(teamA, CONTEXT_KIND, "user"),
+					resource.TestCheckResourceAttr(teamA, "values.#", "2"),
+					resource.TestCheckResourceAttr(teamB, "values.#", "1"),
+					testAccCheckFlagTargetValues(projectKey, flagKey, "user", 0, "team-a-1", "team-a-2", "team-b-1"),
+				),
+			},
+			{
+				Config: withRandomProject(projectKey, withRandomFlag(flagKey, testAccFeatureFlagTargetUpdate)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr(teamA, "values.*", "team-a-3"),
+					resource.TestCheckResourceAttr(teamA, "values.#", "2"),
+					resource.TestCheckResourceAttr(teamB, "values.#", "1"),
+					testAccCheckFlagTargetValues(projectKey, flagKey, "user", 0, "team-a-2", "team-a-3", "team-b-1"),
+				),
+			},
+			{
+				ResourceName:  teamB,
+				ImportState:   true,
+				ImportStateId: fmt.Sprintf("%s/test/%s/user/0", projectKey, flagKey),
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					// An imported target adopts every key targeted to the variation.
+					if got := states[0].Attributes["values.#"]; got != "3" {
+						return fmt.Errorf("expected the import to adopt 3 values, got %s", got)
+					}
+					return nil
+				},
+			},
+			{
+				Config: withRandomProject(projectKey, withRandomFlag(flagKey, testAccFeatureFlagTargetContextKind)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(org, ID, fmt.Sprintf("%s/test/%s/organization/1", projectKey, flagKey)),
+					testAccCheckFlagTargetValues(projectKey, flagKey, "user", 0, "team-b-1"),
+					testAccCheckFlagTargetValues(projectKey, flagKey, "organization", 1, "org-1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccFeatureFlagTarget_Expirations(t *testing.T) {
+	projectKey := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	flagKey := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	beta := "launchdarkly_feature_flag_target.beta"
+	expires := time.Now().Add(30 * 24 * time.Hour).Truncate(time.Hour)
+	eastern := expires.In(time.FixedZone("EST", -5*60*60)).Format(time.RFC3339)
+	later := expires.Add(24 * time.Hour).UTC().Format(time.RFC3339)
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: withRandomProject(projectKey, withRandomFlag(flagKey, fmt.Sprintf(testAccFeatureFlagTargetExpirations, eastern))),
+				Check: resource.ComposeTestCheckFunc(
+					// customer-2 has already expired, so it is not targeted
+					// but stays in values.
+					resource.TestCheckResourceAttr(beta, "values.#", "3"),
+					resource.TestCheckResourceAttr(beta, "expirations.customer-1", eastern),
+					resource.TestCheckResourceAttr(beta, "expirations.customer-2", "2020-01-01T00:00:00Z"),
+					testAccCheckFlagTargetValues(projectKey, flagKey, "user", 0, "customer-1", "customer-3"),
+				),
+			},
+			{
+				Config: withRandomProject(projectKey, withRandomFlag(flagKey, fmt.Sprintf(testAccFeatureFlagTargetExpirations, later))),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(beta, "expirations.customer-1", later),
+					testAccCheckFlagTargetValues(projectKey, flagKey, "user", 0, "customer-1", "customer-3"),
+				),
+			},
+			{
+				ResourceName:  beta,
+				ImportState:   true,
+				ImportStateId: fmt.Sprintf("%s/test/%s/user/0", projectKey, flagKey),
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if got := states[0].Attributes["expirations.customer-1"]; got != later {
+						return fmt.Errorf("expected the import to read expiration %s, got %q", later, got)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+// testAccCheckFlagTargetValues checks the keys of contextKind individually
+// targeted to variation in the flag's "test" environment.
+func testAccCheckFlagTargetValues(projectKey, flagKey, contextKind string, variation int32, expected ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := mustTestAccClient()
+		flag, _, err := getFeatureFlagEnvironment(client, projectKey, flagKey, "test")
+		if err != nil {
+			return fmt.Errorf("failed to get flag %q: %s", flagKey, handleLdapiErr(err))
+		}
+		env := (*flag.Environments)["test"]
+		values := flagTargetValues(env.Targets, env.ContextTargets, contextKind, variation)
+		if len(values) != len(expected) {
+			return fmt.Errorf("expected %s targets %v for variation %d, got %v", contextKind, expected, variation, values)
+		}
+		in, _ := partitionStrings(expected, values)
+		if len(in) != len(expected) {
+			return fmt.Errorf("expected %s targets %v for variation %d, got %v", contextKind, expected, variation, values)
+		}
+		return nil
+	}
+}
@@ -791,7 +791,7 @@ func TestAccFeatureFlag_Number(t *testing.T) {
 
 // importIgnoreOptionalComputedKeys is the canonical set of attribute
 // paths suppressed by ImportStateVerify for tests that declare any of
-// variations / defaults / client_side_availability — Import emits the
+// variations / defaults / client_side_availability / environments — Import emits the
 // API's view of these and diverges from the user's config-anchored
 // state.
 var importIgnoreOptionalComputedKeys = []string{
@@ -803,6 +803,8 @@ var importIgnoreOptionalComputedKeys = []string{
 	"defaults.%", "defaults.on_variation", "defaults.off_variation",
 	"client_side_availability.%",
 	"client_side_availability.using_environment_id", "client_side_availability.using_mobile_key",
+	// Import fills environments with every environment of the flag.
+	"environments",
 }
 
 func TestAccFeatureFlag_JSONBasic(t *testing.T) {
@@ -1868,3 +1870,206 @@ func TestAccFeatureFlag_ArchiveOnDestroy(t *testing.T) {
 		},
 	})
 }
+
+const (
+	testAccFeatureFlagEnvironments = `
+resource "launchdarkly_feature_flag" "inline" {
+	project_key = launchdarkly_project.test.key
+	key = "inline-environments"
+	name = "Inline environments"
+	variation_type = "string"
+	variations = [
+		{ value = "straw", name = "Straw" },
+		{ value = "sticks", name = "Sticks" },
+		{ value = "bricks", name = "Bricks" },
+	]
+	environments = {
+		test = {
+			on = true
+			off_variation_name = "Straw"
+			targets = [{
+				values = ["wolf"]
+				variation = 0
+			}]
+			rules = [{
+				clauses = [{
+					attribute = "country"
+					op = "in"
+					values = ["gb"]
+				}]
+				variation_value = "bricks"
+			}]
+			fallthrough = {
+				variation_name = "Sticks"
+			}
+		}
+	}
+}
+`
+
+	testAccFeatureFlagEnvironmentsUpdate = `
+resource "launchdarkly_feature_flag" "inline" {
+	project_key = launchdarkly_project.test.key
+	key = "inline-environments"
+	name = "Inline environments"
+	variation_type = "string"
+	variations = [
+		{ value = "bricks", name = "Bricks" },
+		{ value = "straw", name = "Straw" },
+		{ value = "sticks", name = "Sticks" },
+	]
+	environments = {
+		test = {
+			on = false
+			track_events = true
+			fallthrough = {
+				rollout = { "straw" = 40000, "bricks" = 60000 }
+			}
+		}
+	}
+}
+`
+)
+
+func TestAccFeatureFlag_Environments(t *testing.T) {
+	projectKey := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resourceName := "launchdarkly_feature_flag.inline"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: withRandomProject(projectKey, testAccFeatureFlagEnvironments),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFeatureFlagExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "environments.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "environments.test.on", "true"),
+					resource.TestCheckResourceAttr(resourceName, "environments.test.off_variation", "0"),
+					resource.TestCheckResourceAttr(resourceName, "environments.test.targets.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "environments.test.rules.0.variation", "2"),
+					resource.TestCheckResourceAttr(resourceName, "environments.test.fallthrough.variation", "1"),
+				),
+			},
+			{
+				// Importing with the environments suffix reads every
+				// environment; LaunchDarkly only stores variation indexes.
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccFeatureFlagImportEnvironmentsId(resourceName),
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"environments.test.off_variation_name",
+					"environments.test.rules.0.variation_value",
+					"environments.test.fallthrough.variation_name",
+				},
+			},
+			{
+				Config: withRandomProject(projectKey, testAccFeatureFlagEnvironmentsUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFeatureFlagExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "environments.test.on", "false"),
+					resource.TestCheckResourceAttr(resourceName, "environments.test.track_events", "true"),
+					resource.TestCheckNoResourceAttr(resourceName, "environments.test.off_variation"),
+					resource.TestCheckNoResourceAttr(resourceName, "environments.test.targets.#"),
+					resource.TestCheckNoResourceAttr(resourceName, "environments.test.rules.#"),
+					resource.TestCheckResourceAttr(resourceName, "environments.test.fallthrough.rollout_weights.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "environments.test.fallthrough.rollout_weights.0", "60000"),
+					resource.TestCheckResourceAttr(resourceName, "environments.test.fallthrough.rollout_weights.1", "40000"),
+					resource.TestCheckResourceAttr(resourceName, "environments.test.fallthrough.rollout_weights.2", "0"),
+				),
+			},
+		},
+	})
+}
+
+// testAccFeatureFlagImportEnvironmentsId returns the import ID of a flag
+// with the suffix that imports its environments.
+func testAccFeatureFlagImportEnvironmentsId(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", resourceName)
+		}
+		return rs.Primary.ID + flagImportEnvironmentsSuffix, nil
+	}
+}
+
+const (
+	testAccFeatureFlagMigration = `
+resource "launchdarkly_feature_flag" "migration" {
+	project_key = launchdarkly_project.test.key
+	key = "orders-db-migration"
+	name = "Orders database migration"
+	variation_type = "string"
+	migration_settings = {
+		context_kind = "user"
+		stage_count = 4
+	}
+}
+
+resource "launchdarkly_feature_flag_environment" "migration" {
+	flag_id = launchdarkly_feature_flag.migration.id
+	env_key = "test"
+	on = true
+	off_variation_value = "off"
+	fallthrough = {
+		variation_value = "shadow"
+	}
+}
+`
+
+	testAccFeatureFlagMigrationInvalidVariations = `
+resource "launchdarkly_feature_flag" "migration" {
+	project_key = launchdarkly_project.test.key
+	key = "orders-db-migration"
+	name = "Orders database migration"
+	variation_type = "string"
+	variations = [
+		{ value = "off" },
+		{ value = "on" },
+	]
+	migration_settings = {
+		context_kind = "user"
+		stage_count = 2
+	}
+}
+`
+)
+
+func TestAccFeatureFlag_Migration(t *testing.T) {
+	projectKey := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resourceName := "launchdarkly_feature_flag.migration"
+	envResourceName := "launchdarkly_feature_flag_environment.migration"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: withRandomProject(projectKey, testAccFeatureFlagMigration),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFeatureFlagExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "migration_settings.context_kind", "user"),
+					resource.TestCheckResourceAttr(resourceName, "migration_settings.stage_count", "4"),
+					resource.TestCheckResourceAttr(resourceName, "variations.#", "4"),
+					resource.TestCheckResourceAttr(resourceName, "variations.0.value", "off"),
+					resource.TestCheckResourceAttr(resourceName, "variations.2.value", "shadow"),
+					resource.TestCheckResourceAttr(envResourceName, OFF_VARIATION, "0"),
+					resource.TestCheckResourceAttr(envResourceName, "fallthrough.variation", "2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:      withRandomProject(projectKey, testAccFeatureFlagMigrationInvalidVariations),
+				ExpectError: regexp.MustCompile("must be its stages"),
+			},
+		},
+	})
+}
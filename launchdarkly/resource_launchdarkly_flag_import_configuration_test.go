@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 // NOTE FOR REVIEWERS: these tests exercise a beta integration resource. The
@@ -50,6 +51,22 @@ resource "launchdarkly_flag_import_configuration" "test" {
 }
 `
 
+const testAccFlagImportConfigurationWriteOnly = `
+resource "launchdarkly_flag_import_configuration" "test" {
+	project_key     = launchdarkly_project.test.key
+	integration_key = "split"
+	name            = "terraform flag import write-only test"
+
+	config_wo = jsonencode({
+		workspaceApiKey = "%s"
+		workspaceId     = "placeholder-workspace-id"
+		environmentId   = "placeholder-environment-id"
+		ldApiKey        = "placeholder-ld-api-key"
+	})
+	config_wo_version = %d
+}
+`
+
 func TestAccFlagImportConfiguration_CreateUpdate(t *testing.T) {
 	projectKey := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
 	resourceName := "launchdarkly_flag_import_configuration.test"
@@ -96,6 +113,44 @@ func TestAccFlagImportConfiguration_CreateUpdate(t *testing.T) {
 	})
 }
 
+func TestAccFlagImportConfiguration_WriteOnlyConfig(t *testing.T) {
+	projectKey := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resourceName := "launchdarkly_flag_import_configuration.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckFlagImportConfigurationDestroy,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: withRandomProject(projectKey, fmt.Sprintf(testAccFlagImportConfigurationWriteOnly, "placeholder-admin-key", 1)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFlagImportConfigurationExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, CONFIG_WO_VERSION, "1"),
+					resource.TestCheckNoResourceAttr(resourceName, CONFIG),
+					resource.TestCheckNoResourceAttr(resourceName, CONFIG_WO),
+				),
+			},
+			{
+				// Changing only the write-only value is invisible to Terraform.
+				Config:   withRandomProject(projectKey, fmt.Sprintf(testAccFlagImportConfigurationWriteOnly, "rotated-admin-key", 1)),
+				PlanOnly: true,
+			},
+			{
+				Config: withRandomProject(projectKey, fmt.Sprintf(testAccFlagImportConfigurationWriteOnly, "rotated-admin-key", 2)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFlagImportConfigurationExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, CONFIG_WO_VERSION, "2"),
+					resource.TestCheckNoResourceAttr(resourceName, CONFIG),
+					resource.TestCheckNoResourceAttr(resourceName, CONFIG_WO),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckFlagImportConfigurationExists(resourceName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[resourceName]
@@ -0,0 +1,112 @@
+package launchdarkly
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const (
+	testAccFlagScheduledChangeCreate = `
+resource "launchdarkly_flag_scheduled_change" "launch" {
+	project_key = launchdarkly_project.test.key
+	env_key = "test"
+	flag_key = launchdarkly_feature_flag.trigger_flag.key
+	execution_date = "%s"
+	instructions = [
+		{
+			kind = "turnFlagOn"
+		},
+		{
+			kind = "addTargets"
+			variation = 0
+			values = ["qa-alice", "qa-bob"]
+		},
+	]
+}
+`
+
+	testAccFlagScheduledChangeUpdate = `
+resource "launchdarkly_flag_scheduled_change" "launch" {
+	project_key = launchdarkly_project.test.key
+	env_key = "test"
+	flag_key = launchdarkly_feature_flag.trigger_flag.key
+	execution_date = "%s"
+	comment = "Moved launch"
+	instructions = [
+		{
+			kind = "turnFlagOn"
+		},
+		{
+			kind = "updateFallthroughVariationOrRollout"
+			rollout_weights = [10000, 90000]
+			bucket_by = "email"
+		},
+		{
+			kind = "addRule"
+			ref = "internal-employees"
+			variation = 0
+			clauses = [
+				{
+					attribute = "email"
+					op = "endsWith"
+					values = ["@example.com"]
+				},
+			]
+		},
+	]
+}
+`
+)
+
+func TestAccFlagScheduledChange_CreateUpdate(t *testing.T) {
+	projectKey := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	flagKey := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resourceName := "launchdarkly_flag_scheduled_change.launch"
+	launch := time.Now().Add(30 * 24 * time.Hour).Truncate(time.Hour)
+	// The same instant written with an offset is not a change.
+	eastern := launch.In(time.FixedZone("EST", -5*60*60)).Format(time.RFC3339)
+	moved := launch.Add(24 * time.Hour).UTC().Format(time.RFC3339)
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: withRandomProject(projectKey, withRandomFlag(flagKey, fmt.Sprintf(testAccFlagScheduledChangeCreate, launch.UTC().Format(time.RFC3339)))),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, ID),
+					resource.TestCheckResourceAttr(resourceName, STATUS, SCHEDULED_CHANGE_PENDING),
+					resource.TestCheckResourceAttr(resourceName, "instructions.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "instructions.1.values.#", "2"),
+				),
+			},
+			{
+				ResourceName:        resourceName,
+				ImportState:         true,
+				ImportStateIdPrefix: fmt.Sprintf("%s/test/%s/", projectKey, flagKey),
+				ImportStateVerify:   true,
+			},
+			{
+				Config: withRandomProject(projectKey, withRandomFlag(flagKey, fmt.Sprintf(testAccFlagScheduledChangeCreate, eastern))),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, EXECUTION_DATE, eastern),
+				),
+			},
+			{
+				Config: withRandomProject(projectKey, withRandomFlag(flagKey, fmt.Sprintf(testAccFlagScheduledChangeUpdate, moved))),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, EXECUTION_DATE, moved),
+					resource.TestCheckResourceAttr(resourceName, STATUS, SCHEDULED_CHANGE_PENDING),
+					resource.TestCheckResourceAttr(resourceName, "instructions.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "instructions.1.rollout_weights.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "instructions.2.ref", "internal-employees"),
+				),
+			},
+		},
+	})
+}
@@ -502,6 +502,55 @@ func TestAccSegment_WithRules(t *testing.T) {
 	})
 }
 
+const testAccSegmentWeightPercentage = `
+resource "launchdarkly_segment" "test" {
+	key         = "segmentKey1"
+	project_key = launchdarkly_project.test.key
+	env_key     = "test"
+	name        = "segment name"
+	rules = [{
+		clauses = [{
+			attribute = "test_att"
+			op        = "in"
+			values    = ["test"]
+		}]
+		weight_percentage = 12.5
+	}]
+}`
+
+func TestAccSegment_WeightPercentage(t *testing.T) {
+	projectKey := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resourceName := "launchdarkly_segment.test"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: withRandomProject(projectKey, testAccSegmentWeightPercentage),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSegmentExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.weight_percentage", "12.5"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.weight", "12500"),
+				),
+			},
+			{
+				// The percentage is kept in state as configured, so a second
+				// plan is empty.
+				Config:   withRandomProject(projectKey, testAccSegmentWeightPercentage),
+				PlanOnly: true,
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"rules.0.weight_percentage"},
+			},
+		},
+	})
+}
+
 func TestAccSegment_WithTargetingByContext(t *testing.T) {
 	projectKey := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
 	resourceName := "launchdarkly_segment.test"
@@ -622,6 +671,80 @@ func TestAccSegment_WithTargetingByContext(t *testing.T) {
 	})
 }
 
+const testAccSegmentIncludedContextExpirations = `
+resource "launchdarkly_segment" "test" {
+	key = "beta-customers"
+	project_key = launchdarkly_project.test.key
+	env_key = "test"
+	name = "Beta customers"
+	included_contexts = [{
+		context_kind = "organization"
+		values = ["customer-1", "customer-2", "customer-3"]
+		expirations = {
+			"customer-1" = "%s"
+			"customer-2" = "2020-01-01T00:00:00Z"
+		}
+	}]
+}
+`
+
+func TestAccSegment_IncludedContextExpirations(t *testing.T) {
+	projectKey := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resourceName := "launchdarkly_segment.test"
+	expires := time.Now().Add(30 * 24 * time.Hour).Truncate(time.Hour)
+	eastern := expires.In(time.FixedZone("EST", -5*60*60)).Format(time.RFC3339)
+	later := expires.Add(24 * time.Hour).UTC().Format(time.RFC3339)
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: withRandomProject(projectKey, fmt.Sprintf(testAccSegmentIncludedContextExpirations, eastern)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSegmentExists(resourceName),
+					// customer-2 has already expired, so it is not included
+					// but stays in values.
+					resource.TestCheckResourceAttr(resourceName, "included_contexts.0.values.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "included_contexts.0.expirations.customer-1", eastern),
+					resource.TestCheckResourceAttr(resourceName, "included_contexts.0.expirations.customer-2", "2020-01-01T00:00:00Z"),
+					testAccCheckSegmentIncludedContexts(projectKey, "beta-customers", "organization", "customer-1", "customer-3"),
+				),
+			},
+			{
+				Config: withRandomProject(projectKey, fmt.Sprintf(testAccSegmentIncludedContextExpirations, later)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "included_contexts.0.expirations.customer-1", later),
+					testAccCheckSegmentIncludedContexts(projectKey, "beta-customers", "organization", "customer-1", "customer-3"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckSegmentIncludedContexts checks the keys of contextKind that
+// LaunchDarkly includes in a segment of the test environment.
+func testAccCheckSegmentIncludedContexts(projectKey, segmentKey, contextKind string, want ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := mustTestAccClient()
+		segment, _, err := client.ld.SegmentsApi.GetSegment(client.ctx, projectKey, "test", segmentKey).Execute()
+		if err != nil {
+			return fmt.Errorf("failed to get segment %q: %s", segmentKey, handleLdapiErr(err))
+		}
+		var got []string
+		for _, t := range segment.IncludedContexts {
+			if t.GetContextKind() == contextKind {
+				got = append(got, t.Values...)
+			}
+		}
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			return fmt.Errorf("expected segment %q to include %s contexts %v, got %v", segmentKey, contextKind, want, got)
+		}
+		return nil
+	}
+}
+
 func testAccCheckSegmentExists(resourceName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[resourceName]
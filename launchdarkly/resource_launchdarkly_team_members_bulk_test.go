@@ -0,0 +1,155 @@
+package launchdarkly
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testAccTeamMembersBulkCreate = `
+resource "launchdarkly_team_members_bulk" "test" {
+	members = [
+		{
+			email = "%s-one+wbteste2e@launchdarkly.com"
+			role  = "reader"
+		},
+		{
+			email = "%s-two+wbteste2e@launchdarkly.com"
+			role  = "writer"
+		},
+	]
+}
+`
+	// Reorders the members, updates the role of one, removes one and adds
+	// one.
+	testAccTeamMembersBulkUpdate = `
+resource "launchdarkly_team_members_bulk" "test" {
+	members = [
+		{
+			email = "%s-three+wbteste2e@launchdarkly.com"
+		},
+		{
+			email = "%s-one+wbteste2e@launchdarkly.com"
+			role  = "no_access"
+		},
+	]
+}
+`
+)
+
+func TestAccTeamMembersBulk_CreateAndUpdate(t *testing.T) {
+	randomName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resourceName := "launchdarkly_team_members_bulk.test"
+	var memberOneID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckTeamMembersBulkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccTeamMembersBulkCreate, randomName, randomName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "members.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "members.0.email", randomName+"-one+wbteste2e@launchdarkly.com"),
+					resource.TestCheckResourceAttr(resourceName, "members.0.role", "reader"),
+					resource.TestCheckResourceAttrSet(resourceName, "members.0.id"),
+					resource.TestCheckResourceAttr(resourceName, "members.1.role", "writer"),
+					resource.TestCheckResourceAttrSet(resourceName, "members.1.id"),
+					resource.TestCheckResourceAttrWith(resourceName, "members.0.id", func(id string) error {
+						memberOneID = id
+						return nil
+					}),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccTeamMembersBulkUpdate, randomName, randomName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "members.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "members.0.email", randomName+"-three+wbteste2e@launchdarkly.com"),
+					resource.TestCheckResourceAttr(resourceName, "members.0.role", "reader"),
+					resource.TestCheckResourceAttrSet(resourceName, "members.0.id"),
+					resource.TestCheckResourceAttr(resourceName, "members.1.role", "no_access"),
+					// The member is matched by email, so it keeps its ID.
+					resource.TestCheckResourceAttrWith(resourceName, "members.1.id", func(id string) error {
+						if id != memberOneID {
+							return fmt.Errorf("expected the member to keep ID %q, got %q", memberOneID, id)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s-three+wbteste2e@launchdarkly.com,%s-one+wbteste2e@launchdarkly.com", randomName, randomName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckTeamMembersBulkDestroy(s *terraform.State) error {
+	client := mustTestAccClient()
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "launchdarkly_team_members_bulk" {
+			continue
+		}
+		for key, id := range rs.Primary.Attributes {
+			if !isTeamMembersBulkIDAttribute(key) {
+				continue
+			}
+			_, res, err := client.ld.AccountMembersApi.GetMember(client.ctx, id).Execute()
+			if isStatusNotFound(res) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			return fmt.Errorf("team member %s still exists", id)
+		}
+	}
+	return nil
+}
+
+// isTeamMembersBulkIDAttribute reports whether key is the flatmap key of a
+// member's id, such as "members.0.id".
+func isTeamMembersBulkIDAttribute(key string) bool {
+	return strings.HasPrefix(key, "members.") && strings.HasSuffix(key, ".id")
+}
+
+func TestDiffTeamMembersBulk(t *testing.T) {
+	member := func(id, email string) teamMembersBulkMemberModel {
+		m := teamMembersBulkMemberModel{ID: types.StringNull(), Email: types.StringValue(email)}
+		if id != "" {
+			m.ID = types.StringValue(id)
+		}
+		return m
+	}
+	state := []teamMembersBulkMemberModel{
+		member("1", "kept@example.com"),
+		member("2", "removed@example.com"),
+		member("", "rejected@example.com"),
+		member("4", "Moved@example.com"),
+	}
+	plan := []teamMembersBulkMemberModel{
+		member("", "moved@example.com"),
+		member("", "new@example.com"),
+		member("", "kept@example.com"),
+		member("", "rejected@example.com"),
+	}
+
+	diff := diffTeamMembersBulk(state, plan)
+	assert.Equal(t, []int{1}, diff.removed)
+	assert.Equal(t, []int{1, 3}, diff.added)
+	assert.Equal(t, map[int]int{0: 3, 2: 0}, diff.kept)
+
+	assert.Equal(t, teamMembersBulkId(state[:2]), teamMembersBulkId([]teamMembersBulkMemberModel{state[1], state[0]}))
+}
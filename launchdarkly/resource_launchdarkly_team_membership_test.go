@@ -0,0 +1,129 @@
+package launchdarkly
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// testAccTeamMembershipSetup scaffolds three members and a team whose
+// members are managed by launchdarkly_team_membership. Team members need to
+// be made sequentially, not in parallel.
+func testAccTeamMembershipSetup(randomName, teamKey string) string {
+	return fmt.Sprintf(`
+resource "launchdarkly_team_member" "one" {
+  email = "%s-one+wbteste2e@launchdarkly.com"
+  role  = "reader"
+}
+
+resource "launchdarkly_team_member" "two" {
+  email      = "%s-two+wbteste2e@launchdarkly.com"
+  role       = "reader"
+  depends_on = [launchdarkly_team_member.one]
+}
+
+resource "launchdarkly_team_member" "three" {
+  email      = "%s-three+wbteste2e@launchdarkly.com"
+  role       = "reader"
+  depends_on = [launchdarkly_team_member.two]
+}
+
+resource "launchdarkly_team" "test" {
+  key  = "%s"
+  name = "Team membership test"
+}
+`, randomName, randomName, randomName, teamKey)
+}
+
+func testAccTeamMembershipConfig(randomName, teamKey string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "launchdarkly_team_membership" "one" {
+  team_key   = launchdarkly_team.test.key
+  member_ids = [launchdarkly_team_member.one.id]
+}
+
+resource "launchdarkly_team_membership" "others" {
+  team_key   = launchdarkly_team.test.key
+  member_ids = [launchdarkly_team_member.two.id]
+  depends_on = [launchdarkly_team_membership.one]
+}
+`, testAccTeamMembershipSetup(randomName, teamKey))
+}
+
+func testAccTeamMembershipConfigUpdate(randomName, teamKey string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "launchdarkly_team_membership" "one" {
+  team_key   = launchdarkly_team.test.key
+  member_ids = [launchdarkly_team_member.one.id]
+}
+
+resource "launchdarkly_team_membership" "others" {
+  team_key   = launchdarkly_team.test.key
+  member_ids = [launchdarkly_team_member.three.id]
+  depends_on = [launchdarkly_team_membership.one]
+}
+`, testAccTeamMembershipSetup(randomName, teamKey))
+}
+
+func TestAccTeamMembership_basic(t *testing.T) {
+	randomName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	teamKey := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resourceName := "launchdarkly_team_membership.others"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTeamMembershipConfig(randomName, teamKey),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, TEAM_KEY, teamKey),
+					resource.TestCheckResourceAttr(resourceName, "member_ids.#", "1"),
+					resource.TestCheckResourceAttrPair(resourceName, "member_ids.0", "launchdarkly_team_member.two", ID),
+					resource.TestCheckResourceAttrPair("launchdarkly_team_membership.one", "member_ids.0", "launchdarkly_team_member.one", ID),
+					testAccCheckTeamMemberCount(teamKey, 2),
+				),
+			},
+			{
+				// Replacing a member leaves the members of the other
+				// launchdarkly_team_membership alone.
+				Config: testAccTeamMembershipConfigUpdate(randomName, teamKey),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "member_ids.#", "1"),
+					resource.TestCheckResourceAttrPair(resourceName, "member_ids.0", "launchdarkly_team_member.three", ID),
+					resource.TestCheckResourceAttrPair("launchdarkly_team_membership.one", "member_ids.0", "launchdarkly_team_member.one", ID),
+					testAccCheckTeamMemberCount(teamKey, 2),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{COMMENT},
+			},
+		},
+	})
+}
+
+// testAccCheckTeamMemberCount checks the number of members of the team with
+// teamKey, including those not managed by launchdarkly_team_membership.
+func testAccCheckTeamMemberCount(teamKey string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := mustTestAccClient()
+		members, err := getAllTeamMembers(client, teamKey)
+		if err != nil {
+			return err
+		}
+		if len(members) != count {
+			return fmt.Errorf("expected team %q to have %d members, got %d", teamKey, count, len(members))
+		}
+		return nil
+	}
+}
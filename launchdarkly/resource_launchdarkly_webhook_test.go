@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 const (
@@ -87,6 +88,26 @@ resource "launchdarkly_webhook" "with_statements" {
 		resources = ["proj/test:env/production:segment/*"]
 	}]
 } 
+`
+
+	testAccWebhookWriteOnlySecret = `
+resource "launchdarkly_webhook" "test" {
+	name              = "write-only-webhook"
+	url               = "http://webhooks.com"
+	on                = true
+	secret_wo         = "%s"
+	secret_wo_version = %d
+}
+`
+
+	testAccWebhookWriteOnlySecretConflict = `
+resource "launchdarkly_webhook" "test" {
+	name              = "write-only-webhook"
+	url               = "http://webhooks.com"
+	secret            = "SuperSecret"
+	secret_wo         = "SuperSecret"
+	secret_wo_version = 1
+}
 `
 
 	testAccWebhookInvalidStatements = `
@@ -215,6 +236,49 @@ func TestAccWebhook_Update(t *testing.T) {
 	})
 }
 
+func TestAccWebhook_WriteOnlySecret(t *testing.T) {
+	resourceName := "launchdarkly_webhook.test"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccWebhookWriteOnlySecret, "FirstSecret", 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWebhookExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, SECRET_WO_VERSION, "1"),
+					resource.TestCheckNoResourceAttr(resourceName, SECRET),
+					resource.TestCheckNoResourceAttr(resourceName, SECRET_WO),
+				),
+			},
+			{
+				// Changing only the write-only value is invisible to Terraform.
+				Config:   fmt.Sprintf(testAccWebhookWriteOnlySecret, "SecondSecret", 1),
+				PlanOnly: true,
+			},
+			{
+				Config: fmt.Sprintf(testAccWebhookWriteOnlySecret, "SecondSecret", 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWebhookExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, SECRET_WO_VERSION, "2"),
+					resource.TestCheckNoResourceAttr(resourceName, SECRET),
+					resource.TestCheckNoResourceAttr(resourceName, SECRET_WO),
+				),
+			},
+			{
+				Config:      testAccWebhookWriteOnlySecretConflict,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
 func TestAccWebhook_CreateWithStatements(t *testing.T) {
 	resourceName := "launchdarkly_webhook.with_statements"
 	resource.ParallelTest(t, resource.TestCase{
@@ -43,6 +43,7 @@ type MetricResourceModel struct {
 	MaintainerID              types.String `tfsdk:"maintainer_id"`
 	Description               types.String `tfsdk:"description"`
 	Tags                      types.Set    `tfsdk:"tags"`
+	TagsAll                   types.Set    `tfsdk:"tags_all"`
 	IsNumeric                 types.Bool   `tfsdk:"is_numeric"`
 	Unit                      types.String `tfsdk:"unit"`
 	Selector                  types.String `tfsdk:"selector"`
@@ -123,6 +124,7 @@ func metricSchemaAttributes() map[string]schema.Attribute {
 			ElementType: types.StringType,
 			Description: "Tags associated with this resource.",
 		},
+		TAGS_ALL: tagsAllAttribute(),
 		IS_NUMERIC: schema.BoolAttribute{
 			Optional:    true,
 			Computed:    true,
@@ -237,6 +239,7 @@ func (r *MetricResource) UpgradeState(_ context.Context) map[int64]resource.Stat
 					MaintainerID:              prior.MaintainerID,
 					Description:               nullIfEmptyString(prior.Description),
 					Tags:                      prior.Tags,
+					TagsAll:                   types.SetNull(types.StringType),
 					IsNumeric:                 prior.IsNumeric,
 					Unit:                      nullIfEmptyString(prior.Unit),
 					Selector:                  nullIfEmptyString(prior.Selector),
@@ -270,6 +273,7 @@ func (r *MetricResource) UpgradeState(_ context.Context) map[int64]resource.Stat
 					MaintainerID:              prior.MaintainerID,
 					Description:               prior.Description,
 					Tags:                      prior.Tags,
+					TagsAll:                   types.SetNull(types.StringType),
 					IsNumeric:                 prior.IsNumeric,
 					Unit:                      prior.Unit,
 					Selector:                  prior.Selector,
@@ -294,11 +298,14 @@ func (r *MetricResource) UpgradeState(_ context.Context) map[int64]resource.Stat
 // analysis_type=percentile, and marks `version` as unknown when any
 // other attribute changes.
 func (r *MetricResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.client.planGuardrails(ctx, req, resp, guardrailScope{project: PROJECT_KEY})
 	if req.Plan.Raw.IsNull() {
 		return
 	}
+	// tags_all is planned first so the version check below sees it.
+	r.client.planTagsAll(ctx, req, resp)
 	var plan MetricResourceModel
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -471,6 +478,9 @@ func (r *MetricResource) Configure(_ context.Context, req resource.ConfigureRequ
 }
 
 func (r *MetricResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_metric", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan MetricResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -487,7 +497,7 @@ func (r *MetricResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	tags, d := stringSliceFromSet(ctx, plan.Tags)
+	tags, d := stringSliceFromSet(ctx, plan.TagsAll)
 	resp.Diagnostics.Append(d...)
 	analysisUnits, d := stringSliceFromSet(ctx, plan.AnalysisUnits)
 	resp.Diagnostics.Append(d...)
@@ -589,6 +599,9 @@ func (r *MetricResource) Read(ctx context.Context, req resource.ReadRequest, res
 }
 
 func (r *MetricResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_metric", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan MetricResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -608,6 +621,9 @@ func (r *MetricResource) Update(ctx context.Context, req resource.UpdateRequest,
 }
 
 func (r *MetricResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_metric", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data MetricResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -639,7 +655,7 @@ func (r *MetricResource) applyMetricUpdate(ctx context.Context, plan *MetricReso
 	projectKey := plan.ProjectKey.ValueString()
 	key := plan.Key.ValueString()
 
-	tags, _ := stringSliceFromSet(ctx, plan.Tags)
+	tags, _ := stringSliceFromSet(ctx, plan.TagsAll)
 	var urls []metricURLModel
 	if !plan.URLs.IsNull() && !plan.URLs.IsUnknown() {
 		_ = plan.URLs.ElementsAs(ctx, &urls, false)
@@ -767,9 +783,12 @@ func (r *MetricResource) readIntoModel(
 		data.MaintainerID = types.StringValue("")
 	}
 
-	tagsSet, d := setFromStringSlice(ctx, metric.Tags)
+	ownTags, tagsAll, d := r.client.splitDefaultTags(ctx, metric.Tags, data.Tags)
+	diags.Append(d...)
+	tagsSet, d := setFromStringSlice(ctx, ownTags)
 	diags.Append(d...)
 	data.Tags = tagsSet
+	data.TagsAll = tagsAll
 
 	// GET mirrors randomizationUnits and analysisUnits server-side at read
 	// time, regardless of metric age or which field was written (verified
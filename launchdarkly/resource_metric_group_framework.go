@@ -39,6 +39,7 @@ type MetricGroupResourceModel struct {
 	Description  types.String `tfsdk:"description"`
 	MaintainerID types.String `tfsdk:"maintainer_id"`
 	Tags         types.Set    `tfsdk:"tags"`
+	TagsAll      types.Set    `tfsdk:"tags_all"`
 	Metrics      types.List   `tfsdk:"metrics"`
 	Version      types.Int64  `tfsdk:"version"`
 }
@@ -107,6 +108,7 @@ func metricGroupSchemaAttributes() map[string]schema.Attribute {
 				setvalidator.ValueStringsAre(tagValidator()),
 			},
 		},
+		TAGS_ALL: tagsAllAttribute(),
 		VERSION: schema.Int64Attribute{
 			Computed:    true,
 			Description: "The version of the metric group.",
@@ -158,11 +160,14 @@ func (r *MetricGroupResource) betaClient() (*Client, error) {
 // the computed `version` as unknown whenever any user-controlled attribute
 // changes, so the post-apply refresh does not trip "inconsistent result".
 func (r *MetricGroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.client.planGuardrails(ctx, req, resp, guardrailScope{project: PROJECT_KEY})
 	if req.Plan.Raw.IsNull() {
 		return
 	}
+	// tags_all is planned first so the version check below sees it.
+	r.client.planTagsAll(ctx, req, resp)
 	var plan MetricGroupResourceModel
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -207,6 +212,9 @@ func (r *MetricGroupResource) ModifyPlan(ctx context.Context, req resource.Modif
 }
 
 func (r *MetricGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_metric_group", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan MetricGroupResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -229,7 +237,7 @@ func (r *MetricGroupResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
-	tags, d := stringSliceFromSet(ctx, plan.Tags)
+	tags, d := stringSliceFromSet(ctx, plan.TagsAll)
 	resp.Diagnostics.Append(d...)
 	if tags == nil {
 		tags = []string{}
@@ -313,6 +321,9 @@ func (r *MetricGroupResource) Read(ctx context.Context, req resource.ReadRequest
 }
 
 func (r *MetricGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_metric_group", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan, state MetricGroupResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -339,8 +350,8 @@ func (r *MetricGroupResource) Update(ctx context.Context, req resource.UpdateReq
 	if !plan.MaintainerID.Equal(state.MaintainerID) && plan.MaintainerID.ValueString() != "" {
 		patch = append(patch, patchReplace("/maintainerId", plan.MaintainerID.ValueString()))
 	}
-	if !plan.Tags.Equal(state.Tags) {
-		tags, d := stringSliceFromSet(ctx, plan.Tags)
+	if !plan.TagsAll.Equal(state.TagsAll) {
+		tags, d := stringSliceFromSet(ctx, plan.TagsAll)
 		resp.Diagnostics.Append(d...)
 		if tags == nil {
 			tags = []string{}
@@ -379,6 +390,9 @@ func (r *MetricGroupResource) Update(ctx context.Context, req resource.UpdateReq
 }
 
 func (r *MetricGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_metric_group", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data MetricGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -458,9 +472,12 @@ func (r *MetricGroupResource) readIntoModel(
 
 	// Optional-only Set attr: preserve the config's null-vs-empty intent so
 	// an omitted `tags` reads back as null, not an empty set.
-	tagsSet, d := setFromStringSlicePreservingPlan(ctx, group.Tags, data.Tags)
+	ownTags, tagsAll, d := r.client.splitDefaultTags(ctx, group.Tags, data.Tags)
+	diags.Append(d...)
+	tagsSet, d := setFromStringSlicePreservingPlan(ctx, ownTags, data.Tags)
 	diags.Append(d...)
 	data.Tags = tagsSet
+	data.TagsAll = tagsAll
 
 	metricsList, err := metricGroupMetricsToList(group.Metrics)
 	if err != nil {
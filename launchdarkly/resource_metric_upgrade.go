@@ -72,6 +72,7 @@ type MetricResourceModelV1 struct {
 func metricSchemaAttributesV1() map[string]schema.Attribute {
 	attrs := metricSchemaAttributes()
 	delete(attrs, ANALYSIS_UNITS)
+	delete(attrs, TAGS_ALL)
 	attrs[RANDOMIZATION_UNITS] = schema.SetAttribute{
 		Optional:    true,
 		Computed:    true,
@@ -28,6 +28,7 @@ var (
 	_ resource.Resource                 = &ModelConfigResource{}
 	_ resource.ResourceWithImportState  = &ModelConfigResource{}
 	_ resource.ResourceWithUpgradeState = &ModelConfigResource{}
+	_ resource.ResourceWithModifyPlan   = &ModelConfigResource{}
 )
 
 type ModelConfigResource struct {
@@ -46,6 +47,7 @@ type ModelConfigResourceModel struct {
 	Params             types.String  `tfsdk:"params"`
 	CustomParameters   types.String  `tfsdk:"custom_parameters"`
 	Tags               types.Set     `tfsdk:"tags"`
+	TagsAll            types.Set     `tfsdk:"tags_all"`
 	Version            types.Int64   `tfsdk:"version"`
 	CostPerInputToken  types.Float64 `tfsdk:"cost_per_input_token"`
 	CostPerOutputToken types.Float64 `tfsdk:"cost_per_output_token"`
@@ -62,7 +64,7 @@ func (r *ModelConfigResource) Metadata(_ context.Context, req resource.MetadataR
 func (r *ModelConfigResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Version:     1,
-		Description: "Provides a LaunchDarkly model config resource.\n\nThis resource allows you to create and manage AI model configurations within your LaunchDarkly project. Because the API does not support updates, any field change forces recreation of the resource.",
+		Description: "Provides a LaunchDarkly model config resource.\n\nThis resource allows you to create and manage AI model configurations within your LaunchDarkly project. Because the API does not support updates, any field change forces recreation of the resource. Changes to the provider's `default_tags` apply only to model configs created after the change.",
 		Attributes:  modelConfigSchemaAttributes(),
 	}
 }
@@ -161,6 +163,7 @@ func modelConfigSchemaAttributes() map[string]schema.Attribute {
 				setplanmodifier.UseStateForUnknown(),
 			},
 		},
+		TAGS_ALL: tagsAllAttribute(),
 		VERSION: schema.Int64Attribute{
 			Computed:    true,
 			Description: "The version of the model config.",
@@ -201,7 +204,44 @@ func (r *ModelConfigResource) Configure(_ context.Context, req resource.Configur
 	r.client = configureResourceClient(req, resp)
 }
 
+// ModifyPlan plans tags_all. Model config tags cannot be updated in place.
+// A change to tags replaces the model config, but a change to the
+// provider's default_tags alone must not, so existing model configs keep
+// their tags_all and only new ones pick up the defaults.
+func (r *ModelConfigResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.client.planGuardrails(ctx, req, resp, guardrailScope{project: PROJECT_KEY})
+	r.client.planTagsAll(ctx, req, resp)
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || resp.Diagnostics.HasError() {
+		return
+	}
+	// Every configurable attribute forces replacement, so any configured
+	// change replaces the model config, which is created with the newly
+	// merged tags.
+	if !req.Plan.Raw.Equal(req.State.Raw) {
+		return
+	}
+	var planned, prior types.Set
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root(TAGS_ALL), &planned)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(TAGS_ALL), &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// State written before tags_all existed has none to compare against.
+	if prior.IsNull() || planned.IsUnknown() || planned.Equal(prior) {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(TAGS_ALL), prior)...)
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root(TAGS_ALL),
+		"Default tags not applied to existing model config",
+		"Model config tags cannot be updated in place, so changes to the provider's default_tags only apply to model configs created or replaced after the change.",
+	)
+}
+
 func (r *ModelConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_model_config", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan ModelConfigResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -243,8 +283,8 @@ func (r *ModelConfigResource) Create(ctx context.Context, req resource.CreateReq
 			post.CustomParams = customParams
 		}
 	}
-	if !plan.Tags.IsNull() && !plan.Tags.IsUnknown() {
-		tags, diags := stringSliceFromSet(ctx, plan.Tags)
+	if !plan.TagsAll.IsNull() && !plan.TagsAll.IsUnknown() {
+		tags, diags := stringSliceFromSet(ctx, plan.TagsAll)
 		resp.Diagnostics.Append(diags...)
 		post.Tags = tags
 	}
@@ -304,6 +344,9 @@ func (r *ModelConfigResource) Update(_ context.Context, _ resource.UpdateRequest
 }
 
 func (r *ModelConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_model_config", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data ModelConfigResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -408,9 +451,12 @@ func (r *ModelConfigResource) readIntoModel(
 	}
 	data.CustomParameters = types.StringValue(customParamsJSON)
 
-	tagsSet, d := setFromStringSlice(ctx, modelConfig.Tags)
+	ownTags, tagsAll, d := r.client.splitDefaultTags(ctx, modelConfig.Tags, data.Tags)
+	diags.Append(d...)
+	tagsSet, d := setFromStringSlice(ctx, ownTags)
 	diags.Append(d...)
 	data.Tags = tagsSet
+	data.TagsAll = tagsAll
 
 	if modelConfig.CostPerInputToken != nil {
 		data.CostPerInputToken = types.Float64Value(*modelConfig.CostPerInputToken)
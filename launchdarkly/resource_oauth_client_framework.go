@@ -98,6 +98,9 @@ func (r *OAuthClientResource) Configure(_ context.Context, req resource.Configur
 }
 
 func (r *OAuthClientResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_oauth_client", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan OAuthClientResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -156,6 +159,9 @@ func (r *OAuthClientResource) Read(ctx context.Context, req resource.ReadRequest
 }
 
 func (r *OAuthClientResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_oauth_client", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan, state OAuthClientResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -196,6 +202,9 @@ func (r *OAuthClientResource) Update(ctx context.Context, req resource.UpdateReq
 }
 
 func (r *OAuthClientResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_oauth_client", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data OAuthClientResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -40,9 +40,11 @@ type ProjectResourceModel struct {
 	Name                                 types.String `tfsdk:"name"`
 	DefaultClientSideAvailability        types.Object `tfsdk:"default_client_side_availability"`
 	Tags                                 types.Set    `tfsdk:"tags"`
+	TagsAll                              types.Set    `tfsdk:"tags_all"`
 	Environments                         types.Map    `tfsdk:"environments"`
 	RequireViewAssociationForNewFlags    types.Bool   `tfsdk:"require_view_association_for_new_flags"`
 	RequireViewAssociationForNewSegments types.Bool   `tfsdk:"require_view_association_for_new_segments"`
+	AcknowledgeCritical                  types.Bool   `tfsdk:"acknowledge_critical"`
 }
 
 // projectCSAAttrTypes describes the inner attribute set of the
@@ -92,6 +94,8 @@ func projectSchemaAttributes() map[string]schema.Attribute {
 			Validators:  []validator.Set{setvalidator.ValueStringsAre(tagValidator())},
 			Description: "Tags associated with your resource.",
 		},
+		TAGS_ALL:             tagsAllAttribute(),
+		ACKNOWLEDGE_CRITICAL: acknowledgeCriticalAttribute(),
 		REQUIRE_VIEW_ASSOCIATION_FOR_NEW_FLAGS: schema.BoolAttribute{
 			Optional:    true,
 			Computed:    true,
@@ -176,6 +180,7 @@ func (r *ProjectResource) UpgradeState(_ context.Context) map[int64]resource.Sta
 					Name:                                 prior.Name,
 					DefaultClientSideAvailability:        priorDCSA,
 					Tags:                                 prior.Tags,
+					TagsAll:                              types.SetNull(types.StringType),
 					RequireViewAssociationForNewFlags:    prior.RequireViewAssociationForNewFlags,
 					RequireViewAssociationForNewSegments: prior.RequireViewAssociationForNewSegments,
 				}
@@ -231,9 +236,13 @@ func (r *ProjectResource) Configure(_ context.Context, req resource.ConfigureReq
 //     consistency check (see [[feedback-nested-attr-computed-sensitive]]).
 //     Mark those fields Unknown when there's no prior state entry for the key.
 func (r *ProjectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.client.planGuardrails(ctx, req, resp, guardrailScope{project: KEY})
+	r.client.planEnvironmentMapGuardrails(ctx, req, resp, KEY, true)
+	r.client.planCriticalProjectEnvironments(ctx, req, resp)
 	if req.Plan.Raw.IsNull() {
 		return
 	}
+	defer r.client.planTagsAll(ctx, req, resp)
 	if r.client == nil {
 		return
 	}
@@ -422,6 +431,9 @@ func projectCSAValueFromAPI(_ context.Context, csa *ldapi.ClientSideAvailability
 }
 
 func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_project", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan ProjectResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -485,6 +497,9 @@ func (r *ProjectResource) Read(ctx context.Context, req resource.ReadRequest, re
 }
 
 func (r *ProjectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_project", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan, state ProjectResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -506,6 +521,9 @@ func (r *ProjectResource) Update(ctx context.Context, req resource.UpdateRequest
 }
 
 func (r *ProjectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_project", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data ProjectResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -531,7 +549,7 @@ func (r *ProjectResource) ImportState(ctx context.Context, req resource.ImportSt
 func (r *ProjectResource) applyProjectUpdates(ctx context.Context, projectKey string, plan, state ProjectResourceModel, isCreate bool) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	tags, d := stringSliceFromSet(ctx, plan.Tags)
+	tags, d := stringSliceFromSet(ctx, plan.TagsAll)
 	diags.Append(d...)
 	if diags.HasError() {
 		return diags
@@ -681,9 +699,12 @@ func (r *ProjectResource) readIntoModel(ctx context.Context, projectKey string,
 	data.Key = types.StringValue(project.Key)
 	data.Name = types.StringValue(project.Name)
 
-	tagsSet, d := setFromStringSlicePreservingPlan(ctx, project.Tags, data.Tags)
+	ownTags, tagsAll, d := r.client.splitDefaultTags(ctx, project.Tags, data.Tags)
+	diags.Append(d...)
+	tagsSet, d := setFromStringSlicePreservingPlan(ctx, ownTags, data.Tags)
 	diags.Append(d...)
 	data.Tags = tagsSet
+	data.TagsAll = tagsAll
 
 	csaObj, d := projectCSAValueFromAPI(ctx, project.DefaultClientSideAvailability, data.DefaultClientSideAvailability)
 	diags.Append(d...)
@@ -48,6 +48,8 @@ type environmentModelV0 struct {
 
 func projectSchemaAttributesV0() map[string]schema.Attribute {
 	attrs := projectSchemaAttributes()
+	delete(attrs, TAGS_ALL)
+	delete(attrs, ACKNOWLEDGE_CRITICAL)
 	attrs[INCLUDE_IN_SNIPPET] = schema.BoolAttribute{
 		Optional:           true,
 		Computed:           true,
@@ -29,6 +29,7 @@ type RelayProxyConfigResourceModel struct {
 	Policy     types.List   `tfsdk:"policy"`
 	FullKey    types.String `tfsdk:"full_key"`
 	DisplayKey types.String `tfsdk:"display_key"`
+	Comment    types.String `tfsdk:"comment"`
 }
 
 func NewRelayProxyConfigResource() resource.Resource {
@@ -69,7 +70,8 @@ This resource allows you to create and manage Relay Proxy configurations within
 				Description:   "The last 4 characters of the Relay Proxy configuration's unique key.",
 				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
-			POLICY: frameworkPolicyStatementsResourceAttribute(true, "The Relay Proxy configuration's rule policy. This determines what content the Relay Proxy receives. To learn more, read [Understanding policies](https://launchdarkly.com/docs/home/account/roles/role-policies#understanding-policies).", ""),
+			POLICY:  frameworkPolicyStatementsResourceAttribute(true, "The Relay Proxy configuration's rule policy. This determines what content the Relay Proxy receives. To learn more, read [Understanding policies](https://launchdarkly.com/docs/home/account/roles/role-policies#understanding-policies).", ""),
+			COMMENT: resourceCommentAttribute(),
 		},
 	}
 }
@@ -79,6 +81,9 @@ func (r *RelayProxyConfigResource) Configure(_ context.Context, req resource.Con
 }
 
 func (r *RelayProxyConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_relay_proxy_configuration", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan RelayProxyConfigResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -133,6 +138,9 @@ func (r *RelayProxyConfigResource) Read(ctx context.Context, req resource.ReadRe
 }
 
 func (r *RelayProxyConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_relay_proxy_configuration", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan RelayProxyConfigResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -149,7 +157,7 @@ func (r *RelayProxyConfigResource) Update(ctx context.Context, req resource.Upda
 		patchReplace("/name", &name),
 		patchReplace("/policy", &policy),
 	}
-	pwc := ldapi.PatchWithComment{Patch: patch, Comment: ldapi.PtrString("Terraform")}
+	pwc := ldapi.PatchWithComment{Patch: patch, Comment: ldapi.PtrString(r.client.changeCommentFor(plan.Comment))}
 
 	err := r.client.withConcurrency(r.client.ctx, func() error {
 		_, _, e := r.client.ld.RelayProxyConfigurationsApi.PatchRelayAutoConfig(r.client.ctx, plan.ID.ValueString()).PatchWithComment(pwc).Execute()
@@ -168,6 +176,9 @@ func (r *RelayProxyConfigResource) Update(ctx context.Context, req resource.Upda
 }
 
 func (r *RelayProxyConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_relay_proxy_configuration", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data RelayProxyConfigResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
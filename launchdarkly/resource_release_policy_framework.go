@@ -22,6 +22,7 @@ import (
 
 var (
 	_ resource.Resource                   = &ReleasePolicyResource{}
+	_ resource.ResourceWithModifyPlan     = &ReleasePolicyResource{}
 	_ resource.ResourceWithImportState    = &ReleasePolicyResource{}
 	_ resource.ResourceWithValidateConfig = &ReleasePolicyResource{}
 )
@@ -205,6 +206,10 @@ func (r *ReleasePolicyResource) Configure(_ context.Context, req resource.Config
 	r.beta = beta
 }
 
+func (r *ReleasePolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.client.planGuardrails(ctx, req, resp, guardrailScope{project: PROJECT_KEY})
+}
+
 func (r *ReleasePolicyResource) betaClient() (*Client, error) {
 	if r.beta != nil {
 		return r.beta, nil
@@ -248,6 +253,9 @@ func (r *ReleasePolicyResource) ValidateConfig(ctx context.Context, req resource
 }
 
 func (r *ReleasePolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_release_policy", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan ReleasePolicyResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -331,6 +339,9 @@ func (r *ReleasePolicyResource) Read(ctx context.Context, req resource.ReadReque
 }
 
 func (r *ReleasePolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_release_policy", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan ReleasePolicyResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -387,6 +398,9 @@ func (r *ReleasePolicyResource) Update(ctx context.Context, req resource.UpdateR
 }
 
 func (r *ReleasePolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_release_policy", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data ReleasePolicyResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -22,17 +22,18 @@ var (
 )
 
 type SdkKeyResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	ProjectKey     types.String `tfsdk:"project_key"`
-	EnvironmentKey types.String `tfsdk:"environment_key"`
-	Key            types.String `tfsdk:"key"`
-	Kind           types.String `tfsdk:"kind"`
-	Name           types.String `tfsdk:"name"`
-	Description    types.String `tfsdk:"description"`
-	Expiry         types.Int64  `tfsdk:"expiry"`
-	Value          types.String `tfsdk:"value"`
-	IsDefault      types.Bool   `tfsdk:"is_default"`
-	Version        types.Int64  `tfsdk:"version"`
+	ID                  types.String `tfsdk:"id"`
+	ProjectKey          types.String `tfsdk:"project_key"`
+	EnvironmentKey      types.String `tfsdk:"environment_key"`
+	Key                 types.String `tfsdk:"key"`
+	Kind                types.String `tfsdk:"kind"`
+	Name                types.String `tfsdk:"name"`
+	Description         types.String `tfsdk:"description"`
+	Expiry              types.Int64  `tfsdk:"expiry"`
+	Value               types.String `tfsdk:"value"`
+	IsDefault           types.Bool   `tfsdk:"is_default"`
+	Version             types.Int64  `tfsdk:"version"`
+	AcknowledgeCritical types.Bool   `tfsdk:"acknowledge_critical"`
 }
 
 type SdkKeyResource struct {
@@ -126,6 +127,7 @@ func sdkKeySchemaAttributes() map[string]schema.Attribute {
 			Computed:    true,
 			Description: "The auto-incremented version number of the SDK key.",
 		},
+		ACKNOWLEDGE_CRITICAL: acknowledgeCriticalAttribute(),
 	}
 }
 
@@ -146,6 +148,9 @@ func sdkKeySchemaAttributes() map[string]schema.Attribute {
 // unspecified order, so an expiry-attribute guard could read `kind` before
 // UseStateForUnknown resolves it and misread the plan as a replacement.
 func (r *SdkKeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	scope := guardrailScope{project: PROJECT_KEY, env: ENVIRONMENT_KEY}
+	r.client.planGuardrails(ctx, req, resp, scope)
+	r.client.planCriticalEnvironment(ctx, req, resp, scope)
 	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
 		// Destroy or create: no in-place expiry transition to guard.
 		return
@@ -197,6 +202,9 @@ func (r *SdkKeyResource) betaClient() (*Client, error) {
 }
 
 func (r *SdkKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_sdk_key", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan SdkKeyResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -255,6 +263,9 @@ func (r *SdkKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 }
 
 func (r *SdkKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_sdk_key", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan, state SdkKeyResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -310,6 +321,9 @@ func (r *SdkKeyResource) Update(ctx context.Context, req resource.UpdateRequest,
 }
 
 func (r *SdkKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_sdk_key", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data SdkKeyResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
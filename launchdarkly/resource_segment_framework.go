@@ -5,8 +5,9 @@ import (
 	"fmt"
 	"log"
 	"net/http"
-	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -45,6 +46,7 @@ type SegmentResourceModel struct {
 	Name                 types.String `tfsdk:"name"`
 	Description          types.String `tfsdk:"description"`
 	Tags                 types.Set    `tfsdk:"tags"`
+	TagsAll              types.Set    `tfsdk:"tags_all"`
 	CreationDate         types.Int64  `tfsdk:"creation_date"`
 	Included             types.List   `tfsdk:"included"`
 	Excluded             types.List   `tfsdk:"excluded"`
@@ -54,6 +56,9 @@ type SegmentResourceModel struct {
 	Unbounded            types.Bool   `tfsdk:"unbounded"`
 	UnboundedContextKind types.String `tfsdk:"unbounded_context_kind"`
 	ViewKeys             types.Set    `tfsdk:"view_keys"`
+	ApprovalMode         types.String `tfsdk:"approval_mode"`
+	Comment              types.String `tfsdk:"comment"`
+	AcknowledgeCritical  types.Bool   `tfsdk:"acknowledge_critical"`
 }
 
 func NewSegmentResource() resource.Resource {
@@ -114,6 +119,7 @@ func segmentSchemaAttributes() map[string]schema.Attribute {
 			Validators:  []validator.Set{setvalidator.ValueStringsAre(tagValidator())},
 			Description: "Tags associated with your resource.",
 		},
+		TAGS_ALL: tagsAllAttribute(),
 		CREATION_DATE: schema.Int64Attribute{
 			Computed:      true,
 			Description:   "The segment's creation date represented as a UNIX epoch timestamp.",
@@ -167,6 +173,12 @@ func segmentSchemaAttributes() map[string]schema.Attribute {
 						Required:    true,
 						Description: "The context kind associated with this segment target. To target on user contexts, use the included and excluded attributes.",
 					},
+					EXPIRATIONS: schema.MapAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+						Description: "When LaunchDarkly stops including some of the keys in `values`, keyed by context key, as RFC 3339 timestamps with a UTC offset, such as `2026-03-01T06:00:00-05:00`. LaunchDarkly removes a key from the segment once it expires. An expired key is not included again: it is kept in `values` without showing up as a change until you remove it from the configuration.",
+						Validators:  []validator.Map{mapvalidator.ValueStringsAre(rfc3339Validator{})},
+					},
 				},
 			},
 		},
@@ -187,7 +199,10 @@ func segmentSchemaAttributes() map[string]schema.Attribute {
 				},
 			},
 		},
-		RULES: segmentRulesResourceAttribute(),
+		RULES:                segmentRulesResourceAttribute(),
+		APPROVAL_MODE:        resourceApprovalModeAttribute(),
+		COMMENT:              resourceCommentAttribute(),
+		ACKNOWLEDGE_CRITICAL: acknowledgeCriticalAttribute(),
 	}
 }
 
@@ -220,6 +235,7 @@ func (r *SegmentResource) UpgradeState(_ context.Context) map[int64]resource.Sta
 func (r *SegmentResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
 	return []resource.ConfigValidator{
 		segmentUnboundedConflictValidator{},
+		segmentExpirationsValidator{},
 	}
 }
 
@@ -264,9 +280,16 @@ func (r *SegmentResource) Configure(_ context.Context, req resource.ConfigureReq
 // ModifyPlan ports customizeSegmentDiff: create-time view_keys validation
 // when the project requires view association for new segments.
 func (r *SegmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	scope := guardrailScope{project: PROJECT_KEY, env: ENV_KEY}
+	r.client.planGuardrails(ctx, req, resp, scope)
+	r.client.planCriticalEnvironment(ctx, req, resp, scope)
 	if req.Plan.Raw.IsNull() {
 		return
 	}
+	planSegmentRuleWeights(ctx, req, resp)
+	planRuleRefs(ctx, req, resp)
+	planSegmentRuleRefs(ctx, req, resp)
+	defer r.client.planTagsAll(ctx, req, resp)
 	if r.client == nil {
 		return
 	}
@@ -303,6 +326,9 @@ func (r *SegmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPla
 }
 
 func (r *SegmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_segment", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan SegmentResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -333,7 +359,7 @@ func (r *SegmentResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	desc := plan.Description.ValueString()
-	tags, d := stringSliceFromSet(ctx, plan.Tags)
+	tags, d := stringSliceFromSet(ctx, plan.TagsAll)
 	resp.Diagnostics.Append(d...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -395,6 +421,10 @@ func (r *SegmentResource) Create(ctx context.Context, req resource.CreateRequest
 		resp.Diagnostics.Append(d...)
 		return
 	}
+	if d := r.applyExpirations(ctx, plan, SegmentResourceModel{}, true); d.HasError() {
+		resp.Diagnostics.Append(d...)
+		return
+	}
 	r.readIntoModel(ctx, &plan, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
@@ -421,6 +451,9 @@ func (r *SegmentResource) Read(ctx context.Context, req resource.ReadRequest, re
 }
 
 func (r *SegmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_segment", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan, state SegmentResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -431,6 +464,10 @@ func (r *SegmentResource) Update(ctx context.Context, req resource.UpdateRequest
 		resp.Diagnostics.Append(d...)
 		return
 	}
+	if d := r.applyExpirations(ctx, plan, state, false); d.HasError() {
+		resp.Diagnostics.Append(d...)
+		return
+	}
 	r.readIntoModel(ctx, &plan, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
@@ -440,6 +477,9 @@ func (r *SegmentResource) Update(ctx context.Context, req resource.UpdateRequest
 }
 
 func (r *SegmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_segment", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data SegmentResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -455,14 +495,14 @@ func (r *SegmentResource) Delete(ctx context.Context, req resource.DeleteRequest
 }
 
 func (r *SegmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	if strings.Count(req.ID, "/") != 2 {
-		resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("expected project_key/env_key/segment_key, got %q", req.ID))
+	projectKey, envKey, segmentKey, err := segmentIdToKeys(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
 		return
 	}
-	parts := strings.SplitN(req.ID, "/", 3)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(PROJECT_KEY), parts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(ENV_KEY), parts[1])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(KEY), parts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(PROJECT_KEY), projectKey)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(ENV_KEY), envKey)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(KEY), segmentKey)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
 }
 
@@ -473,7 +513,7 @@ func (r *SegmentResource) applySegmentUpdate(ctx context.Context, plan, state Se
 	key := plan.Key.ValueString()
 
 	desc := plan.Description.ValueString()
-	tags, d := stringSliceFromSet(ctx, plan.Tags)
+	tags, d := stringSliceFromSet(ctx, plan.TagsAll)
 	diags.Append(d...)
 	if diags.HasError() {
 		return diags
@@ -486,17 +526,19 @@ func (r *SegmentResource) applySegmentUpdate(ctx context.Context, plan, state Se
 		return diags
 	}
 
-	includedContexts, d := segmentTargetsFromList(ctx, plan.IncludedContexts)
+	includedContexts, d := segmentIncludedContextsFromList(ctx, plan.IncludedContexts, time.Now())
 	diags.Append(d...)
 	excludedContexts, d := segmentTargetsFromList(ctx, plan.ExcludedContexts)
 	diags.Append(d...)
-	rules, d := segmentRulesFromList(ctx, plan.Rules)
+	// Refs unknown at plan time are checked once they are known.
+	diags.Append(segmentRuleRefDiags(plan.Rules, state.Rules)...)
+	rules, d := segmentRulesFromList(ctx, plan.Rules, ruleRefs(state.Rules))
 	diags.Append(d...)
 	if diags.HasError() {
 		return diags
 	}
 
-	comment := "Terraform"
+	comment := r.client.changeCommentFor(plan.Comment)
 	var patchOps []ldapi.PatchOperation
 	if isCreate {
 		// The shell created by PostSegment / createSegmentWithViewKeys already
@@ -519,7 +561,7 @@ func (r *SegmentResource) applySegmentUpdate(ctx context.Context, plan, state Se
 			patchReplace("/includedContexts", includedContexts),
 			patchReplace("/excludedContexts", excludedContexts),
 		}
-		if !plan.Tags.Equal(state.Tags) && len(tags) == 0 {
+		if !plan.TagsAll.Equal(state.TagsAll) && len(tags) == 0 {
 			patchOps = append(patchOps, patchRemove("/tags"))
 		} else {
 			patchOps = append(patchOps, patchReplace("/tags", tags))
@@ -536,11 +578,24 @@ func (r *SegmentResource) applySegmentUpdate(ctx context.Context, plan, state Se
 		})
 		if err != nil {
 			if isApprovalRequiredErr(err) {
-				diags.Append(r.segmentApprovalRequiredDiag(isCreate, projectKey, envKey, key))
+				mode := r.client.approvalModeFor(plan.ApprovalMode)
+				// Terraform cannot record a new segment whose targeting is
+				// still under review, so request mode only applies to updates.
+				if mode == APPROVAL_MODE_FAIL || (isCreate && mode == APPROVAL_MODE_REQUEST) {
+					diags.Append(r.segmentApprovalRequiredDiag(isCreate, projectKey, envKey, key))
+					return diags
+				}
+				diags.Append(r.requestApproval(ctx, mode, plan, patchOps, comment)...)
+				if diags.HasError() {
+					if isCreate {
+						diags.AddError(fmt.Sprintf("segment %q was not created in project %q", key, projectKey), r.rollbackSegmentShell(projectKey, envKey, key))
+					}
+					return diags
+				}
+			} else {
+				diags.AddError(fmt.Sprintf("failed to update segment %q in project %q: %s", key, projectKey, handleLdapiErr(err).Error()), "")
 				return diags
 			}
-			diags.AddError(fmt.Sprintf("failed to update segment %q in project %q: %s", key, projectKey, handleLdapiErr(err).Error()), "")
-			return diags
 		}
 	}
 
@@ -638,28 +693,20 @@ func appendSegmentTargetingOps(ops []ldapi.PatchOperation, included, excluded []
 
 // segmentApprovalRequiredDiag builds the diagnostic returned when a segment
 // PATCH is rejected because segment approvals are enabled for the environment
-// and the token's role does not permit bypassing them. Terraform applies
-// changes non-interactively, so it cannot satisfy an inline approval; the fix
-// is to grant the token a custom role with the "bypassRequiredSegmentApproval"
-// action. On create the function also rolls back the shell that PostSegment
+// and the token's role does not permit bypassing them, and approval_mode does
+// not submit the change for approval. The fix is to grant the token a custom
+// role with the "bypassRequiredSegmentApproval" action or to use an approval
+// mode. On create the function also rolls back the shell that PostSegment
 // created (DELETE is not gated by approvals) so a retry does not collide with
 // an orphaned segment. See issue #370.
 func (r *SegmentResource) segmentApprovalRequiredDiag(isCreate bool, projectKey, envKey, key string) diag.Diagnostic {
 	verb := "updated"
-	remediation := "Grant this token a custom role that includes the \"bypassRequiredSegmentApproval\" action so Terraform can apply targeting changes directly, remove the targeting attributes (included / excluded / rules / included_contexts / excluded_contexts) from this resource, or disable segment approvals for this environment."
+	remediation := "Grant this token a custom role that includes the \"bypassRequiredSegmentApproval\" action so Terraform can apply targeting changes directly, set `approval_mode` to \"request\" or \"request_and_wait\" so Terraform submits targeting changes as approval requests, remove the targeting attributes (included / excluded / rules / included_contexts / excluded_contexts) from this resource, or disable segment approvals for this environment."
 	rollback := ""
 	if isCreate {
 		verb = "created"
-		remediation = "Grant this token a custom role that includes the \"bypassRequiredSegmentApproval\" action so Terraform can create the segment with its targeting directly, remove the targeting attributes (included / excluded / rules / included_contexts / excluded_contexts) so Terraform creates only the segment shell and you manage targeting through the approval workflow, or disable segment approvals for this environment."
-		delErr := r.client.withConcurrency(r.client.ctx, func() error {
-			_, e := r.client.ld.SegmentsApi.DeleteSegment(r.client.ctx, projectKey, envKey, key).Execute()
-			return e
-		})
-		if delErr == nil {
-			rollback = " The partially created segment was rolled back to keep Terraform state consistent."
-		} else {
-			rollback = fmt.Sprintf(" Note: the partially created segment %q could not be removed automatically (%s); delete it manually before retrying.", key, handleLdapiErr(delErr).Error())
-		}
+		remediation = "Grant this token a custom role that includes the \"bypassRequiredSegmentApproval\" action so Terraform can create the segment with its targeting directly, set `approval_mode` to \"request_and_wait\" so Terraform submits the targeting as an approval request and waits for it to be applied, remove the targeting attributes (included / excluded / rules / included_contexts / excluded_contexts) so Terraform creates only the segment shell and you manage targeting through the approval workflow, or disable segment approvals for this environment."
+		rollback = " " + r.rollbackSegmentShell(projectKey, envKey, key)
 	}
 	return diag.NewErrorDiagnostic(
 		fmt.Sprintf("segment %q cannot be %s in project %q: segment approvals are enabled for environment %q", key, verb, projectKey, envKey),
@@ -667,6 +714,77 @@ func (r *SegmentResource) segmentApprovalRequiredDiag(isCreate bool, projectKey,
 	)
 }
 
+// rollbackSegmentShell deletes a segment whose create could not apply its
+// targeting and describes the outcome for a diagnostic.
+func (r *SegmentResource) rollbackSegmentShell(projectKey, envKey, key string) string {
+	delErr := r.client.withConcurrency(r.client.ctx, func() error {
+		_, e := r.client.ld.SegmentsApi.DeleteSegment(r.client.ctx, projectKey, envKey, key).Execute()
+		return e
+	})
+	if delErr != nil {
+		return fmt.Sprintf("Note: the partially created segment %q could not be removed automatically (%s); delete it manually before retrying.", key, handleLdapiErr(delErr).Error())
+	}
+	return "The partially created segment was rolled back to keep Terraform state consistent."
+}
+
+// requestApproval submits the targeting change from the live segment to
+// plan as an approval request, after LaunchDarkly has rejected patchOps
+// because the environment requires segment approvals. Approvals only gate
+// targeting, so the remaining operations (name, description, tags) are
+// applied directly first. Diffing against the live segment rather than the
+// prior state keeps a re-run after the request was applied from asking for
+// approval again.
+func (r *SegmentResource) requestApproval(ctx context.Context, mode string, plan SegmentResourceModel, patchOps []ldapi.PatchOperation, comment string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	projectKey := plan.ProjectKey.ValueString()
+	envKey := plan.EnvKey.ValueString()
+	key := plan.Key.ValueString()
+
+	ungated := make([]ldapi.PatchOperation, 0, len(patchOps))
+	for _, op := range patchOps {
+		switch op.Path {
+		case "/included", "/excluded", "/rules", "/includedContexts", "/excludedContexts":
+		default:
+			ungated = append(ungated, op)
+		}
+	}
+	if len(ungated) > 0 {
+		err := r.client.withConcurrency(r.client.ctx, func() error {
+			_, _, e := r.client.ld.SegmentsApi.PatchSegment(r.client.ctx, projectKey, envKey, key).PatchWithComment(ldapi.PatchWithComment{
+				Comment: &comment,
+				Patch:   ungated,
+			}).Execute()
+			return e
+		})
+		if err != nil {
+			diags.AddError(fmt.Sprintf("failed to update segment %q in project %q: %s", key, projectKey, handleLdapiErr(err).Error()), "")
+			return diags
+		}
+	}
+
+	var live *ldapi.UserSegment
+	err := r.client.withConcurrency(r.client.ctx, func() error {
+		var e error
+		live, _, e = r.client.ld.SegmentsApi.GetSegment(r.client.ctx, projectKey, envKey, key).Execute()
+		return e
+	})
+	if err != nil {
+		diags.AddError(fmt.Sprintf("failed to get segment %q of project %q: %s", key, projectKey, handleLdapiErr(err).Error()), "")
+		return diags
+	}
+	instructions, d := buildSegmentInstructions(ctx, plan, live)
+	diags.Append(d...)
+	if diags.HasError() || len(instructions) == 0 {
+		return diags
+	}
+	return r.client.submitForApproval(mode, approvalRequestSpec{
+		resourceID:   segmentApprovalResourceID(projectKey, envKey, key),
+		summary:      fmt.Sprintf("segment %q in environment %q of project %q", key, envKey, projectKey),
+		instructions: instructions,
+		comment:      comment,
+	})
+}
+
 func (r *SegmentResource) readIntoModel(ctx context.Context, data *SegmentResourceModel, diags *diag.Diagnostics) {
 	projectKey := data.ProjectKey.ValueString()
 	envKey := data.EnvKey.ValueString()
@@ -697,9 +815,12 @@ func (r *SegmentResource) readIntoModel(ctx context.Context, data *SegmentResour
 	}
 	data.CreationDate = types.Int64Value(segment.CreationDate)
 
-	tagsSet, d := setFromStringSlicePreservingPlan(ctx, segment.Tags, data.Tags)
+	ownTags, tagsAll, d := r.client.splitDefaultTags(ctx, segment.Tags, data.Tags)
+	diags.Append(d...)
+	tagsSet, d := setFromStringSlicePreservingPlan(ctx, ownTags, data.Tags)
 	diags.Append(d...)
 	data.Tags = tagsSet
+	data.TagsAll = tagsAll
 
 	if segment.Unbounded != nil {
 		data.Unbounded = types.BoolValue(*segment.Unbounded)
@@ -719,9 +840,17 @@ func (r *SegmentResource) readIntoModel(ctx context.Context, data *SegmentResour
 	diags.Append(d...)
 	data.Excluded = excludedList
 
-	data.IncludedContexts = segmentTargetsToFrameworkListImpl(ctx, segment.IncludedContexts)
+	var liveExpirations map[string]map[string]ldapi.ExpiringTarget
+	if segmentExpirationsSet(ctx, data.IncludedContexts) {
+		liveExpirations, err = r.liveExpirations(projectKey, envKey, key)
+		if err != nil {
+			diags.AddError(fmt.Sprintf("failed to get expiring targets of segment %q in project %q, environment %q: %s", key, projectKey, envKey, handleLdapiErr(err).Error()), "")
+			return
+		}
+	}
+	data.IncludedContexts = segmentIncludedContextsValue(ctx, segment.IncludedContexts, data.IncludedContexts, liveExpirations, time.Now(), diags)
 	data.ExcludedContexts = segmentTargetsToFrameworkListImpl(ctx, segment.ExcludedContexts)
-	data.Rules = segmentResourceRulesValue(ctx, segment.Rules, diags)
+	data.Rules = segmentRulesWithPriorPercentages(ctx, segmentResourceRulesValue(ctx, segment.Rules, diags), data.Rules, diags)
 
 	// View association reads — best-effort.
 	betaClient, bcErr := r.client.betaClientFromConfig()
@@ -752,6 +881,17 @@ func (r *SegmentResource) readIntoModel(ctx context.Context, data *SegmentResour
 	data.ViewKeys = viewKeysSet
 }
 
+// segmentResourceRuleAttrTypes are segmentRuleAttrTypes with the weight
+// percentage only the resource accepts.
+var segmentResourceRuleAttrTypes = map[string]attr.Type{
+	CLAUSES:              types.ListType{ElemType: types.ObjectType{AttrTypes: frameworkClauseAttrTypes}},
+	WEIGHT:               types.Int64Type,
+	WEIGHT_PERCENTAGE:    types.Float64Type,
+	BUCKET_BY:            types.StringType,
+	ROLLOUT_CONTEXT_KIND: types.StringType,
+	REF:                  types.StringType,
+}
+
 // segmentResourceRulesValue is the resource-side analogue of
 // segmentRulesToFrameworkList (which the segment data source uses).
 // The data source declares weight / bucket_by / rollout_context_kind
@@ -762,7 +902,7 @@ func (r *SegmentResource) readIntoModel(ctx context.Context, data *SegmentResour
 // schema level so plan and state both end up at "user" when the user
 // omits it.
 func segmentResourceRulesValue(ctx context.Context, rules []ldapi.UserSegmentRule, diags *diag.Diagnostics) types.List {
-	objectType := types.ObjectType{AttrTypes: segmentRuleAttrTypes}
+	objectType := types.ObjectType{AttrTypes: segmentResourceRuleAttrTypes}
 	elements := make([]attr.Value, 0, len(rules))
 	for _, r := range rules {
 		clauses, d := frameworkClausesValue(ctx, r.Clauses)
@@ -775,11 +915,13 @@ func segmentResourceRulesValue(ctx context.Context, rules []ldapi.UserSegmentRul
 		if rckValue.IsNull() {
 			rckValue = types.StringValue("user")
 		}
-		obj, d := types.ObjectValue(segmentRuleAttrTypes, map[string]attr.Value{
+		obj, d := types.ObjectValue(segmentResourceRuleAttrTypes, map[string]attr.Value{
 			CLAUSES:              clauses,
 			WEIGHT:               weight,
+			WEIGHT_PERCENTAGE:    types.Float64Null(),
 			BUCKET_BY:            stringValueOrNullFromPointer(r.BucketBy),
 			ROLLOUT_CONTEXT_KIND: rckValue,
+			REF:                  stringValueOrNullFromPointer(r.Id),
 		})
 		diags.Append(d...)
 		elements = append(elements, obj)
@@ -802,8 +944,10 @@ func segmentRulesResourceAttribute() schema.ListNestedAttribute {
 			Attributes: map[string]schema.Attribute{
 				WEIGHT: schema.Int64Attribute{
 					Optional:    true,
+					Computed:    true,
 					Description: "The integer weight of the rule (between 1 and 100000).",
 				},
+				WEIGHT_PERCENTAGE: segmentWeightPercentageAttribute(),
 				BUCKET_BY: schema.StringAttribute{
 					Optional:    true,
 					Description: "The attribute by which to group contexts together.",
@@ -814,6 +958,11 @@ func segmentRulesResourceAttribute() schema.ListNestedAttribute {
 					Default:     stringdefault.StaticString("user"),
 					Description: "The context kind associated with this segment rule. This argument is only valid if `weight` is also specified. If omitted, defaults to `user`.",
 				},
+				REF: schema.StringAttribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "The rule's ID in LaunchDarkly. Rules are matched by `ref` when planning, so a rule that moves or changes keeps its ID. If omitted, Terraform matches the rule by its content. To track an existing rule, set `ref` to the value in state. LaunchDarkly assigns the IDs of new rules, so `ref` must be the ID of an existing rule of the segment.",
+				},
 				CLAUSES: frameworkClausesResourceAttribute(),
 			},
 		},
@@ -821,27 +970,25 @@ func segmentRulesResourceAttribute() schema.ListNestedAttribute {
 }
 
 // segmentTargetsFromList converts a framework ListValue of
-// included/excluded contexts into ldapi.SegmentTarget slices.
+// included/excluded contexts into ldapi.SegmentTarget slices. Only values
+// and context_kind are read, so it applies to both attributes.
 func segmentTargetsFromList(ctx context.Context, list types.List) ([]ldapi.SegmentTarget, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	if list.IsNull() || list.IsUnknown() {
 		return []ldapi.SegmentTarget{}, diags
 	}
-	type targetModel struct {
-		Values      types.List   `tfsdk:"values"`
-		ContextKind types.String `tfsdk:"context_kind"`
-	}
-	var models []targetModel
-	d := list.ElementsAs(ctx, &models, false)
-	diags.Append(d...)
-	if diags.HasError() {
-		return nil, diags
-	}
-	out := make([]ldapi.SegmentTarget, 0, len(models))
-	for _, m := range models {
-		vals, d := stringSliceFromList(ctx, m.Values)
+	out := make([]ldapi.SegmentTarget, 0, len(list.Elements()))
+	for _, e := range list.Elements() {
+		obj, ok := e.(types.Object)
+		if !ok {
+			continue
+		}
+		attrs := obj.Attributes()
+		valuesList, _ := attrs[VALUES].(types.List)
+		vals, d := stringSliceFromList(ctx, valuesList)
 		diags.Append(d...)
-		ck := m.ContextKind.ValueString()
+		contextKind, _ := attrs[CONTEXT_KIND].(types.String)
+		ck := contextKind.ValueString()
 		out = append(out, ldapi.SegmentTarget{
 			Values:      vals,
 			ContextKind: &ck,
@@ -850,18 +997,48 @@ func segmentTargetsFromList(ctx context.Context, list types.List) ([]ldapi.Segme
 	return out, diags
 }
 
+// planSegmentRuleRefs rejects planned rules whose ref is not the ref of a
+// rule of the segment. LaunchDarkly assigns the IDs of new segment rules, so
+// a ref made up for a new rule would not be kept.
+func planSegmentRuleRefs(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var planned types.List
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root(RULES), &planned)...)
+	prior := types.ListNull(types.ObjectType{AttrTypes: segmentResourceRuleAttrTypes})
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(RULES), &prior)...)
+	}
+	if resp.Diagnostics.HasError() || planned.IsUnknown() {
+		return
+	}
+	resp.Diagnostics.Append(segmentRuleRefDiags(planned, prior)...)
+}
+
+// segmentRuleRefDiags returns an error for each rule of planned whose ref is
+// not the ref of a rule of prior.
+func segmentRuleRefDiags(planned, prior types.List) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, i := range unknownRuleRefs(planned, prior) {
+		ref, _ := ruleRef(planned.Elements()[i])
+		diags.AddAttributeError(path.Root(RULES).AtListIndex(i).AtName(REF), "Unknown rule ref",
+			fmt.Sprintf("%q is not the ref of a rule of this segment. LaunchDarkly assigns the IDs of new segment rules, so leave %s unset on a new rule.", ref, REF))
+	}
+	return diags
+}
+
 // segmentRulesFromList converts a framework ListValue of segment rules
-// into []ldapi.UserSegmentRule.
-func segmentRulesFromList(ctx context.Context, list types.List) ([]ldapi.UserSegmentRule, diag.Diagnostics) {
+// into []ldapi.UserSegmentRule. Only the refs in ids are sent as rule IDs.
+func segmentRulesFromList(ctx context.Context, list types.List, ids map[string]bool) ([]ldapi.UserSegmentRule, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	if list.IsNull() || list.IsUnknown() {
 		return []ldapi.UserSegmentRule{}, diags
 	}
 	type ruleModel struct {
-		Clauses            types.List   `tfsdk:"clauses"`
-		Weight             types.Int64  `tfsdk:"weight"`
-		BucketBy           types.String `tfsdk:"bucket_by"`
-		RolloutContextKind types.String `tfsdk:"rollout_context_kind"`
+		Clauses            types.List    `tfsdk:"clauses"`
+		Weight             types.Int64   `tfsdk:"weight"`
+		WeightPercentage   types.Float64 `tfsdk:"weight_percentage"`
+		BucketBy           types.String  `tfsdk:"bucket_by"`
+		RolloutContextKind types.String  `tfsdk:"rollout_context_kind"`
+		Ref                types.String  `tfsdk:"ref"`
 	}
 	var models []ruleModel
 	d := list.ElementsAs(ctx, &models, false)
@@ -874,6 +1051,11 @@ func segmentRulesFromList(ctx context.Context, list types.List) ([]ldapi.UserSeg
 		clauses, d := frameworkClausesFromList(ctx, m.Clauses)
 		diags.Append(d...)
 		r := ldapi.NewUserSegmentRule(clauses)
+		// Sending the ID of an existing rule keeps it when the rules are
+		// replaced. LaunchDarkly assigns the IDs of new rules.
+		if ref := m.Ref.ValueString(); ids[ref] {
+			r.SetId(ref)
+		}
 		bucketBy := m.BucketBy.ValueString()
 		if bucketBy != "" {
 			r.SetBucketBy(bucketBy)
@@ -36,6 +36,7 @@ type TeamResourceModel struct {
 	Maintainers    types.Set    `tfsdk:"maintainers"`
 	CustomRoleKeys types.Set    `tfsdk:"custom_role_keys"`
 	RoleAttributes types.Map    `tfsdk:"role_attributes"`
+	Comment        types.String `tfsdk:"comment"`
 }
 
 // TeamResourceModelV0 is the pre-map state shape: role_attributes was a
@@ -94,7 +95,7 @@ func teamSchemaAttributes() map[string]schema.Attribute {
 			Optional:    true,
 			Computed:    true,
 			ElementType: types.StringType,
-			Description: "List of member IDs who belong to the team.",
+			Description: "List of member IDs who belong to the team. Leave this unset if you add members with `launchdarkly_team_membership`.",
 		},
 		MAINTAINERS: schema.SetAttribute{
 			Optional:    true,
@@ -109,6 +110,7 @@ func teamSchemaAttributes() map[string]schema.Attribute {
 			Description: "List of custom role keys granted to the team. The referenced custom roles must already exist in LaunchDarkly. If they don't, the provider may behave unexpectedly.",
 		},
 		ROLE_ATTRIBUTES: frameworkRoleAttributesResourceAttribute(),
+		COMMENT:         resourceCommentAttribute(),
 	}
 }
 
@@ -119,6 +121,7 @@ func teamSchemaAttributes() map[string]schema.Attribute {
 func (r *TeamResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
 	priorAttrs := teamSchemaAttributes()
 	priorAttrs[ROLE_ATTRIBUTES] = roleAttributesSetAttributeV0()
+	delete(priorAttrs, COMMENT)
 	priorSchema := schema.Schema{Attributes: priorAttrs}
 	return map[int64]resource.StateUpgrader{
 		0: {
@@ -212,6 +215,9 @@ func formatStillAssignedTeamMembersHint(items []ldapi.Member) string {
 }
 
 func (r *TeamResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_team", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan TeamResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -288,6 +294,9 @@ func (r *TeamResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *TeamResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_team", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan, state TeamResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -306,7 +315,9 @@ func (r *TeamResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		instructions = append(instructions, map[string]interface{}{"kind": "updateDescription", "value": plan.Description.ValueString()})
 	}
 
-	if !plan.MemberIDs.Equal(state.MemberIDs) {
+	// member_ids is unknown when it is not configured, such as when
+	// launchdarkly_team_membership manages the members, so leave them alone.
+	if !plan.MemberIDs.IsUnknown() && !plan.MemberIDs.Equal(state.MemberIDs) {
 		oldArr, d := stringSliceFromSet(ctx, state.MemberIDs)
 		resp.Diagnostics.Append(d...)
 		newArr, d := stringSliceFromSet(ctx, plan.MemberIDs)
@@ -370,7 +381,7 @@ func (r *TeamResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	if len(instructions) > 0 {
 		patch := ldapi.TeamPatchInput{
-			Comment:      nil,
+			Comment:      ldapi.PtrString(r.client.changeCommentFor(plan.Comment)),
 			Instructions: instructions,
 		}
 		err := r.client.withConcurrency(r.client.ctx, func() error {
@@ -391,6 +402,9 @@ func (r *TeamResource) Update(ctx context.Context, req resource.UpdateRequest, r
 }
 
 func (r *TeamResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_team", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data TeamResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -403,7 +417,7 @@ func (r *TeamResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 	if isStatusConflict(deleteResp) {
-		if fallbackErr := r.detachMembersAndRetryDelete(teamKey); fallbackErr == nil {
+		if fallbackErr := r.detachMembersAndRetryDelete(teamKey, r.client.changeCommentFor(data.Comment)); fallbackErr == nil {
 			return
 		} else {
 			addLdapiError(&resp.Diagnostics, fmt.Sprintf("failed to delete team with key %q", teamKey), fallbackErr)
@@ -425,7 +439,7 @@ func (r *TeamResource) deleteTeam(teamKey string) (*http.Response, error) {
 	return deleteResp, err
 }
 
-func (r *TeamResource) detachMembersAndRetryDelete(teamKey string) error {
+func (r *TeamResource) detachMembersAndRetryDelete(teamKey, comment string) error {
 	members, err := getAllTeamMembers(r.client, teamKey)
 	if err != nil {
 		return fmt.Errorf("failed to list team members before delete retry for team %q: %w", teamKey, err)
@@ -456,7 +470,7 @@ func (r *TeamResource) detachMembersAndRetryDelete(teamKey string) error {
 	}
 
 	if len(instructions) > 0 {
-		patch := ldapi.TeamPatchInput{Instructions: instructions}
+		patch := ldapi.TeamPatchInput{Comment: &comment, Instructions: instructions}
 		err = r.client.withConcurrency(r.client.ctx, func() error {
 			_, _, patchErr := r.client.ld.TeamsApi.PatchTeam(r.client.ctx, teamKey).TeamPatchInput(patch).Execute()
 			return patchErr
@@ -155,6 +155,9 @@ func (r *TeamMemberResource) Configure(_ context.Context, req resource.Configure
 }
 
 func (r *TeamMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_team_member", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan TeamMemberResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -225,6 +228,9 @@ func (r *TeamMemberResource) Read(ctx context.Context, req resource.ReadRequest,
 }
 
 func (r *TeamMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_team_member", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan, state TeamMemberResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -268,6 +274,9 @@ func (r *TeamMemberResource) Update(ctx context.Context, req resource.UpdateRequ
 }
 
 func (r *TeamMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_team_member", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data TeamMemberResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -0,0 +1,752 @@
+package launchdarkly
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ldapi "github.com/launchdarkly/api-client-go/v24"
+)
+
+const (
+	// teamMembersBulkChunkSize is the number of members to invite, or look
+	// up by email, per API request.
+	teamMembersBulkChunkSize = 50
+)
+
+var (
+	_ resource.Resource                   = &TeamMembersBulkResource{}
+	_ resource.ResourceWithImportState    = &TeamMembersBulkResource{}
+	_ resource.ResourceWithModifyPlan     = &TeamMembersBulkResource{}
+	_ resource.ResourceWithValidateConfig = &TeamMembersBulkResource{}
+)
+
+// TeamMembersBulkResource invites and manages many team members at once,
+// matching them by email, so large teams do not need one API call per
+// member.
+type TeamMembersBulkResource struct {
+	client *Client
+}
+
+type TeamMembersBulkResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Members types.List   `tfsdk:"members"`
+}
+
+type teamMembersBulkMemberModel struct {
+	ID             types.String `tfsdk:"id"`
+	Email          types.String `tfsdk:"email"`
+	Role           types.String `tfsdk:"role"`
+	CustomRoles    types.Set    `tfsdk:"custom_roles"`
+	RoleAttributes types.Map    `tfsdk:"role_attributes"`
+	Teams          types.Set    `tfsdk:"teams"`
+}
+
+var teamMembersBulkMemberAttrTypes = map[string]attr.Type{
+	ID:              types.StringType,
+	EMAIL:           types.StringType,
+	ROLE:            types.StringType,
+	CUSTOM_ROLES:    types.SetType{ElemType: types.StringType},
+	ROLE_ATTRIBUTES: types.MapType{ElemType: types.ListType{ElemType: types.StringType}},
+	TEAMS:           types.SetType{ElemType: types.StringType},
+}
+
+func NewTeamMembersBulkResource() resource.Resource {
+	return &TeamMembersBulkResource{}
+}
+
+func (r *TeamMembersBulkResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_team_members_bulk"
+}
+
+func (r *TeamMembersBulkResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `Provides a LaunchDarkly bulk team member resource.
+
+This resource allows you to invite and manage many team members within your LaunchDarkly organization at once. Unlike ` + "`launchdarkly_team_member`" + `, which calls the API once per member, it invites new members in batches of 50. Members are matched by email, so reordering ` + "`members`" + ` does not invite or remove anyone.
+
+If LaunchDarkly rejects a member, such as one whose email already belongs to a member, the other members are still invited and the rejected member is reported as a warning. The rejected member is invited again on the next apply.
+
+-> **Note:** You can only manage team members with "admin" level personal access tokens. To learn more, read [Managing Teams](https://launchdarkly.com/docs/home/account/manage-teams).`,
+		Attributes: map[string]schema.Attribute{
+			ID: schema.StringAttribute{
+				Computed:    true,
+				Description: "A hash of the members' emails.",
+			},
+			MEMBERS: schema.ListNestedAttribute{
+				Required:    true,
+				Description: "The team members to invite and manage. Each member must have a different email.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						ID: schema.StringAttribute{
+							Computed:    true,
+							Description: "The 24 character alphanumeric ID of the team member.",
+						},
+						EMAIL: schema.StringAttribute{
+							Required:    true,
+							Description: "The unique email address associated with the team member. Changing it removes the member with the old email and invites the new one.",
+						},
+						ROLE: schema.StringAttribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "The role associated with team member. Supported roles are `reader`, `writer`, `no_access`, or `admin`. If you don't specify a role, `reader` is assigned by default.",
+							Validators: []validator.String{
+								oneOfValidator{allowed: []string{"reader", "writer", "admin", "no_access"}},
+							},
+						},
+						CUSTOM_ROLES: schema.SetAttribute{
+							Optional:    true,
+							ElementType: types.StringType,
+							Description: "The list of custom roles keys associated with the team member. Custom roles are only available to customers on an Enterprise plan. To learn more, [read about our pricing](https://launchdarkly.com/pricing/). To upgrade your plan, [contact LaunchDarkly Sales](https://launchdarkly.com/contact-sales/).",
+						},
+						ROLE_ATTRIBUTES: frameworkRoleAttributesResourceAttribute(),
+						TEAMS: schema.SetAttribute{
+							Optional:    true,
+							ElementType: types.StringType,
+							Description: "The keys of teams to add the team member to. Removing a key removes the member from that team. Other teams the member belongs to are ignored.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *TeamMembersBulkResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = configureResourceClient(req, resp)
+}
+
+func (r *TeamMembersBulkResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var members types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(MEMBERS), &members)...)
+	if resp.Diagnostics.HasError() || members.IsNull() || members.IsUnknown() {
+		return
+	}
+	seen := make(map[string]int, len(members.Elements()))
+	for i, element := range members.Elements() {
+		object, ok := element.(types.Object)
+		if !ok || object.IsNull() || object.IsUnknown() {
+			continue
+		}
+		email, ok := object.Attributes()[EMAIL].(types.String)
+		if !ok || email.IsNull() || email.IsUnknown() {
+			continue
+		}
+		key := strings.ToLower(email.ValueString())
+		if j, found := seen[key]; found {
+			resp.Diagnostics.AddAttributeError(path.Root(MEMBERS).AtListIndex(i).AtName(EMAIL), "Duplicate member email", fmt.Sprintf("%q is also the email of members[%d]. Each member must have a different email.", email.ValueString(), j))
+			continue
+		}
+		seen[key] = i
+	}
+}
+
+// ModifyPlan carries the id and role of each member over from state by
+// email, so that adding, removing or reordering members only shows changes
+// for those members.
+func (r *TeamMembersBulkResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+	var plan, state TeamMembersBulkResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || plan.Members.IsUnknown() {
+		return
+	}
+	for _, element := range plan.Members.Elements() {
+		if element.IsUnknown() {
+			return
+		}
+	}
+
+	planMembers, d := teamMembersBulkMembersFrom(ctx, plan.Members)
+	resp.Diagnostics.Append(d...)
+	stateMembers, d := teamMembersBulkMembersFrom(ctx, state.Members)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diff := diffTeamMembersBulk(stateMembers, planMembers)
+	for i, j := range diff.kept {
+		if planMembers[i].ID.IsUnknown() {
+			planMembers[i].ID = stateMembers[j].ID
+		}
+		if planMembers[i].Role.IsUnknown() {
+			planMembers[i].Role = stateMembers[j].Role
+		}
+	}
+	members, d := teamMembersBulkMembersValue(ctx, planMembers)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(MEMBERS), members)...)
+}
+
+func (r *TeamMembersBulkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_team_members_bulk", "create", &resp.Diagnostics) {
+		return
+	}
+	var data TeamMembersBulkResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	members, d := teamMembersBulkMembersFrom(ctx, data.Members)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	indexes := make([]int, 0, len(members))
+	for i := range members {
+		indexes = append(indexes, i)
+	}
+	r.inviteMembers(ctx, members, indexes, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	value, d := teamMembersBulkMembersValue(ctx, members)
+	resp.Diagnostics.Append(d...)
+	data.ID = types.StringValue(teamMembersBulkId(members))
+	data.Members = value
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamMembersBulkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data TeamMembersBulkResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	members, d := teamMembersBulkMembersFrom(ctx, data.Members)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	emails := make([]string, 0, len(members))
+	for _, m := range members {
+		if !m.ID.IsNull() {
+			emails = append(emails, m.Email.ValueString())
+		}
+	}
+	found, err := getTeamMembersByEmailInChunks(r.client, emails, teamMembersBulkChunkSize)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get team members", err.Error())
+		return
+	}
+
+	roles := newCustomRoleKeyCache(r.client)
+	teamMemberIDs := make(map[string][]string)
+	refreshed := make([]teamMembersBulkMemberModel, 0, len(members))
+	for _, m := range members {
+		member, ok := found[strings.ToLower(m.Email.ValueString())]
+		if m.ID.IsNull() || !ok || member.Id != m.ID.ValueString() {
+			// The member was never invited, because LaunchDarkly rejected
+			// it, or was deleted outside of Terraform. Drop it so that the
+			// next plan invites it. A member with the same email that
+			// someone else invited is left alone.
+			continue
+		}
+		m.ID = types.StringValue(member.Id)
+		m.Role = types.StringValue(member.Role)
+
+		// API returns custom-role IDs; convert to keys for state.
+		customRoleKeys, err := roles.keys(member.CustomRoles)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to resolve custom role keys", err.Error())
+			return
+		}
+		m.CustomRoles, d = setFromStringSlicePreservingPlan(ctx, customRoleKeys, m.CustomRoles)
+		resp.Diagnostics.Append(d...)
+		m.RoleAttributes, d = frameworkRoleAttributesValue(ctx, member.RoleAttributes)
+		resp.Diagnostics.Append(d...)
+
+		// Only keep the teams this resource manages, so teams the member
+		// joined some other way do not show up as drift.
+		if !m.Teams.IsNull() {
+			teamKeys, d := stringSliceFromSet(ctx, m.Teams)
+			resp.Diagnostics.Append(d...)
+			onTeams := make([]string, 0, len(teamKeys))
+			for _, teamKey := range teamKeys {
+				ids, ok := teamMemberIDs[teamKey]
+				if !ok {
+					ids, err = getTeamMemberIDs(r.client, teamKey)
+					if err != nil {
+						resp.Diagnostics.AddError("Unable to get team members", err.Error())
+						return
+					}
+					teamMemberIDs[teamKey] = ids
+				}
+				if stringInSlice(member.Id, ids) {
+					onTeams = append(onTeams, teamKey)
+				}
+			}
+			m.Teams, d = setFromStringSlice(ctx, onTeams)
+			resp.Diagnostics.Append(d...)
+		}
+		refreshed = append(refreshed, m)
+	}
+
+	value, d := teamMembersBulkMembersValue(ctx, refreshed)
+	resp.Diagnostics.Append(d...)
+	data.Members = value
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamMembersBulkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_team_members_bulk", "update", &resp.Diagnostics) {
+		return
+	}
+	var plan, state TeamMembersBulkResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	planMembers, d := teamMembersBulkMembersFrom(ctx, plan.Members)
+	resp.Diagnostics.Append(d...)
+	stateMembers, d := teamMembersBulkMembersFrom(ctx, state.Members)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diff := diffTeamMembersBulk(stateMembers, planMembers)
+	// Members that fail to delete stay in state after the planned members, so
+	// the next apply deletes them again. Their errors are reported once the
+	// rest of the update has been applied.
+	var undeleted []teamMembersBulkMemberModel
+	var deleteDiags diag.Diagnostics
+	defer func() { resp.Diagnostics.Append(deleteDiags...) }()
+	for _, j := range diff.removed {
+		s := stateMembers[j]
+		if err := r.deleteMember(s.ID.ValueString()); err != nil {
+			deleteDiags.AddAttributeError(path.Root(MEMBERS).AtListIndex(len(planMembers)+len(undeleted)), "Failed to delete team member", fmt.Sprintf("Failed to delete %q: %s", s.Email.ValueString(), err))
+			undeleted = append(undeleted, s)
+		}
+	}
+
+	roles := newCustomRoleKeyCache(r.client)
+	teamPatches := make(map[string]*teamMembersPatch)
+	for i := range planMembers {
+		j, ok := diff.kept[i]
+		if !ok {
+			continue
+		}
+		p, s := &planMembers[i], stateMembers[j]
+		p.ID = s.ID
+		if p.Role.IsUnknown() {
+			p.Role = s.Role
+		}
+
+		var patch []ldapi.PatchOperation
+		if !p.Role.Equal(s.Role) {
+			role := p.Role.ValueString()
+			patch = append(patch, patchReplace("/role", &role))
+		}
+		if !p.CustomRoles.Equal(s.CustomRoles) {
+			customRoleKeys, d := stringSliceFromSet(ctx, p.CustomRoles)
+			resp.Diagnostics.Append(d...)
+			if customRoleIds, err := roles.ids(customRoleKeys); err != nil {
+				resp.Diagnostics.AddAttributeWarning(path.Root(MEMBERS).AtListIndex(i), "Failed to look up custom role IDs", err.Error())
+			} else {
+				patch = append(patch, patchReplace("/customRoles", &customRoleIds))
+			}
+		}
+		patch = append(patch, frameworkRoleAttributePatches(ctx, p.RoleAttributes, s.RoleAttributes)...)
+		if len(patch) > 0 {
+			err := r.client.withConcurrency(r.client.ctx, func() error {
+				_, _, e := r.client.ld.AccountMembersApi.PatchMember(r.client.ctx, p.ID.ValueString()).PatchOperation(patch).Execute()
+				return e
+			})
+			if err != nil {
+				resp.Diagnostics.AddAttributeWarning(path.Root(MEMBERS).AtListIndex(i), "Failed to update team member", fmt.Sprintf("Failed to update %q: %s", p.Email.ValueString(), handleLdapiErr(err)))
+			}
+		}
+
+		oldTeams, d := stringSliceFromSet(ctx, s.Teams)
+		resp.Diagnostics.Append(d...)
+		newTeams, d := stringSliceFromSet(ctx, p.Teams)
+		resp.Diagnostics.Append(d...)
+		remove, add := makeAddAndRemoveArrays(oldTeams, newTeams)
+		for _, teamKey := range remove {
+			teamPatch := teamPatchFor(teamPatches, teamKey)
+			teamPatch.remove = append(teamPatch.remove, p.ID.ValueString())
+		}
+		for _, teamKey := range add {
+			teamPatch := teamPatchFor(teamPatches, teamKey)
+			teamPatch.add = append(teamPatch.add, p.ID.ValueString())
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.patchTeams(teamPatches, &resp.Diagnostics)
+
+	// New members join their teams through the invite itself.
+	r.inviteMembers(ctx, planMembers, diff.added, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members := append(planMembers, undeleted...)
+	value, d := teamMembersBulkMembersValue(ctx, members)
+	resp.Diagnostics.Append(d...)
+	plan.ID = types.StringValue(teamMembersBulkId(members))
+	plan.Members = value
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *TeamMembersBulkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_team_members_bulk", "destroy", &resp.Diagnostics) {
+		return
+	}
+	var data TeamMembersBulkResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	members, d := teamMembersBulkMembersFrom(ctx, data.Members)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for i, m := range members {
+		if m.ID.IsNull() {
+			continue
+		}
+		if err := r.deleteMember(m.ID.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(MEMBERS).AtListIndex(i), "Failed to delete team member", fmt.Sprintf("Failed to delete %q: %s", m.Email.ValueString(), err))
+		}
+	}
+}
+
+// ImportState imports the existing members with the comma-separated emails
+// of the import ID.
+func (r *TeamMembersBulkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	emails := strings.Split(req.ID, ",")
+	for i, email := range emails {
+		emails[i] = strings.TrimSpace(email)
+		if emails[i] == "" {
+			resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("found unexpected team members bulk id format: %q expected format: 'email_1,email_2'", req.ID))
+			return
+		}
+	}
+	found, err := getTeamMembersByEmailInChunks(r.client, emails, teamMembersBulkChunkSize)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get team members", err.Error())
+		return
+	}
+
+	members := make([]teamMembersBulkMemberModel, 0, len(emails))
+	for _, email := range emails {
+		member, ok := found[strings.ToLower(email)]
+		if !ok {
+			resp.Diagnostics.AddError("Team member not found", fmt.Sprintf("no member found with email %q", email))
+			continue
+		}
+		members = append(members, teamMembersBulkMemberModel{
+			ID:             types.StringValue(member.Id),
+			Email:          types.StringValue(email),
+			Role:           types.StringNull(),
+			CustomRoles:    types.SetNull(types.StringType),
+			RoleAttributes: types.MapNull(types.ListType{ElemType: types.StringType}),
+			Teams:          types.SetNull(types.StringType),
+		})
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	value, d := teamMembersBulkMembersValue(ctx, members)
+	resp.Diagnostics.Append(d...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(ID), teamMembersBulkId(members))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(MEMBERS), value)...)
+}
+
+// inviteMembers invites members[i] for each of indexes, in chunks of
+// teamMembersBulkChunkSize, and sets their id and role. LaunchDarkly rejects
+// a whole chunk if it rejects any of its members, so the members of a
+// rejected chunk are invited one at a time. Members that are still rejected
+// are reported as warnings and keep a null id, so the next plan invites them
+// again.
+func (r *TeamMembersBulkResource) inviteMembers(ctx context.Context, members []teamMembersBulkMemberModel, indexes []int, diags *diag.Diagnostics) {
+	forms := make([]ldapi.NewMemberForm, 0, len(indexes))
+	for _, i := range indexes {
+		form, d := members[i].newMemberForm(ctx)
+		diags.Append(d...)
+		forms = append(forms, form)
+	}
+	if diags.HasError() {
+		return
+	}
+
+	for c, chunk := range chunkNewMemberForms(forms, teamMembersBulkChunkSize) {
+		chunkIndexes := indexes[c*teamMembersBulkChunkSize : c*teamMembersBulkChunkSize+len(chunk)]
+		invited, err := r.postMembers(chunk)
+		if err != nil {
+			invited = nil
+			for k, form := range chunk {
+				if len(chunk) > 1 {
+					var member []ldapi.Member
+					if member, err = r.postMembers([]ldapi.NewMemberForm{form}); err == nil {
+						invited = append(invited, member...)
+						continue
+					}
+				}
+				diags.AddAttributeWarning(path.Root(MEMBERS).AtListIndex(chunkIndexes[k]), "Failed to invite team member", fmt.Sprintf("Failed to invite %q. It will be invited again on the next apply. %s", form.Email, err))
+			}
+		}
+
+		byEmail := make(map[string]ldapi.Member, len(invited))
+		for _, member := range invited {
+			byEmail[strings.ToLower(member.Email)] = member
+		}
+		for k, form := range chunk {
+			m := &members[chunkIndexes[k]]
+			member, ok := byEmail[strings.ToLower(form.Email)]
+			if !ok {
+				m.ID = types.StringNull()
+				if m.Role.IsUnknown() {
+					m.Role = types.StringNull()
+				}
+				continue
+			}
+			m.ID = types.StringValue(member.Id)
+			m.Role = types.StringValue(member.Role)
+		}
+	}
+}
+
+func (r *TeamMembersBulkResource) postMembers(forms []ldapi.NewMemberForm) ([]ldapi.Member, error) {
+	var members *ldapi.Members
+	err := r.client.withConcurrency(r.client.ctx, func() error {
+		var e error
+		members, _, e = r.client.ld.AccountMembersApi.PostMembers(r.client.ctx).NewMemberForm(forms).Execute()
+		return e
+	})
+	if err != nil {
+		return nil, handleLdapiErr(err)
+	}
+	return members.Items, nil
+}
+
+// deleteMember deletes the member with memberID, if it still exists.
+func (r *TeamMembersBulkResource) deleteMember(memberID string) error {
+	var res *http.Response
+	err := r.client.withConcurrency(r.client.ctx, func() error {
+		var e error
+		res, e = r.client.ld.AccountMembersApi.DeleteMember(r.client.ctx, memberID).Execute()
+		return e
+	})
+	if err != nil && !isStatusNotFound(res) {
+		return handleLdapiErr(err)
+	}
+	return nil
+}
+
+// teamMembersPatch holds the IDs of the members to remove from and add to a
+// team.
+type teamMembersPatch struct {
+	remove []string
+	add    []string
+}
+
+func teamPatchFor(patches map[string]*teamMembersPatch, teamKey string) *teamMembersPatch {
+	if _, ok := patches[teamKey]; !ok {
+		patches[teamKey] = &teamMembersPatch{}
+	}
+	return patches[teamKey]
+}
+
+// patchTeams applies patches with one team patch per team. Failures are
+// warnings, since Read reports the teams the members are actually on.
+func (r *TeamMembersBulkResource) patchTeams(patches map[string]*teamMembersPatch, diags *diag.Diagnostics) {
+	teamKeys := make([]string, 0, len(patches))
+	for teamKey := range patches {
+		teamKeys = append(teamKeys, teamKey)
+	}
+	sort.Strings(teamKeys)
+	for _, teamKey := range teamKeys {
+		instructions := make([]map[string]interface{}, 0)
+		if remove := patches[teamKey].remove; len(remove) > 0 {
+			instructions = append(instructions, map[string]interface{}{
+				"kind":   "removeMembers",
+				"values": remove,
+			})
+		}
+		if add := patches[teamKey].add; len(add) > 0 {
+			instructions = append(instructions, map[string]interface{}{
+				"kind":   "addMembers",
+				"values": add,
+			})
+		}
+		patch := ldapi.TeamPatchInput{
+			Comment:      strPtr(r.client.changeCommentFor(types.StringNull())),
+			Instructions: instructions,
+		}
+		err := r.client.withConcurrency(r.client.ctx, func() error {
+			_, _, err := r.client.ld.TeamsApi.PatchTeam(r.client.ctx, teamKey).TeamPatchInput(patch).Execute()
+			return err
+		})
+		if err != nil {
+			diags.AddWarning("Unable to update team members", fmt.Sprintf("Unable to modify the %q team's members. %s", teamKey, handleLdapiErr(err)))
+		}
+	}
+}
+
+func (m teamMembersBulkMemberModel) newMemberForm(ctx context.Context) (ldapi.NewMemberForm, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	customRoles, d := stringSliceFromSet(ctx, m.CustomRoles)
+	diags.Append(d...)
+	teamKeys, d := stringSliceFromSet(ctx, m.Teams)
+	diags.Append(d...)
+	roleAttrs, d := frameworkRoleAttributesFromMap(ctx, m.RoleAttributes)
+	diags.Append(d...)
+
+	form := ldapi.NewMemberForm{
+		Email:          m.Email.ValueString(),
+		CustomRoles:    customRoles,
+		TeamKeys:       teamKeys,
+		RoleAttributes: roleAttrs,
+	}
+	if !m.Role.IsNull() && !m.Role.IsUnknown() {
+		role := m.Role.ValueString()
+		form.Role = &role
+	}
+	return form, diags
+}
+
+// teamMembersBulkDiff is the difference between the members in state and
+// the planned members, matched by email.
+type teamMembersBulkDiff struct {
+	// removed are the indexes of the state members that are not planned.
+	removed []int
+	// added are the indexes of the planned members that are not in state,
+	// or that were never invited.
+	added []int
+	// kept maps the indexes of the other planned members to their index in
+	// state.
+	kept map[int]int
+}
+
+func diffTeamMembersBulk(state, plan []teamMembersBulkMemberModel) teamMembersBulkDiff {
+	diff := teamMembersBulkDiff{kept: make(map[int]int)}
+	invited := make(map[string]int, len(state))
+	for j, s := range state {
+		if !s.ID.IsNull() && !s.ID.IsUnknown() {
+			invited[strings.ToLower(s.Email.ValueString())] = j
+		}
+	}
+	planned := make(map[string]bool, len(plan))
+	for i, p := range plan {
+		email := strings.ToLower(p.Email.ValueString())
+		planned[email] = true
+		if j, ok := invited[email]; ok {
+			diff.kept[i] = j
+		} else {
+			diff.added = append(diff.added, i)
+		}
+	}
+	for j, s := range state {
+		if _, ok := invited[strings.ToLower(s.Email.ValueString())]; ok && !planned[strings.ToLower(s.Email.ValueString())] {
+			diff.removed = append(diff.removed, j)
+		}
+	}
+	return diff
+}
+
+// teamMembersBulkId returns a hash of the sorted, lowercased emails of
+// members.
+func teamMembersBulkId(members []teamMembersBulkMemberModel) string {
+	emails := make([]string, 0, len(members))
+	for _, m := range members {
+		emails = append(emails, strings.ToLower(m.Email.ValueString()))
+	}
+	sort.Strings(emails)
+	sum := sha256.Sum256([]byte(strings.Join(emails, ",")))
+	return hex.EncodeToString(sum[:])
+}
+
+func teamMembersBulkMembersFrom(ctx context.Context, list types.List) ([]teamMembersBulkMemberModel, diag.Diagnostics) {
+	members := make([]teamMembersBulkMemberModel, 0, len(list.Elements()))
+	if list.IsNull() || list.IsUnknown() {
+		return members, nil
+	}
+	diags := list.ElementsAs(ctx, &members, false)
+	return members, diags
+}
+
+func teamMembersBulkMembersValue(ctx context.Context, members []teamMembersBulkMemberModel) (types.List, diag.Diagnostics) {
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: teamMembersBulkMemberAttrTypes}, members)
+}
+
+// customRoleKeyCache converts between custom role IDs and keys, fetching each
+// custom role at most once, since many members usually share a few custom
+// roles.
+type customRoleKeyCache struct {
+	client   *Client
+	keysByID map[string]string
+	idsByKey map[string]string
+}
+
+func newCustomRoleKeyCache(client *Client) *customRoleKeyCache {
+	return &customRoleKeyCache{
+		client:   client,
+		keysByID: make(map[string]string),
+		idsByKey: make(map[string]string),
+	}
+}
+
+func (c *customRoleKeyCache) keys(ids []string) ([]string, error) {
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		key, ok := c.keysByID[id]
+		if !ok {
+			found, err := customRoleIDsToKeys(c.client, []string{id})
+			if err != nil {
+				return nil, err
+			}
+			key = found[0]
+			c.keysByID[id], c.idsByKey[key] = key, id
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (c *customRoleKeyCache) ids(keys []string) ([]string, error) {
+	ids := make([]string, 0, len(keys))
+	for _, key := range keys {
+		id, ok := c.idsByKey[key]
+		if !ok {
+			found, err := customRoleKeysToIDs(c.client, []string{key})
+			if err != nil {
+				return nil, err
+			}
+			id = found[0]
+			c.keysByID[id], c.idsByKey[key] = key, id
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
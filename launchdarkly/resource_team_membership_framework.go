@@ -0,0 +1,336 @@
+package launchdarkly
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ldapi "github.com/launchdarkly/api-client-go/v24"
+)
+
+var (
+	_ resource.Resource                = &TeamMembershipResource{}
+	_ resource.ResourceWithImportState = &TeamMembershipResource{}
+)
+
+// TeamMembershipResource adds members to a team without owning the team's
+// whole membership, so several configurations, or SCIM, can add members to
+// the same team.
+type TeamMembershipResource struct {
+	client *Client
+}
+
+type TeamMembershipResourceModel struct {
+	TeamKey   types.String `tfsdk:"team_key"`
+	MemberIDs types.Set    `tfsdk:"member_ids"`
+	Comment   types.String `tfsdk:"comment"`
+	ID        types.String `tfsdk:"id"`
+}
+
+func NewTeamMembershipResource() resource.Resource {
+	return &TeamMembershipResource{}
+}
+
+func (r *TeamMembershipResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_team_membership"
+}
+
+func (r *TeamMembershipResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `Provides a LaunchDarkly team membership resource.
+
+This resource allows you to add members to a LaunchDarkly team without managing the team's whole membership. It only adds and removes the members in ` + "`member_ids`" + ` and ignores the team's other members, so several configurations, or SCIM, can add members to the same team. If you manage the team with ` + "`launchdarkly_team`" + `, leave its ` + "`member_ids`" + ` unset.
+
+-> **Note:** Teams are available to customers on an Enterprise LaunchDarkly plan. To learn more, [read about our pricing](https://launchdarkly.com/pricing/). To upgrade your plan, [contact LaunchDarkly Sales](https://launchdarkly.com/contact-sales/).`,
+		Attributes: map[string]schema.Attribute{
+			TEAM_KEY: schema.StringAttribute{
+				Required:    true,
+				Description: "The key of the team to add the members to. A change in this field will force the destruction of the existing resource and the creation of a new one.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			MEMBER_IDS: schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: "The IDs of the members this resource adds to the team. Removing an ID removes that member from the team.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			COMMENT: resourceCommentAttribute(),
+			ID: schema.StringAttribute{
+				Computed:    true,
+				Description: "The team key and the sorted member IDs, in the format `team_key/member_id_1,member_id_2`.",
+			},
+		},
+	}
+}
+
+func (r *TeamMembershipResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = configureResourceClient(req, resp)
+}
+
+func (r *TeamMembershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_team_membership", "create", &resp.Diagnostics) {
+		return
+	}
+	var data TeamMembershipResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	teamKey := data.TeamKey.ValueString()
+	memberIDs, d := stringSliceFromSet(ctx, data.MemberIDs)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found, err := r.teamExists(teamKey)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get team", fmt.Sprintf("Received an error when fetching the team %q: %s", teamKey, err))
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError("Team not found", fmt.Sprintf("Unable to add members to the team %q because it does not exist.", teamKey))
+		return
+	}
+
+	current, err := getTeamMemberIDs(r.client, teamKey)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get team members", err.Error())
+		return
+	}
+	_, add := makeAddAndRemoveArrays(current, memberIDs)
+	if !r.patchTeamMembers(teamKey, data.Comment, nil, add, &resp.Diagnostics) {
+		return
+	}
+
+	r.checkMembersAdded(teamKey, memberIDs, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(teamMembershipId(teamKey, memberIDs))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamMembershipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data TeamMembershipResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	teamKey := data.TeamKey.ValueString()
+	found, err := r.teamExists(teamKey)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get team", fmt.Sprintf("Received an error when fetching the team %q: %s", teamKey, err))
+		return
+	}
+	if !found {
+		// Team was deleted outside of Terraform, remove from state
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	current, err := getTeamMemberIDs(r.client, teamKey)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get team members", err.Error())
+		return
+	}
+	memberIDs, d := stringSliceFromSet(ctx, data.MemberIDs)
+	resp.Diagnostics.Append(d...)
+
+	// Only keep the members this resource manages, so members added by
+	// other configurations or SCIM do not show up as drift.
+	managed := make([]string, 0, len(memberIDs))
+	for _, id := range memberIDs {
+		if stringInSlice(id, current) {
+			managed = append(managed, id)
+		}
+	}
+	memberIDsSet, d := setFromStringSlice(ctx, managed)
+	resp.Diagnostics.Append(d...)
+	data.MemberIDs = memberIDsSet
+	if data.ID.IsNull() || data.ID.IsUnknown() {
+		data.ID = types.StringValue(teamMembershipId(teamKey, memberIDs))
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_team_membership", "update", &resp.Diagnostics) {
+		return
+	}
+	var plan, state TeamMembershipResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	teamKey := plan.TeamKey.ValueString()
+	oldIDs, d := stringSliceFromSet(ctx, state.MemberIDs)
+	resp.Diagnostics.Append(d...)
+	newIDs, d := stringSliceFromSet(ctx, plan.MemberIDs)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := getTeamMemberIDs(r.client, teamKey)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get team members", err.Error())
+		return
+	}
+	// Only remove dropped members that are still on the team, and only add
+	// members that are not on it yet, so the patch leaves other members alone.
+	dropped, _ := makeAddAndRemoveArrays(oldIDs, newIDs)
+	var remove []string
+	for _, id := range dropped {
+		if stringInSlice(id, current) {
+			remove = append(remove, id)
+		}
+	}
+	_, add := makeAddAndRemoveArrays(current, newIDs)
+	if !r.patchTeamMembers(teamKey, plan.Comment, remove, add, &resp.Diagnostics) {
+		return
+	}
+
+	r.checkMembersAdded(teamKey, newIDs, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(teamMembershipId(teamKey, newIDs))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *TeamMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_team_membership", "destroy", &resp.Diagnostics) {
+		return
+	}
+	var data TeamMembershipResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	teamKey := data.TeamKey.ValueString()
+	found, err := r.teamExists(teamKey)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get team", fmt.Sprintf("Received an error when fetching the team %q: %s", teamKey, err))
+		return
+	}
+	if !found {
+		return
+	}
+
+	current, err := getTeamMemberIDs(r.client, teamKey)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get team members", err.Error())
+		return
+	}
+	memberIDs, d := stringSliceFromSet(ctx, data.MemberIDs)
+	resp.Diagnostics.Append(d...)
+	var remove []string
+	for _, id := range memberIDs {
+		if stringInSlice(id, current) {
+			remove = append(remove, id)
+		}
+	}
+	r.patchTeamMembers(teamKey, data.Comment, remove, nil, &resp.Diagnostics)
+}
+
+func (r *TeamMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	teamKey, memberIDs, err := teamMembershipIdToKeys(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+	memberIDsSet, d := setFromStringSlice(ctx, memberIDs)
+	resp.Diagnostics.Append(d...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(TEAM_KEY), teamKey)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(MEMBER_IDS), memberIDsSet)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(ID), teamMembershipId(teamKey, memberIDs))...)
+}
+
+// teamExists reports whether the team exists. It uses the 404-retry client
+// because teams provisioned via Okta team sync may take a while to appear.
+func (r *TeamMembershipResource) teamExists(teamKey string) (bool, error) {
+	var res *http.Response
+	var err error
+	err = r.client.withConcurrency(r.client.ctx, func() error {
+		_, res, err = r.client.ld404Retry.TeamsApi.GetTeam(r.client.ctx, teamKey).Execute()
+		return err
+	})
+	if isStatusNotFound(res) {
+		return false, nil
+	}
+	if err != nil {
+		return false, handleLdapiErr(err)
+	}
+	return true, nil
+}
+
+// patchTeamMembers removes and adds members in a single patch, if there is
+// anything to change. It returns false if the patch failed.
+func (r *TeamMembershipResource) patchTeamMembers(teamKey string, comment types.String, remove, add []string, diags *diag.Diagnostics) bool {
+	instructions := make([]map[string]interface{}, 0)
+	if len(remove) > 0 {
+		instructions = append(instructions, map[string]interface{}{
+			"kind":   "removeMembers",
+			"values": remove,
+		})
+	}
+	if len(add) > 0 {
+		instructions = append(instructions, map[string]interface{}{
+			"kind":   "addMembers",
+			"values": add,
+		})
+	}
+	if len(instructions) == 0 {
+		return true
+	}
+
+	patch := ldapi.TeamPatchInput{
+		Comment:      strPtr(r.client.changeCommentFor(comment)),
+		Instructions: instructions,
+	}
+	err := r.client.withConcurrency(r.client.ctx, func() error {
+		_, _, err := r.client.ld.TeamsApi.PatchTeam(r.client.ctx, teamKey).TeamPatchInput(patch).Execute()
+		return err
+	})
+	if err != nil {
+		diags.AddError("Unable to update team members", fmt.Sprintf("Unable to modify the %q team's members. %s", teamKey, handleLdapiErr(err)))
+		return false
+	}
+	return true
+}
+
+// checkMembersAdded adds an error for each of memberIDs that is not on the
+// team, since the API ignores IDs of members that do not exist.
+func (r *TeamMembershipResource) checkMembersAdded(teamKey string, memberIDs []string, diags *diag.Diagnostics) {
+	current, err := getTeamMemberIDs(r.client, teamKey)
+	if err != nil {
+		diags.AddError("Unable to get team members", err.Error())
+		return
+	}
+	for _, id := range memberIDs {
+		if !stringInSlice(id, current) {
+			diags.AddError("Unable to add member to team", fmt.Sprintf("Unable to add member with ID %q to the team %q. Ensure the member exists first.", id, teamKey))
+		}
+	}
+}
@@ -27,6 +27,7 @@ type TeamRoleMappingResourceModel struct {
 	CustomRoleKeys types.Set    `tfsdk:"custom_role_keys"`
 	RoleAttributes types.Map    `tfsdk:"role_attributes"`
 	ID             types.String `tfsdk:"id"`
+	Comment        types.String `tfsdk:"comment"`
 }
 
 func (r *TeamRoleMappingResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -54,6 +55,7 @@ func (r *TeamRoleMappingResource) Schema(ctx context.Context, req resource.Schem
 				Optional:    true,
 				Description: "Map of role-attribute keys to lists of resource keys. Applied to the team as a whole. Every custom role granted to this team gets these scopes. To learn more, read [role scope](https://launchdarkly.com/docs/home/account/roles/role-scope). Conflicts with `role_attributes` on `launchdarkly_team`. If you manage the team via `launchdarkly_team`, set `role_attributes` there instead, or add `lifecycle { ignore_changes = [role_attributes] }` on the `launchdarkly_team` to avoid plan churn.",
 			},
+			COMMENT: resourceCommentAttribute(),
 			// Framework resources require an explicit id attribute; it
 			// is conventionally Computed and holds the resource identifier.
 			"id": schema.StringAttribute{
@@ -73,6 +75,9 @@ func (r *TeamRoleMappingResource) Configure(ctx context.Context, req resource.Co
 }
 
 func (r *TeamRoleMappingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_team_role_mapping", "create", &resp.Diagnostics) {
+		return
+	}
 	var data *TeamRoleMappingResourceModel
 
 	// Read Terraform plan data into the model
@@ -143,7 +148,7 @@ func (r *TeamRoleMappingResource) Create(ctx context.Context, req resource.Creat
 
 	if len(patchInstructions) > 0 {
 		patch := ldapi.TeamPatchInput{
-			Comment:      strPtr("Updated by Terraform"),
+			Comment:      strPtr(r.client.changeCommentFor(data.Comment)),
 			Instructions: patchInstructions,
 		}
 
@@ -256,6 +261,9 @@ func (r *TeamRoleMappingResource) Read(ctx context.Context, req resource.ReadReq
 }
 
 func (r *TeamRoleMappingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_team_role_mapping", "update", &resp.Diagnostics) {
+		return
+	}
 	var data *TeamRoleMappingResourceModel
 
 	// Read Terraform plan data into the model
@@ -350,7 +358,7 @@ func (r *TeamRoleMappingResource) Update(ctx context.Context, req resource.Updat
 
 	if len(patchInstructions) > 0 {
 		patch := ldapi.TeamPatchInput{
-			Comment:      strPtr("Updated by Terraform"),
+			Comment:      strPtr(r.client.changeCommentFor(data.Comment)),
 			Instructions: patchInstructions,
 		}
 
@@ -394,6 +402,9 @@ func (r *TeamRoleMappingResource) Update(ctx context.Context, req resource.Updat
 }
 
 func (r *TeamRoleMappingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_team_role_mapping", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data *TeamRoleMappingResourceModel
 
 	// Read Terraform plan data into the model
@@ -427,7 +438,7 @@ func (r *TeamRoleMappingResource) Delete(ctx context.Context, req resource.Delet
 
 	if len(instructions) > 0 {
 		patch := ldapi.TeamPatchInput{
-			Comment:      strPtr("Updated by Terraform"),
+			Comment:      strPtr(r.client.changeCommentFor(data.Comment)),
 			Instructions: instructions,
 		}
 
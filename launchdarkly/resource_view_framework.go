@@ -3,7 +3,6 @@ package launchdarkly
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
@@ -19,6 +18,7 @@ import (
 
 var (
 	_ resource.Resource                     = &ViewResource{}
+	_ resource.ResourceWithModifyPlan       = &ViewResource{}
 	_ resource.ResourceWithImportState      = &ViewResource{}
 	_ resource.ResourceWithConfigValidators = &ViewResource{}
 	_ resource.ResourceWithUpgradeState     = &ViewResource{}
@@ -33,6 +33,7 @@ type ViewResourceModel struct {
 	MaintainerID      types.String `tfsdk:"maintainer_id"`
 	MaintainerTeamKey types.String `tfsdk:"maintainer_team_key"`
 	Tags              types.Set    `tfsdk:"tags"`
+	TagsAll           types.Set    `tfsdk:"tags_all"`
 }
 
 func NewViewResource() resource.Resource {
@@ -99,6 +100,7 @@ func viewSchemaAttributes() map[string]schema.Attribute {
 				setvalidator.ValueStringsAre(tagValidator()),
 			},
 		},
+		TAGS_ALL: tagsAllAttribute(),
 	}
 }
 
@@ -161,6 +163,11 @@ func (r *ViewResource) Configure(_ context.Context, req resource.ConfigureReques
 	r.beta = beta
 }
 
+func (r *ViewResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.client.planGuardrails(ctx, req, resp, guardrailScope{project: PROJECT_KEY})
+	r.client.planTagsAll(ctx, req, resp)
+}
+
 func (r *ViewResource) betaClient() (*Client, error) {
 	if r.beta != nil {
 		return r.beta, nil
@@ -169,6 +176,9 @@ func (r *ViewResource) betaClient() (*Client, error) {
 }
 
 func (r *ViewResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_view", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan ViewResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -196,7 +206,7 @@ func (r *ViewResource) Create(ctx context.Context, req resource.CreateRequest, r
 	if !plan.MaintainerTeamKey.IsNull() && plan.MaintainerTeamKey.ValueString() != "" {
 		viewPost["maintainerTeamKey"] = plan.MaintainerTeamKey.ValueString()
 	}
-	tags, diags := stringSliceFromSet(ctx, plan.Tags)
+	tags, diags := stringSliceFromSet(ctx, plan.TagsAll)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -241,6 +251,9 @@ func (r *ViewResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *ViewResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_view", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan, state ViewResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -277,8 +290,8 @@ func (r *ViewResource) Update(ctx context.Context, req resource.UpdateRequest, r
 			patch["maintainerTeamKey"] = plan.MaintainerTeamKey.ValueString()
 		}
 	}
-	if !plan.Tags.Equal(state.Tags) {
-		tags, diags := stringSliceFromSet(ctx, plan.Tags)
+	if !plan.TagsAll.Equal(state.TagsAll) {
+		tags, diags := stringSliceFromSet(ctx, plan.TagsAll)
 		resp.Diagnostics.Append(diags...)
 		patch["tags"] = tags
 	}
@@ -298,6 +311,9 @@ func (r *ViewResource) Update(ctx context.Context, req resource.UpdateRequest, r
 }
 
 func (r *ViewResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_view", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data ViewResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -314,13 +330,13 @@ func (r *ViewResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 }
 
 func (r *ViewResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	parts := strings.SplitN(req.ID, "/", 2)
-	if len(parts) != 2 {
-		resp.Diagnostics.AddError("Invalid import ID", "expected project_key/view_key")
+	projectKey, viewKey, err := viewIdToKeys(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
 		return
 	}
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(PROJECT_KEY), parts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(KEY), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(PROJECT_KEY), projectKey)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(KEY), viewKey)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
 }
 
@@ -368,7 +384,10 @@ func (r *ViewResource) readIntoModel(
 	}
 
 	// Optional-only Set attr with plan-aware null-vs-empty handling.
-	tagsSet, d := setFromStringSlicePreservingPlan(ctx, view.Tags, data.Tags)
+	ownTags, tagsAll, d := r.client.splitDefaultTags(ctx, view.Tags, data.Tags)
+	diags.Append(d...)
+	tagsSet, d := setFromStringSlicePreservingPlan(ctx, ownTags, data.Tags)
 	diags.Append(d...)
 	data.Tags = tagsSet
+	data.TagsAll = tagsAll
 }
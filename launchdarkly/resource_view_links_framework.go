@@ -25,6 +25,7 @@ import (
 
 var (
 	_ resource.Resource                = &ViewLinksResource{}
+	_ resource.ResourceWithModifyPlan  = &ViewLinksResource{}
 	_ resource.ResourceWithImportState = &ViewLinksResource{}
 )
 
@@ -116,6 +117,10 @@ func (r *ViewLinksResource) Configure(_ context.Context, req resource.ConfigureR
 	r.beta = beta
 }
 
+func (r *ViewLinksResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.client.planGuardrails(ctx, req, resp, guardrailScope{project: PROJECT_KEY})
+}
+
 func (r *ViewLinksResource) betaClient() (*Client, error) {
 	if r.beta != nil {
 		return r.beta, nil
@@ -124,6 +129,9 @@ func (r *ViewLinksResource) betaClient() (*Client, error) {
 }
 
 func (r *ViewLinksResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_view_links", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan ViewLinksResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -209,6 +217,9 @@ func (r *ViewLinksResource) Read(ctx context.Context, req resource.ReadRequest,
 }
 
 func (r *ViewLinksResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_view_links", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan, state ViewLinksResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -279,6 +290,9 @@ func (r *ViewLinksResource) Update(ctx context.Context, req resource.UpdateReque
 }
 
 func (r *ViewLinksResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_view_links", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data ViewLinksResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -540,6 +554,7 @@ func (viewFilterLinksValidator) ValidateResource(ctx context.Context, req resour
 // ModifyPlan re-marks resolved_at unknown when reconcile_on_apply is
 // true, so the framework computes a new value each apply.
 func (r *ViewFilterLinksResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.client.planGuardrails(ctx, req, resp, guardrailScope{project: PROJECT_KEY})
 	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
 		return
 	}
@@ -575,6 +590,9 @@ func (r *ViewFilterLinksResource) betaClient() (*Client, error) {
 }
 
 func (r *ViewFilterLinksResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_view_filter_links", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan ViewFilterLinksResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -646,6 +664,9 @@ func (r *ViewFilterLinksResource) Read(ctx context.Context, req resource.ReadReq
 }
 
 func (r *ViewFilterLinksResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_view_filter_links", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan, state ViewFilterLinksResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -729,6 +750,9 @@ func (r *ViewFilterLinksResource) Update(ctx context.Context, req resource.Updat
 }
 
 func (r *ViewFilterLinksResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_view_filter_links", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data ViewFilterLinksResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -19,6 +20,7 @@ import (
 
 var (
 	_ resource.Resource                 = &WebhookResource{}
+	_ resource.ResourceWithModifyPlan   = &WebhookResource{}
 	_ resource.ResourceWithImportState  = &WebhookResource{}
 	_ resource.ResourceWithUpgradeState = &WebhookResource{}
 )
@@ -28,13 +30,16 @@ type WebhookResource struct {
 }
 
 type WebhookResourceModel struct {
-	ID         types.String `tfsdk:"id"`
-	URL        types.String `tfsdk:"url"`
-	Secret     types.String `tfsdk:"secret"`
-	On         types.Bool   `tfsdk:"on"`
-	Name       types.String `tfsdk:"name"`
-	Statements types.List   `tfsdk:"statements"`
-	Tags       types.Set    `tfsdk:"tags"`
+	ID              types.String `tfsdk:"id"`
+	URL             types.String `tfsdk:"url"`
+	Secret          types.String `tfsdk:"secret"`
+	SecretWO        types.String `tfsdk:"secret_wo"`
+	SecretWOVersion types.Int64  `tfsdk:"secret_wo_version"`
+	On              types.Bool   `tfsdk:"on"`
+	Name            types.String `tfsdk:"name"`
+	Statements      types.List   `tfsdk:"statements"`
+	Tags            types.Set    `tfsdk:"tags"`
+	TagsAll         types.Set    `tfsdk:"tags_all"`
 }
 
 func NewWebhookResource() resource.Resource {
@@ -66,8 +71,19 @@ func webhookSchemaAttributes() map[string]schema.Attribute {
 		SECRET: schema.StringAttribute{
 			Optional:    true,
 			Sensitive:   true,
-			Description: "The secret used to sign the webhook.",
+			Description: "The secret used to sign the webhook. This value is stored in the Terraform state; use `secret_wo` to keep it out of state.",
 		},
+		SECRET_WO: schema.StringAttribute{
+			Optional:    true,
+			Sensitive:   true,
+			WriteOnly:   true,
+			Description: writeOnlyDescription("The secret used to sign the webhook.", SECRET_WO_VERSION),
+			Validators: []validator.String{
+				stringvalidator.ConflictsWith(path.MatchRoot(SECRET)),
+				stringvalidator.AlsoRequires(path.MatchRoot(SECRET_WO_VERSION)),
+			},
+		},
+		SECRET_WO_VERSION: writeOnlyVersionAttribute(SECRET_WO),
 		ON: schema.BoolAttribute{
 			Optional:    true,
 			Computed:    true,
@@ -86,6 +102,7 @@ func webhookSchemaAttributes() map[string]schema.Attribute {
 				setvalidator.ValueStringsAre(tagValidator()),
 			},
 		},
+		TAGS_ALL:   tagsAllAttribute(),
 		STATEMENTS: frameworkPolicyStatementsResourceAttribute(false, "List of policy statements used to filter webhook events. For more information on webhook policy filters read [Adding a policy filter](https://launchdarkly.com/docs/home/infrastructure/webhooks#adding-a-policy-filter).", ""),
 	}
 }
@@ -113,7 +130,14 @@ func (r *WebhookResource) Configure(_ context.Context, req resource.ConfigureReq
 	r.client = configureResourceClient(req, resp)
 }
 
+func (r *WebhookResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.client.planTagsAll(ctx, req, resp)
+}
+
 func (r *WebhookResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_webhook", "create", &resp.Diagnostics) {
+		return
+	}
 	var plan WebhookResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -136,7 +160,14 @@ func (r *WebhookResource) Create(ctx context.Context, req resource.CreateRequest
 		Statements: stmts,
 	}
 	secret := plan.Secret.ValueString()
-	if !plan.Secret.IsNull() && !plan.Secret.IsUnknown() && secret != "" {
+	if writeOnlyInUse(plan.SecretWOVersion) {
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(SECRET_WO), &plan.SecretWO)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		secret = plan.SecretWO.ValueString()
+	}
+	if secret != "" {
 		post.Secret = &secret
 		post.Sign = true
 	}
@@ -154,7 +185,7 @@ func (r *WebhookResource) Create(ctx context.Context, req resource.CreateRequest
 	plan.ID = types.StringValue(webhook.Id)
 
 	// LD does not accept tags on create — patch them in after.
-	tags, diags := stringSliceFromSet(ctx, plan.Tags)
+	tags, diags := stringSliceFromSet(ctx, plan.TagsAll)
 	resp.Diagnostics.Append(diags...)
 	patch := []ldapi.PatchOperation{
 		patchReplace("/tags", &tags),
@@ -191,6 +222,9 @@ func (r *WebhookResource) Read(ctx context.Context, req resource.ReadRequest, re
 }
 
 func (r *WebhookResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.rejectReadOnly("launchdarkly_webhook", "update", &resp.Diagnostics) {
+		return
+	}
 	var plan, state WebhookResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -208,16 +242,26 @@ func (r *WebhookResource) Update(ctx context.Context, req resource.UpdateRequest
 	secret := plan.Secret.ValueString()
 	name := plan.Name.ValueString()
 	on := plan.On.ValueBool()
-	tags, diags := stringSliceFromSet(ctx, plan.Tags)
+	tags, diags := stringSliceFromSet(ctx, plan.TagsAll)
 	resp.Diagnostics.Append(diags...)
 
 	patch := []ldapi.PatchOperation{
 		patchReplace("/url", &url),
-		patchReplace("/secret", &secret),
 		patchReplace("/on", &on),
 		patchReplace("/name", &name),
 		patchReplace("/tags", &tags),
 	}
+	switch {
+	case writeOnlyVersionChanged(plan.SecretWOVersion, state.SecretWOVersion):
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(SECRET_WO), &plan.SecretWO)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		secretWO := plan.SecretWO.ValueString()
+		patch = append(patch, patchReplace("/secret", &secretWO))
+	case !writeOnlyInUse(plan.SecretWOVersion):
+		patch = append(patch, patchReplace("/secret", &secret))
+	}
 	if !plan.Statements.Equal(state.Statements) {
 		if len(stmts) > 0 {
 			patch = append(patch, patchReplace("/statements", &stmts))
@@ -243,6 +287,9 @@ func (r *WebhookResource) Update(ctx context.Context, req resource.UpdateRequest
 }
 
 func (r *WebhookResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.rejectReadOnly("launchdarkly_webhook", "destroy", &resp.Diagnostics) {
+		return
+	}
 	var data WebhookResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -287,12 +334,21 @@ func (r *WebhookResource) readIntoModel(
 	data.On = types.BoolValue(webhook.On)
 	// Optional-only attrs: null-when-empty for plan-apply consistency.
 	data.Name = stringValueOrNullFromPointer(webhook.Name)
-	data.Secret = stringValueOrNullFromPointer(webhook.Secret)
+	// The secret is managed through secret_wo, which must never land in state.
+	if writeOnlyInUse(data.SecretWOVersion) {
+		data.Secret = types.StringNull()
+	} else {
+		data.Secret = stringValueOrNullFromPointer(webhook.Secret)
+	}
+	data.SecretWO = types.StringNull()
 
 	// Optional-only Set attr with plan-aware null-vs-empty handling.
-	tagsSet, d := setFromStringSlicePreservingPlan(ctx, webhook.Tags, data.Tags)
+	ownTags, tagsAll, d := r.client.splitDefaultTags(ctx, webhook.Tags, data.Tags)
+	diags.Append(d...)
+	tagsSet, d := setFromStringSlicePreservingPlan(ctx, ownTags, data.Tags)
 	diags.Append(d...)
 	data.Tags = tagsSet
+	data.TagsAll = tagsAll
 
 	stmts, d := frameworkPolicyStatementsValue(ctx, webhook.Statements)
 	diags.Append(d...)
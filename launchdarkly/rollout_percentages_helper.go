@@ -0,0 +1,254 @@
+package launchdarkly
+
+// LaunchDarkly weighs rollouts in thousandths of a percent, so the weights of
+// a rollout must be integers that add up to exactly 100000. Rollouts and
+// segment rule weights can instead be written as percentages, which are
+// converted to weights when planning and kept in state as configured.
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const rolloutWeightsTotal = 100000
+
+// rolloutPercentagesTolerance is how far from 100 percentages may add up to.
+// It allows for percentages computed in floating point, such as 100 / 3.
+var rolloutPercentagesTolerance = big.NewRat(1, 1000000000)
+
+// rolloutWeightsFromPercentages converts percentages to rollout weights that
+// add up to exactly 100000. Each percentage gets the whole number of weights
+// it covers, and the weights left over go to the percentages with the largest
+// remainders, the earliest first when remainders are equal, so the same
+// percentages always give the same weights.
+func rolloutWeightsFromPercentages(percentages []float64) ([]int64, error) {
+	if len(percentages) == 0 {
+		return nil, fmt.Errorf("at least one percentage is required")
+	}
+	sum := new(big.Rat)
+	units := make([]*big.Rat, len(percentages))
+	for i, p := range percentages {
+		if p < 0 || p > 100 {
+			return nil, fmt.Errorf("percentage %d is %s, but each percentage must be between 0 and 100", i, formatPercentage(p))
+		}
+		// The shortest decimal that gives p, so 33.333 is exactly 33.333.
+		r, _ := new(big.Rat).SetString(strconv.FormatFloat(p, 'g', -1, 64))
+		sum.Add(sum, r)
+		units[i] = r.Mul(r, big.NewRat(rolloutWeightsTotal/100, 1))
+	}
+	if diff := new(big.Rat).Sub(big.NewRat(100, 1), sum); new(big.Rat).Abs(diff).Cmp(rolloutPercentagesTolerance) > 0 {
+		total, _ := sum.Float64()
+		missing, _ := diff.Float64()
+		return nil, fmt.Errorf("the percentages add up to %s, but they must add up to exactly 100. Adjust one of them by %s, such as writing [33.333, 33.333, 33.334] rather than [33.333, 33.333, 33.333]", formatPercentage(total), formatPercentage(missing))
+	}
+	weights := make([]int64, len(units))
+	remainders := make([]*big.Rat, len(units))
+	left := int64(rolloutWeightsTotal)
+	for i, u := range units {
+		whole := new(big.Int).Quo(u.Num(), u.Denom())
+		weights[i] = whole.Int64()
+		remainders[i] = new(big.Rat).Sub(u, new(big.Rat).SetInt(whole))
+		left -= weights[i]
+	}
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return remainders[b].Cmp(remainders[a]) })
+	for i := 0; left > 0; i++ {
+		weights[order[i%len(order)]]++
+		left--
+	}
+	return weights, nil
+}
+
+// segmentRuleWeightFromPercentage converts the percentage of contexts a
+// segment rule includes to its weight, as the first of a two-way rollout.
+func segmentRuleWeightFromPercentage(p float64) (int64, error) {
+	weights, err := rolloutWeightsFromPercentages([]float64{p, 100 - p})
+	if err != nil {
+		return 0, err
+	}
+	return weights[0], nil
+}
+
+func formatPercentage(p float64) string {
+	return strconv.FormatFloat(p, 'f', -1, 64)
+}
+
+// rolloutPercentagesAttribute returns the rollout_percentages attribute, an
+// alternative to rollout_weights.
+func rolloutPercentagesAttribute() schema.ListAttribute {
+	return schema.ListAttribute{
+		Optional:    true,
+		ElementType: types.Float64Type,
+		Description: "List of percentage rollout weights, such as `[33.333, 33.333, 33.334]`, to use instead of `rollout_weights`. They must add up to 100, and are converted to `rollout_weights` when planning: each percentage gets the whole thousandths of a percent it covers, and those left over go to the percentages with the largest remainders, so the weights add up to exactly 100000.",
+		Validators: []validator.List{
+			listvalidator.SizeAtLeast(1),
+			listvalidator.ValueFloat64sAre(float64validator.Between(0, 100)),
+			listvalidator.ConflictsWith(siblings(VARIATION, VARIATION_VALUE, VARIATION_NAME, ROLLOUT_WEIGHTS, ROLLOUT)...),
+			rolloutPercentagesValidator{},
+		},
+	}
+}
+
+// segmentWeightPercentageAttribute returns the weight_percentage attribute
+// of segment rules, an alternative to weight.
+func segmentWeightPercentageAttribute() schema.Float64Attribute {
+	return schema.Float64Attribute{
+		Optional:    true,
+		Description: "The percentage of contexts the rule includes, such as `12.5`, to use instead of `weight`. It is converted to `weight` when planning, in thousandths of a percent.",
+		Validators: []validator.Float64{
+			float64validator.Between(0.001, 100),
+			float64validator.ConflictsWith(siblings(WEIGHT)...),
+		},
+	}
+}
+
+// rolloutPercentagesValidator checks that percentages convert to rollout
+// weights.
+type rolloutPercentagesValidator struct{}
+
+func (rolloutPercentagesValidator) Description(context.Context) string {
+	return "must add up to 100"
+}
+
+func (v rolloutPercentagesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (rolloutPercentagesValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	percentages, ok := knownPercentages(ctx, req.ConfigValue)
+	if !ok {
+		return
+	}
+	if _, err := rolloutWeightsFromPercentages(percentages); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid rollout percentages", fmt.Sprintf("%s: %s.", req.Path, err))
+	}
+}
+
+// knownPercentages returns the elements of list, or false when it or any of
+// its elements is null or unknown.
+func knownPercentages(ctx context.Context, list types.List) ([]float64, bool) {
+	if list.IsNull() || list.IsUnknown() {
+		return nil, false
+	}
+	var elements []types.Float64
+	if list.ElementsAs(ctx, &elements, false).HasError() {
+		return nil, false
+	}
+	percentages := make([]float64, 0, len(elements))
+	for _, e := range elements {
+		if e.IsNull() || e.IsUnknown() {
+			return nil, false
+		}
+		percentages = append(percentages, e.ValueFloat64())
+	}
+	return percentages, true
+}
+
+// rolloutWeightsOrPercentages returns the rollout weights that percentages
+// convert to, or weights when percentages is null. The weights are unknown
+// while any percentage is, or when the percentages do not convert, which
+// validation reports.
+func rolloutWeightsOrPercentages(ctx context.Context, weights, percentages types.List) types.List {
+	if percentages.IsNull() {
+		return weights
+	}
+	values, ok := knownPercentages(ctx, percentages)
+	if !ok {
+		return types.ListUnknown(types.Int64Type)
+	}
+	converted, err := rolloutWeightsFromPercentages(values)
+	if err != nil {
+		return types.ListUnknown(types.Int64Type)
+	}
+	elements := make([]attr.Value, 0, len(converted))
+	for _, w := range converted {
+		elements = append(elements, types.Int64Value(w))
+	}
+	return types.ListValueMust(types.Int64Type, elements)
+}
+
+// segmentRuleWeight returns the weight that percentage converts to, or weight
+// when percentage is null.
+func segmentRuleWeight(weight types.Int64, percentage types.Float64) types.Int64 {
+	if percentage.IsNull() {
+		return weight
+	}
+	if percentage.IsUnknown() {
+		return types.Int64Unknown()
+	}
+	converted, err := segmentRuleWeightFromPercentage(percentage.ValueFloat64())
+	if err != nil {
+		return types.Int64Unknown()
+	}
+	return types.Int64Value(converted)
+}
+
+// planSegmentRuleWeights plans the weight of each segment rule from the
+// configuration: the configured weight, the weight its weight_percentage
+// converts to, or none.
+func planSegmentRuleWeights(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var planned, configured types.List
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root(RULES), &planned)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(RULES), &configured)...)
+	if resp.Diagnostics.HasError() || planned.IsNull() || planned.IsUnknown() || configured.IsUnknown() || len(planned.Elements()) != len(configured.Elements()) {
+		return
+	}
+	elements := slices.Clone(planned.Elements())
+	for i, element := range elements {
+		config := configured.Elements()[i].(types.Object)
+		if element.IsUnknown() || config.IsUnknown() {
+			continue
+		}
+		attrs := element.(types.Object).Attributes()
+		configAttrs := config.Attributes()
+		attrs[WEIGHT] = segmentRuleWeight(configAttrs[WEIGHT].(types.Int64), configAttrs[WEIGHT_PERCENTAGE].(types.Float64))
+		obj, d := types.ObjectValue(segmentResourceRuleAttrTypes, attrs)
+		resp.Diagnostics.Append(d...)
+		elements[i] = obj
+	}
+	list, d := types.ListValue(planned.ElementType(ctx), elements)
+	resp.Diagnostics.Append(d...)
+	if !resp.Diagnostics.HasError() && !list.Equal(planned) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(RULES), list)...)
+	}
+}
+
+// segmentRulesWithPriorPercentages returns rules, as read from LaunchDarkly,
+// with the weight_percentage of the prior rules they match, which
+// LaunchDarkly does not store.
+func segmentRulesWithPriorPercentages(ctx context.Context, rules, prior types.List, diags *diag.Diagnostics) types.List {
+	if rules.IsNull() || prior.IsNull() || prior.IsUnknown() {
+		return rules
+	}
+	elements := slices.Clone(rules.Elements())
+	priorElements := prior.Elements()
+	for i, j := range matchRules(elements, priorElements) {
+		if j < 0 {
+			continue
+		}
+		attrs := elements[i].(types.Object).Attributes()
+		attrs[WEIGHT_PERCENTAGE] = priorElements[j].(types.Object).Attributes()[WEIGHT_PERCENTAGE]
+		obj, d := types.ObjectValue(segmentResourceRuleAttrTypes, attrs)
+		diags.Append(d...)
+		elements[i] = obj
+	}
+	list, d := types.ListValue(rules.ElementType(ctx), elements)
+	diags.Append(d...)
+	return list
+}
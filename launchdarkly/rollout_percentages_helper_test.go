@@ -0,0 +1,64 @@
+package launchdarkly
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRolloutWeightsFromPercentages(t *testing.T) {
+	cases := []struct {
+		name        string
+		percentages []float64
+		weights     []int64
+	}{
+		{"exact", []float64{33.333, 33.333, 33.334}, []int64{33333, 33333, 33334}},
+		{"whole percentages", []float64{10, 90}, []int64{10000, 90000}},
+		{"thirds", []float64{100.0 / 3, 100.0 / 3, 100.0 / 3}, []int64{33334, 33333, 33333}},
+		{"largest remainder", []float64{12.3456, 12.3454, 75.309}, []int64{12346, 12345, 75309}},
+		{"equal remainders go to the earliest", []float64{0.0005, 0.0005, 99.999}, []int64{1, 0, 99999}},
+		{"zero", []float64{0, 100}, []int64{0, 100000}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			weights, err := rolloutWeightsFromPercentages(c.percentages)
+			require.NoError(t, err)
+			assert.Equal(t, c.weights, weights)
+		})
+	}
+
+	_, err := rolloutWeightsFromPercentages([]float64{33.333, 33.333, 33.333})
+	assert.ErrorContains(t, err, "add up to 99.999, but they must add up to exactly 100. Adjust one of them by 0.001")
+	_, err = rolloutWeightsFromPercentages([]float64{-1, 101})
+	assert.ErrorContains(t, err, "between 0 and 100")
+	_, err = rolloutWeightsFromPercentages(nil)
+	assert.Error(t, err)
+}
+
+func TestSegmentRuleWeightFromPercentage(t *testing.T) {
+	weight, err := segmentRuleWeightFromPercentage(12.5)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12500), weight)
+	weight, err = segmentRuleWeightFromPercentage(33.3335)
+	require.NoError(t, err)
+	assert.Equal(t, int64(33334), weight)
+}
+
+func TestRolloutWeightsOrPercentages(t *testing.T) {
+	ctx := context.Background()
+	weights := types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(50000), types.Int64Value(50000)})
+	assert.Equal(t, weights, rolloutWeightsOrPercentages(ctx, weights, types.ListNull(types.Float64Type)))
+
+	percentages := types.ListValueMust(types.Float64Type, []attr.Value{types.Float64Value(25), types.Float64Value(75)})
+	assert.Equal(t,
+		types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(25000), types.Int64Value(75000)}),
+		rolloutWeightsOrPercentages(ctx, types.ListNull(types.Int64Type), percentages),
+	)
+
+	unknown := types.ListValueMust(types.Float64Type, []attr.Value{types.Float64Unknown(), types.Float64Value(75)})
+	assert.True(t, rolloutWeightsOrPercentages(ctx, types.ListNull(types.Int64Type), unknown).IsUnknown())
+}
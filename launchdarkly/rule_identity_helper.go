@@ -0,0 +1,197 @@
+package launchdarkly
+
+import (
+	"context"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Flag environment and segment rules carry a ref that identifies the rule in
+// LaunchDarkly. Rules are matched by ref where both sides know it, and
+// otherwise by content, so a rule that moves or changes keeps its ID, and with
+// it experiment results and rule-level evaluation history.
+
+// ruleRef returns the ref of a rule object when it is known and not empty.
+func ruleRef(rule attr.Value) (string, bool) {
+	ref, ok := rule.(types.Object).Attributes()[REF].(types.String)
+	if !ok || ref.IsNull() || ref.IsUnknown() || ref.ValueString() == "" {
+		return "", false
+	}
+	return ref.ValueString(), true
+}
+
+// sameRuleContent reports whether planned matches rule apart from their refs,
+// variation references and percentages, which LaunchDarkly does not store. Attributes
+// still unknown in planned match anything.
+func sameRuleContent(planned, rule attr.Value) bool {
+	a, b := planned.(types.Object).Attributes(), rule.(types.Object).Attributes()
+	for name, value := range a {
+		if name == REF || name == WEIGHT_PERCENTAGE || slices.Contains(variationRefAttrNames, name) || value.IsUnknown() {
+			continue
+		}
+		if !value.Equal(b[name]) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchRules returns, for each rule object in from, the index of the rule in
+// to that it corresponds to, or -1. Rules with the same ref match first, then
+// rules with the same content, then rules with the same clauses. Rules whose
+// known refs differ never match, and each rule in to is matched at most once.
+func matchRules(from, to []attr.Value) []int {
+	matches := make([]int, len(from))
+	for i := range matches {
+		matches[i] = -1
+	}
+	taken := make([]bool, len(to))
+	for _, same := range []func(a, b attr.Value) bool{
+		func(a, b attr.Value) bool {
+			refA, okA := ruleRef(a)
+			refB, okB := ruleRef(b)
+			return okA && okB && refA == refB
+		},
+		sameRuleContent,
+		func(a, b attr.Value) bool {
+			return a.(types.Object).Attributes()[CLAUSES].Equal(b.(types.Object).Attributes()[CLAUSES])
+		},
+	} {
+		for i, rule := range from {
+			if matches[i] >= 0 {
+				continue
+			}
+			refA, okA := ruleRef(rule)
+			for j, candidate := range to {
+				if taken[j] {
+					continue
+				}
+				if refB, okB := ruleRef(candidate); okA && okB && refA != refB {
+					continue
+				}
+				if same(rule, candidate) {
+					matches[i], taken[j] = j, true
+					break
+				}
+			}
+		}
+	}
+	return matches
+}
+
+// rulesWithPriorRefs fills in the unknown refs of planned rules with the refs
+// of the prior rules they match.
+func rulesWithPriorRefs(ctx context.Context, planned, prior types.List) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	elements := slices.Clone(planned.Elements())
+	priorElements := prior.Elements()
+	changed := false
+	for i, j := range matchRules(elements, priorElements) {
+		if j < 0 {
+			continue
+		}
+		attrs := elements[i].(types.Object).Attributes()
+		ref, known := ruleRef(priorElements[j])
+		if !attrs[REF].IsUnknown() || !known {
+			continue
+		}
+		attrs[REF] = types.StringValue(ref)
+		obj, d := types.ObjectValue(elements[i].(types.Object).AttributeTypes(ctx), attrs)
+		diags.Append(d...)
+		elements[i] = obj
+		changed = true
+	}
+	if !changed || diags.HasError() {
+		return planned, diags
+	}
+	list, d := types.ListValue(planned.ElementType(ctx), elements)
+	diags.Append(d...)
+	return list, diags
+}
+
+// ruleRefs returns the known refs of the rule objects of list.
+func ruleRefs(list types.List) map[string]bool {
+	refs := make(map[string]bool)
+	for _, rule := range list.Elements() {
+		if ref, ok := ruleRef(rule); ok {
+			refs[ref] = true
+		}
+	}
+	return refs
+}
+
+// unknownRuleRefs returns the index of each rule of planned whose ref is
+// known but is not the ref of a rule of prior.
+func unknownRuleRefs(planned, prior types.List) []int {
+	refs := ruleRefs(prior)
+	var unknown []int
+	for i, rule := range planned.Elements() {
+		if ref, ok := ruleRef(rule); ok && !refs[ref] {
+			unknown = append(unknown, i)
+		}
+	}
+	return unknown
+}
+
+// planRuleRefs sets the refs of planned rules that the configuration leaves
+// unset to those of the prior rules they match, so the plan shows which
+// existing rule each planned rule is and apply keeps their IDs.
+func planRuleRefs(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	var planned, prior types.List
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root(RULES), &planned)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(RULES), &prior)...)
+	if resp.Diagnostics.HasError() || planned.IsNull() || planned.IsUnknown() || prior.IsNull() {
+		return
+	}
+	filled, diags := rulesWithPriorRefs(ctx, planned, prior)
+	resp.Diagnostics.Append(diags...)
+	if !resp.Diagnostics.HasError() && !filled.Equal(planned) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(RULES), filled)...)
+	}
+}
+
+// reorderRulesInstruction returns the reorderRules instruction that moves
+// existing rules into their planned order, or nil when they already are.
+// liveIDs are the IDs of the rules that remain, in their current order, and
+// ruleIDs the ID of each planned rule, or "" for rules to add. Rules that are
+// not planned keep their positions.
+func reorderRulesInstruction(liveIDs, ruleIDs []string) map[string]interface{} {
+	planned := make(map[string]bool, len(ruleIDs))
+	order := make([]string, 0, len(ruleIDs))
+	for _, id := range ruleIDs {
+		if id != "" {
+			planned[id] = true
+			order = append(order, id)
+		}
+	}
+	reordered := make([]string, 0, len(liveIDs))
+	for _, id := range liveIDs {
+		if planned[id] && len(order) > 0 {
+			id, order = order[0], order[1:]
+		}
+		reordered = append(reordered, id)
+	}
+	if slices.Equal(liveIDs, reordered) {
+		return nil
+	}
+	return map[string]interface{}{"kind": "reorderRules", "ruleIds": reordered}
+}
+
+// nextRuleID returns the ID of the first existing rule planned after
+// position i, which a rule added at i goes before, or "" to add it last.
+func nextRuleID(ruleIDs []string, i int) string {
+	for _, id := range ruleIDs[i+1:] {
+		if id != "" {
+			return id
+		}
+	}
+	return ""
+}
@@ -0,0 +1,81 @@
+package launchdarkly
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchRules(t *testing.T) {
+	attrTypes := map[string]attr.Type{
+		REF:     types.StringType,
+		CLAUSES: types.StringType,
+		WEIGHT:  types.Int64Type,
+	}
+	rule := func(ref types.String, clauses string, weight int64) attr.Value {
+		return types.ObjectValueMust(attrTypes, map[string]attr.Value{
+			REF:     ref,
+			CLAUSES: types.StringValue(clauses),
+			WEIGHT:  types.Int64Value(weight),
+		})
+	}
+	prior := []attr.Value{
+		rule(types.StringValue("a"), "email", 1),
+		rule(types.StringValue("b"), "country", 2),
+		rule(types.StringValue("c"), "plan", 3),
+	}
+
+	assert.Equal(t, []int{1, 0, -1, 2}, matchRules([]attr.Value{
+		rule(types.StringValue("b"), "name", 5), // same ref, new content
+		rule(types.StringUnknown(), "email", 1), // same content
+		rule(types.StringValue("d"), "country", 2),
+		rule(types.StringUnknown(), "plan", 4), // same clauses
+	}, prior))
+
+	t.Run("rules with different refs never match", func(t *testing.T) {
+		assert.Equal(t, []int{-1}, matchRules([]attr.Value{rule(types.StringValue("z"), "email", 1)}, prior))
+	})
+
+	t.Run("unknown refs are filled from the prior rules", func(t *testing.T) {
+		ctx := context.Background()
+		objectType := types.ObjectType{AttrTypes: attrTypes}
+		planned := types.ListValueMust(objectType, []attr.Value{
+			rule(types.StringUnknown(), "name", 9),
+			rule(types.StringUnknown(), "country", 2),
+		})
+		filled, d := rulesWithPriorRefs(ctx, planned, types.ListValueMust(objectType, prior))
+		require.False(t, d.HasError(), "unexpected diagnostics: %v", d)
+		refs := make([]attr.Value, 0, 2)
+		for _, r := range filled.Elements() {
+			refs = append(refs, r.(types.Object).Attributes()[REF])
+		}
+		assert.Equal(t, []attr.Value{types.StringUnknown(), types.StringValue("b")}, refs)
+	})
+
+	t.Run("refs that no prior rule has are reported", func(t *testing.T) {
+		objectType := types.ObjectType{AttrTypes: attrTypes}
+		planned := types.ListValueMust(objectType, []attr.Value{
+			rule(types.StringValue("b"), "country", 2),
+			rule(types.StringValue("made-up"), "name", 9),
+			rule(types.StringUnknown(), "plan", 3),
+			rule(types.StringNull(), "email", 1),
+		})
+		assert.Equal(t, []int{1}, unknownRuleRefs(planned, types.ListValueMust(objectType, prior)))
+		assert.Equal(t, []int{0, 1}, unknownRuleRefs(planned, types.ListNull(objectType)), "a new segment has no rules to refer to")
+	})
+}
+
+func TestReorderRulesInstruction(t *testing.T) {
+	assert.Nil(t, reorderRulesInstruction([]string{"a", "b"}, []string{"a", "", "b"}))
+	assert.Equal(t,
+		map[string]interface{}{"kind": "reorderRules", "ruleIds": []string{"b", "manual", "a"}},
+		reorderRulesInstruction([]string{"a", "manual", "b"}, []string{"b", "a"}),
+		"rules that are not planned keep their positions",
+	)
+	assert.Equal(t, "b", nextRuleID([]string{"", "", "b"}, 0))
+	assert.Equal(t, "", nextRuleID([]string{"a", ""}, 1))
+}
@@ -0,0 +1,457 @@
+package launchdarkly
+
+// Scheduled changes are semantic-patch instructions LaunchDarkly applies to a
+// flag environment at a set time. Each instruction is an object whose kind
+// says which of its other attributes apply. Variations are referred to by
+// index and sent as the variation IDs instructions use, and rules by ref, as
+// in launchdarkly_feature_flag_environment.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ldapi "github.com/launchdarkly/api-client-go/v24"
+)
+
+// scheduledInstructionKind lists the attributes an instruction kind takes.
+// Kinds with variationOrRollout take exactly one of variation and
+// rollout_weights.
+type scheduledInstructionKind struct {
+	required           []string
+	optional           []string
+	variationOrRollout bool
+}
+
+var scheduledInstructionKinds = map[string]scheduledInstructionKind{
+	"turnFlagOn":                          {},
+	"turnFlagOff":                         {},
+	"updateOffVariation":                  {required: []string{VARIATION}},
+	"updateFallthroughVariationOrRollout": {optional: []string{BUCKET_BY, CONTEXT_KIND}, variationOrRollout: true},
+	"addRule":                             {required: []string{CLAUSES}, optional: []string{DESCRIPTION, REF, BUCKET_BY, CONTEXT_KIND}, variationOrRollout: true},
+	"removeRule":                          {required: []string{REF}},
+	"addTargets":                          {required: []string{VARIATION, VALUES}, optional: []string{CONTEXT_KIND}},
+	"removeTargets":                       {required: []string{VARIATION, VALUES}, optional: []string{CONTEXT_KIND}},
+	"addPrerequisite":                     {required: []string{FLAG_KEY, VARIATION}},
+	"updatePrerequisite":                  {required: []string{FLAG_KEY, VARIATION}},
+	"removePrerequisite":                  {required: []string{FLAG_KEY}},
+}
+
+var scheduledInstructionObjectType = schema.NestedAttributeObject{Attributes: scheduledInstructionSchemaAttributes()}.Type().(types.ObjectType)
+
+func scheduledInstructionsAttribute() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Required:     true,
+		Description:  fmt.Sprintf("The semantic-patch instructions to apply, in order. Each instruction's `kind` is one of %s, and decides which of its other attributes apply.", oxfordCommaJoin(slices.Sorted(maps.Keys(scheduledInstructionKinds)))),
+		Validators:   []validator.List{listvalidator.SizeAtLeast(1)},
+		NestedObject: schema.NestedAttributeObject{Attributes: scheduledInstructionSchemaAttributes()},
+	}
+}
+
+func scheduledInstructionSchemaAttributes() map[string]schema.Attribute {
+	clauses := frameworkClausesResourceAttribute()
+	clauses.Required = false
+	clauses.Optional = true
+	clauses.Description = "For `addRule`, the clauses of the rule."
+	return map[string]schema.Attribute{
+		KIND: schema.StringAttribute{
+			Required:    true,
+			Description: "The kind of instruction.",
+			Validators:  []validator.String{oneOfValidator{allowed: slices.Sorted(maps.Keys(scheduledInstructionKinds))}},
+		},
+		VARIATION: schema.Int64Attribute{
+			Optional:    true,
+			Validators:  []validator.Int64{int64validator.AtLeast(0)},
+			Description: "The index of the variation to serve, for `updateOffVariation`, `updateFallthroughVariationOrRollout`, `addRule`, `addTargets` and `removeTargets`, or of the prerequisite flag's variation, for `addPrerequisite` and `updatePrerequisite`.",
+		},
+		ROLLOUT_WEIGHTS: schema.ListAttribute{
+			Optional:    true,
+			ElementType: types.Int64Type,
+			Validators: []validator.List{
+				listvalidator.ValueInt64sAre(int64validator.Between(0, 100000)),
+			},
+			Description: "For `updateFallthroughVariationOrRollout` and `addRule`, percentage rollout weights (in thousandths of a percent) to use instead of `variation`, one for each variation of the flag. They must add up to 100000.",
+		},
+		BUCKET_BY: schema.StringAttribute{
+			Optional:    true,
+			Description: "Group the percentage rollout by a custom attribute. Only valid with `rollout_weights`.",
+		},
+		CONTEXT_KIND: schema.StringAttribute{
+			Optional:    true,
+			Description: "The context kind of the percentage rollout, for `updateFallthroughVariationOrRollout` and `addRule`, or of the targets, for `addTargets` and `removeTargets`. Defaults to `user`.",
+		},
+		CLAUSES: clauses,
+		DESCRIPTION: schema.StringAttribute{
+			Optional:    true,
+			Description: "For `addRule`, a human-readable description of the rule.",
+		},
+		REF: schema.StringAttribute{
+			Optional:    true,
+			Description: "The `ref` of the rule to add, for `addRule`, or to remove, for `removeRule`.",
+		},
+		VALUES: schema.ListAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Description: "For `addTargets` and `removeTargets`, the context keys to target.",
+		},
+		FLAG_KEY: schema.StringAttribute{
+			Optional:    true,
+			Validators:  []validator.String{keyValidator()},
+			Description: "For `addPrerequisite`, `updatePrerequisite` and `removePrerequisite`, the key of the prerequisite flag.",
+		},
+	}
+}
+
+type scheduledInstructionModel struct {
+	Kind           types.String `tfsdk:"kind"`
+	Variation      types.Int64  `tfsdk:"variation"`
+	RolloutWeights types.List   `tfsdk:"rollout_weights"`
+	BucketBy       types.String `tfsdk:"bucket_by"`
+	ContextKind    types.String `tfsdk:"context_kind"`
+	Clauses        types.List   `tfsdk:"clauses"`
+	Description    types.String `tfsdk:"description"`
+	Ref            types.String `tfsdk:"ref"`
+	Values         types.List   `tfsdk:"values"`
+	FlagKey        types.String `tfsdk:"flag_key"`
+}
+
+// validateScheduledInstructions checks that each instruction sets the
+// attributes its kind takes and no others.
+func validateScheduledInstructions(p path.Path, list types.List, diags *diag.Diagnostics) {
+	if list.IsNull() || list.IsUnknown() {
+		return
+	}
+	for i, element := range list.Elements() {
+		obj, ok := element.(types.Object)
+		if !ok || obj.IsNull() || obj.IsUnknown() {
+			continue
+		}
+		attrs := obj.Attributes()
+		kindValue, _ := attrs[KIND].(types.String)
+		if kindValue.IsNull() || kindValue.IsUnknown() {
+			continue
+		}
+		kind, ok := scheduledInstructionKinds[kindValue.ValueString()]
+		if !ok {
+			continue
+		}
+		ip := p.AtListIndex(i)
+		allowed := append(slices.Clone(kind.required), kind.optional...)
+		if kind.variationOrRollout {
+			allowed = append(allowed, VARIATION, ROLLOUT_WEIGHTS)
+		}
+		for _, name := range slices.Sorted(maps.Keys(attrs)) {
+			if name != KIND && !attrs[name].IsNull() && !slices.Contains(allowed, name) {
+				diags.AddAttributeError(ip.AtName(name), "Invalid instruction", fmt.Sprintf("%s does not apply to %s instructions.", name, kindValue.ValueString()))
+			}
+		}
+		for _, name := range kind.required {
+			if attrs[name].IsNull() {
+				diags.AddAttributeError(ip.AtName(name), "Invalid instruction", fmt.Sprintf("%s instructions require %s.", kindValue.ValueString(), name))
+			}
+		}
+		if !kind.variationOrRollout {
+			continue
+		}
+		if attrs[VARIATION].IsNull() == attrs[ROLLOUT_WEIGHTS].IsNull() {
+			diags.AddAttributeError(ip, "Invalid instruction", fmt.Sprintf("%s instructions require exactly one of %s and %s.", kindValue.ValueString(), VARIATION, ROLLOUT_WEIGHTS))
+		}
+		if attrs[ROLLOUT_WEIGHTS].IsNull() && !attrs[BUCKET_BY].IsNull() {
+			diags.AddAttributeError(ip.AtName(BUCKET_BY), "Invalid instruction", fmt.Sprintf("%s is only valid with %s.", BUCKET_BY, ROLLOUT_WEIGHTS))
+		}
+	}
+}
+
+// scheduledInstructionPayloads returns the instructions in list as sent to
+// LaunchDarkly. liveRules are the environment's rules, which supply the IDs
+// of the rules removeRule instructions refer to.
+func scheduledInstructionPayloads(ctx context.Context, flagKey string, list types.List, liveRules []ldapi.Rule, ids *flagVariationIDs) ([]map[string]interface{}, diag.Diagnostics) {
+	var models []scheduledInstructionModel
+	diags := list.ElementsAs(ctx, &models, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+	payloads := make([]map[string]interface{}, 0, len(models))
+	for _, m := range models {
+		in, d := scheduledInstructionPayload(ctx, flagKey, m, liveRules, ids)
+		diags.Append(d...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		payloads = append(payloads, in)
+	}
+	return payloads, diags
+}
+
+func scheduledInstructionPayload(ctx context.Context, flagKey string, m scheduledInstructionModel, liveRules []ldapi.Rule, ids *flagVariationIDs) (map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	kind := m.Kind.ValueString()
+	in := map[string]interface{}{"kind": kind}
+	addErr := func(err error) (map[string]interface{}, diag.Diagnostics) {
+		diags.AddError(fmt.Sprintf("invalid %s instruction", kind), err.Error())
+		return nil, diags
+	}
+	variation := int32(m.Variation.ValueInt64())
+	switch kind {
+	case "updateOffVariation":
+		id, err := ids.id(flagKey, variation)
+		if err != nil {
+			return addErr(err)
+		}
+		in["variationId"] = id
+	case "updateFallthroughVariationOrRollout", "addRule":
+		var rollout *ldapi.Rollout
+		if !m.RolloutWeights.IsNull() {
+			var weights []int64
+			diags.Append(m.RolloutWeights.ElementsAs(ctx, &weights, false)...)
+			if diags.HasError() {
+				return nil, diags
+			}
+			rollout = &ldapi.Rollout{Variations: make([]ldapi.WeightedVariation, 0, len(weights))}
+			for i, w := range weights {
+				rollout.Variations = append(rollout.Variations, ldapi.WeightedVariation{Variation: int32(i), Weight: int32(w)})
+			}
+			if bucketBy := m.BucketBy.ValueString(); bucketBy != "" {
+				rollout.BucketBy = &bucketBy
+			}
+			if ck := m.ContextKind.ValueString(); ck != "" {
+				rollout.ContextKind = &ck
+			}
+		}
+		if err := ffeVariationOrRolloutInstruction(in, &variation, rollout, flagKey, ids); err != nil {
+			return addErr(err)
+		}
+		if kind == "updateFallthroughVariationOrRollout" {
+			break
+		}
+		clauses, d := frameworkClausesFromList(ctx, m.Clauses)
+		diags.Append(d...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		in["clauses"] = clauses
+		if description := m.Description.ValueString(); description != "" {
+			in["description"] = description
+		}
+		if ref := m.Ref.ValueString(); ref != "" {
+			in["ref"] = ref
+		}
+	case "removeRule":
+		k := findFlagRule(liveRules, m.Ref.ValueString())
+		if k < 0 {
+			return addErr(fmt.Errorf("flag %q has no rule with ref %q in this environment", flagKey, m.Ref.ValueString()))
+		}
+		in["ruleId"] = liveRules[k].GetId()
+	case "addTargets", "removeTargets":
+		id, err := ids.id(flagKey, variation)
+		if err != nil {
+			return addErr(err)
+		}
+		values, d := stringSliceFromList(ctx, m.Values)
+		diags.Append(d...)
+		contextKind := m.ContextKind.ValueString()
+		if contextKind == "" {
+			contextKind = "user"
+		}
+		in["variationId"] = id
+		in["values"] = values
+		in["contextKind"] = contextKind
+	case "addPrerequisite", "updatePrerequisite":
+		id, err := ids.id(m.FlagKey.ValueString(), variation)
+		if err != nil {
+			return addErr(err)
+		}
+		in["key"] = m.FlagKey.ValueString()
+		in["variationId"] = id
+	case "removePrerequisite":
+		in["key"] = m.FlagKey.ValueString()
+	}
+	return in, diags
+}
+
+// scheduledInstructionsFromAPI returns instructions as read from
+// LaunchDarkly in the form of the instructions attribute, referring to
+// variations by index and to rules by ref where they can be found.
+func scheduledInstructionsFromAPI(ctx context.Context, flagKey string, instructions []map[string]interface{}, liveRules []ldapi.Rule, ids *flagVariationIDs) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	elements := make([]attr.Value, 0, len(instructions))
+	for _, raw := range instructions {
+		in, err := normalizedJSON(raw)
+		if err != nil {
+			diags.AddError("failed to read scheduled change instruction", err.Error())
+			return types.ListNull(scheduledInstructionObjectType), diags
+		}
+		m := in.(map[string]interface{})
+		str := func(key string) types.String {
+			if s, ok := m[key].(string); ok {
+				return types.StringValue(s)
+			}
+			return types.StringNull()
+		}
+		attrs := map[string]attr.Value{
+			KIND:            str("kind"),
+			VARIATION:       types.Int64Null(),
+			ROLLOUT_WEIGHTS: types.ListNull(types.Int64Type),
+			BUCKET_BY:       str("rolloutBucketBy"),
+			CONTEXT_KIND:    str("rolloutContextKind"),
+			CLAUSES:         types.ListNull(types.ObjectType{AttrTypes: frameworkClauseAttrTypes}),
+			DESCRIPTION:     str("description"),
+			REF:             str("ref"),
+			VALUES:          types.ListNull(types.StringType),
+			FLAG_KEY:        str("key"),
+		}
+		variationFlag := flagKey
+		if key, ok := m["key"].(string); ok {
+			variationFlag = key
+		}
+		if id, ok := m["variationId"].(string); ok {
+			if i, err := ids.index(variationFlag, id); err == nil && i >= 0 {
+				attrs[VARIATION] = types.Int64Value(int64(i))
+			}
+		}
+		if weights, ok := m["rolloutWeights"].(map[string]interface{}); ok {
+			variationIDs, err := ids.of(flagKey)
+			if err != nil {
+				diags.AddError("failed to read scheduled change instruction", err.Error())
+				return types.ListNull(scheduledInstructionObjectType), diags
+			}
+			values := make([]attr.Value, len(variationIDs))
+			for i, id := range variationIDs {
+				w, _ := weights[id].(float64)
+				values[i] = types.Int64Value(int64(w))
+			}
+			attrs[ROLLOUT_WEIGHTS] = types.ListValueMust(types.Int64Type, values)
+		}
+		if ck, ok := m["contextKind"].(string); ok {
+			attrs[CONTEXT_KIND] = types.StringValue(ck)
+		}
+		if ruleID, ok := m["ruleId"].(string); ok {
+			attrs[REF] = types.StringValue(ruleID)
+			if k := slices.IndexFunc(liveRules, func(r ldapi.Rule) bool { return r.GetId() == ruleID }); k >= 0 {
+				attrs[REF] = types.StringValue(ffeRuleRef(liveRules[k]))
+			}
+		}
+		if rawClauses, ok := m["clauses"]; ok {
+			var clauses []ldapi.Clause
+			if b, err := json.Marshal(rawClauses); err == nil && json.Unmarshal(b, &clauses) == nil {
+				list, d := frameworkClausesValue(ctx, clauses)
+				diags.Append(d...)
+				attrs[CLAUSES] = list
+			}
+		}
+		if rawValues, ok := m["values"].([]interface{}); ok {
+			list, d := listFromStringSlice(ctx, interfaceSliceToStringSlice(rawValues))
+			diags.Append(d...)
+			attrs[VALUES] = list
+		}
+		obj, d := types.ObjectValue(scheduledInstructionObjectType.AttrTypes, attrs)
+		diags.Append(d...)
+		elements = append(elements, obj)
+	}
+	if diags.HasError() {
+		return types.ListNull(scheduledInstructionObjectType), diags
+	}
+	list, d := types.ListValue(scheduledInstructionObjectType, elements)
+	diags.Append(d...)
+	return list, diags
+}
+
+// scheduledInstructionsMatch reports whether LaunchDarkly holds the
+// instructions sent. LaunchDarkly may add fields, such as clause IDs, and
+// leave out empty ones, so each sent instruction need only be contained in
+// the one read back.
+func scheduledInstructionsMatch(sent, live []map[string]interface{}) bool {
+	if len(sent) != len(live) {
+		return false
+	}
+	for i := range sent {
+		a, err := normalizedJSON(sent[i])
+		if err != nil {
+			return false
+		}
+		b, err := normalizedJSON(live[i])
+		if err != nil || !jsonContains(b, a) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizedJSON returns v as decoded from its JSON encoding, so values of
+// different Go types that encode alike compare equal.
+func normalizedJSON(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	err = json.Unmarshal(b, &out)
+	return out, err
+}
+
+// jsonContains reports whether the decoded JSON value got contains want:
+// every field of want is in got, apart from empty ones, with a value that
+// contains want's.
+func jsonContains(got, want interface{}) bool {
+	switch want := want.(type) {
+	case map[string]interface{}:
+		gotMap, ok := got.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, w := range want {
+			g, ok := gotMap[k]
+			if !ok {
+				if jsonEmpty(w) {
+					continue
+				}
+				return false
+			}
+			if !jsonContains(g, w) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		gotList, ok := got.([]interface{})
+		if !ok || len(gotList) != len(want) {
+			return false
+		}
+		for i := range want {
+			if !jsonContains(gotList[i], want[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(got, want)
+}
+
+func jsonEmpty(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case string:
+		return v == ""
+	case float64:
+		return v == 0
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
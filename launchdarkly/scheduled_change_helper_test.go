@@ -0,0 +1,97 @@
+package launchdarkly
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	ldapi "github.com/launchdarkly/api-client-go/v24"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testScheduledInstruction(t *testing.T, attrs map[string]attr.Value) attr.Value {
+	ctx := context.Background()
+	values := make(map[string]attr.Value)
+	for name, attrType := range scheduledInstructionObjectType.AttrTypes {
+		null, err := attrType.ValueFromTerraform(ctx, tftypes.NewValue(attrType.TerraformType(ctx), nil))
+		require.NoError(t, err)
+		values[name] = null
+	}
+	for name, v := range attrs {
+		values[name] = v
+	}
+	return types.ObjectValueMust(scheduledInstructionObjectType.AttrTypes, values)
+}
+
+func TestValidateScheduledInstructions(t *testing.T) {
+	weights := types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(10000), types.Int64Value(90000)})
+	cases := []struct {
+		name  string
+		attrs map[string]attr.Value
+		valid bool
+	}{
+		{"turn on", map[string]attr.Value{KIND: types.StringValue("turnFlagOn")}, true},
+		{"turn on with variation", map[string]attr.Value{KIND: types.StringValue("turnFlagOn"), VARIATION: types.Int64Value(0)}, false},
+		{"fallthrough variation", map[string]attr.Value{KIND: types.StringValue("updateFallthroughVariationOrRollout"), VARIATION: types.Int64Value(1)}, true},
+		{"fallthrough rollout", map[string]attr.Value{KIND: types.StringValue("updateFallthroughVariationOrRollout"), ROLLOUT_WEIGHTS: weights, BUCKET_BY: types.StringValue("email")}, true},
+		{"fallthrough both", map[string]attr.Value{KIND: types.StringValue("updateFallthroughVariationOrRollout"), VARIATION: types.Int64Value(1), ROLLOUT_WEIGHTS: weights}, false},
+		{"fallthrough neither", map[string]attr.Value{KIND: types.StringValue("updateFallthroughVariationOrRollout")}, false},
+		{"bucket by without rollout", map[string]attr.Value{KIND: types.StringValue("updateFallthroughVariationOrRollout"), VARIATION: types.Int64Value(1), BUCKET_BY: types.StringValue("email")}, false},
+		{"remove rule without ref", map[string]attr.Value{KIND: types.StringValue("removeRule")}, false},
+		{"prerequisite", map[string]attr.Value{KIND: types.StringValue("addPrerequisite"), FLAG_KEY: types.StringValue("other"), VARIATION: types.Int64Value(0)}, true},
+		{"unknown kind is left to the kind validator", map[string]attr.Value{KIND: types.StringValue("deleteFlag"), VARIATION: types.Int64Value(0)}, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			list := types.ListValueMust(scheduledInstructionObjectType, []attr.Value{testScheduledInstruction(t, c.attrs)})
+			var diags diag.Diagnostics
+			validateScheduledInstructions(path.Root(INSTRUCTIONS), list, &diags)
+			assert.Equal(t, c.valid, !diags.HasError(), "%v", diags)
+		})
+	}
+}
+
+func TestScheduledInstructionsRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	ids := newFlagVariationIDs(nil, "p")
+	ids.byFlag["f"] = []string{"var-true", "var-false"}
+	ids.byFlag["other"] = []string{"other-true", "other-false"}
+	rules := []ldapi.Rule{{Id: ldapi.PtrString("r1"), Ref: ldapi.PtrString("employees")}}
+
+	// As read back from LaunchDarkly, which adds clause IDs.
+	live := []map[string]interface{}{
+		{"kind": "turnFlagOn"},
+		{"kind": "updateFallthroughVariationOrRollout", "rolloutWeights": map[string]interface{}{"var-true": 10000, "var-false": 90000}, "rolloutBucketBy": "email"},
+		{"kind": "addTargets", "variationId": "var-false", "contextKind": "user", "values": []interface{}{"a", "b"}},
+		{"kind": "addRule", "variationId": "var-true", "ref": "beta", "clauses": []interface{}{
+			map[string]interface{}{"_id": "c1", "attribute": "beta", "op": "in", "values": []interface{}{"true"}, "contextKind": "user", "negate": false},
+		}},
+		{"kind": "removeRule", "ruleId": "r1"},
+		{"kind": "updatePrerequisite", "key": "other", "variationId": "other-false"},
+	}
+
+	list, diags := scheduledInstructionsFromAPI(ctx, "f", live, rules, ids)
+	require.False(t, diags.HasError(), "%v", diags)
+	var models []scheduledInstructionModel
+	require.False(t, list.ElementsAs(ctx, &models, false).HasError())
+	require.Len(t, models, 6)
+	assert.Equal(t, []attr.Value{types.Int64Value(10000), types.Int64Value(90000)}, models[1].RolloutWeights.Elements())
+	assert.Equal(t, int64(1), models[2].Variation.ValueInt64())
+	assert.Equal(t, "employees", models[4].Ref.ValueString(), "rule IDs are read as refs")
+	assert.Equal(t, "other", models[5].FlagKey.ValueString())
+	assert.Equal(t, int64(1), models[5].Variation.ValueInt64(), "prerequisite variations index the prerequisite flag")
+
+	sent, diags := scheduledInstructionPayloads(ctx, "f", list, rules, ids)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, map[string]interface{}{"kind": "removeRule", "ruleId": "r1"}, sent[4])
+	assert.True(t, scheduledInstructionsMatch(sent, live))
+
+	live[2]["values"] = []interface{}{"a", "c"}
+	assert.False(t, scheduledInstructionsMatch(sent, live), "changed values are drift")
+	assert.False(t, scheduledInstructionsMatch(sent[:5], live[:6]), "extra instructions are drift")
+}
@@ -0,0 +1,332 @@
+package launchdarkly
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ldapi "github.com/launchdarkly/api-client-go/v24"
+)
+
+// Each included_contexts entry of a launchdarkly_segment can set when
+// LaunchDarkly stops including some of its keys, using the segment
+// expiring-targets endpoints. LaunchDarkly removes a key from the segment
+// once it expires. The key stays in values without showing up as drift, and
+// is not sent again, until it is removed from the configuration.
+
+// segmentIncludedContextAttrTypes are the attributes of an included_contexts
+// entry of the resource.
+var segmentIncludedContextAttrTypes = map[string]attr.Type{
+	VALUES:       types.ListType{ElemType: types.StringType},
+	CONTEXT_KIND: types.StringType,
+	EXPIRATIONS:  types.MapType{ElemType: types.StringType},
+}
+
+// segmentIncludedContextModel is an included_contexts entry of the resource.
+type segmentIncludedContextModel struct {
+	Values      types.List   `tfsdk:"values"`
+	ContextKind types.String `tfsdk:"context_kind"`
+	Expirations types.Map    `tfsdk:"expirations"`
+}
+
+// segmentIncludedContextModels returns the entries of included_contexts, or
+// nil when it is null or unknown.
+func segmentIncludedContextModels(ctx context.Context, list types.List) ([]segmentIncludedContextModel, diag.Diagnostics) {
+	if list.IsNull() || list.IsUnknown() {
+		return nil, nil
+	}
+	var models []segmentIncludedContextModel
+	diags := list.ElementsAs(ctx, &models, false)
+	return models, diags
+}
+
+// segmentContextExpirations parses the expirations of included_contexts,
+// keyed by context kind and then by context key.
+func segmentContextExpirations(ctx context.Context, list types.List) (map[string]map[string]time.Time, diag.Diagnostics) {
+	models, diags := segmentIncludedContextModels(ctx, list)
+	expirations := make(map[string]map[string]time.Time)
+	for i, m := range models {
+		raw, d := mapStringFromAttr(ctx, m.Expirations)
+		diags.Append(d...)
+		kind := m.ContextKind.ValueString()
+		for key, value := range raw {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				diags.AddAttributeError(path.Root(INCLUDED_CONTEXTS).AtListIndex(i).AtName(EXPIRATIONS).AtMapKey(key), "Invalid timestamp", err.Error())
+				continue
+			}
+			if expirations[kind] == nil {
+				expirations[kind] = make(map[string]time.Time)
+			}
+			expirations[kind][key] = t
+		}
+	}
+	return expirations, diags
+}
+
+// segmentIncludedContextsFromList returns the included contexts to send to
+// LaunchDarkly: those of list without the keys whose expiration has passed,
+// so keys LaunchDarkly has removed are not included again.
+func segmentIncludedContextsFromList(ctx context.Context, list types.List, now time.Time) ([]ldapi.SegmentTarget, diag.Diagnostics) {
+	targets, diags := segmentTargetsFromList(ctx, list)
+	expirations, d := segmentContextExpirations(ctx, list)
+	diags.Append(d...)
+	if diags.HasError() || len(expirations) == 0 {
+		return targets, diags
+	}
+	out := make([]ldapi.SegmentTarget, 0, len(targets))
+	for _, t := range targets {
+		t.Values = flagTargetUnexpired(t.Values, expirations[t.GetContextKind()], now)
+		if len(t.Values) > 0 {
+			out = append(out, t)
+		}
+	}
+	return out, diags
+}
+
+// segmentIncludedContextsValue returns included_contexts for the live
+// targets. When no prior entry sets expirations, it is the live targets.
+// Otherwise entries follow the prior order, keep the prior keys whose
+// expiration has passed and that LaunchDarkly has removed, and read their
+// expirations from live, the segment's expiring targets by context kind.
+// Live targets of other context kinds follow.
+func segmentIncludedContextsValue(ctx context.Context, targets []ldapi.SegmentTarget, prior types.List, live map[string]map[string]ldapi.ExpiringTarget, now time.Time, diags *diag.Diagnostics) types.List {
+	objectType := types.ObjectType{AttrTypes: segmentIncludedContextAttrTypes}
+	priorModels, d := segmentIncludedContextModels(ctx, prior)
+	diags.Append(d...)
+
+	var kinds []string
+	liveValues := make(map[string][]string)
+	for _, t := range targets {
+		kind := t.GetContextKind()
+		if _, ok := liveValues[kind]; !ok {
+			kinds = append(kinds, kind)
+		}
+		liveValues[kind] = append(liveValues[kind], t.Values...)
+	}
+
+	elements := make([]attr.Value, 0, len(kinds))
+	add := func(kind string, values []string, expirations types.Map) {
+		list, d := listFromStringSlice(ctx, values)
+		diags.Append(d...)
+		obj, d := types.ObjectValue(segmentIncludedContextAttrTypes, map[string]attr.Value{
+			VALUES:       list,
+			CONTEXT_KIND: types.StringValue(kind),
+			EXPIRATIONS:  expirations,
+		})
+		diags.Append(d...)
+		elements = append(elements, obj)
+	}
+	done := make(map[string]bool)
+	if segmentExpirationsSet(ctx, prior) {
+		for _, m := range priorModels {
+			kind := m.ContextKind.ValueString()
+			if done[kind] {
+				continue
+			}
+			done[kind] = true
+			values := liveValues[kind]
+			expirations := types.MapNull(types.StringType)
+			if !m.Expirations.IsNull() {
+				managed, d := stringSliceFromList(ctx, m.Values)
+				diags.Append(d...)
+				priorExpirations, d := mapStringFromAttr(ctx, m.Expirations)
+				diags.Append(d...)
+				// Keep the configured order, then any keys added elsewhere.
+				values = slices.DeleteFunc(managed, func(key string) bool {
+					return !slices.Contains(liveValues[kind], key) && !rfc3339Passed(types.StringValue(priorExpirations[key]), now)
+				})
+				_, others := partitionStrings(liveValues[kind], managed)
+				values = append(values, others...)
+				expirations = flagTargetExpirationsValue(values, priorExpirations, live[kind], now, true)
+			}
+			if len(values) > 0 {
+				add(kind, values, expirations)
+			}
+		}
+	}
+	for _, kind := range kinds {
+		if !done[kind] {
+			add(kind, liveValues[kind], types.MapNull(types.StringType))
+		}
+	}
+	if len(elements) == 0 {
+		return types.ListNull(objectType)
+	}
+	list, d := types.ListValue(objectType, elements)
+	diags.Append(d...)
+	return list
+}
+
+// segmentExpirationsSet reports whether any entry of included_contexts sets
+// expirations.
+func segmentExpirationsSet(ctx context.Context, list types.List) bool {
+	models, _ := segmentIncludedContextModels(ctx, list)
+	return slices.ContainsFunc(models, func(m segmentIncludedContextModel) bool { return !m.Expirations.IsNull() })
+}
+
+// liveExpirations returns the expiring targets of the contexts included in
+// the segment, keyed by context kind and then by context key.
+func (r *SegmentResource) liveExpirations(projectKey, envKey, key string) (map[string]map[string]ldapi.ExpiringTarget, error) {
+	var targets *ldapi.ExpiringTargetGetResponse
+	err := r.client.withConcurrency(r.client.ctx, func() error {
+		var e error
+		targets, _, e = r.client.ld.SegmentsApi.GetExpiringTargetsForSegment(r.client.ctx, projectKey, envKey, key).Execute()
+		return e
+	})
+	if err != nil {
+		return nil, err
+	}
+	live := make(map[string]map[string]ldapi.ExpiringTarget)
+	for _, t := range targets.Items {
+		if t.GetTargetType() != "included" {
+			continue
+		}
+		if live[t.ContextKind] == nil {
+			live[t.ContextKind] = make(map[string]ldapi.ExpiringTarget)
+		}
+		live[t.ContextKind][t.ContextKey] = t
+	}
+	return live, nil
+}
+
+// applyExpirations makes the live expiring targets of the segment's included
+// contexts match the planned expirations, after applySegmentUpdate has
+// applied the targeting. Expirations that have passed are left to
+// LaunchDarkly, and expirations the plan drops are removed. When the
+// environment requires approval, the change is submitted for approval
+// according to approval_mode, as targeting changes are.
+func (r *SegmentResource) applyExpirations(ctx context.Context, plan, state SegmentResourceModel, isCreate bool) diag.Diagnostics {
+	planned, diags := segmentContextExpirations(ctx, plan.IncludedContexts)
+	prior, d := segmentContextExpirations(ctx, state.IncludedContexts)
+	diags.Append(d...)
+	if diags.HasError() || (len(planned) == 0 && len(prior) == 0) {
+		return diags
+	}
+	projectKey, envKey, key := plan.ProjectKey.ValueString(), plan.EnvKey.ValueString(), plan.Key.ValueString()
+	live, err := r.liveExpirations(projectKey, envKey, key)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("failed to get expiring targets of segment %q in project %q, environment %q: %s", key, projectKey, envKey, handleLdapiErr(err).Error()), "")
+		return diags
+	}
+
+	now := time.Now()
+	kinds := slices.Collect(maps.Keys(planned))
+	for kind := range prior {
+		if _, ok := planned[kind]; !ok {
+			kinds = append(kinds, kind)
+		}
+	}
+	slices.Sort(kinds)
+	var instructions []map[string]interface{}
+	for _, kind := range kinds {
+		pending := make(map[string]int64, len(planned[kind]))
+		for contextKey, t := range planned[kind] {
+			if t.After(now) {
+				pending[contextKey] = t.UnixMilli()
+			}
+		}
+		target := map[string]interface{}{"contextKind": kind, "targetType": "included"}
+		instructions = append(instructions, expiringTargetInstructions(target, pending, slices.Collect(maps.Keys(prior[kind])), live[kind])...)
+	}
+	if len(instructions) == 0 {
+		return diags
+	}
+
+	comment := r.client.changeCommentFor(plan.Comment)
+	input := ldapi.PatchSegmentExpiringTargetInputRep{
+		Comment:      ldapi.PtrString(comment),
+		Instructions: segmentExpiringTargetInstructions(instructions),
+	}
+	err = r.client.withConcurrency(r.client.ctx, func() error {
+		_, _, e := r.client.ld.SegmentsApi.PatchExpiringTargetsForSegment(r.client.ctx, projectKey, envKey, key).PatchSegmentExpiringTargetInputRep(input).Execute()
+		return e
+	})
+	if err == nil {
+		return diags
+	}
+	if !isApprovalRequiredErr(err) {
+		diags.AddError(fmt.Sprintf("failed to update expiring targets of segment %q in project %q, environment %q: %s", key, projectKey, envKey, handleLdapiErr(err).Error()), "")
+		return diags
+	}
+	mode := r.client.approvalModeFor(plan.ApprovalMode)
+	// As with targeting, a new segment cannot be recorded while its
+	// expirations are still under review.
+	if mode == APPROVAL_MODE_FAIL || (isCreate && mode == APPROVAL_MODE_REQUEST) {
+		diags.Append(r.segmentApprovalRequiredDiag(isCreate, projectKey, envKey, key))
+		return diags
+	}
+	diags.Append(r.client.submitForApproval(mode, approvalRequestSpec{
+		resourceID:   segmentApprovalResourceID(projectKey, envKey, key),
+		summary:      fmt.Sprintf("expiring targets of segment %q in environment %q of project %q", key, envKey, projectKey),
+		instructions: instructions,
+		comment:      comment,
+	})...)
+	if diags.HasError() && isCreate {
+		diags.AddError(fmt.Sprintf("segment %q was not created in project %q", key, projectKey), r.rollbackSegmentShell(projectKey, envKey, key))
+	}
+	return diags
+}
+
+// segmentExpiringTargetInstructions converts instructions built by
+// expiringTargetInstructions for the segment expiring-targets endpoint.
+func segmentExpiringTargetInstructions(instructions []map[string]interface{}) []ldapi.PatchSegmentExpiringTargetInstruction {
+	out := make([]ldapi.PatchSegmentExpiringTargetInstruction, 0, len(instructions))
+	for _, in := range instructions {
+		i := ldapi.PatchSegmentExpiringTargetInstruction{
+			Kind:        in["kind"].(string),
+			ContextKey:  in["contextKey"].(string),
+			ContextKind: in["contextKind"].(string),
+			TargetType:  in["targetType"].(string),
+		}
+		if value, ok := in["value"].(int64); ok {
+			i.Value = &value
+		}
+		if version, ok := in["version"].(int32); ok {
+			i.Version = &version
+		}
+		out = append(out, i)
+	}
+	return out
+}
+
+// segmentExpirationsValidator rejects expirations for keys that are not in
+// the values of the same included_contexts entry.
+type segmentExpirationsValidator struct{}
+
+func (v segmentExpirationsValidator) Description(_ context.Context) string {
+	return "each key in included_contexts expirations must be one of the entry's values"
+}
+
+func (v segmentExpirationsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v segmentExpirationsValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var list types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(INCLUDED_CONTEXTS), &list)...)
+	models, d := segmentIncludedContextModels(ctx, list)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for i, m := range models {
+		if m.Values.IsUnknown() || m.Expirations.IsNull() || m.Expirations.IsUnknown() {
+			continue
+		}
+		values, d := stringSliceFromList(ctx, m.Values)
+		resp.Diagnostics.Append(d...)
+		for key := range m.Expirations.Elements() {
+			if !slices.Contains(values, key) {
+				resp.Diagnostics.AddAttributeError(path.Root(INCLUDED_CONTEXTS).AtListIndex(i).AtName(EXPIRATIONS).AtMapKey(key), "Invalid expiration", fmt.Sprintf("%q is not one of the context keys in %s.", key, VALUES))
+			}
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package launchdarkly
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ldapi "github.com/launchdarkly/api-client-go/v24"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSegmentIncludedContexts(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	entry := func(kind string, values []string, expirations map[string]string) attr.Value {
+		list, _ := listFromStringSlice(ctx, values)
+		exp := types.MapNull(types.StringType)
+		if expirations != nil {
+			elements := make(map[string]attr.Value, len(expirations))
+			for k, v := range expirations {
+				elements[k] = types.StringValue(v)
+			}
+			exp = types.MapValueMust(types.StringType, elements)
+		}
+		return types.ObjectValueMust(segmentIncludedContextAttrTypes, map[string]attr.Value{
+			VALUES:       list,
+			CONTEXT_KIND: types.StringValue(kind),
+			EXPIRATIONS:  exp,
+		})
+	}
+	objectType := types.ObjectType{AttrTypes: segmentIncludedContextAttrTypes}
+	configured := types.ListValueMust(objectType, []attr.Value{
+		entry("organization", []string{"expired", "pending", "kept"}, map[string]string{
+			"expired": "2026-02-01T00:00:00Z",
+			"pending": "2026-04-01T00:00:00-05:00",
+		}),
+		entry("device", []string{"d-1"}, nil),
+	})
+
+	t.Run("keys whose expiration has passed are not sent", func(t *testing.T) {
+		targets, diags := segmentIncludedContextsFromList(ctx, configured, now)
+		require.False(t, diags.HasError())
+		require.Len(t, targets, 2)
+		assert.Equal(t, []string{"pending", "kept"}, targets[0].Values)
+		assert.Equal(t, []string{"d-1"}, targets[1].Values)
+	})
+
+	t.Run("expired keys are read back without drift", func(t *testing.T) {
+		live := []ldapi.SegmentTarget{
+			{ContextKind: ldapi.PtrString("device"), Values: []string{"d-1"}},
+			{ContextKind: ldapi.PtrString("organization"), Values: []string{"kept", "pending", "theirs"}},
+		}
+		pending := time.Date(2026, 4, 1, 5, 0, 0, 0, time.UTC).UnixMilli()
+		expiring := map[string]map[string]ldapi.ExpiringTarget{
+			"organization": {"pending": {ContextKey: "pending", ExpirationDate: pending}},
+		}
+		var diags diag.Diagnostics
+		got := segmentIncludedContextsValue(ctx, live, configured, expiring, now, &diags)
+		require.False(t, diags.HasError())
+		assert.Equal(t, types.ListValueMust(objectType, []attr.Value{
+			entry("organization", []string{"expired", "pending", "kept", "theirs"}, map[string]string{
+				"expired": "2026-02-01T00:00:00Z",
+				"pending": "2026-04-01T00:00:00-05:00",
+			}),
+			entry("device", []string{"d-1"}, nil),
+		}), got)
+	})
+
+	t.Run("live order is kept without expirations", func(t *testing.T) {
+		live := []ldapi.SegmentTarget{{ContextKind: ldapi.PtrString("device"), Values: []string{"d-2", "d-1"}}}
+		prior := types.ListValueMust(objectType, []attr.Value{entry("device", []string{"d-1", "d-2"}, nil)})
+		var diags diag.Diagnostics
+		got := segmentIncludedContextsValue(ctx, live, prior, nil, now, &diags)
+		require.False(t, diags.HasError())
+		assert.Equal(t, types.ListValueMust(objectType, []attr.Value{entry("device", []string{"d-2", "d-1"}, nil)}), got)
+	})
+}
+
+func TestSegmentExpiringTargetInstructions(t *testing.T) {
+	target := map[string]interface{}{"contextKind": "organization", "targetType": "included"}
+	live := map[string]ldapi.ExpiringTarget{"moved": {ContextKey: "moved", ExpirationDate: 1000, Version: 2}}
+	instructions := expiringTargetInstructions(target, map[string]int64{"moved": 2000, "new": 3000}, nil, live)
+	assert.Equal(t, []map[string]interface{}{
+		{"kind": "updateExpiringTarget", "contextKey": "moved", "contextKind": "organization", "targetType": "included", "value": int64(2000), "version": int32(2)},
+		{"kind": "addExpiringTarget", "contextKey": "new", "contextKind": "organization", "targetType": "included", "value": int64(3000)},
+	}, instructions)
+
+	converted := segmentExpiringTargetInstructions(instructions)
+	require.Len(t, converted, 2)
+	assert.Equal(t, "updateExpiringTarget", converted[0].Kind)
+	assert.Equal(t, "included", converted[0].TargetType)
+	assert.Equal(t, int64(2000), *converted[0].Value)
+	assert.Equal(t, int32(2), *converted[0].Version)
+	assert.Nil(t, converted[1].Version)
+}
@@ -0,0 +1,137 @@
+package launchdarkly
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	ldapi "github.com/launchdarkly/api-client-go/v24"
+)
+
+// buildSegmentInstructions translates the difference between the desired
+// targeting of a segment and its live targeting into semantic-patch
+// instructions, which approval requests require. Only targeting is
+// translated: included / excluded users, included / excluded contexts, and
+// rules. Rules are matched to live rules by ref or content; unchanged rules
+// are kept and reordered, and changed ones are removed and added again.
+func buildSegmentInstructions(ctx context.Context, plan SegmentResourceModel, live *ldapi.UserSegment) ([]map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	instructions := make([]map[string]interface{}, 0)
+
+	included, d := stringSliceFromList(ctx, plan.Included)
+	diags.Append(d...)
+	excluded, d := stringSliceFromList(ctx, plan.Excluded)
+	diags.Append(d...)
+	includedContexts, d := segmentIncludedContextsFromList(ctx, plan.IncludedContexts, time.Now())
+	diags.Append(d...)
+	excludedContexts, d := segmentTargetsFromList(ctx, plan.ExcludedContexts)
+	diags.Append(d...)
+	rules, d := segmentRulesFromList(ctx, plan.Rules, nil)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	instructions = appendSegmentValueInstructions(instructions, "IncludedUsers", "", included, live.Included)
+	instructions = appendSegmentValueInstructions(instructions, "ExcludedUsers", "", excluded, live.Excluded)
+	instructions = appendSegmentTargetInstructions(instructions, "IncludedTargets", includedContexts, live.IncludedContexts)
+	instructions = appendSegmentTargetInstructions(instructions, "ExcludedTargets", excludedContexts, live.ExcludedContexts)
+
+	liveRules := segmentResourceRulesValue(ctx, live.Rules, &diags)
+	if plan.Rules.Equal(liveRules) {
+		return instructions, diags
+	}
+	// Planned rules that match a live rule exactly are kept and, if needed,
+	// moved. Other live rules are removed and other planned rules added.
+	planned, liveElements := plan.Rules.Elements(), liveRules.Elements()
+	ruleIDs := make([]string, len(planned))
+	keep := make(map[string]bool)
+	for i, k := range matchRules(planned, liveElements) {
+		if k >= 0 && sameRuleContent(planned[i], liveElements[k]) {
+			ruleIDs[i] = live.Rules[k].GetId()
+			keep[ruleIDs[i]] = true
+		}
+	}
+	remaining := make([]string, 0, len(live.Rules))
+	for _, r := range live.Rules {
+		id := r.GetId()
+		if keep[id] {
+			remaining = append(remaining, id)
+		} else if id != "" {
+			instructions = append(instructions, map[string]interface{}{"kind": "removeRule", "ruleId": id})
+		}
+	}
+	if i := reorderRulesInstruction(remaining, ruleIDs); i != nil {
+		instructions = append(instructions, i)
+	}
+	for n, r := range rules {
+		if ruleIDs[n] != "" {
+			continue
+		}
+		i := map[string]interface{}{"kind": "addRule", "clauses": r.Clauses}
+		if r.Weight != nil {
+			i["rolloutWeight"] = *r.Weight
+			if r.RolloutContextKind != nil {
+				i["rolloutContextKind"] = *r.RolloutContextKind
+			}
+		}
+		if r.BucketBy != nil {
+			i["rolloutBucketBy"] = *r.BucketBy
+		}
+		if next := nextRuleID(ruleIDs, n); next != "" {
+			i["beforeRuleId"] = next
+		}
+		instructions = append(instructions, i)
+	}
+	return instructions, diags
+}
+
+// appendSegmentValueInstructions appends the add<list> / remove<list>
+// instructions that turn current into desired. contextKind is only set for
+// context targets.
+func appendSegmentValueInstructions(instructions []map[string]interface{}, list, contextKind string, desired, current []string) []map[string]interface{} {
+	for _, change := range []struct {
+		kind   string
+		values []string
+	}{
+		{"add" + list, stringSliceDifference(desired, current)},
+		{"remove" + list, stringSliceDifference(current, desired)},
+	} {
+		if len(change.values) == 0 {
+			continue
+		}
+		i := map[string]interface{}{"kind": change.kind, "values": change.values}
+		if contextKind != "" {
+			i["contextKind"] = contextKind
+		}
+		instructions = append(instructions, i)
+	}
+	return instructions
+}
+
+// appendSegmentTargetInstructions diffs context targets per context kind.
+func appendSegmentTargetInstructions(instructions []map[string]interface{}, list string, desired, current []ldapi.SegmentTarget) []map[string]interface{} {
+	byKind := func(targets []ldapi.SegmentTarget) map[string][]string {
+		out := make(map[string][]string)
+		for _, t := range targets {
+			out[t.GetContextKind()] = append(out[t.GetContextKind()], t.Values...)
+		}
+		return out
+	}
+	want, have := byKind(desired), byKind(current)
+	kinds := make([]string, 0, len(want)+len(have))
+	for k := range want {
+		kinds = append(kinds, k)
+	}
+	for k := range have {
+		if _, ok := want[k]; !ok {
+			kinds = append(kinds, k)
+		}
+	}
+	sort.Strings(kinds)
+	for _, k := range kinds {
+		instructions = appendSegmentValueInstructions(instructions, list, k, want[k], have[k])
+	}
+	return instructions
+}
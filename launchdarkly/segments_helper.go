@@ -8,8 +8,18 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
+// segmentIdToKeys parses a `project_key/env_key/segment_key` composite id.
+func segmentIdToKeys(id string) (projectKey, envKey, segmentKey string, err error) {
+	if strings.Count(id, "/") != 2 {
+		return "", "", "", fmt.Errorf("found unexpected segment id format: %q expected format: 'project_key/env_key/segment_key'", id)
+	}
+	parts := strings.SplitN(id, "/", 3)
+	return parts[0], parts[1], parts[2], nil
+}
+
 // SegmentBodyWithViewKeys represents the segment creation request body with view_keys support.
 // This is needed because the API client doesn't include the viewKeys field (it's hidden in the API spec).
 type SegmentBodyWithViewKeys struct {
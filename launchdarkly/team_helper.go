@@ -2,6 +2,8 @@ package launchdarkly
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	ldapi "github.com/launchdarkly/api-client-go/v24"
 )
@@ -45,6 +47,28 @@ func makeAddAndRemoveArrays(old, updated []string) (remove, add []string) {
 	return remove, add
 }
 
+// teamMembershipId returns the ID of a launchdarkly_team_membership: the team
+// key and the sorted member IDs, as in 'team_key/member_id_1,member_id_2'.
+func teamMembershipId(teamKey string, memberIDs []string) string {
+	sorted := append([]string{}, memberIDs...)
+	sort.Strings(sorted)
+	return teamKey + "/" + strings.Join(sorted, ",")
+}
+
+func teamMembershipIdToKeys(id string) (teamKey string, memberIDs []string, err error) {
+	teamKey, members, found := strings.Cut(id, "/")
+	if !found || teamKey == "" || members == "" || strings.Contains(members, "/") {
+		return "", nil, fmt.Errorf("found unexpected team membership id format: %q expected format: 'team_key/member_id_1,member_id_2'", id)
+	}
+	memberIDs = strings.Split(members, ",")
+	for _, memberID := range memberIDs {
+		if memberID == "" {
+			return "", nil, fmt.Errorf("found empty member id in team membership id %q", id)
+		}
+	}
+	return teamKey, memberIDs, nil
+}
+
 // getAllTeamCustomRoleKeys fetches all custom role keys for a team using pagination.
 // The LaunchDarkly API returns a maximum of 25 roles by default when using the expand=roles
 // parameter on GetTeam.
@@ -149,6 +173,19 @@ func getAllTeamMembers(client *Client, teamKey string) ([]ldapi.Member, error) {
 
 }
 
+// getTeamMemberIDs returns the IDs of all of the team's members.
+func getTeamMemberIDs(client *Client, teamKey string) ([]string, error) {
+	members, err := getAllTeamMembers(client, teamKey)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(members))
+	for _, m := range members {
+		ids = append(ids, m.Id)
+	}
+	return ids, nil
+}
+
 // getAllTeamMaintainers fetches all maintainers for a team using pagination.
 // The LaunchDarkly API returns a maximum of 25 maintainers by default when using the expand=maintainers
 // parameter on GetTeam. For teams with more than 25 maintainers, we need to use the dedicated
@@ -642,3 +642,16 @@ func TestRolePagination_50Roles(t *testing.T) {
 	assert.Equal(t, "role-1", roleKeys[0])
 	assert.Equal(t, "role-50", roleKeys[49])
 }
+
+func TestTeamMembershipIdToKeys(t *testing.T) {
+	teamKey, memberIDs, err := teamMembershipIdToKeys("platform/b,a")
+	require.NoError(t, err)
+	assert.Equal(t, "platform", teamKey)
+	assert.Equal(t, []string{"b", "a"}, memberIDs)
+	assert.Equal(t, "platform/a,b", teamMembershipId(teamKey, memberIDs))
+
+	for _, id := range []string{"platform", "platform/", "/a", "platform/a/b", "platform/a,,b"} {
+		_, _, err := teamMembershipIdToKeys(id)
+		assert.Error(t, err, id)
+	}
+}
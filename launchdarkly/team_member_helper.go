@@ -37,6 +37,35 @@ func getTeamMembersByEmail(client *Client, memberEmails []string) ([]ldapi.Membe
 	return members, nil
 }
 
+// getTeamMembersByEmailInChunks looks up the members with emails, chunkSize
+// emails per request, and returns them keyed by lowercased email.
+func getTeamMembersByEmailInChunks(client *Client, emails []string, chunkSize int) (map[string]ldapi.Member, error) {
+	found := make(map[string]ldapi.Member, len(emails))
+	for _, chunk := range chunkStringSlice(emails, chunkSize) {
+		members, err := getTeamMembersByEmail(client, chunk)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			found[strings.ToLower(member.Email)] = member
+		}
+	}
+	return found, nil
+}
+
+// chunkNewMemberForms splits a member form slice into chunks of the specified size
+func chunkNewMemberForms(slice []ldapi.NewMemberForm, chunkSize int) [][]ldapi.NewMemberForm {
+	var chunks [][]ldapi.NewMemberForm
+	for i := 0; i < len(slice); i += chunkSize {
+		end := i + chunkSize
+		if end > len(slice) {
+			end = len(slice)
+		}
+		chunks = append(chunks, slice[i:end])
+	}
+	return chunks
+}
+
 func getMembersPaginated(client *Client, filter, expand, sort *string, limit int64, initialOffset *int64) ([]ldapi.Member, error) {
 	offset := int64(0)
 	if initialOffset != nil {
@@ -0,0 +1,651 @@
+package launchdarkly
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ldapi "github.com/launchdarkly/api-client-go/v24"
+)
+
+// LaunchDarkly identifies a flag's variations by index, so adding, removing
+// or reordering variations silently changes which value an index serves.
+// Attributes that take a variation index can instead reference a variation by
+// value or by name, and rollouts can be keyed by variation value. References
+// are resolved to indexes against the flag's variations when planning, so the
+// plan shows the index that is sent, and are kept in state as configured.
+// Rollout percentages are handled the same way.
+
+// ffeResourcePrerequisiteAttrTypes, ffeResourceRuleAttrTypes and
+// ffeResourceFallthroughAttrTypes extend the data source's attribute types
+// with the variation references and experiment settings only the resource
+// accepts.
+var (
+	ffeResourcePrerequisiteAttrTypes = withVariationRefAttrTypes(ffePrerequisiteAttrTypes, false)
+	ffeResourceRuleAttrTypes         = withRolloutExperimentAttrTypes(withVariationRefAttrTypes(ffeRuleAttrTypes, true), true)
+	ffeResourceFallthroughAttrTypes  = withRolloutExperimentAttrTypes(withVariationRefAttrTypes(ffeFallthroughAttrTypes, true), false)
+
+	featureFlagResourceDefaultsAttrTypes = map[string]attr.Type{
+		ON_VARIATION:        types.Int64Type,
+		ON_VARIATION_VALUE:  types.StringType,
+		ON_VARIATION_NAME:   types.StringType,
+		OFF_VARIATION:       types.Int64Type,
+		OFF_VARIATION_VALUE: types.StringType,
+		OFF_VARIATION_NAME:  types.StringType,
+	}
+)
+
+// variationRefAttrNames are the nested attributes that reference variations
+// instead of indexes, or give rollout weights as percentages. They are only
+// ever configured, never read.
+var variationRefAttrNames = []string{VARIATION_VALUE, VARIATION_NAME, ROLLOUT, ROLLOUT_PERCENTAGES}
+
+func withVariationRefAttrTypes(attrTypes map[string]attr.Type, rollout bool) map[string]attr.Type {
+	out := maps.Clone(attrTypes)
+	out[VARIATION_VALUE] = types.StringType
+	out[VARIATION_NAME] = types.StringType
+	if rollout {
+		out[ROLLOUT] = types.MapType{ElemType: types.Int64Type}
+		out[ROLLOUT_PERCENTAGES] = types.ListType{ElemType: types.Float64Type}
+	}
+	return out
+}
+
+// withNullVariationRefs adds null variation references to the attributes of
+// an object read from LaunchDarkly.
+func withNullVariationRefs(attrs map[string]attr.Value, rollout bool) map[string]attr.Value {
+	attrs[VARIATION_VALUE] = types.StringNull()
+	attrs[VARIATION_NAME] = types.StringNull()
+	if rollout {
+		attrs[ROLLOUT] = types.MapNull(types.Int64Type)
+		attrs[ROLLOUT_PERCENTAGES] = types.ListNull(types.Float64Type)
+	}
+	return attrs
+}
+
+// siblings matches the named attributes of the object an attribute is in.
+func siblings(names ...string) []path.Expression {
+	expressions := make([]path.Expression, 0, len(names))
+	for _, name := range names {
+		expressions = append(expressions, path.MatchRelative().AtParent().AtName(name))
+	}
+	return expressions
+}
+
+// variationValueAttribute returns the attribute that references by value the
+// variation the index attribute of is otherwise set to.
+func variationValueAttribute(of string, conflicts ...path.Expression) schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Description: fmt.Sprintf("The value of the variation to use instead of `%s`, written as in the flag's `variations`. It is resolved to `%s` against the flag's variations when planning, so it keeps referring to the same variation when variations are added or reordered. For a migration flag, this is the name of a stage, such as `dualwrite`.", of, of),
+		Validators:  conflictsWith(conflicts),
+	}
+}
+
+// variationNameAttribute returns the attribute that references by name the
+// variation the index attribute of is otherwise set to.
+func variationNameAttribute(of string, conflicts ...path.Expression) schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Description: fmt.Sprintf("The name of the variation to use instead of `%s`. It is resolved to `%s` against the flag's variations when planning, so it keeps referring to the same variation when variations are added or reordered.", of, of),
+		Validators:  conflictsWith(conflicts),
+	}
+}
+
+func conflictsWith(conflicts []path.Expression) []validator.String {
+	if len(conflicts) == 0 {
+		return nil
+	}
+	return []validator.String{stringvalidator.ConflictsWith(conflicts...)}
+}
+
+// rolloutAttribute returns the rollout attribute, keyed by variation value,
+// that is an alternative to rollout_weights.
+func rolloutAttribute() schema.MapAttribute {
+	return schema.MapAttribute{
+		Optional:    true,
+		ElementType: types.Int64Type,
+		Description: "Percentage rollout weights (in thousandths of a percent) keyed by variation value, to use instead of `rollout_weights`. They are resolved to `rollout_weights` against the flag's variations when planning, so each weight stays with its variation when variations are added or reordered. Variations left out get a weight of `0`.",
+		Validators: []validator.Map{
+			mapvalidator.ValueInt64sAre(int64validator.Between(0, 100000)),
+			mapvalidator.ConflictsWith(siblings(VARIATION, VARIATION_VALUE, VARIATION_NAME, ROLLOUT_WEIGHTS, ROLLOUT_PERCENTAGES)...),
+		},
+	}
+}
+
+// variationKey is what a variation reference can identify a variation by.
+type variationKey struct {
+	value string
+	name  string
+}
+
+// variationIndex returns the index of the variation in keys that value and
+// name, where not null, refer to, or -1. Values match when they are equal as
+// strings or as JSON, so `1.0` refers to a number variation of `1`.
+func variationIndex(keys []variationKey, value, name types.String) int {
+	return slices.IndexFunc(keys, func(k variationKey) bool {
+		if !name.IsNull() && k.name != name.ValueString() {
+			return false
+		}
+		return value.IsNull() || k.value == value.ValueString() || jsonSemanticallyEqual(k.value, value.ValueString())
+	})
+}
+
+func variationKeysFromAPI(variations []ldapi.Variation) ([]variationKey, error) {
+	if len(variations) == 0 {
+		return []variationKey{}, nil
+	}
+	variationType, err := variationsToVariationType(variations)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]variationKey, 0, len(variations))
+	for _, v := range variations {
+		value, err := variationValueToString(&v.Value, variationType)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, variationKey{value: value, name: v.GetName()})
+	}
+	return keys, nil
+}
+
+// variationKeysFromList returns the keys of launchdarkly_feature_flag's
+// variations, or nil when any of their values is unknown. Names are only
+// unknown when they are not configured, and then do not match.
+func variationKeysFromList(ctx context.Context, list types.List) ([]variationKey, diag.Diagnostics) {
+	if list.IsNull() || list.IsUnknown() {
+		return nil, nil
+	}
+	var rows []variationPriorView
+	diags := list.ElementsAs(ctx, &rows, true)
+	if diags.HasError() {
+		return nil, diags
+	}
+	keys := make([]variationKey, 0, len(rows))
+	for _, row := range rows {
+		if row.Value.IsUnknown() {
+			return nil, diags
+		}
+		keys = append(keys, variationKey{value: row.Value.ValueString(), name: row.Name.ValueString()})
+	}
+	return keys, diags
+}
+
+// variationLookup returns the variations of a flag, or nil when they are not
+// known, such as while planning a flag that does not exist yet.
+type variationLookup func(flagKey string) ([]variationKey, error)
+
+// flagVariationLookup looks up the variations of the flags of a project,
+// fetching each flag at most once.
+func (c *Client) flagVariationLookup(projectKey string) variationLookup {
+	cache := make(map[string][]variationKey)
+	return func(flagKey string) ([]variationKey, error) {
+		if c == nil || projectKey == "" || flagKey == "" {
+			return nil, nil
+		}
+		if keys, ok := cache[flagKey]; ok {
+			return keys, nil
+		}
+		var flag *ldapi.FeatureFlag
+		var res *http.Response
+		err := c.withConcurrency(c.ctx, func() error {
+			var e error
+			flag, res, e = c.ld.FeatureFlagsApi.GetFeatureFlag(c.ctx, projectKey, flagKey).Execute()
+			return e
+		})
+		if isStatusNotFound(res) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get flag %q in project %q: %s", flagKey, projectKey, handleLdapiErr(err).Error())
+		}
+		keys, err := variationKeysFromAPI(flag.Variations)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the variations of flag %q in project %q: %s", flagKey, projectKey, err)
+		}
+		cache[flagKey] = keys
+		return keys, nil
+	}
+}
+
+// variationRefResolver resolves variation references to indexes. While
+// planning, references that cannot be resolved yet are left unknown. When
+// applying, every reference must resolve, and to the index planned: anything
+// else means the flag's variations changed after planning.
+type variationRefResolver struct {
+	lookup variationLookup
+	apply  bool
+	diags  diag.Diagnostics
+}
+
+// index returns the index of the variation of flagKey that value or name
+// refers to, or configured when neither is set. planned is the index planned
+// for the attribute at p.
+func (v *variationRefResolver) index(p path.Path, flagKey string, planned, configured types.Int64, value, name types.String) types.Int64 {
+	if value.IsNull() && name.IsNull() {
+		return configured
+	}
+	if value.IsUnknown() || name.IsUnknown() {
+		return types.Int64Unknown()
+	}
+	keys, ok := v.variations(p, flagKey)
+	if !ok {
+		return types.Int64Unknown()
+	}
+	i := variationIndex(keys, value, name)
+	if i < 0 {
+		if v.apply {
+			v.diags.AddAttributeError(p, "Unknown variation", fmt.Sprintf("Flag %q has no variation %s.", flagKey, describeVariationRef(value, name)))
+		}
+		return types.Int64Unknown()
+	}
+	resolved := types.Int64Value(int64(i))
+	v.checkPlanned(p, planned, resolved)
+	return resolved
+}
+
+// weights returns the rollout weights that rollout assigns to the variations
+// of flagKey, in variation order, or configured when rollout is null.
+func (v *variationRefResolver) weights(p path.Path, flagKey string, planned, configured types.List, rollout types.Map) types.List {
+	if rollout.IsNull() {
+		return configured
+	}
+	if rollout.IsUnknown() {
+		return types.ListUnknown(types.Int64Type)
+	}
+	keys, ok := v.variations(p, flagKey)
+	if !ok {
+		return types.ListUnknown(types.Int64Type)
+	}
+	weights := make([]attr.Value, len(keys))
+	for i := range weights {
+		weights[i] = types.Int64Value(0)
+	}
+	values := slices.Sorted(maps.Keys(rollout.Elements()))
+	for _, value := range values {
+		i := variationIndex(keys, types.StringValue(value), types.StringNull())
+		if i < 0 {
+			if v.apply {
+				v.diags.AddAttributeError(p, "Unknown variation", fmt.Sprintf("Flag %q has no variation %s.", flagKey, describeVariationRef(types.StringValue(value), types.StringNull())))
+			}
+			return types.ListUnknown(types.Int64Type)
+		}
+		weights[i] = rollout.Elements()[value]
+	}
+	resolved, d := types.ListValue(types.Int64Type, weights)
+	v.diags.Append(d...)
+	v.checkPlanned(p, planned, resolved)
+	return resolved
+}
+
+// variations returns the variations of flagKey, or false when they are not
+// known.
+func (v *variationRefResolver) variations(p path.Path, flagKey string) ([]variationKey, bool) {
+	keys, err := v.lookup(flagKey)
+	if err != nil {
+		v.diags.AddAttributeError(p, "Failed to resolve variation", err.Error())
+		return nil, false
+	}
+	if keys == nil {
+		if v.apply {
+			v.diags.AddAttributeError(p, "Failed to resolve variation", fmt.Sprintf("cannot find flag %q", flagKey))
+		}
+		return nil, false
+	}
+	return keys, true
+}
+
+func (v *variationRefResolver) checkPlanned(p path.Path, planned, resolved attr.Value) {
+	if v.apply && !planned.IsUnknown() && !planned.Equal(resolved) {
+		v.diags.AddAttributeError(p,
+			"Variations changed after planning",
+			fmt.Sprintf("The variation referenced at %s was planned as %s but now resolves to %s. Plan again.", p, planned, resolved),
+		)
+	}
+}
+
+func describeVariationRef(value, name types.String) string {
+	if !value.IsNull() {
+		return fmt.Sprintf("with value %q", value.ValueString())
+	}
+	return fmt.Sprintf("named %q", name.ValueString())
+}
+
+// resolveFFEVariationRefs returns plan, configured at p, with the variation
+// references and rollout percentages set in config resolved. Indexes and
+// rollout weights that config leaves unset, and that no reference sets, are
+// null.
+func resolveFFEVariationRefs(ctx context.Context, p path.Path, plan, config FeatureFlagEnvironmentResourceModel, flagKey string, v *variationRefResolver) FeatureFlagEnvironmentResourceModel {
+	plan.OffVariation = v.index(p.AtName(OFF_VARIATION), flagKey, plan.OffVariation, config.OffVariation, config.OffVariationValue, config.OffVariationName)
+
+	plan.Rules = v.list(ctx, p.AtName(RULES), plan.Rules, config.Rules, func(p path.Path, attrs, configured map[string]attr.Value) {
+		attrs[VARIATION] = v.index(p.AtName(VARIATION), flagKey, attrs[VARIATION].(types.Int64), configured[VARIATION].(types.Int64), configured[VARIATION_VALUE].(types.String), configured[VARIATION_NAME].(types.String))
+		attrs[ROLLOUT_WEIGHTS] = v.weights(p.AtName(ROLLOUT_WEIGHTS), flagKey, attrs[ROLLOUT_WEIGHTS].(types.List), rolloutWeightsOrPercentages(ctx, configured[ROLLOUT_WEIGHTS].(types.List), configured[ROLLOUT_PERCENTAGES].(types.List)), configured[ROLLOUT].(types.Map))
+	})
+
+	// Prerequisites refer to the variations of the prerequisite flag.
+	plan.Prerequisites = v.list(ctx, p.AtName(PREREQUISITES), plan.Prerequisites, config.Prerequisites, func(p path.Path, attrs, configured map[string]attr.Value) {
+		attrs[VARIATION] = v.index(p.AtName(VARIATION), attrs[FLAG_KEY].(types.String).ValueString(), attrs[VARIATION].(types.Int64), configured[VARIATION].(types.Int64), configured[VARIATION_VALUE].(types.String), configured[VARIATION_NAME].(types.String))
+	})
+
+	// fallthrough's variation defaults to 0, so the planned value is kept
+	// when no reference is set.
+	if !plan.Fallthrough.IsNull() && !plan.Fallthrough.IsUnknown() && !config.Fallthrough.IsNull() && !config.Fallthrough.IsUnknown() {
+		fp := p.AtName(FALLTHROUGH)
+		attrs, configured := plan.Fallthrough.Attributes(), config.Fallthrough.Attributes()
+		attrs[VARIATION] = v.index(fp.AtName(VARIATION), flagKey, attrs[VARIATION].(types.Int64), attrs[VARIATION].(types.Int64), configured[VARIATION_VALUE].(types.String), configured[VARIATION_NAME].(types.String))
+		attrs[ROLLOUT_WEIGHTS] = v.weights(fp.AtName(ROLLOUT_WEIGHTS), flagKey, attrs[ROLLOUT_WEIGHTS].(types.List), rolloutWeightsOrPercentages(ctx, configured[ROLLOUT_WEIGHTS].(types.List), configured[ROLLOUT_PERCENTAGES].(types.List)), configured[ROLLOUT].(types.Map))
+		obj, d := types.ObjectValue(ffeResourceFallthroughAttrTypes, attrs)
+		v.diags.Append(d...)
+		plan.Fallthrough = obj
+	}
+	return plan
+}
+
+// list applies resolve to the attributes of each object in planned, along
+// with those of the object config has in its place.
+func (v *variationRefResolver) list(ctx context.Context, p path.Path, planned, config types.List, resolve func(p path.Path, attrs, configured map[string]attr.Value)) types.List {
+	if planned.IsNull() || planned.IsUnknown() || config.IsNull() || config.IsUnknown() || len(planned.Elements()) != len(config.Elements()) {
+		return planned
+	}
+	elements := slices.Clone(planned.Elements())
+	for i, element := range elements {
+		configured := config.Elements()[i].(types.Object)
+		if element.IsUnknown() || configured.IsUnknown() {
+			continue
+		}
+		attrs := element.(types.Object).Attributes()
+		resolve(p.AtListIndex(i), attrs, configured.Attributes())
+		obj, d := types.ObjectValue(element.(types.Object).AttributeTypes(ctx), attrs)
+		v.diags.Append(d...)
+		elements[i] = obj
+	}
+	list, d := types.ListValue(planned.ElementType(ctx), elements)
+	v.diags.Append(d...)
+	return list
+}
+
+// planVariationRefs resolves the variation references in the plan against
+// the flag's current variations.
+func (r *FeatureFlagEnvironmentResource) planVariationRefs(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var plan, config FeatureFlagEnvironmentResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var projectKey, flagKey string
+	if !plan.FlagID.IsUnknown() {
+		projectKey, flagKey, _ = flagIdToKeys(plan.FlagID.ValueString())
+	}
+	v := &variationRefResolver{lookup: r.client.flagVariationLookup(projectKey)}
+	resolved := resolveFFEVariationRefs(ctx, path.Empty(), plan, config, flagKey, v)
+	resp.Diagnostics.Append(v.diags...)
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &resolved)...)
+	}
+}
+
+// applyFFEVariationRefs resolves the variation references in plan when
+// applying, against the flag's variations at that time.
+func (c *Client) applyFFEVariationRefs(ctx context.Context, config tfsdk.Config, projectKey, flagKey string, plan FeatureFlagEnvironmentResourceModel, diags *diag.Diagnostics) FeatureFlagEnvironmentResourceModel {
+	var configured FeatureFlagEnvironmentResourceModel
+	diags.Append(config.Get(ctx, &configured)...)
+	if diags.HasError() {
+		return plan
+	}
+	v := &variationRefResolver{lookup: c.flagVariationLookup(projectKey), apply: true}
+	plan = resolveFFEVariationRefs(ctx, path.Empty(), plan, configured, flagKey, v)
+	diags.Append(v.diags...)
+	return plan
+}
+
+// ffeWithPriorVariationRefs returns data, as read from LaunchDarkly, with the
+// variation references of the rules, prerequisites and fallthrough of prior,
+// which LaunchDarkly does not store.
+func ffeWithPriorVariationRefs(ctx context.Context, prior, data FeatureFlagEnvironmentResourceModel, diags *diag.Diagnostics) FeatureFlagEnvironmentResourceModel {
+	if !data.Rules.IsNull() && !prior.Rules.IsNull() && !prior.Rules.IsUnknown() {
+		elements := slices.Clone(data.Rules.Elements())
+		priorElements := prior.Rules.Elements()
+		for i, j := range matchRules(elements, priorElements) {
+			if j >= 0 {
+				elements[i] = withVariationRefsOf(ctx, elements[i], priorElements[j], diags)
+			}
+		}
+		list, d := types.ListValue(data.Rules.ElementType(ctx), elements)
+		diags.Append(d...)
+		data.Rules = list
+	}
+	if !data.Prerequisites.IsNull() && !prior.Prerequisites.IsNull() && !prior.Prerequisites.IsUnknown() {
+		elements := slices.Clone(data.Prerequisites.Elements())
+		for i, element := range elements {
+			key := element.(types.Object).Attributes()[FLAG_KEY]
+			for _, p := range prior.Prerequisites.Elements() {
+				if p.(types.Object).Attributes()[FLAG_KEY].Equal(key) {
+					elements[i] = withVariationRefsOf(ctx, element, p, diags)
+					break
+				}
+			}
+		}
+		list, d := types.ListValue(data.Prerequisites.ElementType(ctx), elements)
+		diags.Append(d...)
+		data.Prerequisites = list
+	}
+	if !data.Fallthrough.IsNull() && !prior.Fallthrough.IsNull() && !prior.Fallthrough.IsUnknown() {
+		data.Fallthrough = withVariationRefsOf(ctx, data.Fallthrough, prior.Fallthrough, diags).(types.Object)
+	}
+	return data
+}
+
+// withVariationRefsOf returns obj with the variation references of prior.
+func withVariationRefsOf(ctx context.Context, obj, prior attr.Value, diags *diag.Diagnostics) attr.Value {
+	attrs := obj.(types.Object).Attributes()
+	priorAttrs := prior.(types.Object).Attributes()
+	for _, name := range variationRefAttrNames {
+		if value, ok := priorAttrs[name]; ok {
+			attrs[name] = value
+		}
+	}
+	out, d := types.ObjectValue(obj.(types.Object).AttributeTypes(ctx), attrs)
+	diags.Append(d...)
+	return out
+}
+
+// resolveDefaultsVariationRefs returns the planned defaults of a flag with the
+// variation references set in configured resolved against the flag's planned
+// variations, whose keys are nil when not known.
+func resolveDefaultsVariationRefs(planned, configured types.Object, keys []variationKey, apply bool) (types.Object, diag.Diagnostics) {
+	if planned.IsNull() || planned.IsUnknown() || configured.IsNull() || configured.IsUnknown() {
+		return planned, nil
+	}
+	v := &variationRefResolver{lookup: func(string) ([]variationKey, error) { return keys, nil }, apply: apply}
+	attrs, config := planned.Attributes(), configured.Attributes()
+	for _, names := range [][3]string{
+		{ON_VARIATION, ON_VARIATION_VALUE, ON_VARIATION_NAME},
+		{OFF_VARIATION, OFF_VARIATION_VALUE, OFF_VARIATION_NAME},
+	} {
+		p := path.Root(DEFAULTS).AtName(names[0])
+		attrs[names[0]] = v.index(p, "this flag", attrs[names[0]].(types.Int64), config[names[0]].(types.Int64), config[names[1]].(types.String), config[names[2]].(types.String))
+	}
+	obj, d := types.ObjectValue(featureFlagResourceDefaultsAttrTypes, attrs)
+	v.diags.Append(d...)
+	return obj, v.diags
+}
+
+// planDefaultsVariationRefs resolves the variation references of defaults
+// against the planned variations.
+func planDefaultsVariationRefs(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var planned, configured types.Object
+	var variations types.List
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root(DEFAULTS), &planned)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(DEFAULTS), &configured)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root(VARIATIONS), &variations)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	keys, d := variationKeysFromList(ctx, variations)
+	resp.Diagnostics.Append(d...)
+	resolved, d := resolveDefaultsVariationRefs(planned, configured, keys, false)
+	resp.Diagnostics.Append(d...)
+	if !resp.Diagnostics.HasError() && !resolved.Equal(planned) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(DEFAULTS), resolved)...)
+	}
+}
+
+// applyDefaultsVariationRefs resolves the variation references of defaults
+// against the variations being applied.
+func applyDefaultsVariationRefs(ctx context.Context, config tfsdk.Config, defaults types.Object, variations types.List, diags *diag.Diagnostics) types.Object {
+	var configured types.Object
+	diags.Append(config.GetAttribute(ctx, path.Root(DEFAULTS), &configured)...)
+	keys, d := variationKeysFromList(ctx, variations)
+	diags.Append(d...)
+	if diags.HasError() {
+		return defaults
+	}
+	resolved, d := resolveDefaultsVariationRefs(defaults, configured, keys, true)
+	diags.Append(d...)
+	return resolved
+}
+
+// variationIndexMoves returns, for each variation in prior that planned keeps
+// at a different index, its prior index mapped to its planned index. Each
+// variation that planned removes while another prior variation moves into its
+// index is mapped to -1. Values edited in place and variations removed from
+// the end keep their indexes.
+func variationIndexMoves(prior, planned []variationKey) map[int]int {
+	index := func(keys []variationKey, value string) int {
+		return slices.IndexFunc(keys, func(k variationKey) bool { return k.value == value })
+	}
+	moves := make(map[int]int)
+	for i, k := range prior {
+		j := index(planned, k.value)
+		switch {
+		case j >= 0 && j != i:
+			moves[i] = j
+		case j < 0 && i < len(planned) && index(prior, planned[i].value) >= 0:
+			moves[i] = -1
+		}
+	}
+	return moves
+}
+
+// environmentsServingVariations returns the keys of the environments of flag
+// that serve any of the variations at indexes by index: as their off
+// variation, fallthrough, or in a rule or an individual target.
+func environmentsServingVariations(flag *ldapi.FeatureFlag, indexes map[int]int) []string {
+	if flag.Environments == nil {
+		return nil
+	}
+	serves := func(variation *int32, rollout *ldapi.Rollout) bool {
+		if variation != nil {
+			_, ok := indexes[int(*variation)]
+			return ok
+		}
+		if rollout != nil {
+			for _, w := range rollout.Variations {
+				if _, ok := indexes[int(w.Variation)]; ok && w.Weight > 0 {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	var envKeys []string
+	for envKey, env := range *flag.Environments {
+		uses := serves(env.OffVariation, nil)
+		if env.Fallthrough != nil {
+			uses = uses || serves(env.Fallthrough.Variation, env.Fallthrough.Rollout)
+		}
+		for _, rule := range env.Rules {
+			uses = uses || serves(rule.Variation, rule.Rollout)
+		}
+		for _, target := range append(slices.Clone(env.Targets), env.ContextTargets...) {
+			variation := target.Variation
+			uses = uses || serves(&variation, nil)
+		}
+		if uses {
+			envKeys = append(envKeys, envKey)
+		}
+	}
+	sort.Strings(envKeys)
+	return envKeys
+}
+
+// planVariationMoves warns when the planned variations of a flag move
+// variations that its environments serve by index, which would then serve
+// whichever variation takes their place.
+func (r *FeatureFlagResource) planVariationMoves(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.client == nil || req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	var prior, planned types.List
+	var projectKey, flagKey types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(VARIATIONS), &prior)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root(VARIATIONS), &planned)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(PROJECT_KEY), &projectKey)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(KEY), &flagKey)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	priorKeys, d := variationKeysFromList(ctx, prior)
+	resp.Diagnostics.Append(d...)
+	plannedKeys, d := variationKeysFromList(ctx, planned)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() || priorKeys == nil || plannedKeys == nil {
+		return
+	}
+	moves := variationIndexMoves(priorKeys, plannedKeys)
+	if len(moves) == 0 {
+		return
+	}
+	var flag *ldapi.FeatureFlag
+	err := r.client.withConcurrency(r.client.ctx, func() error {
+		var e error
+		flag, _, e = r.client.ld.FeatureFlagsApi.GetFeatureFlag(r.client.ctx, projectKey.ValueString(), flagKey.ValueString()).Execute()
+		return e
+	})
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			fmt.Sprintf("could not check which environments of flag %q serve the variations this plan moves", flagKey.ValueString()),
+			handleLdapiErr(err).Error(),
+		)
+		return
+	}
+	envKeys := environmentsServingVariations(flag, moves)
+	if len(envKeys) == 0 {
+		return
+	}
+	described := make([]string, 0, len(moves))
+	for _, i := range slices.Sorted(maps.Keys(moves)) {
+		if moves[i] < 0 {
+			described = append(described, fmt.Sprintf("%q out of index %d, which %q takes", priorKeys[i].value, i, plannedKeys[i].value))
+			continue
+		}
+		described = append(described, fmt.Sprintf("%q from index %d to %d", priorKeys[i].value, i, moves[i]))
+	}
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root(VARIATIONS),
+		fmt.Sprintf("Variations of flag %q change index", flagKey.ValueString()),
+		fmt.Sprintf("This plan moves or removes the variations %s. Environments %s serve these variations by index, so they will serve whichever variation takes their place. Use variation_value or variation_name in launchdarkly_feature_flag_environment to reference variations independently of their order, or update the indexes in those environments.",
+			strings.Join(described, ", "), strings.Join(envKeys, ", ")),
+	)
+}
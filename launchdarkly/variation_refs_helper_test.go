@@ -0,0 +1,62 @@
+package launchdarkly
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVariationRefs(t *testing.T) {
+	keys := []variationKey{{value: "1", name: "control"}, {value: "2", name: "treatment"}, {value: `{"a":1}`}}
+	lookup := func(flagKey string) ([]variationKey, error) {
+		if flagKey == "unknown" {
+			return nil, nil
+		}
+		return keys, nil
+	}
+	p := path.Root(OFF_VARIATION)
+
+	assert.Equal(t, 1, variationIndex(keys, types.StringValue("2"), types.StringNull()))
+	assert.Equal(t, 0, variationIndex(keys, types.StringValue("1.0"), types.StringNull()), "values match as JSON")
+	assert.Equal(t, 2, variationIndex(keys, types.StringValue(`{ "a": 1 }`), types.StringNull()))
+	assert.Equal(t, 1, variationIndex(keys, types.StringNull(), types.StringValue("treatment")))
+	assert.Equal(t, -1, variationIndex(keys, types.StringValue("1"), types.StringValue("treatment")))
+
+	t.Run("plan", func(t *testing.T) {
+		v := &variationRefResolver{lookup: lookup}
+		assert.Equal(t, types.Int64Value(3), v.index(p, "flag", types.Int64Unknown(), types.Int64Value(3), types.StringNull(), types.StringNull()))
+		assert.Equal(t, types.Int64Value(1), v.index(p, "flag", types.Int64Unknown(), types.Int64Null(), types.StringValue("2"), types.StringNull()))
+		assert.True(t, v.index(p, "unknown", types.Int64Unknown(), types.Int64Null(), types.StringValue("2"), types.StringNull()).IsUnknown(), "flags that do not exist yet resolve at apply")
+		assert.True(t, v.index(p, "flag", types.Int64Unknown(), types.Int64Null(), types.StringValue("3"), types.StringNull()).IsUnknown())
+
+		rollout := types.MapValueMust(types.Int64Type, map[string]attr.Value{"2": types.Int64Value(60000), "1": types.Int64Value(40000)})
+		weights := v.weights(p, "flag", types.ListUnknown(types.Int64Type), types.ListNull(types.Int64Type), rollout)
+		assert.Equal(t, types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(40000), types.Int64Value(60000), types.Int64Value(0)}), weights)
+		assert.False(t, v.diags.HasError())
+	})
+
+	t.Run("apply", func(t *testing.T) {
+		v := &variationRefResolver{lookup: lookup, apply: true}
+		v.index(p, "flag", types.Int64Value(1), types.Int64Null(), types.StringValue("2"), types.StringNull())
+		assert.False(t, v.diags.HasError())
+		v.index(p, "flag", types.Int64Value(0), types.Int64Null(), types.StringValue("2"), types.StringNull())
+		assert.True(t, v.diags.HasError(), "variations that moved since planning must be planned again")
+
+		v = &variationRefResolver{lookup: lookup, apply: true}
+		v.index(p, "flag", types.Int64Unknown(), types.Int64Null(), types.StringValue("3"), types.StringNull())
+		assert.True(t, v.diags.HasError(), "unknown variations fail at apply")
+	})
+}
+
+func TestVariationIndexMoves(t *testing.T) {
+	prior := []variationKey{{value: "a"}, {value: "b"}, {value: "c"}}
+	assert.Empty(t, variationIndexMoves(prior, []variationKey{{value: "a"}, {value: "b"}, {value: "c"}, {value: "d"}}), "appending moves nothing")
+	assert.Empty(t, variationIndexMoves(prior, []variationKey{{value: "a"}, {value: "b"}}))
+	assert.Equal(t, map[int]int{1: 2, 2: 1}, variationIndexMoves(prior, []variationKey{{value: "a"}, {value: "c"}, {value: "b"}}))
+	assert.Equal(t, map[int]int{0: -1, 1: 0, 2: 1}, variationIndexMoves(prior, []variationKey{{value: "b"}, {value: "c"}}))
+	assert.Equal(t, map[int]int{1: -1, 2: 1}, variationIndexMoves(prior, []variationKey{{value: "a"}, {value: "c"}}), "removing a variation moves the next one into its index")
+	assert.Empty(t, variationIndexMoves(prior, []variationKey{{value: "a"}, {value: "x"}, {value: "c"}}), "editing a value in place moves nothing")
+}
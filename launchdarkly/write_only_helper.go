@@ -0,0 +1,50 @@
+package launchdarkly
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Write-only attributes are sent to LaunchDarkly on create and update but are
+// never persisted to the plan or state, so Terraform has nothing to diff them
+// against. Each one is therefore paired with a `<name>_version` attribute:
+// the write-only value is sent on create and re-sent on update only when the
+// version changes. Resources read the write-only value from req.Config (the
+// framework nulls it in the plan) and leave their plaintext counterpart null
+// in state while the version attribute is set, so the value the API returns
+// is not surfaced as drift.
+
+// writeOnlyDescription appends the standard write-only note to desc.
+func writeOnlyDescription(desc, versionAttr string) string {
+	return fmt.Sprintf("%s This value is write-only: it is never stored in the Terraform plan or state, and changes to it are only sent to LaunchDarkly when `%s` changes. Requires Terraform 1.11 or later.", desc, versionAttr)
+}
+
+// writeOnlyVersionAttribute builds the version companion of the write-only
+// attribute writeOnlyAttr.
+func writeOnlyVersionAttribute(writeOnlyAttr string) schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Optional:    true,
+		Description: fmt.Sprintf("The version of `%s`. Increment this value to send an updated `%s` to LaunchDarkly, for example when rotating a credential.", writeOnlyAttr, writeOnlyAttr),
+		Validators: []validator.Int64{
+			int64validator.AlsoRequires(path.MatchRoot(writeOnlyAttr)),
+		},
+	}
+}
+
+// writeOnlyInUse reports whether a resource manages its secret through the
+// write-only attribute whose version is version.
+func writeOnlyInUse(version types.Int64) bool {
+	return !version.IsNull() && !version.IsUnknown()
+}
+
+// writeOnlyVersionChanged reports whether an update must re-send the
+// write-only value: the practitioner is using the write-only attribute and
+// has changed its version since the last apply.
+func writeOnlyVersionChanged(plan, state types.Int64) bool {
+	return writeOnlyInUse(plan) && !plan.Equal(state)
+}
@@ -8,7 +8,7 @@ resource "launchdarkly_custom_role" "exclude_terraform" {
   policy {
     effect = "deny"
     resources = [
-      "proj/*:env/:flag/*;terraform-managed"
+      "proj/*:env/*:flag/*;terraform-managed"
     ]
     actions = [
       "*"